@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
@@ -17,6 +22,8 @@ import (
 	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/queue"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/handlers"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
@@ -42,6 +49,11 @@ import (
 // @name Authorization
 // @description Digite 'Bearer ' seguido do token JWT
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Chave de API do estabelecimento, limitada aos escopos concedidos
+
 func main() {
 	err := godotenv.Load()
 	if err != nil {
@@ -111,28 +123,144 @@ func main() {
 	// Criar adaptador de cache
 	cacheAdapter := cache.NewRedisAdapter(redisHost, redisPort, redisPassword, 0)
 
+	// Criar a lista de revogação dos tokens, consultada em todas as rotas protegidas
+	revogacaoAdapter := cache.NewRedisRevogacaoAdapter(redisHost, redisPort, redisPassword, 0)
+
+	// Criar a fila de jobs: Redis por padrão, ou em memória para uma única instância
+	var filaJobs queue.QueueAdapter
+	filaDriver := getEnv("JOBS_QUEUE", "redis")
+	if filaDriver == "memory" {
+		filaJobs = queue.NewMemoryAdapter(1000)
+	} else {
+		filaJobs = queue.NewRedisAdapter(redisHost, redisPort, redisPassword, 0, "pix_jobs")
+	}
+
+	// Criar o envio de emails: SMTP, ou escrita dos emails no log ou em um arquivo para desenvolvimento
+	var mailer email.Mailer
+	if getEnv("MAIL_DRIVER", "log") == "smtp" {
+		mailer, err = email.NewSMTPMailer(
+			getEnv("SMTP_HOST", "localhost"),
+			getEnv("SMTP_PORT", "587"),
+			getEnv("SMTP_USER", ""),
+			getEnv("SMTP_PASSWORD", ""),
+			getEnv("MAIL_FROM", "Gerador de PIX <nao-responda@localhost>"),
+		)
+		if err != nil {
+			log.Fatalf("Falha ao configurar o envio de emails: %v", err)
+		}
+	} else {
+		saidaEmails := io.Writer(log.Writer())
+		if arquivo := getEnv("MAIL_LOG_FILE", ""); arquivo != "" {
+			saidaEmails, err = os.OpenFile(arquivo, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+			if err != nil {
+				log.Fatalf("Falha ao abrir o arquivo de emails: %v", err)
+			}
+		}
+		mailer = email.NewLogMailer(saidaEmails)
+	}
+
+	// URL da aplicação que recebe os links dos emails e exigência do email confirmado no login
+	urlAplicacao := getEnv("APP_URL", "http://localhost:8080")
+	exigirVerificacao := getEnv("EMAIL_VERIFICATION_REQUIRED", "false") == "true"
+
+	// Proteção do login contra força bruta: limites de senhas incorretas por email e por IP na
+	// janela, que bloqueiam o login pela duração do bloqueio
+	protecaoLogin := usecases.ConfiguracaoProtecaoLogin{
+		MaxTentativasEmail: getEnvInt("LOGIN_MAX_ATTEMPTS", 5),
+		MaxTentativasIP:    getEnvInt("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+		Janela:             getEnvDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+		Bloqueio:           getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+	}
+
+	// Nome exibido no aplicativo autenticador do segundo fator
+	emissorDoisFatores := getEnv("TOTP_ISSUER", "Gerador de PIX")
+
+	jobWorkers, err := strconv.Atoi(getEnv("JOBS_WORKERS", "2"))
+	if err != nil || jobWorkers < 1 {
+		log.Fatalf("JOBS_WORKERS deve ser um número inteiro positivo")
+	}
+
+	// Tempo sem progresso após o qual um job em processamento é considerado interrompido
+	jobLimiteSemProgresso := getEnvDuration("JOBS_STALE_TIMEOUT", 10*time.Minute)
+
 	// Serviços
 	pixService := services.NewPixGeneratorService()
-	autenticacaoService := services.NewAutenticacaoService()
+	autenticacaoService, err := services.NewAutenticacaoService()
+	if err != nil {
+		log.Fatalf("Falha ao configurar a assinatura dos tokens: %v", err)
+	}
+	calculadoraCobV := services.NewCalculadoraCobVService()
 
 	// Repositórios
 	pixRepository := repositories.NewMysqlPixRepository(db)
 	estabelecimentoRepository := repositories.NewMysqlEstabelecimentoRepository(db)
+	cobvRepository := repositories.NewMysqlCobVRepository(db)
+	jobRepository := repositories.NewMysqlJobRepository(db)
+	perfilRepository := repositories.NewMysqlPerfilRepository(db)
+	refreshTokenRepository := repositories.NewMysqlRefreshTokenRepository(db)
+	chaveAPIRepository := repositories.NewMysqlChaveAPIRepository(db)
+	usuarioRepository := repositories.NewMysqlUsuarioRepository(db)
+	tokenContaRepository := repositories.NewMysqlTokenContaRepository(db)
+	bloqueioLoginRepository := repositories.NewMysqlBloqueioLoginRepository(db)
+	doisFatoresRepository := repositories.NewMysqlDoisFatoresRepository(db)
+	clienteOAuthRepository := repositories.NewMysqlClienteOAuthRepository(db)
 
 	// Casos de uso
-	generatePixUseCase := usecases.NewGeneratePixUseCase(pixService, pixRepository)
-	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository)
+	generatePixUseCase := usecases.NewGeneratePixUseCase(pixService, pixRepository, perfilRepository)
+	listPixUseCase := usecases.NewListPixUseCase(pixService, pixRepository)
+	contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, mailer, urlAplicacao, exigirVerificacao)
+	protecaoLoginUseCase := usecases.NewProtecaoLoginUseCase(cacheAdapter, bloqueioLoginRepository, protecaoLogin)
+	doisFatoresUseCase := usecases.NewDoisFatoresUseCase(doisFatoresRepository, estabelecimentoRepository, usuarioRepository, autenticacaoService, cacheAdapter, emissorDoisFatores)
+	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, revogacaoAdapter, contaUseCase, protecaoLoginUseCase, doisFatoresUseCase)
+	cobvUseCase := usecases.NewCobVUseCase(pixService, calculadoraCobV, pixRepository, cobvRepository)
+	jobUseCase := usecases.NewJobUseCase(generatePixUseCase, templateProcessor, jobRepository, filaJobs)
+	perfilUseCase := usecases.NewPerfilUseCase(pixService, perfilRepository)
+	estabelecimentoUseCase := usecases.NewEstabelecimentoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, contaUseCase)
+	chaveAPIUseCase := usecases.NewChaveAPIUseCase(autenticacaoService, chaveAPIRepository, estabelecimentoRepository)
+	clienteOAuthUseCase := usecases.NewClienteOAuthUseCase(autenticacaoService, clienteOAuthRepository, estabelecimentoRepository)
+	usuarioUseCase := usecases.NewUsuarioUseCase(usuarioRepository, estabelecimentoRepository)
 
 	// Handlers
-	pixHandler := handlers.NewPixHandler(generatePixUseCase, pixRepository, cacheAdapter, templateProcessor)
+	pixHandler := handlers.NewPixHandler(generatePixUseCase, listPixUseCase, pixRepository, cacheAdapter, templateProcessor, pixService)
 	autenticacaoHandler := handlers.NovaAutenticacaoHandler(autenticacaoUseCase)
+	cobvHandler := handlers.NewCobVHandler(cobvUseCase)
+	jobHandler := handlers.NewJobHandler(jobUseCase)
+	perfilHandler := handlers.NewPerfilHandler(perfilUseCase)
+	estabelecimentoHandler := handlers.NewEstabelecimentoHandler(estabelecimentoUseCase)
+	chaveAPIHandler := handlers.NewChaveAPIHandler(chaveAPIUseCase)
+	clienteOAuthHandler := handlers.NewClienteOAuthHandler(clienteOAuthUseCase)
+	usuarioHandler := handlers.NewUsuarioHandler(usuarioUseCase)
+	contaHandler := handlers.NewContaHandler(contaUseCase)
+	doisFatoresHandler := handlers.NewDoisFatoresHandler(doisFatoresUseCase)
+
+	// Workers dos jobs em segundo plano. Os jobs interrompidos por um reinício são registrados
+	// como falha, e os pendentes são colocados na fila novamente, com os workers já consumindo
+	// a fila para que ela não fique cheia
+	jobUseCase.IniciarWorkers(context.Background(), jobWorkers)
+	jobUseCase.MonitorarInterrompidos(context.Background(), jobLimiteSemProgresso)
+	go func() {
+		if err := jobUseCase.ReenfileirarPendentes(context.Background()); err != nil {
+			log.Printf("Aviso: não foi possível reenfileirar os jobs pendentes: %v", err)
+		}
+	}()
 
 	// Middlewares
-	autenticacaoMiddleware := middlewares.NewAutenticacaoMiddleware(autenticacaoService)
+	autenticacaoMiddleware := middlewares.NewAutenticacaoMiddleware(autenticacaoService, autenticacaoUseCase, chaveAPIUseCase, clienteOAuthUseCase)
+	autorizacaoMiddleware := middlewares.NewAutorizacaoMiddleware()
 
 	// Configurar o router Gin
 	router := gin.Default()
 
+	// O IP do cliente, usado na proteção do login, só é lido de X-Forwarded-For quando a
+	// requisição vem de um dos proxies confiáveis informados; sem proxies, vale o IP da conexão
+	var proxiesConfiaveis []string
+	if proxies := getEnv("TRUSTED_PROXIES", ""); proxies != "" {
+		proxiesConfiaveis = strings.Split(proxies, ",")
+	}
+	if err := router.SetTrustedProxies(proxiesConfiaveis); err != nil {
+		log.Fatalf("TRUSTED_PROXIES inválido: %v", err)
+	}
+
 	// Servir arquivos estáticos (incluindo templates)
 	router.Static("/templates", absTemplatesDir)
 
@@ -140,7 +268,7 @@ func main() {
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Configurar as rotas
-	routes.SetupRoutes(router, pixHandler, autenticacaoHandler, autenticacaoMiddleware)
+	routes.SetupRoutes(router, pixHandler, cobvHandler, jobHandler, perfilHandler, estabelecimentoHandler, chaveAPIHandler, clienteOAuthHandler, usuarioHandler, contaHandler, doisFatoresHandler, autenticacaoHandler, autenticacaoMiddleware, autorizacaoMiddleware)
 
 	// Iniciar o servidor
 	port := getEnv("PORT", "8080")
@@ -150,6 +278,34 @@ func main() {
 	router.Run(":" + port)
 }
 
+// getEnvInt obtém uma variável de ambiente com um número inteiro positivo ou usa o valor padrão
+func getEnvInt(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	numero, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || numero < 1 {
+		log.Fatalf("%s deve ser um número inteiro positivo", key)
+	}
+	return numero
+}
+
+// getEnvDuration obtém uma variável de ambiente com uma duração positiva (como 15m) ou usa o valor padrão
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duracao, err := time.ParseDuration(value)
+	if err != nil || duracao <= 0 {
+		log.Fatalf("%s deve ser uma duração positiva, como 15m", key)
+	}
+	return duracao
+}
+
 // getEnv obtém uma variável de ambiente ou usa o valor padrão
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -23,41 +23,24 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/download-qrcode": {
+        "/chaves-api": {
             "get": {
-                "description": "Faz o download de um QR code para o código PIX gerado, opcionalmente aplicando um template",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as chaves de API do estabelecimento, inclusive as revogadas e expiradas, com o último uso de cada uma",
                 "produces": [
-                    "image/png",
                     "application/json"
                 ],
                 "tags": [
-                    "pix"
-                ],
-                "summary": "Download QR Code",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Código PIX gerado",
-                        "name": "codigo_pix",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Formato de resposta (json ou png, padrão é png)",
-                        "name": "format",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Nome do template a ser aplicado (ex: template_pix_1)",
-                        "name": "template",
-                        "in": "query"
-                    }
+                    "chaves-api"
                 ],
+                "summary": "Listar chaves de API",
                 "responses": {
                     "200": {
-                        "description": "Detalhes do QR Code em JSON",
+                        "description": "Chaves de API",
                         "schema": {
                             "allOf": [
                                 {
@@ -67,21 +50,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveAPI"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Código PIX não fornecido",
+                    "401": {
+                        "description": "Não autorizado",
                         "schema": {
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
                     },
-                    "404": {
-                        "description": "Código PIX não encontrado",
+                    "403": {
+                        "description": "Rota disponível apenas com login",
                         "schema": {
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
@@ -93,16 +79,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/generate": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Gera um novo código PIX estático com base nos dados fornecidos",
+                "description": "Cria uma chave de API para integrações servidor a servidor, enviada no header X-API-Key. A chave é exibida apenas nesta resposta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -110,23 +94,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pix"
+                    "chaves-api"
                 ],
-                "summary": "Gerar código PIX",
+                "summary": "Criar chave de API",
                 "parameters": [
                     {
-                        "description": "Dados para geração do PIX",
+                        "description": "Dados da chave",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest"
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CriarChaveAPIRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Código PIX gerado com sucesso",
+                    "201": {
+                        "description": "Chave criada",
                         "schema": {
                             "allOf": [
                                 {
@@ -136,7 +120,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveAPICriada"
                                         }
                                     }
                                 }
@@ -155,6 +139,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
                     },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
@@ -164,33 +160,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Autentica um estabelecimento e retorna um token JWT",
-                "consumes": [
-                    "application/json"
+        "/chaves-api/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoga uma chave de API, que deixa de ser aceita imediatamente",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "autenticacao"
+                    "chaves-api"
                 ],
-                "summary": "Login de estabelecimento",
+                "summary": "Revogar chave de API",
                 "parameters": [
                     {
-                        "description": "Credenciais de login",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "ID da chave de API",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login realizado com sucesso",
+                        "description": "Chave revogada",
                         "schema": {
                             "allOf": [
                                 {
@@ -200,21 +196,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse"
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveAPI"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Erro de validação dos dados",
+                    "401": {
+                        "description": "Não autorizado",
                         "schema": {
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
                     },
-                    "401": {
-                        "description": "Credenciais inválidas",
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Chave de API não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
                         "schema": {
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
@@ -222,9 +230,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/registrar": {
+        "/clientes-oauth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os clientes OAuth2 do estabelecimento, inclusive os revogados, com a emissão do último token de cada um",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes-oauth"
+                ],
+                "summary": "Listar clientes OAuth2",
+                "responses": {
+                    "200": {
+                        "description": "Clientes OAuth2",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ClienteOAuth"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Registra um novo estabelecimento no sistema",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra um cliente OAuth2 para uma plataforma parceira, que obtém tokens de acesso em POST /oauth/token com o grant client_credentials. O segredo é exibido apenas nesta resposta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,23 +301,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "autenticacao"
+                    "clientes-oauth"
                 ],
-                "summary": "Registrar estabelecimento",
+                "summary": "Criar cliente OAuth2",
                 "parameters": [
                     {
-                        "description": "Dados do estabelecimento",
+                        "description": "Dados do cliente",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoRequest"
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CriarClienteOAuthRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Estabelecimento criado com sucesso",
+                        "description": "Cliente criado",
                         "schema": {
                             "allOf": [
                                 {
@@ -258,7 +327,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ClienteOAuthCriado"
                                         }
                                     }
                                 }
@@ -266,157 +335,4980 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Erro de validação dos dados",
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
                         "schema": {
                             "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "nome",
-                "senha"
-            ],
-            "properties": {
-                "descricao": {
-                    "description": "Descrição do estabelecimento (opcional)\nexample: Loja de produtos diversos",
-                    "type": "string"
-                },
-                "email": {
-                    "description": "Email do estabelecimento (usado para login)\nrequired: true\nexample: contato@lojadojose.com.br",
-                    "type": "string"
-                },
-                "nome": {
-                    "description": "Nome do estabelecimento\nrequired: true\nexample: Loja do José",
-                    "type": "string"
-                },
-                "senha": {
-                    "description": "Senha do estabelecimento (mínimo 6 caracteres)\nrequired: true\nexample: senha123\nmin length: 6",
-                    "type": "string",
-                    "minLength": 6
+        },
+        "/clientes-oauth/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoga um cliente OAuth2, que deixa de obter tokens; os tokens já emitidos deixam de ser aceitos imediatamente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "clientes-oauth"
+                ],
+                "summary": "Revogar cliente OAuth2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente (client_id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente revogado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ClienteOAuth"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente OAuth não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
                 }
             }
         },
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse": {
-            "type": "object",
+        "/cob": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Gera um código PIX dinâmico de uso único que aponta para a URL de payload da cobrança no PSP",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Gerar cobrança imediata",
+                "parameters": [
+                    {
+                        "description": "Dados da cobrança",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Código PIX gerado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "txid já utilizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos para o BR Code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cobv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Gera um PIX dinâmico com vencimento, juros, multa, descontos e abatimento, retornando o valor a pagar hoje",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cobv"
+                ],
+                "summary": "Gerar cobrança com vencimento",
+                "parameters": [
+                    {
+                        "description": "Dados da cobrança com vencimento",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobVRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cobrança gerada com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobVResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "txid já utilizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos para a cobrança",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cobv/{txid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna a cobrança e o valor a pagar na data informada (padrão: hoje), com o detalhamento de juros, multa, desconto e abatimento",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cobv"
+                ],
+                "summary": "Consultar cobrança com vencimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da transação",
+                        "name": "txid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data de pagamento (AAAA-MM-DD, padrão hoje)",
+                        "name": "data",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Valor a pagar na data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobVResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Data inválida",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cobrança não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Cobrança expirada ou valor a pagar acima do máximo na data informada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/decode": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lê um código PIX \"copia e cola\", confere o CRC e retorna os campos estruturados com os problemas encontrados",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Decodificar BR Code",
+                "parameters": [
+                    {
+                        "description": "Código PIX a ser decodificado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.DecodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resultado da leitura do BR Code",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeDecodificado"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Informa se o segundo fator está ativo no login, se o estabelecimento o exige e quantos códigos de recuperação restam",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Situação do segundo fator",
+                "responses": {
+                    "200": {
+                        "description": "Situação do segundo fator",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.StatusDoisFatoresResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores/cadastro": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gera o segredo TOTP do login, com a URI otpauth:// e o QR code para o aplicativo autenticador. O segundo fator só passa a ser exigido depois de confirmado em /dois-fatores/confirmar; um cadastro pendente anterior é substituído.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Cadastrar segundo fator",
+                "responses": {
+                    "201": {
+                        "description": "Segredo gerado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CadastroDoisFatoresResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Segundo fator já ativo",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores/codigos-recuperacao": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui todos os códigos de recuperação do login, confirmando com um código do aplicativo. Os novos códigos são exibidos apenas nesta resposta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Gerar novos códigos de recuperação",
+                "parameters": [
+                    {
+                        "description": "Código do aplicativo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigoDoisFatoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Novos códigos de recuperação",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigosRecuperacaoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados, código inválido ou segundo fator não cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Muitos códigos incorretos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores/confirmar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ativa o segundo fator com um código do aplicativo e retorna os códigos de recuperação, exibidos apenas nesta resposta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Confirmar segundo fator",
+                "parameters": [
+                    {
+                        "description": "Código do aplicativo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigoDoisFatoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Segundo fator ativado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigosRecuperacaoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados, código inválido ou cadastro não iniciado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Segundo fator já ativo",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Muitos códigos incorretos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores/desativar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove o segundo fator e os códigos de recuperação do login, confirmando com a senha e um código do aplicativo ou de recuperação. Indisponível quando o estabelecimento exige o segundo fator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Desativar segundo fator",
+                "parameters": [
+                    {
+                        "description": "Senha e código",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesativarDoisFatoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Segundo fator desativado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados, código inválido ou segundo fator não cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Segundo fator exigido pelo estabelecimento ou rota disponível apenas com login",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Senha incorreta",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Muitos códigos incorretos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dois-fatores/politica": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define se o segundo fator é exigido no login do estabelecimento e dos usuários da equipe. Os logins sem o segundo fator passam a cadastrá-lo no próximo login, e ele não pode ser desativado enquanto for exigido.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dois-fatores"
+                ],
+                "summary": "Exigir segundo fator",
+                "parameters": [
+                    {
+                        "description": "Exigência do segundo fator",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PoliticaDoisFatoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exigência atualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.StatusDoisFatoresResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Papel sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/download-qrcode": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Faz o download de um QR code para o código PIX gerado, opcionalmente aplicando um template e opções de renderização",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml",
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Download QR Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código PIX gerado",
+                        "name": "codigo_pix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato de resposta (json, png ou svg, padrão é png)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do template a ser aplicado (ex: template_pix_1), apenas para PNG",
+                        "name": "template",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nível de correção de erro: L, M, Q ou H (padrão M)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Largura do PNG em pixels, de 64 a 2048 (padrão 256)",
+                        "name": "tamanho",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Zona de silêncio em módulos, de 0 a 16 (padrão 4)",
+                        "name": "margem",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cor dos módulos em hexadecimal (padrão 000000)",
+                        "name": "cor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cor de fundo em hexadecimal (padrão FFFFFF); vazio gera fundo transparente",
+                        "name": "fundo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Gera o fundo transparente",
+                        "name": "transparente",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detalhes do QR Code em JSON",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Código PIX não fornecido ou opções de renderização inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Código PIX não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/verificacao": {
+            "post": {
+                "description": "Reenvia o link de confirmação, válido por 24 horas, ao estabelecimento com o email informado e invalida os links anteriores. A resposta é a mesma para emails não cadastrados ou já confirmados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conta"
+                ],
+                "summary": "Reenviar verificação de email",
+                "parameters": [
+                    {
+                        "description": "Email do estabelecimento",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ReenviarVerificacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Solicitação recebida",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/verificar": {
+            "post": {
+                "description": "Confirma o email do estabelecimento com o token recebido por email. O token vale para um único uso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conta"
+                ],
+                "summary": "Verificar email",
+                "parameters": [
+                    {
+                        "description": "Token de verificação",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.VerificarEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email confirmado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados ou token inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/estabelecimentos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista todos os estabelecimentos, ativos e inativos. Restrito a administradores.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Listar estabelecimentos",
+                "responses": {
+                    "200": {
+                        "description": "Estabelecimentos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Acesso restrito a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/estabelecimentos/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o cadastro do estabelecimento autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Consultar cadastro",
+                "responses": {
+                    "200": {
+                        "description": "Cadastro do estabelecimento",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera o nome, a descrição, o email e, com nova_senha, a senha do estabelecimento autenticado. A senha atual é obrigatória, e uma nova senha encerra as sessões abertas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Atualizar cadastro",
+                "parameters": [
+                    {
+                        "description": "Dados do estabelecimento",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AtualizarEstabelecimentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cadastro atualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Senha atual incorreta",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desativa a conta do estabelecimento autenticado e revoga os tokens emitidos. A senha atual é obrigatória.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Desativar conta",
+                "parameters": [
+                    {
+                        "description": "Confirmação da senha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesativarEstabelecimentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Conta desativada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Senha atual incorreta",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/estabelecimentos/{id}/ativar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ativa um estabelecimento. Os tokens revogados na desativação continuam inválidos. Restrito a administradores.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Ativar estabelecimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do estabelecimento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estabelecimento ativado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Acesso restrito a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Estabelecimento não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/estabelecimentos/{id}/desativar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desativa um estabelecimento e invalida imediatamente os tokens já emitidos. Restrito a administradores.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estabelecimentos"
+                ],
+                "summary": "Desativar estabelecimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do estabelecimento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estabelecimento desativado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Acesso restrito a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Estabelecimento não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Gera um novo código PIX estático com base nos dados fornecidos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Gerar código PIX",
+                "parameters": [
+                    {
+                        "description": "Dados para geração do PIX",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Código PIX gerado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos para o BR Code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Gera até 5000 PIX estáticos a partir de um array JSON ou de um CSV (corpo text/csv ou campo \"arquivo\" em multipart/form-data), retornando o resultado de cada linha. Com format=zip, retorna um ZIP com os QR codes em PNG nomeados pelo identificador e o arquivo resultado.json",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Gerar lote de códigos PIX",
+                "parameters": [
+                    {
+                        "description": "Lote em JSON",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Lote em CSV, com cabeçalho (nome, chave, cidade, valor, identificador, descricao, mcc, cep, modalidade)",
+                        "name": "arquivo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato de resposta (json ou zip, padrão é json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do template a ser aplicado às imagens do ZIP (ex: template_pix_1)",
+                        "name": "template",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP com os QR codes e o resultado do lote",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Lote vazio, muito grande ou em formato inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registra um lote de até 5000 PIX para geração em segundo plano, com a mesma entrada de /generate/batch (array JSON, CSV no corpo ou campo \"arquivo\" em multipart/form-data). Acompanhe o progresso em /jobs/{id}",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Criar job de geração em lote",
+                "parameters": [
+                    {
+                        "description": "Lote em JSON",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Lote em CSV, com cabeçalho (nome, chave, cidade, valor, identificador, descricao, mcc, cep, modalidade)",
+                        "name": "arquivo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato do resultado (json ou zip, padrão é json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do template a ser aplicado às imagens do ZIP (ex: template_pix_1)",
+                        "name": "template",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Job criado e colocado na fila",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Lote vazio, muito grande ou em formato inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna a situação e o progresso de um job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Consultar job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Situação do job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Job não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancela um job pendente ou em processamento. Um job em processamento para ao fim da etapa atual e os PIX já salvos são mantidos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Cancelar job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job cancelado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Job não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Job já finalizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/result": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna o resultado de um job concluído: o JSON com o resultado de cada linha ou o ZIP com os QR codes",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Download do resultado do job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resultado do job",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Job não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Job ainda não foi concluído",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Autentica um estabelecimento e retorna um token JWT de curta duração e um refresh token para renová-lo. Quando o segundo fator é exigido, responde 202 com o desafio a ser concluído em /login/dois-fatores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Login de estabelecimento",
+                "parameters": [
+                    {
+                        "description": "Credenciais de login",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login realizado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Senha correta; o login exige o segundo fator",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesafioDoisFatoresResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Email não verificado, quando a verificação é obrigatória",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Muitas tentativas incorretas; o header Retry-After informa os segundos até uma nova tentativa",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/dois-fatores": {
+            "post": {
+                "description": "Conclui o login com o desafio retornado por /login e um código do aplicativo autenticador ou um código de recuperação, que vale uma única vez. No cadastro obrigatório, o código confirma o cadastro iniciado em /login/dois-fatores/cadastro e a resposta traz os códigos de recuperação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Login com segundo fator",
+                "parameters": [
+                    {
+                        "description": "Desafio e código",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginDoisFatoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login realizado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados ou cadastro não iniciado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Desafio inválido ou expirado, código inválido ou conta desativada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Muitos códigos incorretos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/dois-fatores/cadastro": {
+            "post": {
+                "description": "Com o desafio do login que indica cadastro_obrigatorio, gera o segredo TOTP do login. O cadastro é confirmado com o primeiro código em /login/dois-fatores, que conclui o login e retorna os códigos de recuperação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Cadastrar segundo fator no login",
+                "parameters": [
+                    {
+                        "description": "Desafio do login",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CadastroDesafioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Segredo gerado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.CadastroDoisFatoresResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Desafio inválido ou expirado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoga o token JWT da requisição. Com o refresh token no corpo, os refresh tokens da sessão também são revogados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token da sessão",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sessão encerrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/perfil": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retorna a razão social, a cidade, o MCC, o CEP e as chaves PIX usados como padrão na geração",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "perfil"
+                ],
+                "summary": "Consultar perfil",
+                "responses": {
+                    "200": {
+                        "description": "Perfil do estabelecimento",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilEstabelecimento"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Estabelecimento não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Substitui a razão social, a cidade, o MCC e o CEP do perfil; campos omitidos são removidos. As chaves cadastradas não são alteradas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "perfil"
+                ],
+                "summary": "Atualizar perfil",
+                "parameters": [
+                    {
+                        "description": "Dados do perfil",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Perfil atualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilEstabelecimento"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/perfil/chaves": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cadastra uma chave PIX no perfil. A primeira chave cadastrada é a padrão; com padrao=true, a nova chave substitui a padrão atual.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "perfil"
+                ],
+                "summary": "Cadastrar chave PIX",
+                "parameters": [
+                    {
+                        "description": "Chave PIX",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveCadastradaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Chave cadastrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveCadastrada"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Chave já cadastrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Chave ou apelido inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/perfil/chaves/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Altera o apelido de uma chave cadastrada; com padrao=true, a chave passa a ser a padrão do perfil",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "perfil"
+                ],
+                "summary": "Atualizar chave PIX",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alterações da chave",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AtualizarChaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Perfil atualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilEstabelecimento"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Chave não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove uma chave do perfil. Se era a padrão, a chave cadastrada há mais tempo passa a ser a padrão.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "perfil"
+                ],
+                "summary": "Excluir chave PIX",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chave excluída",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Chave não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pix": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lista os PIX do estabelecimento com paginação por cursor, filtros e ordenação. Para a próxima página, repita a consulta com os mesmos filtros e o proximo_cursor retornado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pix"
+                ],
+                "summary": "Listar PIX gerados",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (AAAA-MM-DD ou RFC 3339)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados até, inclusive (AAAA-MM-DD ou RFC 3339)",
+                        "name": "criado_ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Valor mínimo",
+                        "name": "valor_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Valor máximo",
+                        "name": "valor_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identificador da transação",
+                        "name": "identificador",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave PIX do beneficiário",
+                        "name": "chave",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Situação: ATIVO ou EXPIRADO",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campo de ordenação: criado_em ou valor (padrão criado_em)",
+                        "name": "ordenar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Direção da ordenação: asc ou desc (padrão desc)",
+                        "name": "ordem",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página, de 1 a 200 (padrão 50)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor da próxima página",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Página de PIX",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PaginaPix"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de filtros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/registrar": {
+            "post": {
+                "description": "Registra um novo estabelecimento no sistema e envia ao email informado o link de confirmação, válido por 24 horas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Registrar estabelecimento",
+                "parameters": [
+                    {
+                        "description": "Dados do estabelecimento",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Estabelecimento criado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/senha/esqueci": {
+            "post": {
+                "description": "Envia ao email informado um link de uso único, válido por 1 hora, para redefinir a senha do estabelecimento ou do usuário da equipe. A resposta é a mesma para emails não cadastrados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conta"
+                ],
+                "summary": "Esqueci a senha",
+                "parameters": [
+                    {
+                        "description": "Email do login",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EsqueciSenhaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Solicitação recebida",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/senha/redefinir": {
+            "post": {
+                "description": "Define a nova senha com o token recebido por email e encerra as sessões abertas do login. O token vale para um único uso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conta"
+                ],
+                "summary": "Redefinir senha",
+                "parameters": [
+                    {
+                        "description": "Token e nova senha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.RedefinirSenhaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Senha redefinida",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados ou token inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Troca um refresh token por um novo token JWT e um novo refresh token. Cada refresh token vale para uma única troca; a reutilização de um token já trocado encerra a sessão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autenticacao"
+                ],
+                "summary": "Renovar token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token renovado com sucesso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token inválido, expirado ou reutilizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os usuários da equipe do estabelecimento, inclusive os convites pendentes e os usuários desativados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Listar usuários",
+                "responses": {
+                    "200": {
+                        "description": "Usuários da equipe",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Papel sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/convites": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um usuário com o papel gerente, caixa ou leitura e retorna o convite, exibido apenas nesta resposta, com o qual ele define o nome e a senha. Gerentes convidam apenas caixas e usuários de leitura.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Convidar usuário",
+                "parameters": [
+                    {
+                        "description": "Email e papel do usuário",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ConvidarUsuarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Convite criado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ConviteCriado"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Papel sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/convites/aceitar": {
+            "post": {
+                "description": "Define o nome e a senha do usuário convidado, que passa a fazer login em /login com o email do convite",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Aceitar convite",
+                "parameters": [
+                    {
+                        "description": "Convite, nome e senha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AceitarConviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Convite aceito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição ou convite inválido",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera o papel de um usuário da equipe, com efeito na próxima requisição dele. Gerentes alteram apenas caixas e usuários de leitura.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Alterar papel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo papel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AlterarPapelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Papel alterado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de requisição",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Papel sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuário não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Lista de campos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desativa um usuário da equipe, revogando imediatamente os tokens emitidos e o convite pendente. Gerentes desativam apenas caixas e usuários de leitura.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Desativar usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuário desativado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Não autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Papel sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuário não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_interfaces_api_views.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AbatimentoCobV": {
+            "type": "object",
+            "properties": {
+                "modalidade": {
+                    "description": "Modalidade: VALOR_FIXO ou PERCENTUAL\nrequired: true\nexample: VALOR_FIXO",
+                    "type": "string"
+                },
+                "valor_perc": {
+                    "description": "Valor em reais ou percentual, conforme a modalidade\nrequired: true\nexample: 10.00",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AceitarConviteRequest": {
+            "type": "object",
+            "required": [
+                "convite",
+                "nome",
+                "senha"
+            ],
+            "properties": {
+                "convite": {
+                    "description": "Convite recebido\nrequired: true\nexample: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do usuário\nrequired: true\nexample: Maria Souza",
+                    "type": "string"
+                },
+                "senha": {
+                    "description": "Senha do usuário (mínimo 6 caracteres)\nrequired: true\nexample: senha123\nmin length: 6",
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AjusteCampo": {
+            "type": "object",
+            "properties": {
+                "campo": {
+                    "description": "Campo alterado\nexample: cidade",
+                    "type": "string"
+                },
+                "original": {
+                    "description": "Valor informado na requisição\nexample: São Paulo",
+                    "type": "string"
+                },
+                "utilizado": {
+                    "description": "Valor que consta no BR Code e que será exibido ao pagador\nexample: SAO PAULO",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AlterarPapelRequest": {
+            "type": "object",
+            "required": [
+                "papel"
+            ],
+            "properties": {
+                "papel": {
+                    "description": "Novo papel: gerente, caixa ou leitura\nrequired: true\nexample: leitura",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AtualizarChaveRequest": {
+            "type": "object",
+            "properties": {
+                "apelido": {
+                    "description": "Novo apelido da chave\nexample: Conta da filial",
+                    "type": "string"
+                },
+                "padrao": {
+                    "description": "true torna a chave a padrão do perfil\nexample: true",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.AtualizarEstabelecimentoRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nome",
+                "senha_atual"
+            ],
+            "properties": {
+                "descricao": {
+                    "description": "Descrição do estabelecimento (opcional; omitida, é removida)\nexample: Loja de produtos diversos",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email do estabelecimento (usado para login); alterado, precisa ser confirmado novamente\nrequired: true\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do estabelecimento\nrequired: true\nexample: Loja do José",
+                    "type": "string"
+                },
+                "nova_senha": {
+                    "description": "Nova senha (opcional, mínimo 6 caracteres); omitida, a senha atual é mantida\nexample: novaSenha456",
+                    "type": "string",
+                    "minLength": 6
+                },
+                "senha_atual": {
+                    "description": "Senha atual, exigida para confirmar a alteração\nrequired: true\nexample: senha123",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeCampo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Identificador do campo\nexample: 26",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do campo segundo o padrão EMV/BR Code\nexample: Merchant Account Information",
+                    "type": "string"
+                },
+                "subcampos": {
+                    "description": "Sub-campos, para os campos que são templates (26, 62, 64, 80-99)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeCampo"
+                    }
+                },
+                "tamanho": {
+                    "description": "Tamanho declarado do valor\nexample: 58",
+                    "type": "integer"
+                },
+                "valor": {
+                    "description": "Valor bruto do campo\nexample: 0014BR.GOV.BCB.PIX0136josesilva@email.com",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeDecodificado": {
+            "type": "object",
+            "properties": {
+                "campos": {
+                    "description": "Árvore completa de campos lidos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeCampo"
+                    }
+                },
+                "cep": {
+                    "description": "CEP do recebedor (61)\nexample: 01310100",
+                    "type": "string"
+                },
+                "chave": {
+                    "description": "Chave PIX do recebedor (26.01)\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "cidade": {
+                    "description": "Cidade do recebedor (60)\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "crc": {
+                    "description": "CRC informado no BR Code (63)\nexample: 1D3D",
+                    "type": "string"
+                },
+                "crc_calculado": {
+                    "description": "CRC calculado sobre o payload\nexample: 1D3D",
+                    "type": "string"
+                },
+                "crc_valido": {
+                    "description": "Indica se o CRC informado confere com o calculado\nexample: true",
+                    "type": "boolean"
+                },
+                "erros": {
+                    "description": "Problemas encontrados durante a leitura",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeErro"
+                    }
+                },
+                "gui": {
+                    "description": "GUI do arranjo de pagamento (26.00)\nexample: BR.GOV.BCB.PIX",
+                    "type": "string"
+                },
+                "info_adicional": {
+                    "description": "Informação adicional do recebedor (26.02)",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "URL do payload da cobrança, para PIX dinâmico (26.25)\nexample: pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+                    "type": "string"
+                },
+                "mcc": {
+                    "description": "Merchant Category Code (52)\nexample: 0000",
+                    "type": "string"
+                },
+                "modalidade": {
+                    "description": "Modalidade do PIX Saque ou PIX Troco (62.50.01)\nexample: TROCO",
+                    "type": "string"
+                },
+                "moeda": {
+                    "description": "Código da moeda (53)\nexample: 986",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do recebedor (59)\nexample: JOSE DA SILVA",
+                    "type": "string"
+                },
+                "pais": {
+                    "description": "Código do país (58)\nexample: BR",
+                    "type": "string"
+                },
+                "payload_format_indicator": {
+                    "description": "Payload Format Indicator (00)\nexample: 01",
+                    "type": "string"
+                },
+                "ponto_iniciacao": {
+                    "description": "Point of Initiation Method (01)\nexample: 11",
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "Tipo do PIX (ESTATICO ou DINAMICO)\nexample: ESTATICO",
+                    "type": "string"
+                },
+                "txid": {
+                    "description": "Identificador da transação (62.05)\nexample: FATURA123",
+                    "type": "string"
+                },
+                "valido": {
+                    "description": "Indica se o BR Code está íntegro e contém todos os campos obrigatórios\nexample: true",
+                    "type": "boolean"
+                },
+                "valor": {
+                    "description": "Valor da transação (54)\nexample: 100.50",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.BRCodeErro": {
+            "type": "object",
+            "properties": {
+                "campo": {
+                    "description": "Caminho do campo com problema (ex: 26.01)\nexample: 63",
+                    "type": "string"
+                },
+                "mensagem": {
+                    "description": "Descrição do problema\nexample: CRC inválido: esperado 1D3D, encontrado ABCD",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CadastroDesafioRequest": {
+            "type": "object",
+            "required": [
+                "desafio"
+            ],
+            "properties": {
+                "desafio": {
+                    "description": "Desafio retornado pelo login, com cadastro_obrigatorio\nrequired: true\nexample: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CadastroDoisFatoresResponse": {
+            "type": "object",
+            "properties": {
+                "qrcode": {
+                    "description": "QR code da URI, em PNG (base64), para leitura pelo aplicativo\nexample: data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAA...",
+                    "type": "string"
+                },
+                "segredo": {
+                    "description": "Segredo em base32, para digitação manual no aplicativo\nexample: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "URI otpauth:// com o segredo, o emissor e a conta\nexample: otpauth://totp/Gerador%20de%20PIX:contato@lojadojose.com.br?algorithm=SHA1\u0026digits=6\u0026issuer=Gerador+de+PIX\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveAPI": {
+            "type": "object",
+            "properties": {
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "escopos": {
+                    "description": "Escopos concedidos; vazio concede todos\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expira_em": {
+                    "description": "Expiração da chave (opcional)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID da chave de API\nexample: 9b2f6c1e-4a7d-4e8b-9c3f-2d1e0a5b7c6d",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome que identifica a integração\nexample: ERP",
+                    "type": "string"
+                },
+                "prefixo": {
+                    "description": "Início da chave, para identificá-la sem expô-la\nexample: pixk_3q2-7wS",
+                    "type": "string"
+                },
+                "revogada_em": {
+                    "description": "Revogação da chave",
+                    "type": "string"
+                },
+                "ultimo_uso_em": {
+                    "description": "Último uso da chave, atualizado no máximo uma vez por minuto",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveAPICriada": {
+            "type": "object",
+            "properties": {
+                "chave": {
+                    "description": "Chave de API, exibida apenas uma vez; envie-a no header X-API-Key\nexample: pixk_3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "escopos": {
+                    "description": "Escopos concedidos; vazio concede todos\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expira_em": {
+                    "description": "Expiração da chave (opcional)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID da chave de API\nexample: 9b2f6c1e-4a7d-4e8b-9c3f-2d1e0a5b7c6d",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome que identifica a integração\nexample: ERP",
+                    "type": "string"
+                },
+                "prefixo": {
+                    "description": "Início da chave, para identificá-la sem expô-la\nexample: pixk_3q2-7wS",
+                    "type": "string"
+                },
+                "revogada_em": {
+                    "description": "Revogação da chave",
+                    "type": "string"
+                },
+                "ultimo_uso_em": {
+                    "description": "Último uso da chave, atualizado no máximo uma vez por minuto",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveCadastrada": {
+            "type": "object",
+            "properties": {
+                "apelido": {
+                    "description": "Apelido para identificar a chave\nexample: Conta principal",
+                    "type": "string"
+                },
+                "chave": {
+                    "description": "Chave PIX normalizada\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de cadastro",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID da chave, usado em chave_id na geração\nexample: 1",
+                    "type": "integer"
+                },
+                "padrao": {
+                    "description": "Indica a chave usada quando nenhuma é informada\nexample: true",
+                    "type": "boolean"
+                },
+                "tipo_chave": {
+                    "description": "Tipo da chave: CPF, CNPJ, EMAIL, TELEFONE ou ALEATORIA\nexample: EMAIL",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveCadastradaRequest": {
+            "type": "object",
+            "required": [
+                "chave"
+            ],
+            "properties": {
+                "apelido": {
+                    "description": "Apelido para identificar a chave (opcional)\nexample: Conta principal",
+                    "type": "string"
+                },
+                "chave": {
+                    "description": "Chave PIX (obrigatório)\nrequired: true\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "padrao": {
+                    "description": "Torna a chave a padrão do perfil (opcional; a primeira chave cadastrada é sempre a padrão)\nexample: false",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ClienteOAuth": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "description": "Identificador do cliente (client_id)\nexample: 4f1c2b7e-8d3a-4e5f-9a6b-1c2d3e4f5a6b",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "escopos": {
+                    "description": "Escopos que o cliente pode solicitar; vazio permite todos\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nome": {
+                    "description": "Nome que identifica a plataforma parceira\nexample: Plataforma de pedidos",
+                    "type": "string"
+                },
+                "revogado_em": {
+                    "description": "Revogação do cliente",
+                    "type": "string"
+                },
+                "ultimo_uso_em": {
+                    "description": "Emissão do último token de acesso",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ClienteOAuthCriado": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "description": "Identificador do cliente (client_id)\nexample: 4f1c2b7e-8d3a-4e5f-9a6b-1c2d3e4f5a6b",
+                    "type": "string"
+                },
+                "client_secret": {
+                    "description": "Segredo do cliente (client_secret), exibido apenas uma vez\nexample: pixs_Vb3kR9xQ1mZtY7wLc2NfHs8aJdPeUo4iGqK6rT0yXvA",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "escopos": {
+                    "description": "Escopos que o cliente pode solicitar; vazio permite todos\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nome": {
+                    "description": "Nome que identifica a plataforma parceira\nexample: Plataforma de pedidos",
+                    "type": "string"
+                },
+                "revogado_em": {
+                    "description": "Revogação do cliente",
+                    "type": "string"
+                },
+                "ultimo_uso_em": {
+                    "description": "Emissão do último token de acesso",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobRequest": {
+            "type": "object",
+            "required": [
+                "chave",
+                "cidade",
+                "location",
+                "nome",
+                "txid"
+            ],
+            "properties": {
+                "chave": {
+                    "description": "Chave PIX à qual a cobrança está vinculada no PSP (obrigatório)\nrequired: true\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário (obrigatório)\nrequired: true\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "expiracao": {
+                    "description": "Tempo de vida da cobrança em segundos (opcional, padrão 3600)\nexample: 3600",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "location": {
+                    "description": "URL do payload da cobrança no PSP, sem o prefixo https://\nrequired: true\nexample: pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do beneficiário do PIX (obrigatório)\nrequired: true\nexample: JOSE DA SILVA",
+                    "type": "string"
+                },
+                "qrcode": {
+                    "description": "Opções de renderização do QR Code (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.QRCodeRequest"
+                        }
+                    ]
+                },
+                "txid": {
+                    "description": "Identificador da transação junto ao PSP (26 a 35 caracteres alfanuméricos)\nrequired: true\nexample: 7978c0c97ea847e78e8849634473c1f1",
+                    "type": "string",
+                    "maxLength": 35,
+                    "minLength": 26
+                },
+                "valor": {
+                    "description": "Valor da cobrança com no máximo duas casas decimais (opcional)\nexample: 100.50",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobVRequest": {
+            "type": "object",
+            "required": [
+                "chave",
+                "cidade",
+                "location",
+                "nome",
+                "txid",
+                "valor",
+                "vencimento"
+            ],
+            "properties": {
+                "abatimento": {
+                    "description": "Abatimento concedido em qualquer data de pagamento (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AbatimentoCobV"
+                        }
+                    ]
+                },
+                "chave": {
+                    "description": "Chave PIX à qual a cobrança está vinculada no PSP (obrigatório)\nrequired: true\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário (obrigatório)\nrequired: true\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "descontos": {
+                    "description": "Descontos por antecipação, em faixas de data (opcional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.DescontoCobV"
+                    }
+                },
+                "juros": {
+                    "description": "Juros por atraso (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.JurosCobV"
+                        }
+                    ]
+                },
+                "location": {
+                    "description": "URL do payload da cobrança no PSP, sem o prefixo https://\nrequired: true\nexample: pix.example.com/qr/v2/cobv/9d36b84fc70b478fb95c12729b90ca25",
+                    "type": "string"
+                },
+                "multa": {
+                    "description": "Multa por atraso (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.MultaCobV"
+                        }
+                    ]
+                },
+                "nome": {
+                    "description": "Nome do beneficiário do PIX (obrigatório)\nrequired: true\nexample: JOSE DA SILVA",
+                    "type": "string"
+                },
+                "qrcode": {
+                    "description": "Opções de renderização do QR Code (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.QRCodeRequest"
+                        }
+                    ]
+                },
+                "txid": {
+                    "description": "Identificador da transação junto ao PSP (26 a 35 caracteres alfanuméricos)\nrequired: true\nexample: 7978c0c97ea847e78e8849634473c1f1",
+                    "type": "string",
+                    "maxLength": 35,
+                    "minLength": 26
+                },
+                "validade_apos_vencimento": {
+                    "description": "Dias corridos após o vencimento em que a cobrança ainda pode ser paga (opcional, padrão 30)\nexample: 30",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "valor": {
+                    "description": "Valor original da cobrança, com no máximo duas casas decimais\nrequired: true\nexample: 100.00",
+                    "type": "number"
+                },
+                "vencimento": {
+                    "description": "Data de vencimento (AAAA-MM-DD)\nrequired: true\nexample: 2025-01-31",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CobVResponse": {
+            "type": "object",
+            "properties": {
+                "pagavel_ate": {
+                    "description": "Último dia em que a cobrança pode ser paga (AAAA-MM-DD)\nexample: 2025-03-02",
+                    "type": "string"
+                },
+                "pix": {
+                    "description": "Código PIX e QR Codes da cobrança",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse"
+                        }
+                    ]
+                },
+                "txid": {
+                    "description": "Identificador da transação junto ao PSP\nexample: 7978c0c97ea847e78e8849634473c1f1",
+                    "type": "string"
+                },
+                "valores": {
+                    "description": "Valor a pagar na data consultada",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ValoresCobV"
+                        }
+                    ]
+                },
+                "vencimento": {
+                    "description": "Data de vencimento (AAAA-MM-DD)\nexample: 2025-01-31",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigoDoisFatoresRequest": {
+            "type": "object",
+            "required": [
+                "codigo"
+            ],
+            "properties": {
+                "codigo": {
+                    "description": "Código de 6 dígitos do aplicativo autenticador\nrequired: true\nexample: 123456",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CodigosRecuperacaoResponse": {
+            "type": "object",
+            "properties": {
+                "codigos_recuperacao": {
+                    "description": "Códigos de uso único que substituem o aplicativo autenticador no login\nexample: [\"k7m2q-x9p4t\",\"a3b8c-d2e6f\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ConvidarUsuarioRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "papel"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email do usuário convidado\nrequired: true\nexample: maria@lojadojose.com.br",
+                    "type": "string"
+                },
+                "papel": {
+                    "description": "Papel do usuário: gerente, caixa ou leitura\nrequired: true\nexample: caixa",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ConviteCriado": {
+            "type": "object",
+            "properties": {
+                "aceito_em": {
+                    "description": "Aceite do convite; vazio enquanto o convite está pendente",
+                    "type": "string"
+                },
+                "ativo": {
+                    "description": "Indica se o usuário pode acessar a API\nexample: true",
+                    "type": "boolean"
+                },
+                "atualizado_em": {
+                    "description": "Data da última atualização",
+                    "type": "string"
+                },
+                "convite": {
+                    "description": "Convite a ser entregue ao usuário, exibido apenas uma vez; é aceito em /usuarios/convites/aceitar\nexample: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
+                    "type": "string"
+                },
+                "convite_expira_em": {
+                    "description": "Expiração do convite ainda não aceito",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email do usuário (usado para login)\nexample: maria@lojadojose.com.br",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID do usuário\nexample: 5f0c2b7e-8d1a-4c3e-9b6f-7a2d4e1c0b9a",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do usuário, informado ao aceitar o convite\nexample: Maria Souza",
+                    "type": "string"
+                },
+                "papel": {
+                    "description": "Papel do usuário: gerente, caixa ou leitura\nexample: caixa",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CriarChaveAPIRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "escopos": {
+                    "description": "Escopos concedidos (opcional); omitidos, a chave acessa todas as rotas de PIX, cobranças e perfil\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expira_em": {
+                    "description": "Expiração da chave (opcional)\nexample: 2026-12-31T23:59:59Z",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome que identifica a integração\nrequired: true\nexample: ERP",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.CriarClienteOAuthRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "escopos": {
+                    "description": "Escopos que o cliente pode solicitar (opcional); omitidos, o cliente pode solicitar todos\nexample: [\"pix.write\",\"pix.read\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nome": {
+                    "description": "Nome que identifica a plataforma parceira\nrequired: true\nexample: Plataforma de pedidos",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.DecodeRequest": {
+            "type": "object",
+            "required": [
+                "codigo_pix"
+            ],
+            "properties": {
+                "codigo_pix": {
+                    "description": "Código PIX \"copia e cola\" a ser decodificado\nrequired: true\nexample: 00020101021126580014BR.GOV.BCB.PIX0136josesilva@email.com5204000053039865802BR5913JOSE DA SILVA6009SAO PAULO62070503***6304ABCD",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesafioDoisFatoresResponse": {
+            "type": "object",
+            "properties": {
+                "cadastro_obrigatorio": {
+                    "description": "Indica que o estabelecimento exige o segundo fator e o login ainda não o cadastrou: o\ncadastro é iniciado em /login/dois-fatores/cadastro e confirmado com o primeiro código\nexample: false",
+                    "type": "boolean"
+                },
+                "desafio": {
+                    "description": "Desafio a ser enviado com o código em /login/dois-fatores; vale para um único login\nexample: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA",
+                    "type": "string"
+                },
+                "expira_em_segundos": {
+                    "description": "Validade do desafio, em segundos\nexample: 300",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesativarDoisFatoresRequest": {
+            "type": "object",
+            "required": [
+                "codigo",
+                "senha"
+            ],
+            "properties": {
+                "codigo": {
+                    "description": "Código de 6 dígitos do aplicativo autenticador ou um código de recuperação\nrequired: true\nexample: 123456",
+                    "type": "string"
+                },
+                "senha": {
+                    "description": "Senha atual do login\nrequired: true\nexample: senha123",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.DesativarEstabelecimentoRequest": {
+            "type": "object",
+            "required": [
+                "senha_atual"
+            ],
+            "properties": {
+                "senha_atual": {
+                    "description": "Senha atual, exigida para confirmar a desativação\nrequired: true\nexample: senha123",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.DescontoCobV": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Último dia em que o desconto é concedido (AAAA-MM-DD), até o vencimento\nrequired: true\nexample: 2025-01-20",
+                    "type": "string"
+                },
+                "modalidade": {
+                    "description": "Modalidade: VALOR_FIXO ou PERCENTUAL\nrequired: true\nexample: PERCENTUAL",
+                    "type": "string"
+                },
+                "valor_perc": {
+                    "description": "Valor em reais ou percentual, conforme a modalidade\nrequired: true\nexample: 5.00",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ErroValidacao": {
+            "type": "object",
+            "properties": {
+                "campo": {
+                    "description": "Campo que falhou na validação\nexample: chave",
+                    "type": "string"
+                },
+                "mensagem": {
+                    "description": "Descrição do problema encontrado\nexample: CPF inválido: dígitos verificadores não conferem",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.EsqueciSenhaRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email do estabelecimento ou do usuário da equipe\nrequired: true\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nome",
+                "senha"
+            ],
+            "properties": {
+                "descricao": {
+                    "description": "Descrição do estabelecimento (opcional)\nexample: Loja de produtos diversos",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email do estabelecimento (usado para login)\nrequired: true\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do estabelecimento\nrequired: true\nexample: Loja do José",
+                    "type": "string"
+                },
+                "senha": {
+                    "description": "Senha do estabelecimento (mínimo 6 caracteres)\nrequired: true\nexample: senha123\nmin length: 6",
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "description": "Indica se o estabelecimento administra os demais\nexample: false",
+                    "type": "boolean"
+                },
+                "ativo": {
+                    "description": "Status de ativação do estabelecimento\nexample: true",
+                    "type": "boolean"
+                },
+                "atualizado_em": {
+                    "description": "Data da última atualização\nexample: 2023-01-01T12:00:00Z",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação\nexample: 2023-01-01T12:00:00Z",
+                    "type": "string"
+                },
+                "descricao": {
+                    "description": "Descrição do estabelecimento (opcional)\nexample: Loja de produtos diversos",
+                    "type": "string"
+                },
+                "dois_fatores_obrigatorio": {
+                    "description": "Indica se o segundo fator é exigido no login do estabelecimento e dos usuários da equipe\nexample: false",
+                    "type": "boolean"
+                },
+                "email": {
+                    "description": "Email do estabelecimento\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                },
+                "email_verificado_em": {
+                    "description": "Data da confirmação do email, ausente enquanto ele não é confirmado\nexample: 2023-01-01T12:30:00Z",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do estabelecimento\nexample: 123e4567-e89b-12d3-a456-426614174000",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do estabelecimento\nexample: Loja do José",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.IdiomaAlternativoRequest": {
+            "type": "object",
+            "properties": {
+                "cidade": {
+                    "description": "Cidade do beneficiário no idioma alternativo (até 15 caracteres, opcional)\nexample: SAINT PAUL",
+                    "type": "string"
+                },
+                "idioma": {
+                    "description": "Idioma no padrão ISO 639-1 (2 letras)\nrequired: true\nexample: EN",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do beneficiário no idioma alternativo (até 25 caracteres)\nrequired: true\nexample: JOSEPH SILVA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.JobResponse": {
+            "type": "object",
+            "properties": {
+                "concluido_em": {
+                    "description": "Fim do processamento",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "erro": {
+                    "description": "Mensagem de erro, quando o job falhou",
+                    "type": "string"
+                },
+                "falhas": {
+                    "description": "Linhas com erro\nexample: 5",
+                    "type": "integer"
+                },
+                "formato": {
+                    "description": "Formato do resultado: json ou zip\nexample: zip",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Identificador do job\nexample: 3f1c2a9e-8b7d-4e6f-9a1b-2c3d4e5f6a7b",
+                    "type": "string"
+                },
+                "iniciado_em": {
+                    "description": "Início do processamento",
+                    "type": "string"
+                },
+                "processados": {
+                    "description": "Linhas já processadas\nexample: 1200",
+                    "type": "integer"
+                },
+                "progresso": {
+                    "description": "Percentual de linhas processadas\nexample: 24",
+                    "type": "integer"
+                },
+                "resultado_url": {
+                    "description": "Caminho para download do resultado, quando o job foi concluído\nexample: /api/jobs/3f1c2a9e-8b7d-4e6f-9a1b-2c3d4e5f6a7b/result",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Situação: PENDENTE, PROCESSANDO, CONCLUIDO, FALHOU ou CANCELADO\nexample: PROCESSANDO",
+                    "type": "string"
+                },
+                "sucessos": {
+                    "description": "Linhas geradas e salvas com sucesso\nexample: 1195",
+                    "type": "integer"
+                },
+                "template": {
+                    "description": "Template aplicado às imagens do ZIP\nexample: template_pix_1",
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "Tipo do job\nexample: GERACAO_LOTE",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total de linhas do lote\nexample: 5000",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.JurosCobV": {
+            "type": "object",
+            "properties": {
+                "modalidade": {
+                    "description": "Modalidade: SIMPLES_DIA, SIMPLES_MES, COMPOSTO_DIA ou COMPOSTO_MES\nrequired: true\nexample: SIMPLES_MES",
+                    "type": "string"
+                },
+                "valor_perc": {
+                    "description": "Taxa percentual, com até duas casas decimais\nrequired: true\nexample: 1.00",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginDoisFatoresRequest": {
+            "type": "object",
+            "required": [
+                "codigo",
+                "desafio"
+            ],
+            "properties": {
+                "codigo": {
+                    "description": "Código de 6 dígitos do aplicativo autenticador ou um código de recuperação\nrequired: true\nexample: 123456",
+                    "type": "string"
+                },
+                "desafio": {
+                    "description": "Desafio retornado pelo login\nrequired: true\nexample: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "senha"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email do estabelecimento ou de um usuário da equipe\nrequired: true\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                },
+                "senha": {
+                    "description": "Senha do estabelecimento ou do usuário\nrequired: true\nexample: senha123",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "codigos_recuperacao": {
+                    "description": "Códigos de recuperação do segundo fator cadastrado durante o login, exibidos apenas nesta resposta\nexample: [\"k7m2q-x9p4t\",\"a3b8c-d2e6f\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "estabelecimento": {
+                    "description": "Informações do estabelecimento autenticado",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
+                        }
+                    ]
+                },
+                "expira_em_segundos": {
+                    "description": "Validade do token JWT, em segundos\nexample: 900",
+                    "type": "integer"
+                },
+                "papel": {
+                    "description": "Papel de quem fez login: proprietario, no login do estabelecimento, ou o papel do usuário da equipe\nexample: proprietario",
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "Refresh token para obter um novo token JWT em /token/refresh; é trocado a cada uso\nexample: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token JWT de curta duração para autenticação nas rotas protegidas\nexample: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...",
+                    "type": "string"
+                },
+                "usuario": {
+                    "description": "Informações do usuário da equipe, ausentes no login do estabelecimento",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token da sessão (opcional); informado, os refresh tokens da sessão também são revogados\nexample: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.MultaCobV": {
+            "type": "object",
+            "properties": {
+                "modalidade": {
+                    "description": "Modalidade: VALOR_FIXO ou PERCENTUAL\nrequired: true\nexample: PERCENTUAL",
+                    "type": "string"
+                },
+                "valor_perc": {
+                    "description": "Valor em reais ou percentual, conforme a modalidade\nrequired: true\nexample: 2.00",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PaginaPix": {
+            "type": "object",
+            "properties": {
+                "itens": {
+                    "description": "PIX da página",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResumo"
+                    }
+                },
+                "proximo_cursor": {
+                    "description": "Cursor para a próxima página; ausente na última página\nexample: eyJvIjoiY3JpYWRvX2VtIiwiZCI6dHJ1ZSwiaSI6NDJ9",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total de PIX que atendem aos filtros, em todas as páginas\nexample: 1250",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilEstabelecimento": {
+            "type": "object",
+            "properties": {
+                "cep": {
+                    "description": "CEP com 8 dígitos, sem hífen\nexample: 01310100",
+                    "type": "string"
+                },
+                "chaves": {
+                    "description": "Chaves PIX cadastradas; a chave padrão é usada quando a requisição não informa uma",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ChaveCadastrada"
+                    }
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "mcc": {
+                    "description": "Merchant Category Code com 4 dígitos\nexample: 5812",
+                    "type": "string"
+                },
+                "razao_social": {
+                    "description": "Razão social, usada como nome do beneficiário\nexample: JOSE DA SILVA COMERCIO LTDA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PerfilRequest": {
+            "type": "object",
+            "properties": {
+                "cep": {
+                    "description": "CEP com 8 dígitos, com ou sem hífen (opcional)\nexample: 01310-100",
+                    "type": "string"
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário (opcional)\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "mcc": {
+                    "description": "Merchant Category Code com 4 dígitos (opcional)\nexample: 5812",
+                    "type": "string"
+                },
+                "razao_social": {
+                    "description": "Razão social, usada como nome do beneficiário (opcional)\nexample: JOSE DA SILVA COMERCIO LTDA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest": {
+            "type": "object",
+            "properties": {
+                "cep": {
+                    "description": "CEP do beneficiário com 8 dígitos (opcional, padrão: CEP do perfil)\nexample: 01310-100",
+                    "type": "string"
+                },
+                "chave": {
+                    "description": "Chave PIX do beneficiário (padrão: chave padrão do perfil)\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "chave_id": {
+                    "description": "ID de uma chave cadastrada no perfil, em vez da chave (opcional)\nexample: 1",
+                    "type": "integer"
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário (padrão: cidade do perfil)\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "descricao": {
+                    "description": "Descrição da transação (opcional)\nexample: PAGAMENTO DE SERVICOS",
+                    "type": "string"
+                },
+                "identificador": {
+                    "description": "Identificador único da transação, com até 25 letras e números (opcional)\nexample: FATURA123",
+                    "type": "string"
+                },
+                "idioma_alternativo": {
+                    "description": "Nome e cidade do beneficiário em um idioma alternativo (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.IdiomaAlternativoRequest"
+                        }
+                    ]
+                },
+                "mcc": {
+                    "description": "Merchant Category Code com 4 dígitos (opcional, padrão: MCC do perfil ou 0000)\nexample: 5812",
+                    "type": "string"
+                },
+                "modalidade": {
+                    "description": "Modalidade do PIX: COMPRA, SAQUE ou TROCO (opcional, padrão COMPRA)\nexample: TROCO",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do beneficiário do PIX (padrão: razão social do perfil)\nexample: JOSE DA SILVA",
+                    "type": "string"
+                },
+                "qrcode": {
+                    "description": "Opções de renderização do QR Code (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.QRCodeRequest"
+                        }
+                    ]
+                },
+                "saque_troco": {
+                    "description": "Dados do agente de saque, obrigatórios nas modalidades SAQUE e TROCO",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.SaqueTrocoRequest"
+                        }
+                    ]
+                },
+                "templates": {
+                    "description": "Templates de uso livre (80 a 99) com dados de parceiros (opcional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.TemplateNaoReservadoRequest"
+                    }
+                },
+                "valor": {
+                    "description": "Valor da transação com no máximo duas casas decimais (opcional)\nexample: 100.50",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse": {
+            "type": "object",
             "properties": {
-                "ativo": {
-                    "description": "Status de ativação do estabelecimento\nexample: true",
-                    "type": "boolean"
+                "ajustes": {
+                    "description": "Campos de texto que foram alterados para caber no BR Code (acentos, caracteres e tamanho)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AjusteCampo"
+                    }
                 },
-                "atualizado_em": {
-                    "description": "Data da última atualização\nexample: 2023-01-01T12:00:00Z",
+                "codigo_pix": {
+                    "description": "Código PIX gerado conforme padrão EMV\nexample: 00020101021126580014BR.GOV.BCB.PIX0136josesilva@email.com5204000053039865802BR5913JOSE DA SILVA6009SAO PAULO62150511FATURA12308103100.506304E5B1",
+                    "type": "string"
+                },
+                "modalidade": {
+                    "description": "Modalidade do PIX (COMPRA, SAQUE ou TROCO)\nexample: COMPRA",
+                    "type": "string"
+                },
+                "qrcode_png": {
+                    "description": "QR Code em formato PNG (base64)\nexample: data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAA...",
+                    "type": "string"
+                },
+                "qrcode_svg": {
+                    "description": "QR Code em formato SVG\nexample: \u003csvg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 41 41\" shape-rendering=\"crispEdges\"\u003e...\u003c/svg\u003e",
+                    "type": "string"
+                },
+                "tipo_chave": {
+                    "description": "Tipo da chave PIX detectado (CPF, CNPJ, TELEFONE, EMAIL ou EVP)\nexample: EMAIL",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResumo": {
+            "type": "object",
+            "properties": {
+                "chave": {
+                    "description": "Chave PIX do beneficiário\nexample: josesilva@email.com",
+                    "type": "string"
+                },
+                "cidade": {
+                    "description": "Cidade do beneficiário\nexample: SAO PAULO",
+                    "type": "string"
+                },
+                "codigo_pix": {
+                    "description": "Código PIX \"copia e cola\"",
                     "type": "string"
                 },
                 "criado_em": {
-                    "description": "Data de criação\nexample: 2023-01-01T12:00:00Z",
+                    "description": "Data de criação",
                     "type": "string"
                 },
                 "descricao": {
-                    "description": "Descrição do estabelecimento (opcional)\nexample: Loja de produtos diversos",
+                    "description": "Descrição da transação",
                     "type": "string"
                 },
-                "email": {
-                    "description": "Email do estabelecimento\nexample: contato@lojadojose.com.br",
+                "expira_em": {
+                    "description": "Data de expiração da cobrança",
                     "type": "string"
                 },
                 "id": {
-                    "description": "ID único do estabelecimento\nexample: 123e4567-e89b-12d3-a456-426614174000",
+                    "description": "ID do PIX\nexample: 42",
+                    "type": "integer"
+                },
+                "identificador": {
+                    "description": "Identificador da transação\nexample: FATURA123",
+                    "type": "string"
+                },
+                "modalidade": {
+                    "description": "Modalidade do PIX estático\nexample: COMPRA",
                     "type": "string"
                 },
                 "nome": {
-                    "description": "Nome do estabelecimento\nexample: Loja do José",
+                    "description": "Nome do beneficiário\nexample: JOSE DA SILVA",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Situação: ATIVO ou EXPIRADO\nexample: ATIVO",
+                    "type": "string"
+                },
+                "tipo": {
+                    "description": "Tipo: ESTATICO, DINAMICO ou COBV\nexample: ESTATICO",
+                    "type": "string"
+                },
+                "tipo_chave": {
+                    "description": "Tipo da chave PIX\nexample: EMAIL",
                     "type": "string"
+                },
+                "txid": {
+                    "description": "Identificador da cobrança",
+                    "type": "string"
+                },
+                "valor": {
+                    "description": "Valor da transação\nexample: 100.50",
+                    "type": "number"
                 }
             }
         },
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginRequest": {
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PoliticaDoisFatoresRequest": {
             "type": "object",
             "required": [
-                "email",
-                "senha"
+                "obrigatorio"
             ],
             "properties": {
-                "email": {
-                    "description": "Email do estabelecimento\nrequired: true\nexample: contato@lojadojose.com.br",
+                "obrigatorio": {
+                    "description": "Exige o segundo fator no login do estabelecimento e dos usuários da equipe\nrequired: true\nexample: true",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.QRCodeRequest": {
+            "type": "object",
+            "properties": {
+                "cor": {
+                    "description": "Cor dos módulos em hexadecimal, #RGB ou #RRGGBB (padrão #000000)\nexample: #1A1A1A",
                     "type": "string"
                 },
-                "senha": {
-                    "description": "Senha do estabelecimento\nrequired: true\nexample: senha123",
+                "fundo": {
+                    "description": "Cor de fundo em hexadecimal, #RGB ou #RRGGBB (padrão #FFFFFF)\nexample: #FFFFFF",
+                    "type": "string"
+                },
+                "margem": {
+                    "description": "Zona de silêncio ao redor do QR Code em módulos, de 0 a 16 (padrão 4)\nexample: 4",
+                    "type": "integer"
+                },
+                "nivel": {
+                    "description": "Nível de correção de erro: L, M, Q ou H (padrão M)\nexample: H",
                     "type": "string"
+                },
+                "tamanho": {
+                    "description": "Largura e altura do PNG em pixels, de 64 a 2048 (padrão 256)\nexample: 1024",
+                    "type": "integer"
+                },
+                "transparente": {
+                    "description": "Gera o fundo transparente, ignorando a cor de fundo\nexample: false",
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.LoginResponse": {
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.RedefinirSenhaRequest": {
             "type": "object",
+            "required": [
+                "nova_senha",
+                "token"
+            ],
             "properties": {
-                "estabelecimento": {
-                    "description": "Informações do estabelecimento autenticado",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.EstabelecimentoResponse"
-                        }
-                    ]
+                "nova_senha": {
+                    "description": "Nova senha (mínimo 6 caracteres)\nrequired: true\nexample: novaSenha456\nmin length: 6",
+                    "type": "string",
+                    "minLength": 6
                 },
                 "token": {
-                    "description": "Token JWT para autenticação nas rotas protegidas\nexample: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...",
+                    "description": "Token recebido no email de redefinição de senha\nrequired: true\nexample: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA",
                     "type": "string"
                 }
             }
         },
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixRequest": {
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ReenviarVerificacaoRequest": {
             "type": "object",
             "required": [
-                "chave",
-                "cidade",
-                "nome"
+                "email"
             ],
             "properties": {
-                "chave": {
-                    "description": "Chave PIX do beneficiário (obrigatório)\nrequired: true\nexample: josesilva@email.com",
+                "email": {
+                    "description": "Email do estabelecimento\nrequired: true\nexample: contato@lojadojose.com.br",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token recebido no login ou na última renovação\nrequired: true\nexample: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA",
                     "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ResultadoItemLote": {
+            "type": "object",
+            "properties": {
+                "ajustes": {
+                    "description": "Campos de texto ajustados para caber no padrão do BR Code",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.AjusteCampo"
+                    }
                 },
-                "cidade": {
-                    "description": "Cidade do beneficiário (obrigatório)\nrequired: true\nexample: SAO PAULO",
+                "codigo_pix": {
+                    "description": "Código PIX gerado\nexample: 00020101021126580014BR.GOV.BCB.PIX0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913FULANO DE TAL6008BRASILIA62070503***63041D3D",
                     "type": "string"
                 },
-                "descricao": {
-                    "description": "Descrição da transação (opcional)\nexample: PAGAMENTO DE SERVICOS",
+                "erro": {
+                    "description": "Mensagem de erro, quando a linha falhou\nexample: chave: chave PIX inválida",
                     "type": "string"
                 },
+                "erros": {
+                    "description": "Erros de validação por campo, quando a linha falhou na validação",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ErroValidacao"
+                    }
+                },
+                "id": {
+                    "description": "ID do PIX salvo\nexample: 42",
+                    "type": "integer"
+                },
                 "identificador": {
-                    "description": "Identificador único da transação (opcional)\nexample: FATURA123",
+                    "description": "Identificador da transação informado na linha\nexample: FATURA123",
                     "type": "string"
                 },
-                "nome": {
-                    "description": "Nome do beneficiário do PIX (obrigatório)\nrequired: true\nexample: JOSE DA SILVA",
+                "linha": {
+                    "description": "Linha na entrada original, a partir de 1\nexample: 1",
+                    "type": "integer"
+                },
+                "modalidade": {
+                    "description": "Modalidade do PIX: COMPRA, SAQUE ou TROCO\nexample: COMPRA",
                     "type": "string"
                 },
-                "valor": {
-                    "description": "Valor da transação (opcional)\nexample: 100.50",
+                "sucesso": {
+                    "description": "Indica se o PIX foi gerado e salvo\nexample: true",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ResultadoLote": {
+            "type": "object",
+            "properties": {
+                "falhas": {
+                    "description": "Linhas com erro\nexample: 1",
+                    "type": "integer"
+                },
+                "itens": {
+                    "description": "Resultado de cada linha, na ordem da entrada",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ResultadoItemLote"
+                    }
+                },
+                "sucessos": {
+                    "description": "Linhas geradas e salvas com sucesso\nexample: 2",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total de linhas recebidas\nexample: 3",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.SaqueTrocoRequest": {
+            "type": "object",
+            "properties": {
+                "modalidade_agente": {
+                    "description": "Modalidade do agente: AGTEC (estabelecimento comercial), AGTOT (outra pessoa jurídica) ou AGPSS (facilitador de serviço de saque)\nrequired: true\nexample: AGTEC",
+                    "type": "string"
+                },
+                "prestador_ispb": {
+                    "description": "ISPB do prestador do serviço de saque, com 8 dígitos\nrequired: true\nexample: 12345678",
+                    "type": "string"
+                },
+                "valor_compra": {
+                    "description": "Valor da compra no PIX Troco; o troco é a diferença entre o valor do PIX e o da compra\nexample: 80.00",
                     "type": "number"
                 }
             }
         },
-        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.PixResponse": {
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.StatusDoisFatoresResponse": {
             "type": "object",
             "properties": {
-                "codigo_pix": {
-                    "description": "Código PIX gerado conforme padrão EMV\nexample: 00020101021126580014BR.GOV.BCB.PIX0136josesilva@email.com5204000053039865802BR5913JOSE DA SILVA6009SAO PAULO62150511FATURA12308103100.506304E5B1",
+                "ativo": {
+                    "description": "Indica se o segundo fator está ativo no login\nexample: true",
+                    "type": "boolean"
+                },
+                "codigos_recuperacao_restantes": {
+                    "description": "Códigos de recuperação ainda não usados\nexample: 10",
+                    "type": "integer"
+                },
+                "obrigatorio": {
+                    "description": "Indica se o estabelecimento exige o segundo fator de todos os logins\nexample: false",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.SubcampoEMVRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID do sub-campo com 2 dígitos\nexample: 01",
                     "type": "string"
                 },
-                "qrcode_png": {
-                    "description": "QR Code em formato PNG (base64)\nexample: data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAA...",
+                "valor": {
+                    "description": "Conteúdo do sub-campo\nexample: LOJA123",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.TemplateNaoReservadoRequest": {
+            "type": "object",
+            "properties": {
+                "gui": {
+                    "description": "Identificador único do parceiro, gravado no sub-campo 00 (até 32 caracteres)\nrequired: true\nexample: BR.COM.PARCEIRO",
                     "type": "string"
                 },
-                "qrcode_svg": {
-                    "description": "QR Code em formato SVG\nexample: \u003csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 250 250'\u003e...\u003c/svg\u003e",
+                "id": {
+                    "description": "ID do template, de 80 a 99\nrequired: true\nexample: 80",
+                    "type": "string"
+                },
+                "subcampos": {
+                    "description": "Sub-campos com os dados do parceiro, de 01 a 99",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.SubcampoEMVRequest"
+                    }
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.Usuario": {
+            "type": "object",
+            "properties": {
+                "aceito_em": {
+                    "description": "Aceite do convite; vazio enquanto o convite está pendente",
+                    "type": "string"
+                },
+                "ativo": {
+                    "description": "Indica se o usuário pode acessar a API\nexample: true",
+                    "type": "boolean"
+                },
+                "atualizado_em": {
+                    "description": "Data da última atualização",
+                    "type": "string"
+                },
+                "convite_expira_em": {
+                    "description": "Expiração do convite ainda não aceito",
+                    "type": "string"
+                },
+                "criado_em": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email do usuário (usado para login)\nexample: maria@lojadojose.com.br",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID do usuário\nexample: 5f0c2b7e-8d1a-4c3e-9b6f-7a2d4e1c0b9a",
+                    "type": "string"
+                },
+                "nome": {
+                    "description": "Nome do usuário, informado ao aceitar o convite\nexample: Maria Souza",
+                    "type": "string"
+                },
+                "papel": {
+                    "description": "Papel do usuário: gerente, caixa ou leitura\nexample: caixa",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.ValoresCobV": {
+            "type": "object",
+            "properties": {
+                "abatimento": {
+                    "description": "Abatimento concedido\nexample: 0.00",
+                    "type": "number"
+                },
+                "data_pagamento": {
+                    "description": "Data de pagamento considerada no cálculo (AAAA-MM-DD)\nexample: 2025-02-10",
+                    "type": "string"
+                },
+                "desconto": {
+                    "description": "Desconto por antecipação concedido\nexample: 0.00",
+                    "type": "number"
+                },
+                "dias_atraso": {
+                    "description": "Dias corridos de atraso em relação ao vencimento\nexample: 10",
+                    "type": "integer"
+                },
+                "final": {
+                    "description": "Valor a pagar na data\nexample: 102.33",
+                    "type": "number"
+                },
+                "juros": {
+                    "description": "Juros por atraso\nexample: 0.33",
+                    "type": "number"
+                },
+                "multa": {
+                    "description": "Multa por atraso\nexample: 2.00",
+                    "type": "number"
+                },
+                "original": {
+                    "description": "Valor original da cobrança\nexample: 100.00",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_rodrigocostarcs_pix-generator_internal_domain_models.VerificarEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "Token recebido no email de verificação\nrequired: true\nexample: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA",
                     "type": "string"
                 }
             }
@@ -431,6 +5323,13 @@ const docTemplate = `{
                     "description": "Mensagem de erro (apenas quando Success = false)\nexample: Credenciais inválidas",
                     "type": "string"
                 },
+                "erros": {
+                    "description": "Erros de validação por campo (apenas quando a requisição é rejeitada com 422)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rodrigocostarcs_pix-generator_internal_domain_models.ErroValidacao"
+                    }
+                },
                 "success": {
                     "description": "Indica se a requisição foi bem-sucedida\nexample: true",
                     "type": "boolean"
@@ -439,6 +5338,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Chave de API do estabelecimento, limitada aos escopos concedidos",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Digite 'Bearer ' seguido do token JWT",
             "type": "apiKey",
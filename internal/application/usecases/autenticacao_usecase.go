@@ -1,33 +1,70 @@
 package usecases
 
 import (
+	"context"
 	"errors"
+	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
 )
 
+var (
+	// ErrRefreshTokenInvalido indica um refresh token inexistente ou expirado
+	ErrRefreshTokenInvalido = errors.New("refresh token inválido ou expirado")
+
+	// ErrRefreshTokenReutilizado indica a reutilização de um refresh token já trocado ou
+	// revogado; por precaução, todos os refresh tokens da sessão são revogados
+	ErrRefreshTokenReutilizado = errors.New("refresh token reutilizado: a sessão foi encerrada")
+
+	// errCredenciaisInvalidas é retornado no login sem revelar se o email existe
+	errCredenciaisInvalidas = errors.New("credenciais inválidas")
+)
+
 // AutenticacaoUseCase implementa o caso de uso para autenticação
 type AutenticacaoUseCase struct {
 	autenticacaoService       *services.AutenticacaoService
 	estabelecimentoRepository repositories.EstabelecimentoRepository
+	usuarioRepository         repositories.UsuarioRepository
+	refreshTokenRepository    repositories.RefreshTokenRepository
+	revogacao                 cache.RevogacaoAdapter
+	contaUseCase              *ContaUseCase
+	protecaoLogin             *ProtecaoLoginUseCase
+	doisFatores               *DoisFatoresUseCase
 }
 
 // NewAutenticacaoUseCase cria uma nova instância do caso de uso de autenticação
-func NewAutenticacaoUseCase(autenticacaoService *services.AutenticacaoService, estabelecimentoRepository repositories.EstabelecimentoRepository) *AutenticacaoUseCase {
+func NewAutenticacaoUseCase(
+	autenticacaoService *services.AutenticacaoService,
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+	usuarioRepository repositories.UsuarioRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	revogacao cache.RevogacaoAdapter,
+	contaUseCase *ContaUseCase,
+	protecaoLogin *ProtecaoLoginUseCase,
+	doisFatores *DoisFatoresUseCase,
+) *AutenticacaoUseCase {
 	return &AutenticacaoUseCase{
 		autenticacaoService:       autenticacaoService,
 		estabelecimentoRepository: estabelecimentoRepository,
+		usuarioRepository:         usuarioRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		revogacao:                 revogacao,
+		contaUseCase:              contaUseCase,
+		protecaoLogin:             protecaoLogin,
+		doisFatores:               doisFatores,
 	}
 }
 
-// Registrar registra um novo estabelecimento
+// Registrar registra um novo estabelecimento e envia o link de confirmação do email
 func (uc *AutenticacaoUseCase) Registrar(req models.EstabelecimentoRequest) (models.EstabelecimentoResponse, error) {
-	// Verificar se já existe um estabelecimento com o mesmo email
-	_, err := uc.estabelecimentoRepository.BuscarPorEmail(req.Email)
-	if err == nil {
-		return models.EstabelecimentoResponse{}, errors.New("email já cadastrado")
+	// Verificar se já existe um estabelecimento ou usuário com o mesmo email
+	if err := verificarEmailDisponivel(uc.estabelecimentoRepository, uc.usuarioRepository, req.Email, ""); err != nil {
+		return models.EstabelecimentoResponse{}, err
 	}
 
 	// Criar o estabelecimento
@@ -36,58 +73,327 @@ func (uc *AutenticacaoUseCase) Registrar(req models.EstabelecimentoRequest) (mod
 		return models.EstabelecimentoResponse{}, err
 	}
 
+	// O cadastro vale mesmo sem o envio, e o link pode ser reenviado depois
+	if err := uc.contaUseCase.EnviarVerificacao(context.Background(), estabelecimento); err != nil {
+		log.Printf("Erro ao enviar a verificação de email do estabelecimento %s: %v", estabelecimento.ID, err)
+	}
+
 	// Preparar a resposta sem expor a senha
-	response := models.EstabelecimentoResponse{
-		ID:           estabelecimento.ID,
-		Nome:         estabelecimento.Nome,
-		Descricao:    estabelecimento.Descricao,
-		Email:        estabelecimento.Email,
-		Ativo:        estabelecimento.Ativo,
-		CriadoEm:     estabelecimento.CriadoEm,
-		AtualizadoEm: estabelecimento.AtualizadoEm,
+	return models.NovoEstabelecimentoResponse(estabelecimento), nil
+}
+
+// Login autentica um estabelecimento ou um usuário da sua equipe e gera um token JWT com um
+// refresh token de uma nova sessão. Cada tentativa é reservada na proteção contra força bruta
+// do email e do IP informado antes da conferência da senha, e as senhas incorretas continuam
+// contando; além do limite, o login é recusado com ErroLoginBloqueado. Quando o segundo fator
+// é exigido, a resposta traz apenas o desafio, concluído em ConcluirLogin.
+func (uc *AutenticacaoUseCase) Login(ctx context.Context, req models.LoginRequest, ip string) (models.LoginResponse, error) {
+	reserva, err := uc.protecaoLogin.Reservar(ctx, req.Email, ip)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	response, err := uc.autenticar(ctx, req)
+	if errors.Is(err, errCredenciaisInvalidas) {
+		if errFalha := uc.protecaoLogin.RegistrarFalha(ctx, reserva); errFalha != nil {
+			return models.LoginResponse{}, errFalha
+		}
+		return models.LoginResponse{}, err
+	}
+	if err != nil {
+		// A senha não foi recusada, então a tentativa não conta para os limites
+		if errLiberar := uc.protecaoLogin.Liberar(ctx, reserva); errLiberar != nil {
+			log.Printf("Erro ao liberar a tentativa de login de %s: %v", req.Email, errLiberar)
+		}
+		return models.LoginResponse{}, err
+	}
+
+	if err := uc.protecaoLogin.RegistrarSucesso(ctx, reserva); err != nil {
+		return models.LoginResponse{}, err
 	}
 
 	return response, nil
 }
 
-// Login autentica um estabelecimento e gera um token JWT
-func (uc *AutenticacaoUseCase) Login(req models.LoginRequest) (models.LoginResponse, error) {
-	// Buscar o estabelecimento pelo email
+// autenticar confere as credenciais do estabelecimento ou do usuário da equipe e emite os tokens
+func (uc *AutenticacaoUseCase) autenticar(ctx context.Context, req models.LoginRequest) (models.LoginResponse, error) {
+	// Buscar o estabelecimento pelo email; sem estabelecimento, o email pode ser de um usuário
 	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorEmail(req.Email)
+	if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+		return uc.loginUsuario(ctx, req)
+	}
 	if err != nil {
-		return models.LoginResponse{}, errors.New("credenciais inválidas")
+		return models.LoginResponse{}, errCredenciaisInvalidas
+	}
+
+	// Verificar a senha antes da situação da conta, que só é revelada a quem conhece a senha
+	err = uc.autenticacaoService.VerificarSenha(estabelecimento.Senha, req.Senha)
+	if err != nil {
+		return models.LoginResponse{}, errCredenciaisInvalidas
 	}
 
 	// Verificar se o estabelecimento está ativo
 	if !estabelecimento.Ativo {
-		return models.LoginResponse{}, errors.New("conta desativada")
+		return models.LoginResponse{}, services.ErrContaDesativada
 	}
 
-	// Verificar a senha
-	err = uc.autenticacaoService.VerificarSenha(estabelecimento.Senha, req.Senha)
+	// Com a verificação obrigatória, o email precisa estar confirmado
+	if err := uc.contaUseCase.ConferirVerificacao(estabelecimento); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	return uc.iniciarSessao(ctx, estabelecimento, nil)
+}
+
+// loginUsuario autentica um usuário da equipe de um estabelecimento
+func (uc *AutenticacaoUseCase) loginUsuario(ctx context.Context, req models.LoginRequest) (models.LoginResponse, error) {
+	usuario, err := uc.usuarioRepository.BuscarPorEmail(req.Email)
+	if err != nil {
+		return models.LoginResponse{}, errCredenciaisInvalidas
+	}
+
+	// Enquanto o convite não é aceito, o usuário não tem senha
+	if usuario.AceitoEm == nil {
+		return models.LoginResponse{}, errCredenciaisInvalidas
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(usuario.EstabelecimentoID)
+	if err != nil {
+		return models.LoginResponse{}, errCredenciaisInvalidas
+	}
+
+	if err := uc.autenticacaoService.VerificarSenha(usuario.Senha, req.Senha); err != nil {
+		return models.LoginResponse{}, errCredenciaisInvalidas
+	}
+
+	// O usuário e o estabelecimento devem estar ativos, o que só é revelado a quem conhece a senha
+	if !usuario.Sessao(estabelecimento).Ativo {
+		return models.LoginResponse{}, services.ErrContaDesativada
+	}
+
+	return uc.iniciarSessao(ctx, estabelecimento, &usuario)
+}
+
+// iniciarSessao conclui o login com a senha conferida: retorna o desafio quando o segundo
+// fator é exigido ou, caso contrário, emite os tokens de uma nova sessão
+func (uc *AutenticacaoUseCase) iniciarSessao(ctx context.Context, estabelecimento models.Estabelecimento, usuario *models.Usuario) (models.LoginResponse, error) {
+	desafio, err := uc.doisFatores.Desafiar(ctx, estabelecimento, usuario)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	if desafio != nil {
+		return models.LoginResponse{DoisFatores: desafio}, nil
+	}
+
+	// Cada login inicia uma nova família de refresh tokens
+	return uc.emitirTokens(estabelecimento, usuario, uuid.New().String())
+}
+
+// ConcluirLogin conclui o login que exigiu o segundo fator, com o desafio e um código do
+// aplicativo ou de recuperação, e gera os tokens de uma nova sessão. No cadastro obrigatório
+// do segundo fator, a resposta também traz os códigos de recuperação.
+func (uc *AutenticacaoUseCase) ConcluirLogin(ctx context.Context, req models.LoginDoisFatoresRequest) (models.LoginResponse, error) {
+	desafio, codigosRecuperacao, err := uc.doisFatores.ConcluirDesafio(ctx, req)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// O login pode ter sido desativado depois da senha ser conferida
+	estabelecimento, usuario, sessao, err := uc.carregarSessao(desafio.EstabelecimentoID, desafio.UsuarioID)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	if !sessao.Ativo {
+		return models.LoginResponse{}, services.ErrContaDesativada
+	}
+
+	response, err := uc.emitirTokens(estabelecimento, usuario, uuid.New().String())
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	response.CodigosRecuperacao = codigosRecuperacao
+	return response, nil
+}
+
+// Renovar troca um refresh token por um novo token JWT e um novo refresh token da mesma
+// sessão. Cada refresh token vale para uma única troca: a reutilização revoga a sessão.
+func (uc *AutenticacaoUseCase) Renovar(req models.RefreshTokenRequest) (models.LoginResponse, error) {
+	agora := time.Now()
+
+	refreshToken, err := uc.refreshTokenRepository.BuscarPorHash(uc.autenticacaoService.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenNaoEncontrado) {
+			return models.LoginResponse{}, ErrRefreshTokenInvalido
+		}
+		return models.LoginResponse{}, err
+	}
+
+	// Um token já trocado ou revogado indica que ele pode ter vazado
+	if refreshToken.UsadoEm != nil || refreshToken.RevogadoEm != nil {
+		return models.LoginResponse{}, uc.revogarPorReuso(refreshToken.FamiliaID, agora)
+	}
+
+	if !agora.Before(refreshToken.ExpiraEm) {
+		return models.LoginResponse{}, ErrRefreshTokenInvalido
+	}
+
+	// A desativação do estabelecimento ou do usuário também encerra as sessões abertas
+	estabelecimento, usuario, sessao, err := uc.carregarSessao(refreshToken.EstabelecimentoID, refreshToken.UsuarioID)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	if err := uc.autenticacaoService.ValidarSessao(sessao, refreshToken.CriadoEm); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// Duas renovações simultâneas com o mesmo token também são tratadas como reutilização
+	usado, err := uc.refreshTokenRepository.MarcarUsado(refreshToken.ID, agora)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	if !usado {
+		return models.LoginResponse{}, uc.revogarPorReuso(refreshToken.FamiliaID, agora)
+	}
+
+	return uc.emitirTokens(estabelecimento, usuario, refreshToken.FamiliaID)
+}
+
+// Logout revoga o token JWT informado até a sua expiração e, se o refresh token for
+// informado, todos os refresh tokens da sessão. Refresh tokens desconhecidos ou de
+// outro estabelecimento são ignorados.
+func (uc *AutenticacaoUseCase) Logout(ctx context.Context, estabelecimentoID, jti string, expiraEm time.Time, req models.LogoutRequest) error {
+	if err := uc.revogacao.Revogar(ctx, jti, expiraEm); err != nil {
+		return err
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	refreshToken, err := uc.refreshTokenRepository.BuscarPorHash(uc.autenticacaoService.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenNaoEncontrado) {
+			return nil
+		}
+		return err
+	}
+
+	if refreshToken.EstabelecimentoID != estabelecimentoID {
+		return nil
+	}
+
+	return uc.refreshTokenRepository.RevogarFamilia(refreshToken.FamiliaID, time.Now())
+}
+
+// VerificarSessao confere, a cada requisição autenticada, se o token não está na lista de
+// revogação, se o estabelecimento e o usuário informado continuam ativos e se o token não foi
+// revogado na desativação. A sessão retornada traz o papel atual do usuário.
+func (uc *AutenticacaoUseCase) VerificarSessao(ctx context.Context, estabelecimentoID, usuarioID, jti string, emitidoEm time.Time) (models.SessaoEstabelecimento, error) {
+	revogado, err := uc.revogacao.Revogado(ctx, jti)
 	if err != nil {
-		return models.LoginResponse{}, errors.New("credenciais inválidas")
+		return models.SessaoEstabelecimento{}, err
+	}
+	if revogado {
+		return models.SessaoEstabelecimento{}, services.ErrTokenRevogado
+	}
+
+	_, _, sessao, err := uc.carregarSessao(estabelecimentoID, usuarioID)
+	if err != nil {
+		return models.SessaoEstabelecimento{}, err
+	}
+
+	if err := uc.autenticacaoService.ValidarSessao(sessao, emitidoEm); err != nil {
+		return models.SessaoEstabelecimento{}, err
+	}
+
+	return sessao, nil
+}
+
+// carregarSessao busca o estabelecimento e, quando informado, o usuário da sua equipe, com a
+// sessão que reúne os dois. Registros inexistentes revogam o token.
+func (uc *AutenticacaoUseCase) carregarSessao(estabelecimentoID, usuarioID string) (models.Estabelecimento, *models.Usuario, models.SessaoEstabelecimento, error) {
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(estabelecimentoID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+			err = services.ErrTokenRevogado
+		}
+		return models.Estabelecimento{}, nil, models.SessaoEstabelecimento{}, err
+	}
+
+	if usuarioID == "" {
+		return estabelecimento, nil, estabelecimento.Sessao(), nil
+	}
+
+	usuario, err := uc.usuarioRepository.BuscarPorID(usuarioID)
+	if err == nil && usuario.EstabelecimentoID != estabelecimentoID {
+		err = repositories.ErrUsuarioNaoEncontrado
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrUsuarioNaoEncontrado) {
+			err = services.ErrTokenRevogado
+		}
+		return models.Estabelecimento{}, nil, models.SessaoEstabelecimento{}, err
 	}
 
-	// Gerar token JWT
-	token, err := uc.autenticacaoService.GerarToken(estabelecimento)
+	return estabelecimento, &usuario, usuario.Sessao(estabelecimento), nil
+}
+
+// JWKS retorna as chaves públicas que verificam os tokens JWT emitidos
+func (uc *AutenticacaoUseCase) JWKS() models.JWKS {
+	return uc.autenticacaoService.JWKS()
+}
+
+// emitirTokens gera o token JWT e um novo refresh token na família informada, para o login do
+// estabelecimento ou, quando informado, do usuário da equipe
+func (uc *AutenticacaoUseCase) emitirTokens(estabelecimento models.Estabelecimento, usuario *models.Usuario, familiaID string) (models.LoginResponse, error) {
+	token, err := uc.autenticacaoService.GerarToken(estabelecimento, usuario)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	refreshToken, refreshTokenHash, err := uc.autenticacaoService.GerarRefreshToken()
 	if err != nil {
 		return models.LoginResponse{}, err
 	}
 
-	// Preparar a resposta
 	response := models.LoginResponse{
-		Token: token,
-		Estabelecimento: models.EstabelecimentoResponse{
-			ID:           estabelecimento.ID,
-			Nome:         estabelecimento.Nome,
-			Descricao:    estabelecimento.Descricao,
-			Email:        estabelecimento.Email,
-			Ativo:        estabelecimento.Ativo,
-			CriadoEm:     estabelecimento.CriadoEm,
-			AtualizadoEm: estabelecimento.AtualizadoEm,
-		},
+		Token:            token,
+		ExpiraEmSegundos: int64(uc.autenticacaoService.DuracaoToken().Seconds()),
+		RefreshToken:     refreshToken,
+		Papel:            models.PapelProprietario,
+		Estabelecimento:  models.NovoEstabelecimentoResponse(estabelecimento),
+	}
+
+	var usuarioID string
+	if usuario != nil {
+		usuarioID = usuario.ID
+		response.Papel = usuario.Papel
+		response.Usuario = usuario
+	}
+
+	agora := time.Now()
+	err = uc.refreshTokenRepository.Salvar(models.RefreshToken{
+		ID:                uuid.New().String(),
+		FamiliaID:         familiaID,
+		EstabelecimentoID: estabelecimento.ID,
+		UsuarioID:         usuarioID,
+		TokenHash:         refreshTokenHash,
+		ExpiraEm:          agora.Add(uc.autenticacaoService.DuracaoRefreshToken()),
+		CriadoEm:          agora,
+	})
+	if err != nil {
+		return models.LoginResponse{}, err
 	}
 
 	return response, nil
 }
+
+// revogarPorReuso revoga a família de um refresh token reutilizado
+func (uc *AutenticacaoUseCase) revogarPorReuso(familiaID string, agora time.Time) error {
+	if err := uc.refreshTokenRepository.RevogarFamilia(familiaID, agora); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReutilizado
+}
@@ -0,0 +1,346 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// estabelecimentoRepositoryMemoria guarda os estabelecimentos em memória
+type estabelecimentoRepositoryMemoria struct {
+	mu               sync.Mutex
+	estabelecimentos map[string]models.Estabelecimento
+}
+
+func (r *estabelecimentoRepositoryMemoria) Salvar(req models.EstabelecimentoRequest) (models.Estabelecimento, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Senha), bcrypt.MinCost)
+	if err != nil {
+		return models.Estabelecimento{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento := models.Estabelecimento{
+		ID:       "est-" + req.Email,
+		Nome:     req.Nome,
+		Email:    req.Email,
+		Senha:    string(hash),
+		Ativo:    true,
+		CriadoEm: time.Now(),
+	}
+	r.estabelecimentos[estabelecimento.ID] = estabelecimento
+	return estabelecimento, nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) BuscarPorID(id string) (models.Estabelecimento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return models.Estabelecimento{}, repositories.ErrEstabelecimentoNaoEncontrado
+	}
+	return estabelecimento, nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) BuscarPorEmail(email string) (models.Estabelecimento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, estabelecimento := range r.estabelecimentos {
+		if estabelecimento.Email == email {
+			return estabelecimento, nil
+		}
+	}
+	return models.Estabelecimento{}, repositories.ErrEstabelecimentoNaoEncontrado
+}
+
+func (r *estabelecimentoRepositoryMemoria) Listar() ([]models.Estabelecimento, error) {
+	return nil, nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) Atualizar(id string, req models.EstabelecimentoRequest) (models.Estabelecimento, error) {
+	if req.Senha != "" {
+		if err := r.AlterarSenha(id, req.Senha); err != nil {
+			return models.Estabelecimento{}, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return models.Estabelecimento{}, repositories.ErrEstabelecimentoNaoEncontrado
+	}
+	estabelecimento.Nome = req.Nome
+	estabelecimento.Descricao = req.Descricao
+	estabelecimento.Email = req.Email
+	r.estabelecimentos[id] = estabelecimento
+	return estabelecimento, nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) Excluir(id string) error {
+	return r.AlterarAtivo(id, false)
+}
+
+func (r *estabelecimentoRepositoryMemoria) AlterarAtivo(id string, ativo bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return repositories.ErrEstabelecimentoNaoEncontrado
+	}
+
+	estabelecimento.Ativo = ativo
+	if !ativo {
+		agora := time.Now()
+		estabelecimento.TokensRevogadosEm = &agora
+	}
+	r.estabelecimentos[id] = estabelecimento
+	return nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) AlterarSenha(id, senha string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return repositories.ErrEstabelecimentoNaoEncontrado
+	}
+	estabelecimento.Senha = string(hash)
+	r.estabelecimentos[id] = estabelecimento
+	return nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) AlterarDoisFatoresObrigatorio(id string, obrigatorio bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return repositories.ErrEstabelecimentoNaoEncontrado
+	}
+	estabelecimento.DoisFatoresObrigatorio = obrigatorio
+	r.estabelecimentos[id] = estabelecimento
+	return nil
+}
+
+func (r *estabelecimentoRepositoryMemoria) MarcarEmailVerificado(id string, verificadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	estabelecimento, existe := r.estabelecimentos[id]
+	if !existe {
+		return repositories.ErrEstabelecimentoNaoEncontrado
+	}
+	if estabelecimento.EmailVerificadoEm == nil {
+		estabelecimento.EmailVerificadoEm = &verificadoEm
+	}
+	r.estabelecimentos[id] = estabelecimento
+	return nil
+}
+
+// refreshTokenRepositoryMemoria guarda os refresh tokens em memória
+type refreshTokenRepositoryMemoria struct {
+	mu     sync.Mutex
+	tokens map[string]models.RefreshToken
+}
+
+func (r *refreshTokenRepositoryMemoria) Salvar(token models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = token
+	return nil
+}
+
+func (r *refreshTokenRepositoryMemoria) BuscarPorHash(tokenHash string) (models.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return models.RefreshToken{}, repositories.ErrRefreshTokenNaoEncontrado
+}
+
+func (r *refreshTokenRepositoryMemoria) MarcarUsado(id string, usadoEm time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token := r.tokens[id]
+	if token.UsadoEm != nil || token.RevogadoEm != nil {
+		return false, nil
+	}
+	token.UsadoEm = &usadoEm
+	r.tokens[id] = token
+	return true, nil
+}
+
+func (r *refreshTokenRepositoryMemoria) RevogarFamilia(familiaID string, revogadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.FamiliaID == familiaID && token.RevogadoEm == nil {
+			token.RevogadoEm = &revogadoEm
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+func (r *refreshTokenRepositoryMemoria) RevogarLogin(estabelecimentoID, usuarioID string, revogadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.EstabelecimentoID == estabelecimentoID && token.UsuarioID == usuarioID && token.RevogadoEm == nil {
+			token.RevogadoEm = &revogadoEm
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// revogacaoMemoria guarda a lista de revogação em memória
+type revogacaoMemoria struct {
+	mu   sync.Mutex
+	jtis map[string]bool
+}
+
+func (r *revogacaoMemoria) Revogar(ctx context.Context, jti string, expiraEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jtis[jti] = true
+	return nil
+}
+
+func (r *revogacaoMemoria) Revogado(ctx context.Context, jti string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.jtis[jti], nil
+}
+
+func TestAutenticacaoUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+	usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+	revogacao := &revogacaoMemoria{jtis: map[string]bool{}}
+	tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+	contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+	uc := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, revogacao, contaUseCase, novaProtecaoLogin(configuracaoProtecaoLoginTeste), novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+
+	estabelecimento, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", Senha: "senha123"})
+	assert.NoError(t, err)
+
+	login := func(t *testing.T) models.LoginResponse {
+		resposta, err := uc.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		return resposta
+	}
+
+	t.Run("RenovarComRotacao", func(t *testing.T) {
+		sessao := login(t)
+
+		// Executar o método a ser testado
+		renovada, err := uc.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+
+		// Verificar resultados: um novo par de tokens é emitido e o refresh token é trocado
+		assert.NoError(t, err)
+		assert.NotEmpty(t, renovada.Token)
+		assert.NotEqual(t, sessao.RefreshToken, renovada.RefreshToken)
+		assert.Equal(t, int64(900), renovada.ExpiraEmSegundos)
+
+		// Apenas o hash do refresh token é armazenado
+		_, err = refreshTokenRepository.BuscarPorHash(renovada.RefreshToken)
+		assert.ErrorIs(t, err, repositories.ErrRefreshTokenNaoEncontrado)
+	})
+
+	t.Run("ReusoRevogaFamilia", func(t *testing.T) {
+		sessao := login(t)
+		renovada, err := uc.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado: reutilizar o refresh token já trocado
+		_, err = uc.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+
+		// Verificar resultados: o reuso é detectado e o token mais recente da família também é revogado
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenReutilizado)
+
+		_, err = uc.Renovar(models.RefreshTokenRequest{RefreshToken: renovada.RefreshToken})
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenReutilizado)
+
+		// Outras sessões do estabelecimento não são afetadas
+		outra := login(t)
+		_, err = uc.Renovar(models.RefreshTokenRequest{RefreshToken: outra.RefreshToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("RefreshTokenDesconhecido", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := uc.Renovar(models.RefreshTokenRequest{RefreshToken: "desconhecido"})
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenInvalido)
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		sessao := login(t)
+		claims, err := autenticacaoService.ValidarToken(sessao.Token)
+		assert.NoError(t, err)
+		jti := claims["jti"].(string)
+
+		// Executar o método a ser testado
+		err = uc.Logout(context.Background(), estabelecimento.ID, jti, time.Now().Add(time.Minute), models.LogoutRequest{RefreshToken: sessao.RefreshToken})
+		assert.NoError(t, err)
+
+		// Verificar resultados: o token de acesso e o refresh token deixam de valer
+		_, err = uc.VerificarSessao(context.Background(), estabelecimento.ID, "", jti, time.Now())
+		assert.ErrorIs(t, err, services.ErrTokenRevogado)
+
+		_, err = uc.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenReutilizado)
+	})
+
+	t.Run("EstabelecimentoDesativado", func(t *testing.T) {
+		sessao := login(t)
+		assert.NoError(t, estabelecimentoRepository.AlterarAtivo(estabelecimento.ID, false))
+		defer estabelecimentoRepository.AlterarAtivo(estabelecimento.ID, true)
+
+		// Executar o método a ser testado
+		_, err := uc.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, services.ErrContaDesativada)
+	})
+}
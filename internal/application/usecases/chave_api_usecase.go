@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// intervaloRegistroUso é o intervalo mínimo entre duas gravações do último uso de uma chave
+const intervaloRegistroUso = time.Minute
+
+// ChaveAPIUseCase implementa a gestão e a autenticação das chaves de API dos estabelecimentos
+type ChaveAPIUseCase struct {
+	autenticacaoService       *services.AutenticacaoService
+	chaveAPIRepository        repositories.ChaveAPIRepository
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+}
+
+// NewChaveAPIUseCase cria uma nova instância do caso de uso de chaves de API
+func NewChaveAPIUseCase(
+	autenticacaoService *services.AutenticacaoService,
+	chaveAPIRepository repositories.ChaveAPIRepository,
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+) *ChaveAPIUseCase {
+	return &ChaveAPIUseCase{
+		autenticacaoService:       autenticacaoService,
+		chaveAPIRepository:        chaveAPIRepository,
+		estabelecimentoRepository: estabelecimentoRepository,
+	}
+}
+
+// Criar gera uma nova chave de API para o estabelecimento. A chave só é retornada aqui.
+func (uc *ChaveAPIUseCase) Criar(estabelecimentoID string, req models.CriarChaveAPIRequest) (models.ChaveAPICriada, error) {
+	agora := time.Now()
+
+	chave, err := services.NovaChaveAPI(req, agora)
+	if err != nil {
+		return models.ChaveAPICriada{}, err
+	}
+
+	segredo, prefixo, hash, err := services.GerarChaveAPI()
+	if err != nil {
+		return models.ChaveAPICriada{}, err
+	}
+
+	chave.ID = uuid.New().String()
+	chave.EstabelecimentoID = estabelecimentoID
+	chave.Prefixo = prefixo
+	chave.Hash = hash
+	chave.CriadoEm = agora
+
+	if err := uc.chaveAPIRepository.Salvar(chave); err != nil {
+		return models.ChaveAPICriada{}, err
+	}
+
+	return models.ChaveAPICriada{ChaveAPI: chave, Chave: segredo}, nil
+}
+
+// Listar lista as chaves de API do estabelecimento, sem o valor das chaves
+func (uc *ChaveAPIUseCase) Listar(estabelecimentoID string) ([]models.ChaveAPI, error) {
+	return uc.chaveAPIRepository.Listar(estabelecimentoID)
+}
+
+// Revogar revoga uma chave de API do estabelecimento, que deixa de ser aceita imediatamente
+func (uc *ChaveAPIUseCase) Revogar(estabelecimentoID, id string) (models.ChaveAPI, error) {
+	return uc.chaveAPIRepository.Revogar(estabelecimentoID, id, time.Now())
+}
+
+// Autenticar valida a chave de API do header X-API-Key e retorna a chave e o estabelecimento
+// dono dela. Como os tokens, as chaves criadas antes de uma desativação não voltam a valer
+// quando o estabelecimento é reativado.
+func (uc *ChaveAPIUseCase) Autenticar(ctx context.Context, segredo string) (models.ChaveAPI, models.Estabelecimento, error) {
+	agora := time.Now()
+
+	chave, err := uc.chaveAPIRepository.BuscarPorHash(services.HashChaveAPI(segredo))
+	if err != nil {
+		if errors.Is(err, repositories.ErrChaveAPINaoEncontrada) {
+			return models.ChaveAPI{}, models.Estabelecimento{}, services.ErrChaveAPIInvalida
+		}
+		return models.ChaveAPI{}, models.Estabelecimento{}, err
+	}
+
+	if chave.RevogadaEm != nil || (chave.ExpiraEm != nil && !agora.Before(*chave.ExpiraEm)) {
+		return models.ChaveAPI{}, models.Estabelecimento{}, services.ErrChaveAPIInvalida
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(chave.EstabelecimentoID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+			return models.ChaveAPI{}, models.Estabelecimento{}, services.ErrChaveAPIInvalida
+		}
+		return models.ChaveAPI{}, models.Estabelecimento{}, err
+	}
+
+	if err := uc.autenticacaoService.ValidarSessao(estabelecimento.Sessao(), chave.CriadoEm); err != nil {
+		return models.ChaveAPI{}, models.Estabelecimento{}, err
+	}
+
+	// A falha ao registrar o uso não impede a requisição
+	if err := uc.chaveAPIRepository.RegistrarUso(chave.ID, agora, intervaloRegistroUso); err != nil {
+		log.Printf("Erro ao registrar o uso da chave de API %s: %v", chave.ID, err)
+	}
+
+	return chave, estabelecimento, nil
+}
@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+var (
+	// ErrRequisicaoOAuthInvalida indica uma requisição ao endpoint de token sem os parâmetros obrigatórios
+	ErrRequisicaoOAuthInvalida = errors.New("grant_type é obrigatório")
+
+	// ErrGrantOAuthNaoSuportado indica um grant diferente de client_credentials
+	ErrGrantOAuthNaoSuportado = errors.New("grant_type não suportado: use client_credentials")
+)
+
+// ClienteOAuthUseCase implementa a gestão dos clientes OAuth2 dos estabelecimentos, a emissão
+// dos tokens de acesso com o grant client_credentials e a introspecção desses tokens
+type ClienteOAuthUseCase struct {
+	autenticacaoService       *services.AutenticacaoService
+	clienteOAuthRepository    repositories.ClienteOAuthRepository
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+}
+
+// NewClienteOAuthUseCase cria uma nova instância do caso de uso de clientes OAuth2
+func NewClienteOAuthUseCase(
+	autenticacaoService *services.AutenticacaoService,
+	clienteOAuthRepository repositories.ClienteOAuthRepository,
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+) *ClienteOAuthUseCase {
+	return &ClienteOAuthUseCase{
+		autenticacaoService:       autenticacaoService,
+		clienteOAuthRepository:    clienteOAuthRepository,
+		estabelecimentoRepository: estabelecimentoRepository,
+	}
+}
+
+// Criar registra um novo cliente OAuth2 para o estabelecimento. O segredo só é retornado aqui.
+func (uc *ClienteOAuthUseCase) Criar(estabelecimentoID string, req models.CriarClienteOAuthRequest) (models.ClienteOAuthCriado, error) {
+	cliente, err := services.NovoClienteOAuth(req)
+	if err != nil {
+		return models.ClienteOAuthCriado{}, err
+	}
+
+	segredo, hash, err := services.GerarSegredoClienteOAuth()
+	if err != nil {
+		return models.ClienteOAuthCriado{}, err
+	}
+
+	cliente.ID = uuid.New().String()
+	cliente.EstabelecimentoID = estabelecimentoID
+	cliente.SegredoHash = hash
+	cliente.CriadoEm = time.Now()
+
+	if err := uc.clienteOAuthRepository.Salvar(cliente); err != nil {
+		return models.ClienteOAuthCriado{}, err
+	}
+
+	return models.ClienteOAuthCriado{ClienteOAuth: cliente, Segredo: segredo}, nil
+}
+
+// Listar lista os clientes OAuth2 do estabelecimento, sem os segredos
+func (uc *ClienteOAuthUseCase) Listar(estabelecimentoID string) ([]models.ClienteOAuth, error) {
+	return uc.clienteOAuthRepository.Listar(estabelecimentoID)
+}
+
+// Revogar revoga um cliente OAuth2 do estabelecimento. O cliente deixa de obter tokens, e os
+// tokens já emitidos deixam de ser aceitos imediatamente.
+func (uc *ClienteOAuthUseCase) Revogar(estabelecimentoID, id string) (models.ClienteOAuth, error) {
+	return uc.clienteOAuthRepository.Revogar(estabelecimentoID, id, time.Now())
+}
+
+// EmitirToken emite um token de acesso com o grant client_credentials. Os escopos solicitados
+// devem estar entre os concedidos ao cliente; sem escopos, o token recebe todos eles.
+func (uc *ClienteOAuthUseCase) EmitirToken(ctx context.Context, req models.TokenOAuthRequest) (models.TokenOAuthResponse, error) {
+	if req.GrantType == "" {
+		return models.TokenOAuthResponse{}, ErrRequisicaoOAuthInvalida
+	}
+	if req.GrantType != models.GrantClientCredentials {
+		return models.TokenOAuthResponse{}, ErrGrantOAuthNaoSuportado
+	}
+
+	cliente, estabelecimento, err := uc.autenticarCliente(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return models.TokenOAuthResponse{}, err
+	}
+
+	escopos, err := services.EscoposSolicitados(cliente, req.Scope)
+	if err != nil {
+		return models.TokenOAuthResponse{}, err
+	}
+
+	token, duracao, err := uc.autenticacaoService.GerarTokenClienteOAuth(estabelecimento, cliente, escopos)
+	if err != nil {
+		return models.TokenOAuthResponse{}, err
+	}
+
+	// A falha ao registrar o uso não impede a emissão do token
+	if err := uc.clienteOAuthRepository.RegistrarUso(cliente.ID, time.Now()); err != nil {
+		log.Printf("Erro ao registrar o uso do cliente OAuth %s: %v", cliente.ID, err)
+	}
+
+	return models.TokenOAuthResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(duracao.Seconds()),
+		Scope:       strings.Join(escopos, " "),
+	}, nil
+}
+
+// VerificarAcesso confere, a cada requisição com um token de cliente OAuth2, se o cliente
+// continua ativo e pertence ao estabelecimento e se o token não foi revogado na desativação
+// do estabelecimento
+func (uc *ClienteOAuthUseCase) VerificarAcesso(ctx context.Context, estabelecimentoID, clienteID string, emitidoEm time.Time) error {
+	cliente, err := uc.clienteOAuthRepository.BuscarPorID(clienteID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrClienteOAuthNaoEncontrado) {
+			return services.ErrTokenRevogado
+		}
+		return err
+	}
+
+	if cliente.RevogadoEm != nil || cliente.EstabelecimentoID != estabelecimentoID {
+		return services.ErrTokenRevogado
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(estabelecimentoID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+			return services.ErrTokenRevogado
+		}
+		return err
+	}
+
+	return uc.autenticacaoService.ValidarSessao(estabelecimento.Sessao(), emitidoEm)
+}
+
+// Introspectar informa se um token de acesso de cliente OAuth2 dá acesso à API (RFC 7662). O
+// cliente que consulta se autentica com as próprias credenciais e só vê os tokens do seu
+// estabelecimento; os demais tokens, como os de login, são informados como inativos.
+func (uc *ClienteOAuthUseCase) Introspectar(ctx context.Context, clientID, clientSecret, token string) (models.IntrospeccaoOAuthResponse, error) {
+	cliente, _, err := uc.autenticarCliente(clientID, clientSecret)
+	if err != nil {
+		return models.IntrospeccaoOAuthResponse{}, err
+	}
+
+	inativo := models.IntrospeccaoOAuthResponse{Active: false}
+
+	claims, err := uc.autenticacaoService.ValidarToken(token)
+	if err != nil {
+		return inativo, nil
+	}
+
+	estabelecimentoID, _ := claims["id"].(string)
+	clienteID, _ := claims["cid"].(string)
+	scope, _ := claims["scope"].(string)
+	jti, _ := claims["jti"].(string)
+	if clienteID == "" || scope == "" || estabelecimentoID != cliente.EstabelecimentoID {
+		return inativo, nil
+	}
+
+	var emitidoEm, expiraEm time.Time
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		emitidoEm = iat.Time
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiraEm = exp.Time
+	}
+
+	if err := uc.VerificarAcesso(ctx, estabelecimentoID, clienteID, emitidoEm); err != nil {
+		if errors.Is(err, services.ErrContaDesativada) || errors.Is(err, services.ErrTokenRevogado) {
+			return inativo, nil
+		}
+		return models.IntrospeccaoOAuthResponse{}, err
+	}
+
+	return models.IntrospeccaoOAuthResponse{
+		Active:    true,
+		Scope:     scope,
+		ClientID:  clienteID,
+		TokenType: "Bearer",
+		Sub:       estabelecimentoID,
+		Exp:       expiraEm.Unix(),
+		Iat:       emitidoEm.Unix(),
+		Jti:       jti,
+	}, nil
+}
+
+// autenticarCliente confere as credenciais do cliente OAuth2 e retorna o cliente e o
+// estabelecimento dono dele. Como as chaves de API, os clientes criados antes de uma
+// desativação não voltam a valer quando o estabelecimento é reativado.
+func (uc *ClienteOAuthUseCase) autenticarCliente(clientID, clientSecret string) (models.ClienteOAuth, models.Estabelecimento, error) {
+	if clientID == "" || clientSecret == "" {
+		return models.ClienteOAuth{}, models.Estabelecimento{}, services.ErrClienteOAuthInvalido
+	}
+
+	cliente, err := uc.clienteOAuthRepository.BuscarPorID(clientID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrClienteOAuthNaoEncontrado) {
+			return models.ClienteOAuth{}, models.Estabelecimento{}, services.ErrClienteOAuthInvalido
+		}
+		return models.ClienteOAuth{}, models.Estabelecimento{}, err
+	}
+
+	hash := services.HashSegredoClienteOAuth(clientSecret)
+	if cliente.RevogadoEm != nil || subtle.ConstantTimeCompare([]byte(hash), []byte(cliente.SegredoHash)) != 1 {
+		return models.ClienteOAuth{}, models.Estabelecimento{}, services.ErrClienteOAuthInvalido
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(cliente.EstabelecimentoID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+			return models.ClienteOAuth{}, models.Estabelecimento{}, services.ErrClienteOAuthInvalido
+		}
+		return models.ClienteOAuth{}, models.Estabelecimento{}, err
+	}
+
+	if err := uc.autenticacaoService.ValidarSessao(estabelecimento.Sessao(), cliente.CriadoEm); err != nil {
+		return models.ClienteOAuth{}, models.Estabelecimento{}, err
+	}
+
+	return cliente, estabelecimento, nil
+}
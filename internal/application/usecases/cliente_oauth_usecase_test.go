@@ -0,0 +1,231 @@
+package usecases_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// clienteOAuthRepositoryMemoria guarda os clientes OAuth2 em memória
+type clienteOAuthRepositoryMemoria struct {
+	mu       sync.Mutex
+	clientes map[string]models.ClienteOAuth
+}
+
+func (r *clienteOAuthRepositoryMemoria) Salvar(cliente models.ClienteOAuth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clientes[cliente.ID] = cliente
+	return nil
+}
+
+func (r *clienteOAuthRepositoryMemoria) Listar(estabelecimentoID string) ([]models.ClienteOAuth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clientes := []models.ClienteOAuth{}
+	for _, cliente := range r.clientes {
+		if cliente.EstabelecimentoID == estabelecimentoID {
+			clientes = append(clientes, cliente)
+		}
+	}
+	return clientes, nil
+}
+
+func (r *clienteOAuthRepositoryMemoria) BuscarPorID(id string) (models.ClienteOAuth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cliente, existe := r.clientes[id]
+	if !existe {
+		return models.ClienteOAuth{}, repositories.ErrClienteOAuthNaoEncontrado
+	}
+	return cliente, nil
+}
+
+func (r *clienteOAuthRepositoryMemoria) Revogar(estabelecimentoID, id string, revogadoEm time.Time) (models.ClienteOAuth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cliente, existe := r.clientes[id]
+	if !existe || cliente.EstabelecimentoID != estabelecimentoID {
+		return models.ClienteOAuth{}, repositories.ErrClienteOAuthNaoEncontrado
+	}
+	if cliente.RevogadoEm == nil {
+		cliente.RevogadoEm = &revogadoEm
+		r.clientes[id] = cliente
+	}
+	return cliente, nil
+}
+
+func (r *clienteOAuthRepositoryMemoria) RegistrarUso(id string, usadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cliente := r.clientes[id]
+	cliente.UltimoUsoEm = &usadoEm
+	r.clientes[id] = cliente
+	return nil
+}
+
+func TestClienteOAuthUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	clienteOAuthRepository := &clienteOAuthRepositoryMemoria{clientes: map[string]models.ClienteOAuth{}}
+	uc := usecases.NewClienteOAuthUseCase(autenticacaoService, clienteOAuthRepository, estabelecimentoRepository)
+	ctx := context.Background()
+
+	loja, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", Senha: "senha123"})
+	assert.NoError(t, err)
+	outra, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Outra", Email: "outra@teste.com", Senha: "senha123"})
+	assert.NoError(t, err)
+
+	parceiro, err := uc.Criar(loja.ID, models.CriarClienteOAuthRequest{Nome: "Plataforma", Escopos: []string{models.EscopoPixLeitura, models.EscopoPixEscrita}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, parceiro.Segredo, parceiro.SegredoHash)
+
+	t.Run("ValidarCriacao", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := uc.Criar(loja.ID, models.CriarClienteOAuthRequest{Nome: " ", Escopos: []string{"admin"}})
+
+		// Verificar resultados
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Len(t, erros, 2)
+	})
+
+	t.Run("EmitirToken", func(t *testing.T) {
+		// Executar o método a ser testado
+		token, err := uc.EmitirToken(ctx, models.TokenOAuthRequest{
+			GrantType:    models.GrantClientCredentials,
+			ClientID:     parceiro.ID,
+			ClientSecret: parceiro.Segredo,
+			Scope:        "pix.write",
+		})
+
+		// Verificar resultados: o token traz apenas os escopos solicitados
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer", token.TokenType)
+		assert.Equal(t, "pix.write", token.Scope)
+		assert.Equal(t, int64(autenticacaoService.DuracaoToken().Seconds()), token.ExpiresIn)
+
+		claims, err := autenticacaoService.ValidarToken(token.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, loja.ID, claims["id"])
+		assert.Equal(t, parceiro.ID, claims["cid"])
+		assert.Nil(t, claims["papel"])
+
+		// Sem scope, o token recebe todos os escopos do cliente
+		token, err = uc.EmitirToken(ctx, models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: parceiro.ID, ClientSecret: parceiro.Segredo})
+		assert.NoError(t, err)
+		assert.Equal(t, "pix.read pix.write", token.Scope)
+
+		cliente, err := clienteOAuthRepository.BuscarPorID(parceiro.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, cliente.UltimoUsoEm)
+	})
+
+	t.Run("RequisicoesRecusadas", func(t *testing.T) {
+		casos := map[string]struct {
+			req models.TokenOAuthRequest
+			err error
+		}{
+			"SemGrant":            {models.TokenOAuthRequest{ClientID: parceiro.ID, ClientSecret: parceiro.Segredo}, usecases.ErrRequisicaoOAuthInvalida},
+			"GrantNaoSuportado":   {models.TokenOAuthRequest{GrantType: "password", ClientID: parceiro.ID, ClientSecret: parceiro.Segredo}, usecases.ErrGrantOAuthNaoSuportado},
+			"SegredoIncorreto":    {models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: parceiro.ID, ClientSecret: "pixs_errado"}, services.ErrClienteOAuthInvalido},
+			"ClienteDesconhecido": {models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: "desconhecido", ClientSecret: parceiro.Segredo}, services.ErrClienteOAuthInvalido},
+			"EscopoNaoConcedido":  {models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: parceiro.ID, ClientSecret: parceiro.Segredo, Scope: "pix.read cob.write"}, services.ErrEscopoOAuthInvalido},
+		}
+
+		for nome, caso := range casos {
+			// Executar o método a ser testado
+			_, err := uc.EmitirToken(ctx, caso.req)
+
+			// Verificar resultados
+			assert.ErrorIs(t, err, caso.err, nome)
+		}
+	})
+
+	t.Run("Introspectar", func(t *testing.T) {
+		token, err := uc.EmitirToken(ctx, models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: parceiro.ID, ClientSecret: parceiro.Segredo, Scope: "pix.read"})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		introspeccao, err := uc.Introspectar(ctx, parceiro.ID, parceiro.Segredo, token.AccessToken)
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.True(t, introspeccao.Active)
+		assert.Equal(t, "pix.read", introspeccao.Scope)
+		assert.Equal(t, parceiro.ID, introspeccao.ClientID)
+		assert.Equal(t, loja.ID, introspeccao.Sub)
+
+		// O cliente de outro estabelecimento não vê o token
+		vizinho, err := uc.Criar(outra.ID, models.CriarClienteOAuthRequest{Nome: "Vizinho"})
+		assert.NoError(t, err)
+		introspeccao, err = uc.Introspectar(ctx, vizinho.ID, vizinho.Segredo, token.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, models.IntrospeccaoOAuthResponse{Active: false}, introspeccao)
+
+		// Os tokens de login não são tokens de cliente
+		login, err := autenticacaoService.GerarToken(loja, nil)
+		assert.NoError(t, err)
+		introspeccao, err = uc.Introspectar(ctx, parceiro.ID, parceiro.Segredo, login)
+		assert.NoError(t, err)
+		assert.False(t, introspeccao.Active)
+
+		_, err = uc.Introspectar(ctx, parceiro.ID, "pixs_errado", token.AccessToken)
+		assert.ErrorIs(t, err, services.ErrClienteOAuthInvalido)
+	})
+
+	t.Run("Revogar", func(t *testing.T) {
+		revogado, err := uc.Criar(loja.ID, models.CriarClienteOAuthRequest{Nome: "Antigo"})
+		assert.NoError(t, err)
+		token, err := uc.EmitirToken(ctx, models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: revogado.ID, ClientSecret: revogado.Segredo})
+		assert.NoError(t, err)
+		claims, err := autenticacaoService.ValidarToken(token.AccessToken)
+		assert.NoError(t, err)
+		emitidoEm, err := claims.GetIssuedAt()
+		assert.NoError(t, err)
+		assert.NoError(t, uc.VerificarAcesso(ctx, loja.ID, revogado.ID, emitidoEm.Time))
+
+		// Outro estabelecimento não revoga o cliente
+		_, err = uc.Revogar(outra.ID, revogado.ID)
+		assert.ErrorIs(t, err, repositories.ErrClienteOAuthNaoEncontrado)
+
+		// Executar o método a ser testado
+		_, err = uc.Revogar(loja.ID, revogado.ID)
+
+		// Verificar resultados: o token já emitido e as credenciais deixam de valer
+		assert.NoError(t, err)
+		assert.ErrorIs(t, uc.VerificarAcesso(ctx, loja.ID, revogado.ID, emitidoEm.Time), services.ErrTokenRevogado)
+
+		_, err = uc.EmitirToken(ctx, models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: revogado.ID, ClientSecret: revogado.Segredo})
+		assert.ErrorIs(t, err, services.ErrClienteOAuthInvalido)
+	})
+
+	t.Run("EstabelecimentoDesativado", func(t *testing.T) {
+		assert.NoError(t, estabelecimentoRepository.AlterarAtivo(loja.ID, false))
+		defer estabelecimentoRepository.AlterarAtivo(loja.ID, true)
+
+		// Executar o método a ser testado
+		_, err := uc.EmitirToken(ctx, models.TokenOAuthRequest{GrantType: models.GrantClientCredentials, ClientID: parceiro.ID, ClientSecret: parceiro.Segredo})
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, services.ErrContaDesativada)
+		assert.ErrorIs(t, uc.VerificarAcesso(ctx, loja.ID, parceiro.ID, time.Now()), services.ErrContaDesativada)
+	})
+}
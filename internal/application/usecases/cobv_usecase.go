@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// CobVUseCase implementa os casos de uso de cobranças com vencimento
+type CobVUseCase struct {
+	pixService     *services.PixGeneratorService
+	calculadora    *services.CalculadoraCobVService
+	pixRepository  repositories.PixRepository
+	cobvRepository repositories.CobVRepository
+}
+
+// NewCobVUseCase cria uma nova instância do caso de uso de cobranças com vencimento
+func NewCobVUseCase(
+	pixService *services.PixGeneratorService,
+	calculadora *services.CalculadoraCobVService,
+	pixRepository repositories.PixRepository,
+	cobvRepository repositories.CobVRepository,
+) *CobVUseCase {
+	return &CobVUseCase{
+		pixService:     pixService,
+		calculadora:    calculadora,
+		pixRepository:  pixRepository,
+		cobvRepository: cobvRepository,
+	}
+}
+
+// Execute cria uma cobrança com vencimento em nome do estabelecimento e retorna o valor a pagar hoje
+func (uc *CobVUseCase) Execute(estabelecimentoID string, req models.CobVRequest) (models.CobVResponse, error) {
+	agora := time.Now()
+
+	// Validar as regras de cálculo da cobrança
+	cobv, err := uc.calculadora.NovaCobV(req, agora)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	// Gerar o código PIX dinâmico. O valor não consta no BR Code, pois varia com a data
+	// de pagamento: o pagador o obtém no payload da location
+	pixResponse, err := uc.pixService.GerarPixDinamico(models.CobRequest{
+		Txid:     req.Txid,
+		Location: req.Location,
+		Nome:     req.Nome,
+		Chave:    req.Chave,
+		Cidade:   req.Cidade,
+		QRCode:   req.QRCode,
+	})
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	// Persistir a chave e a location já normalizadas
+	chave, err := uc.pixService.ClassificarChave(req.Chave)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	location, err := services.NormalizarLocation(req.Location)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	// A cobrança expira ao fim do último dia em que pode ser paga
+	pagavelAte := uc.calculadora.PagavelAte(cobv)
+	expiraEm := pagavelAte.AddDate(0, 0, 1)
+
+	// Criar a entidade PIX para persistência
+	pix := models.Pix{
+		EstabelecimentoID: estabelecimentoID,
+		Tipo:              models.TipoPixCobV,
+		Nome:              req.Nome,
+		Chave:             chave.Valor,
+		TipoChave:         chave.Tipo,
+		Cidade:            req.Cidade,
+		Valor:             &cobv.ValorOriginal,
+		Txid:              &cobv.Txid,
+		Location:          &location,
+		ExpiraEm:          &expiraEm,
+		CodigoPix:         pixResponse.CodigoPix,
+		QRCodeSVG:         pixResponse.QRCodeSVG,
+		QRCodePNG:         pixResponse.QRCodePNG,
+		CriadoEm:          agora,
+	}
+
+	if _, err := uc.cobvRepository.Save(pix, cobv); err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	valores, err := uc.calculadora.Calcular(cobv, agora)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	return models.CobVResponse{
+		Txid:       cobv.Txid,
+		Vencimento: cobv.Vencimento.Format(models.FormatoData),
+		PagavelAte: pagavelAte.Format(models.FormatoData),
+		Valores:    valores,
+		Pix:        pixResponse,
+	}, nil
+}
+
+// Consultar retorna uma cobrança com vencimento do estabelecimento e o valor a pagar na data informada
+func (uc *CobVUseCase) Consultar(estabelecimentoID string, txid string, data time.Time) (models.CobVResponse, error) {
+	cobv, err := uc.cobvRepository.FindByTxid(estabelecimentoID, txid)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	pix, err := uc.pixRepository.FindByID(estabelecimentoID, cobv.PixID)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	valores, err := uc.calculadora.Calcular(cobv, data)
+	if err != nil {
+		return models.CobVResponse{}, err
+	}
+
+	return models.CobVResponse{
+		Txid:       cobv.Txid,
+		Vencimento: cobv.Vencimento.Format(models.FormatoData),
+		PagavelAte: uc.calculadora.PagavelAte(cobv).Format(models.FormatoData),
+		Valores:    valores,
+		Pix: models.PixResponse{
+			CodigoPix: pix.CodigoPix,
+			TipoChave: pix.TipoChave,
+			QRCodeSVG: pix.QRCodeSVG,
+			QRCodePNG: pix.QRCodePNG,
+		},
+	}, nil
+}
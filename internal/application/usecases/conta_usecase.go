@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// ErrTokenContaInvalido indica um token de verificação de email ou de redefinição de senha
+// inexistente, expirado, já usado ou substituído por um token mais recente
+var ErrTokenContaInvalido = errors.New("token inválido, expirado ou já utilizado")
+
+const (
+	// duracaoVerificacaoEmail é a validade do link de confirmação do email
+	duracaoVerificacaoEmail = 24 * time.Hour
+
+	// duracaoRedefinicaoSenha é a validade do link de redefinição de senha
+	duracaoRedefinicaoSenha = time.Hour
+)
+
+// ContaUseCase implementa a verificação do email dos estabelecimentos e a redefinição de senha
+// dos logins, com tokens de uso único enviados por email
+type ContaUseCase struct {
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+	usuarioRepository         repositories.UsuarioRepository
+	tokenContaRepository      repositories.TokenContaRepository
+	refreshTokenRepository    repositories.RefreshTokenRepository
+	mailer                    email.Mailer
+	urlBase                   string
+	exigirVerificacao         bool
+}
+
+// NewContaUseCase cria uma nova instância do caso de uso de conta. Os links dos emails apontam
+// para urlBase; com exigirVerificacao, o login do estabelecimento exige o email confirmado.
+func NewContaUseCase(
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+	usuarioRepository repositories.UsuarioRepository,
+	tokenContaRepository repositories.TokenContaRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	mailer email.Mailer,
+	urlBase string,
+	exigirVerificacao bool,
+) *ContaUseCase {
+	return &ContaUseCase{
+		estabelecimentoRepository: estabelecimentoRepository,
+		usuarioRepository:         usuarioRepository,
+		tokenContaRepository:      tokenContaRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		mailer:                    mailer,
+		urlBase:                   urlBase,
+		exigirVerificacao:         exigirVerificacao,
+	}
+}
+
+// ConferirVerificacao recusa o login do estabelecimento que ainda não confirmou o email,
+// quando a verificação é obrigatória
+func (uc *ContaUseCase) ConferirVerificacao(estabelecimento models.Estabelecimento) error {
+	if uc.exigirVerificacao && estabelecimento.EmailVerificadoEm == nil {
+		return services.ErrEmailNaoVerificado
+	}
+	return nil
+}
+
+// EnviarVerificacao envia ao estabelecimento o link de confirmação do email. Um novo envio
+// invalida os links anteriores.
+func (uc *ContaUseCase) EnviarVerificacao(ctx context.Context, estabelecimento models.Estabelecimento) error {
+	token, err := uc.emitirToken(models.TokenVerificacaoEmail, estabelecimento.ID, "", duracaoVerificacaoEmail)
+	if err != nil {
+		return err
+	}
+
+	return uc.mailer.Enviar(ctx, services.MensagemVerificacaoEmail(estabelecimento, uc.urlBase, token, duracaoVerificacaoEmail))
+}
+
+// ReenviarVerificacao reenvia o link de confirmação ao estabelecimento com o email informado.
+// Emails desconhecidos, já confirmados ou de contas desativadas são ignorados sem erro, para
+// não revelar quais emails estão cadastrados.
+func (uc *ContaUseCase) ReenviarVerificacao(ctx context.Context, req models.ReenviarVerificacaoRequest) error {
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorEmail(req.Email)
+	if errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !estabelecimento.Ativo || estabelecimento.EmailVerificadoEm != nil {
+		return nil
+	}
+
+	// A falha no envio é registrada sem ser revelada, pelo mesmo motivo
+	if err := uc.EnviarVerificacao(ctx, estabelecimento); err != nil {
+		log.Printf("Erro ao enviar a verificação de email do estabelecimento %s: %v", estabelecimento.ID, err)
+	}
+	return nil
+}
+
+// VerificarEmail confirma o email do estabelecimento com o token recebido por email
+func (uc *ContaUseCase) VerificarEmail(req models.VerificarEmailRequest) (models.EstabelecimentoResponse, error) {
+	token, err := uc.consumirToken(models.TokenVerificacaoEmail, req.Token)
+	if err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	if err := uc.estabelecimentoRepository.MarcarEmailVerificado(token.EstabelecimentoID, time.Now()); err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(token.EstabelecimentoID)
+	if err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	return models.NovoEstabelecimentoResponse(estabelecimento), nil
+}
+
+// EsqueciSenha envia o link de redefinição de senha ao estabelecimento ou ao usuário da equipe
+// com o email informado. Emails desconhecidos, convites não aceitos e contas desativadas são
+// ignorados sem erro, para não revelar quais emails estão cadastrados.
+func (uc *ContaUseCase) EsqueciSenha(ctx context.Context, req models.EsqueciSenhaRequest) error {
+	var estabelecimentoID, usuarioID, nome string
+
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorEmail(req.Email)
+	switch {
+	case err == nil:
+		if !estabelecimento.Ativo {
+			return nil
+		}
+		estabelecimentoID, nome = estabelecimento.ID, estabelecimento.Nome
+	case errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado):
+		usuario, err := uc.usuarioRepository.BuscarPorEmail(req.Email)
+		if errors.Is(err, repositories.ErrUsuarioNaoEncontrado) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !usuario.Ativo || usuario.AceitoEm == nil {
+			return nil
+		}
+		estabelecimentoID, usuarioID, nome = usuario.EstabelecimentoID, usuario.ID, usuario.Nome
+	default:
+		return err
+	}
+
+	token, err := uc.emitirToken(models.TokenRedefinicaoSenha, estabelecimentoID, usuarioID, duracaoRedefinicaoSenha)
+	if err != nil {
+		return err
+	}
+
+	// A falha no envio é registrada sem ser revelada, pelo mesmo motivo
+	mensagem := services.MensagemRedefinicaoSenha(nome, req.Email, uc.urlBase, token, duracaoRedefinicaoSenha)
+	if err := uc.mailer.Enviar(ctx, mensagem); err != nil {
+		log.Printf("Erro ao enviar a redefinição de senha do estabelecimento %s: %v", estabelecimentoID, err)
+	}
+	return nil
+}
+
+// RedefinirSenha define a nova senha do login com o token recebido por email e encerra as
+// sessões abertas desse login. Como o token chegou pelo email, a redefinição também confirma
+// o email do estabelecimento.
+func (uc *ContaUseCase) RedefinirSenha(req models.RedefinirSenhaRequest) error {
+	agora := time.Now()
+
+	token, err := uc.consumirToken(models.TokenRedefinicaoSenha, req.Token)
+	if err != nil {
+		return err
+	}
+
+	if token.UsuarioID == "" {
+		err = uc.estabelecimentoRepository.AlterarSenha(token.EstabelecimentoID, req.NovaSenha)
+		if err == nil {
+			err = uc.estabelecimentoRepository.MarcarEmailVerificado(token.EstabelecimentoID, agora)
+		}
+	} else {
+		err = uc.usuarioRepository.AlterarSenha(token.UsuarioID, req.NovaSenha)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Os tokens de acesso já emitidos expiram sozinhos em poucos minutos
+	if err := uc.refreshTokenRepository.RevogarLogin(token.EstabelecimentoID, token.UsuarioID, agora); err != nil {
+		return err
+	}
+
+	return uc.tokenContaRepository.InvalidarPendentes(models.TokenRedefinicaoSenha, token.EstabelecimentoID, token.UsuarioID, agora)
+}
+
+// emitirToken invalida os tokens pendentes do mesmo tipo do login e gera um novo token
+func (uc *ContaUseCase) emitirToken(tipo, estabelecimentoID, usuarioID string, validade time.Duration) (string, error) {
+	agora := time.Now()
+
+	if err := uc.tokenContaRepository.InvalidarPendentes(tipo, estabelecimentoID, usuarioID, agora); err != nil {
+		return "", err
+	}
+
+	token, tokenHash, err := services.GerarTokenConta()
+	if err != nil {
+		return "", err
+	}
+
+	err = uc.tokenContaRepository.Salvar(models.TokenConta{
+		ID:                uuid.New().String(),
+		Tipo:              tipo,
+		EstabelecimentoID: estabelecimentoID,
+		UsuarioID:         usuarioID,
+		TokenHash:         tokenHash,
+		ExpiraEm:          agora.Add(validade),
+		CriadoEm:          agora,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumirToken valida o token do tipo informado e o marca como usado
+func (uc *ContaUseCase) consumirToken(tipo, token string) (models.TokenConta, error) {
+	agora := time.Now()
+
+	tokenConta, err := uc.tokenContaRepository.BuscarPorHash(services.HashTokenConta(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrTokenContaNaoEncontrado) {
+			return models.TokenConta{}, ErrTokenContaInvalido
+		}
+		return models.TokenConta{}, err
+	}
+
+	if tokenConta.Tipo != tipo || tokenConta.UsadoEm != nil || !agora.Before(tokenConta.ExpiraEm) {
+		return models.TokenConta{}, ErrTokenContaInvalido
+	}
+
+	// Duas requisições simultâneas com o mesmo token: apenas a primeira o usa
+	usado, err := uc.tokenContaRepository.MarcarUsado(tokenConta.ID, agora)
+	if err != nil {
+		return models.TokenConta{}, err
+	}
+	if !usado {
+		return models.TokenConta{}, ErrTokenContaInvalido
+	}
+
+	return tokenConta, nil
+}
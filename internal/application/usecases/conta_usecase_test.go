@@ -0,0 +1,217 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenContaRepositoryMemoria guarda os tokens de conta em memória
+type tokenContaRepositoryMemoria struct {
+	mu     sync.Mutex
+	tokens map[string]models.TokenConta
+}
+
+func (r *tokenContaRepositoryMemoria) Salvar(token models.TokenConta) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = token
+	return nil
+}
+
+func (r *tokenContaRepositoryMemoria) BuscarPorHash(tokenHash string) (models.TokenConta, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return models.TokenConta{}, repositories.ErrTokenContaNaoEncontrado
+}
+
+func (r *tokenContaRepositoryMemoria) MarcarUsado(id string, usadoEm time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token := r.tokens[id]
+	if token.UsadoEm != nil {
+		return false, nil
+	}
+	token.UsadoEm = &usadoEm
+	r.tokens[id] = token
+	return true, nil
+}
+
+func (r *tokenContaRepositoryMemoria) InvalidarPendentes(tipo, estabelecimentoID, usuarioID string, invalidadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.Tipo == tipo && token.EstabelecimentoID == estabelecimentoID && token.UsuarioID == usuarioID && token.UsadoEm == nil {
+			token.UsadoEm = &invalidadoEm
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// tokenNoEmail extrai o token do link do último email enviado
+var tokenNoEmail = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+func ultimoToken(t *testing.T, mailer *email.LogMailer) string {
+	enviadas := mailer.Enviadas()
+	if len(enviadas) == 0 {
+		t.Fatal("nenhum email enviado")
+	}
+
+	correspondencia := tokenNoEmail.FindStringSubmatch(enviadas[len(enviadas)-1].Corpo)
+	if correspondencia == nil {
+		t.Fatal("email sem o link com o token")
+	}
+	return correspondencia[1]
+}
+
+func TestContaUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+	refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+	tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+	revogacao := &revogacaoMemoria{jtis: map[string]bool{}}
+	mailer := email.NewLogMailer(io.Discard)
+	uc := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, mailer, "https://app.exemplo.com", true)
+	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, revogacao, uc, novaProtecaoLogin(configuracaoProtecaoLoginTeste), novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+	usuarioUseCase := usecases.NewUsuarioUseCase(usuarioRepository, estabelecimentoRepository)
+	ctx := context.Background()
+
+	t.Run("VerificacaoDoEmail", func(t *testing.T) {
+		_, err := autenticacaoUseCase.Registrar(models.EstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", Senha: "senha123"})
+		assert.NoError(t, err)
+		token := ultimoToken(t, mailer)
+
+		// Sem o email confirmado, o login é recusado após a senha ser conferida
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.ErrorIs(t, err, services.ErrEmailNaoVerificado)
+
+		// Executar o método a ser testado
+		estabelecimento, err := uc.VerificarEmail(models.VerificarEmailRequest{Token: token})
+
+		// Verificar resultados: o email é confirmado, o token vale uma única vez e o login é liberado
+		assert.NoError(t, err)
+		assert.NotNil(t, estabelecimento.EmailVerificadoEm)
+
+		_, err = uc.VerificarEmail(models.VerificarEmailRequest{Token: token})
+		assert.ErrorIs(t, err, usecases.ErrTokenContaInvalido)
+
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ReenvioInvalidaLinkAnterior", func(t *testing.T) {
+		_, err := autenticacaoUseCase.Registrar(models.EstabelecimentoRequest{Nome: "Reenvio", Email: "reenvio@teste.com", Senha: "senha123"})
+		assert.NoError(t, err)
+		anterior := ultimoToken(t, mailer)
+
+		// Executar o método a ser testado
+		err = uc.ReenviarVerificacao(ctx, models.ReenviarVerificacaoRequest{Email: "reenvio@teste.com"})
+		assert.NoError(t, err)
+
+		// Verificar resultados
+		_, err = uc.VerificarEmail(models.VerificarEmailRequest{Token: anterior})
+		assert.ErrorIs(t, err, usecases.ErrTokenContaInvalido)
+
+		_, err = uc.VerificarEmail(models.VerificarEmailRequest{Token: ultimoToken(t, mailer)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("RedefinirSenha", func(t *testing.T) {
+		sessao, err := autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+
+		err = uc.EsqueciSenha(ctx, models.EsqueciSenhaRequest{Email: "loja@teste.com"})
+		assert.NoError(t, err)
+		token := ultimoToken(t, mailer)
+
+		// Executar o método a ser testado
+		err = uc.RedefinirSenha(models.RedefinirSenhaRequest{Token: token, NovaSenha: "novaSenha456"})
+		assert.NoError(t, err)
+
+		// Verificar resultados: a senha antiga deixa de valer, as sessões abertas são encerradas
+		// e o token não pode ser usado de novo
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.Error(t, err)
+
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "novaSenha456"}, ipTeste)
+		assert.NoError(t, err)
+
+		_, err = autenticacaoUseCase.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenReutilizado)
+
+		err = uc.RedefinirSenha(models.RedefinirSenhaRequest{Token: token, NovaSenha: "outraSenha789"})
+		assert.ErrorIs(t, err, usecases.ErrTokenContaInvalido)
+	})
+
+	t.Run("RedefinirSenhaDeUsuario", func(t *testing.T) {
+		estabelecimento, err := estabelecimentoRepository.BuscarPorEmail("loja@teste.com")
+		assert.NoError(t, err)
+		convite, err := usuarioUseCase.Convidar(estabelecimento.ID, models.PapelProprietario, models.ConvidarUsuarioRequest{Email: "caixa@teste.com", Papel: models.PapelCaixa})
+		assert.NoError(t, err)
+		_, err = usuarioUseCase.AceitarConvite(models.AceitarConviteRequest{Convite: convite.Convite, Nome: "Maria", Senha: "senha456"})
+		assert.NoError(t, err)
+
+		err = uc.EsqueciSenha(ctx, models.EsqueciSenhaRequest{Email: "caixa@teste.com"})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		err = uc.RedefinirSenha(models.RedefinirSenhaRequest{Token: ultimoToken(t, mailer), NovaSenha: "novaSenha789"})
+		assert.NoError(t, err)
+
+		// Verificar resultados: o usuário entra com a nova senha e o estabelecimento mantém a dele
+		resposta, err := autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "caixa@teste.com", Senha: "novaSenha789"}, ipTeste)
+		assert.NoError(t, err)
+		assert.Equal(t, models.PapelCaixa, resposta.Papel)
+
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: "novaSenha456"}, ipTeste)
+		assert.NoError(t, err)
+	})
+
+	t.Run("EmailDesconhecido", func(t *testing.T) {
+		enviadas := len(mailer.Enviadas())
+
+		// Executar o método a ser testado
+		err := uc.EsqueciSenha(ctx, models.EsqueciSenhaRequest{Email: "desconhecido@teste.com"})
+
+		// Verificar resultados: a resposta é a mesma, mas nenhum email é enviado
+		assert.NoError(t, err)
+		assert.Len(t, mailer.Enviadas(), enviadas)
+	})
+
+	t.Run("TokenDeOutroTipo", func(t *testing.T) {
+		_, err := autenticacaoUseCase.Registrar(models.EstabelecimentoRequest{Nome: "Outra", Email: "outra@teste.com", Senha: "senha123"})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado: usar o link de verificação para redefinir a senha
+		err = uc.RedefinirSenha(models.RedefinirSenhaRequest{Token: ultimoToken(t, mailer), NovaSenha: "invasora123"})
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, usecases.ErrTokenContaInvalido)
+	})
+}
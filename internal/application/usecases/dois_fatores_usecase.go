@@ -0,0 +1,478 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+var (
+	// ErrDoisFatoresJaAtivo indica um novo cadastro do segundo fator em um login que já o tem
+	ErrDoisFatoresJaAtivo = errors.New("o segundo fator já está ativo: desative-o antes de um novo cadastro")
+
+	// ErrDoisFatoresNaoCadastrado indica a confirmação sem um cadastro pendente ou o uso do
+	// segundo fator em um login que não o tem
+	ErrDoisFatoresNaoCadastrado = errors.New("segundo fator não cadastrado")
+
+	// ErrDoisFatoresObrigatorio indica a desativação do segundo fator exigido pelo estabelecimento
+	ErrDoisFatoresObrigatorio = errors.New("o estabelecimento exige o segundo fator, que não pode ser desativado")
+
+	// ErrDesafioDoisFatoresInvalido indica um desafio inexistente, expirado ou já usado
+	ErrDesafioDoisFatoresInvalido = errors.New("desafio inválido ou expirado: faça login novamente")
+
+	// ErrMuitasTentativasDoisFatores indica que o login atingiu o limite de códigos incorretos
+	ErrMuitasTentativasDoisFatores = errors.New("muitos códigos incorretos: aguarde alguns minutos antes de tentar novamente")
+)
+
+const (
+	// prefixoDoisFatores é o prefixo das chaves do segundo fator no cache
+	prefixoDoisFatores = "2fa:"
+
+	// duracaoDesafioDoisFatores é a validade do desafio retornado pelo login
+	duracaoDesafioDoisFatores = 5 * time.Minute
+
+	// maxTentativasDoisFatores é o número de códigos incorretos de um login, dentro da janela,
+	// que bloqueia novas tentativas até o fim dela
+	maxTentativasDoisFatores = 5
+
+	// janelaTentativasDoisFatores é o período em que os códigos incorretos são contados
+	janelaTentativasDoisFatores = 15 * time.Minute
+)
+
+// DoisFatoresUseCase implementa o segundo fator (TOTP) dos logins do estabelecimento e dos
+// usuários da equipe: o cadastro confirmado com um código do aplicativo, os códigos de
+// recuperação, a exigência pelo estabelecimento e o desafio que conclui o login.
+type DoisFatoresUseCase struct {
+	doisFatoresRepository     repositories.DoisFatoresRepository
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+	usuarioRepository         repositories.UsuarioRepository
+	autenticacaoService       *services.AutenticacaoService
+	cache                     cache.ContadorAdapter
+	emissor                   string
+}
+
+// NewDoisFatoresUseCase cria uma nova instância do caso de uso do segundo fator. O emissor é
+// o nome exibido no aplicativo autenticador.
+func NewDoisFatoresUseCase(
+	doisFatoresRepository repositories.DoisFatoresRepository,
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+	usuarioRepository repositories.UsuarioRepository,
+	autenticacaoService *services.AutenticacaoService,
+	cache cache.ContadorAdapter,
+	emissor string,
+) *DoisFatoresUseCase {
+	return &DoisFatoresUseCase{
+		doisFatoresRepository:     doisFatoresRepository,
+		estabelecimentoRepository: estabelecimentoRepository,
+		usuarioRepository:         usuarioRepository,
+		autenticacaoService:       autenticacaoService,
+		cache:                     cache,
+		emissor:                   emissor,
+	}
+}
+
+// Status retorna a situação do segundo fator do login autenticado
+func (uc *DoisFatoresUseCase) Status(estabelecimentoID, usuarioID string) (models.StatusDoisFatoresResponse, error) {
+	login, err := uc.carregarLogin(estabelecimentoID, usuarioID)
+	if err != nil {
+		return models.StatusDoisFatoresResponse{}, err
+	}
+
+	status := models.StatusDoisFatoresResponse{Obrigatorio: login.obrigatorio}
+
+	doisFatores, err := uc.doisFatoresRepository.Buscar(login.id)
+	if errors.Is(err, repositories.ErrDoisFatoresNaoEncontrado) || (err == nil && !doisFatores.Ativo()) {
+		return status, nil
+	}
+	if err != nil {
+		return models.StatusDoisFatoresResponse{}, err
+	}
+
+	status.Ativo = true
+	status.CodigosRecuperacaoRestantes, err = uc.doisFatoresRepository.ContarCodigosRecuperacao(login.id)
+	if err != nil {
+		return models.StatusDoisFatoresResponse{}, err
+	}
+
+	return status, nil
+}
+
+// IniciarCadastro gera um novo segredo para o login autenticado, que só passa a ser exigido
+// depois de confirmado com um código do aplicativo. Um cadastro pendente anterior é substituído.
+func (uc *DoisFatoresUseCase) IniciarCadastro(estabelecimentoID, usuarioID string) (models.CadastroDoisFatoresResponse, error) {
+	login, err := uc.carregarLogin(estabelecimentoID, usuarioID)
+	if err != nil {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+
+	return uc.iniciarCadastro(estabelecimentoID, login.id, login.email)
+}
+
+// Confirmar ativa o cadastro pendente do login autenticado com um código do aplicativo e
+// retorna os códigos de recuperação
+func (uc *DoisFatoresUseCase) Confirmar(ctx context.Context, estabelecimentoID, usuarioID string, req models.CodigoDoisFatoresRequest) (models.CodigosRecuperacaoResponse, error) {
+	login, err := uc.carregarLogin(estabelecimentoID, usuarioID)
+	if err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	codigos, err := uc.confirmarCadastro(ctx, login.id, req.Codigo)
+	if err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	return models.CodigosRecuperacaoResponse{CodigosRecuperacao: codigos}, nil
+}
+
+// RegenerarCodigosRecuperacao substitui os códigos de recuperação do login autenticado,
+// confirmando a troca com um código do aplicativo
+func (uc *DoisFatoresUseCase) RegenerarCodigosRecuperacao(ctx context.Context, estabelecimentoID, usuarioID string, req models.CodigoDoisFatoresRequest) (models.CodigosRecuperacaoResponse, error) {
+	login, err := uc.carregarLogin(estabelecimentoID, usuarioID)
+	if err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	doisFatores, err := uc.buscarAtivo(login.id)
+	if err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	// Um código de recuperação não gera novos códigos, para que um código vazado não se renove
+	if err := uc.conferirCodigo(ctx, doisFatores, req.Codigo, false); err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	codigos, hashes, err := services.GerarCodigosRecuperacao()
+	if err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	if err := uc.doisFatoresRepository.SubstituirCodigosRecuperacao(login.id, hashes, time.Now()); err != nil {
+		return models.CodigosRecuperacaoResponse{}, err
+	}
+
+	return models.CodigosRecuperacaoResponse{CodigosRecuperacao: codigos}, nil
+}
+
+// Desativar remove o segundo fator do login autenticado, confirmando com a senha e um código
+// do aplicativo ou de recuperação. O segundo fator exigido pelo estabelecimento não pode ser
+// desativado.
+func (uc *DoisFatoresUseCase) Desativar(ctx context.Context, estabelecimentoID, usuarioID string, req models.DesativarDoisFatoresRequest) error {
+	login, err := uc.carregarLogin(estabelecimentoID, usuarioID)
+	if err != nil {
+		return err
+	}
+
+	if login.obrigatorio {
+		return ErrDoisFatoresObrigatorio
+	}
+
+	if err := uc.autenticacaoService.VerificarSenha(login.senha, req.Senha); err != nil {
+		return models.ErrosValidacao{{Campo: "senha", Mensagem: "senha incorreta"}}
+	}
+
+	doisFatores, err := uc.buscarAtivo(login.id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.conferirCodigo(ctx, doisFatores, req.Codigo, true); err != nil {
+		return err
+	}
+
+	return uc.doisFatoresRepository.Remover(login.id)
+}
+
+// AlterarPolitica define se o estabelecimento exige o segundo fator no próprio login e no dos
+// usuários da equipe. Os logins sem o segundo fator passam a cadastrá-lo no próximo login.
+func (uc *DoisFatoresUseCase) AlterarPolitica(estabelecimentoID string, req models.PoliticaDoisFatoresRequest) (models.StatusDoisFatoresResponse, error) {
+	if err := uc.estabelecimentoRepository.AlterarDoisFatoresObrigatorio(estabelecimentoID, *req.Obrigatorio); err != nil {
+		return models.StatusDoisFatoresResponse{}, err
+	}
+
+	return uc.Status(estabelecimentoID, "")
+}
+
+// Desafiar é chamado no login com a senha correta e retorna o desafio do segundo fator quando
+// o login tem o segundo fator ativo ou quando o estabelecimento o exige; caso contrário,
+// retorna nil e o login é concluído sem o segundo fator.
+func (uc *DoisFatoresUseCase) Desafiar(ctx context.Context, estabelecimento models.Estabelecimento, usuario *models.Usuario) (*models.DesafioDoisFatoresResponse, error) {
+	desafio := models.DesafioDoisFatores{EstabelecimentoID: estabelecimento.ID, Email: estabelecimento.Email}
+	if usuario != nil {
+		desafio.UsuarioID = usuario.ID
+		desafio.Email = usuario.Email
+	}
+
+	doisFatores, err := uc.doisFatoresRepository.Buscar(desafio.LoginID())
+	if err != nil && !errors.Is(err, repositories.ErrDoisFatoresNaoEncontrado) {
+		return nil, err
+	}
+
+	ativo := err == nil && doisFatores.Ativo()
+	if !ativo && !estabelecimento.DoisFatoresObrigatorio {
+		return nil, nil
+	}
+	desafio.Cadastro = !ativo
+
+	token, hash, err := services.GerarDesafioDoisFatores()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.SetObject(uc.cache, ctx, chaveDoisFatores("desafio", hash), desafio, duracaoDesafioDoisFatores); err != nil {
+		return nil, err
+	}
+
+	return &models.DesafioDoisFatoresResponse{
+		Desafio:             token,
+		ExpiraEmSegundos:    int64(duracaoDesafioDoisFatores.Seconds()),
+		CadastroObrigatorio: desafio.Cadastro,
+	}, nil
+}
+
+// IniciarCadastroDesafio inicia o cadastro do segundo fator exigido pelo estabelecimento
+// durante o login, com o desafio que indica o cadastro obrigatório
+func (uc *DoisFatoresUseCase) IniciarCadastroDesafio(ctx context.Context, req models.CadastroDesafioRequest) (models.CadastroDoisFatoresResponse, error) {
+	desafio, err := uc.lerDesafio(ctx, req.Desafio)
+	if err != nil {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+
+	if !desafio.Cadastro {
+		return models.CadastroDoisFatoresResponse{}, ErrDesafioDoisFatoresInvalido
+	}
+
+	return uc.iniciarCadastro(desafio.EstabelecimentoID, desafio.LoginID(), desafio.Email)
+}
+
+// ConcluirDesafio confere o código do desafio do login e retorna o login a ser concluído. No
+// cadastro obrigatório, o código confirma o cadastro e os códigos de recuperação são
+// retornados. O desafio vale para um único login.
+func (uc *DoisFatoresUseCase) ConcluirDesafio(ctx context.Context, req models.LoginDoisFatoresRequest) (models.DesafioDoisFatores, []string, error) {
+	desafio, err := uc.lerDesafio(ctx, req.Desafio)
+	if err != nil {
+		return models.DesafioDoisFatores{}, nil, err
+	}
+
+	var codigos []string
+	if desafio.Cadastro {
+		codigos, err = uc.confirmarCadastro(ctx, desafio.LoginID(), req.Codigo)
+	} else {
+		var doisFatores models.DoisFatores
+		doisFatores, err = uc.buscarAtivo(desafio.LoginID())
+		if errors.Is(err, ErrDoisFatoresNaoCadastrado) {
+			// O segundo fator foi desativado depois do login com a senha
+			err = ErrDesafioDoisFatoresInvalido
+		}
+		if err == nil {
+			err = uc.conferirCodigo(ctx, doisFatores, req.Codigo, true)
+		}
+	}
+	if err != nil {
+		return models.DesafioDoisFatores{}, nil, err
+	}
+
+	if err := uc.cache.Delete(ctx, chaveDoisFatores("desafio", services.HashTokenConta(req.Desafio))); err != nil {
+		return models.DesafioDoisFatores{}, nil, err
+	}
+
+	return desafio, codigos, nil
+}
+
+// loginDoisFatores reúne os dados do login do estabelecimento ou do usuário da equipe usados
+// no segundo fator
+type loginDoisFatores struct {
+	id          string
+	email       string
+	senha       string
+	obrigatorio bool
+}
+
+// carregarLogin busca o login do estabelecimento ou, quando informado, do usuário da equipe
+func (uc *DoisFatoresUseCase) carregarLogin(estabelecimentoID, usuarioID string) (loginDoisFatores, error) {
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(estabelecimentoID)
+	if err != nil {
+		return loginDoisFatores{}, err
+	}
+
+	login := loginDoisFatores{
+		id:          estabelecimento.ID,
+		email:       estabelecimento.Email,
+		senha:       estabelecimento.Senha,
+		obrigatorio: estabelecimento.DoisFatoresObrigatorio,
+	}
+
+	if usuarioID == "" {
+		return login, nil
+	}
+
+	usuario, err := uc.usuarioRepository.BuscarPorID(usuarioID)
+	if err == nil && usuario.EstabelecimentoID != estabelecimentoID {
+		err = repositories.ErrUsuarioNaoEncontrado
+	}
+	if err != nil {
+		return loginDoisFatores{}, err
+	}
+
+	login.id = usuario.ID
+	login.email = usuario.Email
+	login.senha = usuario.Senha
+	return login, nil
+}
+
+// iniciarCadastro gera e salva um novo segredo pendente para o login
+func (uc *DoisFatoresUseCase) iniciarCadastro(estabelecimentoID, loginID, email string) (models.CadastroDoisFatoresResponse, error) {
+	doisFatores, err := uc.doisFatoresRepository.Buscar(loginID)
+	if err != nil && !errors.Is(err, repositories.ErrDoisFatoresNaoEncontrado) {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+	if err == nil && doisFatores.Ativo() {
+		return models.CadastroDoisFatoresResponse{}, ErrDoisFatoresJaAtivo
+	}
+
+	segredo, err := services.GerarSegredoTOTP()
+	if err != nil {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+
+	err = uc.doisFatoresRepository.SalvarPendente(models.DoisFatores{
+		LoginID:           loginID,
+		EstabelecimentoID: estabelecimentoID,
+		Segredo:           segredo,
+		CriadoEm:          time.Now(),
+	})
+	if err != nil {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+
+	uri := services.URIOTPAuth(uc.emissor, email, segredo)
+	qrcode, err := services.QRCodeOTPAuth(uri)
+	if err != nil {
+		return models.CadastroDoisFatoresResponse{}, err
+	}
+
+	return models.CadastroDoisFatoresResponse{Segredo: segredo, URI: uri, QRCode: qrcode}, nil
+}
+
+// confirmarCadastro ativa o cadastro pendente do login com um código do aplicativo e gera os
+// códigos de recuperação
+func (uc *DoisFatoresUseCase) confirmarCadastro(ctx context.Context, loginID, codigo string) ([]string, error) {
+	doisFatores, err := uc.doisFatoresRepository.Buscar(loginID)
+	if errors.Is(err, repositories.ErrDoisFatoresNaoEncontrado) {
+		return nil, ErrDoisFatoresNaoCadastrado
+	}
+	if err != nil {
+		return nil, err
+	}
+	if doisFatores.Ativo() {
+		return nil, ErrDoisFatoresJaAtivo
+	}
+
+	if err := uc.reservarTentativa(ctx, loginID); err != nil {
+		return nil, err
+	}
+
+	passo, err := services.ValidarCodigoTOTP(doisFatores.Segredo, codigo, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	codigos, hashes, err := services.GerarCodigosRecuperacao()
+	if err != nil {
+		return nil, err
+	}
+
+	ativado, err := uc.doisFatoresRepository.Ativar(loginID, passo, hashes, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ativado {
+		return nil, ErrDoisFatoresNaoCadastrado
+	}
+
+	return codigos, uc.cache.Delete(ctx, chaveDoisFatores("falhas", loginID))
+}
+
+// buscarAtivo busca o segundo fator confirmado do login
+func (uc *DoisFatoresUseCase) buscarAtivo(loginID string) (models.DoisFatores, error) {
+	doisFatores, err := uc.doisFatoresRepository.Buscar(loginID)
+	if errors.Is(err, repositories.ErrDoisFatoresNaoEncontrado) || (err == nil && !doisFatores.Ativo()) {
+		return models.DoisFatores{}, ErrDoisFatoresNaoCadastrado
+	}
+	return doisFatores, err
+}
+
+// conferirCodigo confere um código do aplicativo ou, quando aceito, um código de recuperação.
+// Cada código do aplicativo e de recuperação vale uma única vez, e cada código é reservado no
+// limite de tentativas do login antes de ser conferido.
+func (uc *DoisFatoresUseCase) conferirCodigo(ctx context.Context, doisFatores models.DoisFatores, codigo string, aceitarRecuperacao bool) error {
+	if err := uc.reservarTentativa(ctx, doisFatores.LoginID); err != nil {
+		return err
+	}
+
+	valido := false
+	codigo = strings.TrimSpace(codigo)
+	if services.CodigoTOTPValido(strings.ReplaceAll(codigo, " ", "")) {
+		passo, err := services.ValidarCodigoTOTP(doisFatores.Segredo, codigo, time.Now())
+		if err == nil {
+			valido, err = uc.doisFatoresRepository.RegistrarPasso(doisFatores.LoginID, passo)
+		}
+		if err != nil && !errors.Is(err, services.ErrCodigoDoisFatoresInvalido) {
+			return err
+		}
+	} else if aceitarRecuperacao {
+		var err error
+		valido, err = uc.doisFatoresRepository.UsarCodigoRecuperacao(doisFatores.LoginID, services.HashCodigoRecuperacao(codigo), time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if !valido {
+		return services.ErrCodigoDoisFatoresInvalido
+	}
+
+	return uc.cache.Delete(ctx, chaveDoisFatores("falhas", doisFatores.LoginID))
+}
+
+// reservarTentativa conta o código do login antes da conferência e retorna
+// ErrMuitasTentativasDoisFatores quando ele ultrapassa o limite da janela. Como o contador é
+// incrementado atomicamente, requisições simultâneas não conferem mais códigos que o limite;
+// um código correto zera o contador.
+func (uc *DoisFatoresUseCase) reservarTentativa(ctx context.Context, loginID string) error {
+	tentativas, err := uc.cache.Incrementar(ctx, chaveDoisFatores("falhas", loginID), janelaTentativasDoisFatores)
+	if err != nil {
+		return err
+	}
+
+	if tentativas > maxTentativasDoisFatores {
+		return ErrMuitasTentativasDoisFatores
+	}
+	return nil
+}
+
+// lerDesafio busca no cache o login pendente do desafio informado
+func (uc *DoisFatoresUseCase) lerDesafio(ctx context.Context, token string) (models.DesafioDoisFatores, error) {
+	var desafio models.DesafioDoisFatores
+
+	err := cache.GetObject(uc.cache, ctx, chaveDoisFatores("desafio", services.HashTokenConta(token)), &desafio)
+	if errors.Is(err, cache.ErrChaveInexistente) {
+		return models.DesafioDoisFatores{}, ErrDesafioDoisFatoresInvalido
+	}
+	if err != nil {
+		return models.DesafioDoisFatores{}, err
+	}
+
+	return desafio, nil
+}
+
+// chaveDoisFatores monta a chave do cache de um desafio ou de um contador de códigos incorretos
+func chaveDoisFatores(categoria, identificador string) string {
+	return prefixoDoisFatores + categoria + ":" + identificador
+}
@@ -0,0 +1,381 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// doisFatoresRepositoryMemoria guarda o segundo fator e os códigos de recuperação em memória
+type doisFatoresRepositoryMemoria struct {
+	mu          sync.Mutex
+	doisFatores map[string]models.DoisFatores
+	codigos     map[string]map[string]bool
+
+	// atraso simula a latência do banco na conferência dos códigos de recuperação, e
+	// conferencias conta as conferências feitas
+	atraso       time.Duration
+	conferencias int
+}
+
+func novoDoisFatoresRepositoryMemoria() *doisFatoresRepositoryMemoria {
+	return &doisFatoresRepositoryMemoria{
+		doisFatores: map[string]models.DoisFatores{},
+		codigos:     map[string]map[string]bool{},
+	}
+}
+
+func (r *doisFatoresRepositoryMemoria) Buscar(loginID string) (models.DoisFatores, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doisFatores, existe := r.doisFatores[loginID]
+	if !existe {
+		return models.DoisFatores{}, repositories.ErrDoisFatoresNaoEncontrado
+	}
+	return doisFatores, nil
+}
+
+func (r *doisFatoresRepositoryMemoria) SalvarPendente(doisFatores models.DoisFatores) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if atual, existe := r.doisFatores[doisFatores.LoginID]; existe && atual.Ativo() {
+		return nil
+	}
+	r.doisFatores[doisFatores.LoginID] = doisFatores
+	return nil
+}
+
+func (r *doisFatoresRepositoryMemoria) Ativar(loginID string, passo int64, codigosHash []string, ativadoEm time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doisFatores, existe := r.doisFatores[loginID]
+	if !existe || doisFatores.Ativo() {
+		return false, nil
+	}
+	doisFatores.AtivadoEm = &ativadoEm
+	doisFatores.UltimoPasso = passo
+	r.doisFatores[loginID] = doisFatores
+	r.substituir(loginID, codigosHash)
+	return true, nil
+}
+
+func (r *doisFatoresRepositoryMemoria) RegistrarPasso(loginID string, passo int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doisFatores := r.doisFatores[loginID]
+	if doisFatores.UltimoPasso >= passo {
+		return false, nil
+	}
+	doisFatores.UltimoPasso = passo
+	r.doisFatores[loginID] = doisFatores
+	return true, nil
+}
+
+func (r *doisFatoresRepositoryMemoria) Remover(loginID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.doisFatores, loginID)
+	delete(r.codigos, loginID)
+	return nil
+}
+
+func (r *doisFatoresRepositoryMemoria) SubstituirCodigosRecuperacao(loginID string, codigosHash []string, criadoEm time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.substituir(loginID, codigosHash)
+	return nil
+}
+
+func (r *doisFatoresRepositoryMemoria) UsarCodigoRecuperacao(loginID, codigoHash string, usadoEm time.Time) (bool, error) {
+	time.Sleep(r.atraso)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conferencias++
+
+	usado, existe := r.codigos[loginID][codigoHash]
+	if !existe || usado {
+		return false, nil
+	}
+	r.codigos[loginID][codigoHash] = true
+	return true, nil
+}
+
+func (r *doisFatoresRepositoryMemoria) ContarCodigosRecuperacao(loginID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := 0
+	for _, usado := range r.codigos[loginID] {
+		if !usado {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *doisFatoresRepositoryMemoria) substituir(loginID string, codigosHash []string) {
+	r.codigos[loginID] = map[string]bool{}
+	for _, codigoHash := range codigosHash {
+		r.codigos[loginID][codigoHash] = false
+	}
+}
+
+// novosDoisFatores cria o segundo fator com o repositório e o cache em memória
+func novosDoisFatores(estabelecimentoRepository repositories.EstabelecimentoRepository, usuarioRepository repositories.UsuarioRepository, autenticacaoService *services.AutenticacaoService) *usecases.DoisFatoresUseCase {
+	return usecases.NewDoisFatoresUseCase(
+		novoDoisFatoresRepositoryMemoria(),
+		estabelecimentoRepository,
+		usuarioRepository,
+		autenticacaoService,
+		&contadorMemoria{valores: map[string]valorContador{}},
+		"Gerador de PIX",
+	)
+}
+
+// codigoAtual calcula o código do aplicativo para o segredo, deslocado em passos do atual
+func codigoAtual(t *testing.T, segredo string, deslocamento int64) string {
+	codigo, err := services.CodigoTOTP(segredo, services.PassoTOTP(time.Now())+deslocamento)
+	if err != nil {
+		t.Fatalf("Não foi possível calcular o código TOTP: %v", err)
+	}
+	return codigo
+}
+
+func TestDoisFatoresUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+	refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+	tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+	contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+	doisFatoresRepository := novoDoisFatoresRepositoryMemoria()
+	uc := usecases.NewDoisFatoresUseCase(doisFatoresRepository, estabelecimentoRepository, usuarioRepository, autenticacaoService, &contadorMemoria{valores: map[string]valorContador{}}, "Gerador de PIX")
+	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, &revogacaoMemoria{jtis: map[string]bool{}}, contaUseCase, novaProtecaoLogin(configuracaoProtecaoLoginTeste), uc)
+	ctx := context.Background()
+
+	novoEstabelecimento := func(t *testing.T, email string) models.Estabelecimento {
+		estabelecimento, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: email, Senha: "senha123"})
+		assert.NoError(t, err)
+		return estabelecimento
+	}
+
+	loja := novoEstabelecimento(t, "loja@teste.com")
+	var segredo string
+	var codigosRecuperacao []string
+
+	t.Run("Cadastro", func(t *testing.T) {
+		cadastro, err := uc.IniciarCadastro(loja.ID, "")
+		assert.NoError(t, err)
+		assert.Contains(t, cadastro.URI, "secret="+cadastro.Segredo)
+		segredo = cadastro.Segredo
+
+		// Um código incorreto não confirma o cadastro, que ainda não é exigido no login
+		_, err = uc.Confirmar(ctx, loja.ID, "", models.CodigoDoisFatoresRequest{Codigo: "abcdef"})
+		assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		assert.Nil(t, resposta.DoisFatores)
+		assert.NotEmpty(t, resposta.Token)
+
+		// Executar o método a ser testado
+		codigos, err := uc.Confirmar(ctx, loja.ID, "", models.CodigoDoisFatoresRequest{Codigo: codigoAtual(t, segredo, 0)})
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Len(t, codigos.CodigosRecuperacao, 10)
+		codigosRecuperacao = codigos.CodigosRecuperacao
+
+		status, err := uc.Status(loja.ID, "")
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusDoisFatoresResponse{Ativo: true, CodigosRecuperacaoRestantes: 10}, status)
+
+		_, err = uc.IniciarCadastro(loja.ID, "")
+		assert.ErrorIs(t, err, usecases.ErrDoisFatoresJaAtivo)
+	})
+
+	t.Run("LoginComCodigoDoAplicativo", func(t *testing.T) {
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+
+		// A senha correta retorna apenas o desafio
+		if !assert.NotNil(t, resposta.DoisFatores) {
+			return
+		}
+		assert.Empty(t, resposta.Token)
+		assert.False(t, resposta.DoisFatores.CadastroObrigatorio)
+		desafio := resposta.DoisFatores.Desafio
+
+		// O código que confirmou o cadastro não pode ser reutilizado
+		_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigoAtual(t, segredo, 0)})
+		assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+
+		// Executar o método a ser testado
+		sessao, err := autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigoAtual(t, segredo, 1)})
+
+		// Verificar resultados: os tokens são emitidos e o desafio vale uma única vez
+		assert.NoError(t, err)
+		assert.NotEmpty(t, sessao.Token)
+		assert.NotEmpty(t, sessao.RefreshToken)
+		assert.Empty(t, sessao.CodigosRecuperacao)
+
+		_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigosRecuperacao[0]})
+		assert.ErrorIs(t, err, usecases.ErrDesafioDoisFatoresInvalido)
+	})
+
+	t.Run("LoginComCodigoDeRecuperacao", func(t *testing.T) {
+		login := func() string {
+			resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+			assert.NoError(t, err)
+			if resposta.DoisFatores == nil {
+				t.Fatal("login sem o desafio do segundo fator")
+			}
+			return resposta.DoisFatores.Desafio
+		}
+
+		// Executar o método a ser testado
+		_, err := autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: login(), Codigo: codigosRecuperacao[0]})
+
+		// Verificar resultados: cada código de recuperação vale uma única vez
+		assert.NoError(t, err)
+
+		_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: login(), Codigo: codigosRecuperacao[0]})
+		assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+
+		status, err := uc.Status(loja.ID, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 9, status.CodigosRecuperacaoRestantes)
+	})
+
+	t.Run("Desativar", func(t *testing.T) {
+		err := uc.Desativar(ctx, loja.ID, "", models.DesativarDoisFatoresRequest{Senha: "errada", Codigo: codigosRecuperacao[1]})
+		_, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+
+		// Executar o método a ser testado
+		err = uc.Desativar(ctx, loja.ID, "", models.DesativarDoisFatoresRequest{Senha: "senha123", Codigo: codigosRecuperacao[1]})
+
+		// Verificar resultados: o login volta a emitir os tokens com a senha
+		assert.NoError(t, err)
+
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		assert.Nil(t, resposta.DoisFatores)
+		assert.NotEmpty(t, resposta.Token)
+	})
+
+	t.Run("LimiteDeTentativas", func(t *testing.T) {
+		outra := novoEstabelecimento(t, "tentativas@teste.com")
+		cadastro, err := uc.IniciarCadastro(outra.ID, "")
+		assert.NoError(t, err)
+		_, err = uc.Confirmar(ctx, outra.ID, "", models.CodigoDoisFatoresRequest{Codigo: codigoAtual(t, cadastro.Segredo, 0)})
+		assert.NoError(t, err)
+
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "tentativas@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		desafio := resposta.DoisFatores.Desafio
+
+		// Executar o método a ser testado
+		for i := 0; i < 5; i++ {
+			_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: "xxxxx-xxxxx"})
+			assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+		}
+
+		// Verificar resultados: nem o código correto é aceito até o fim da janela
+		_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigoAtual(t, cadastro.Segredo, 1)})
+		assert.ErrorIs(t, err, usecases.ErrMuitasTentativasDoisFatores)
+	})
+
+	t.Run("TentativasSimultaneas", func(t *testing.T) {
+		outra := novoEstabelecimento(t, "simultaneas@teste.com")
+		cadastro, err := uc.IniciarCadastro(outra.ID, "")
+		assert.NoError(t, err)
+		_, err = uc.Confirmar(ctx, outra.ID, "", models.CodigoDoisFatoresRequest{Codigo: codigoAtual(t, cadastro.Segredo, 0)})
+		assert.NoError(t, err)
+
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "simultaneas@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		desafio := resposta.DoisFatores.Desafio
+		doisFatoresRepository.atraso = 10 * time.Millisecond
+		defer func() { doisFatoresRepository.atraso = 0 }()
+		conferenciasAntes := doisFatoresRepository.conferencias
+
+		// Executar o método a ser testado: uma rajada de códigos incorretos ao mesmo tempo
+		var wg sync.WaitGroup
+		erros := make(chan error, 20)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: "xxxxx-xxxxx"})
+				erros <- err
+			}()
+		}
+		wg.Wait()
+		close(erros)
+
+		// Verificar resultados: apenas as tentativas dentro do limite chegam à conferência
+		for err := range erros {
+			if !errors.Is(err, usecases.ErrMuitasTentativasDoisFatores) {
+				assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+			}
+		}
+		assert.LessOrEqual(t, doisFatoresRepository.conferencias-conferenciasAntes, 5)
+
+		_, err = autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigoAtual(t, cadastro.Segredo, 1)})
+		assert.ErrorIs(t, err, usecases.ErrMuitasTentativasDoisFatores)
+	})
+
+	t.Run("CadastroObrigatorio", func(t *testing.T) {
+		exigente := novoEstabelecimento(t, "exigente@teste.com")
+		obrigatorio := true
+		status, err := uc.AlterarPolitica(exigente.ID, models.PoliticaDoisFatoresRequest{Obrigatorio: &obrigatorio})
+		assert.NoError(t, err)
+		assert.True(t, status.Obrigatorio)
+
+		resposta, err := autenticacaoUseCase.Login(ctx, models.LoginRequest{Email: "exigente@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+		if !assert.NotNil(t, resposta.DoisFatores) {
+			return
+		}
+		assert.True(t, resposta.DoisFatores.CadastroObrigatorio)
+		desafio := resposta.DoisFatores.Desafio
+
+		// Executar o método a ser testado: cadastrar e confirmar o segundo fator durante o login
+		cadastro, err := uc.IniciarCadastroDesafio(ctx, models.CadastroDesafioRequest{Desafio: desafio})
+		assert.NoError(t, err)
+		sessao, err := autenticacaoUseCase.ConcluirLogin(ctx, models.LoginDoisFatoresRequest{Desafio: desafio, Codigo: codigoAtual(t, cadastro.Segredo, 0)})
+
+		// Verificar resultados: o login é concluído com os códigos de recuperação, e o segundo
+		// fator exigido não pode ser desativado
+		assert.NoError(t, err)
+		assert.NotEmpty(t, sessao.Token)
+		assert.Len(t, sessao.CodigosRecuperacao, 10)
+
+		err = uc.Desativar(ctx, exigente.ID, "", models.DesativarDoisFatoresRequest{Senha: "senha123", Codigo: sessao.CodigosRecuperacao[0]})
+		assert.ErrorIs(t, err, usecases.ErrDoisFatoresObrigatorio)
+	})
+}
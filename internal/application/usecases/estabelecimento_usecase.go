@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// ErrEmailJaCadastrado indica que o email informado pertence a outro estabelecimento ou a um usuário
+var ErrEmailJaCadastrado = errors.New("email já cadastrado")
+
+// EstabelecimentoUseCase implementa a gestão do cadastro dos estabelecimentos: o
+// autoatendimento do estabelecimento autenticado e a administração dos demais
+type EstabelecimentoUseCase struct {
+	autenticacaoService       *services.AutenticacaoService
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+	usuarioRepository         repositories.UsuarioRepository
+	refreshTokenRepository    repositories.RefreshTokenRepository
+	contaUseCase              *ContaUseCase
+}
+
+// NewEstabelecimentoUseCase cria uma nova instância do caso de uso de estabelecimentos
+func NewEstabelecimentoUseCase(
+	autenticacaoService *services.AutenticacaoService,
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+	usuarioRepository repositories.UsuarioRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	contaUseCase *ContaUseCase,
+) *EstabelecimentoUseCase {
+	return &EstabelecimentoUseCase{
+		autenticacaoService:       autenticacaoService,
+		estabelecimentoRepository: estabelecimentoRepository,
+		usuarioRepository:         usuarioRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		contaUseCase:              contaUseCase,
+	}
+}
+
+// Consultar retorna o cadastro do estabelecimento
+func (uc *EstabelecimentoUseCase) Consultar(id string) (models.EstabelecimentoResponse, error) {
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(id)
+	if err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	return models.NovoEstabelecimentoResponse(estabelecimento), nil
+}
+
+// Atualizar altera o nome, a descrição, o email e, opcionalmente, a senha do estabelecimento,
+// após confirmar a senha atual. Um novo email recebe o link de confirmação, e uma nova senha
+// encerra as sessões abertas do login do estabelecimento.
+func (uc *EstabelecimentoUseCase) Atualizar(id string, req models.AtualizarEstabelecimentoRequest) (models.EstabelecimentoResponse, error) {
+	atual, err := uc.confirmarSenha(id, req.SenhaAtual)
+	if err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	// O email identifica o estabelecimento no login e não pode pertencer a outro cadastro
+	if err := verificarEmailDisponivel(uc.estabelecimentoRepository, uc.usuarioRepository, req.Email, id); err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	estabelecimento, err := uc.estabelecimentoRepository.Atualizar(id, models.EstabelecimentoRequest{
+		Nome:      req.Nome,
+		Descricao: req.Descricao,
+		Email:     req.Email,
+		Senha:     req.NovaSenha,
+	})
+	if err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	// Como na redefinição, os tokens de acesso já emitidos expiram sozinhos em poucos minutos
+	if req.NovaSenha != "" {
+		if err := uc.refreshTokenRepository.RevogarLogin(id, "", time.Now()); err != nil {
+			return models.EstabelecimentoResponse{}, err
+		}
+	}
+
+	// A alteração vale mesmo sem o envio, e o link pode ser reenviado depois
+	if estabelecimento.Email != atual.Email {
+		if err := uc.contaUseCase.EnviarVerificacao(context.Background(), estabelecimento); err != nil {
+			log.Printf("Erro ao enviar a verificação de email do estabelecimento %s: %v", estabelecimento.ID, err)
+		}
+	}
+
+	return models.NovoEstabelecimentoResponse(estabelecimento), nil
+}
+
+// Desativar desativa a conta do próprio estabelecimento após confirmar a senha atual,
+// revogando os tokens emitidos
+func (uc *EstabelecimentoUseCase) Desativar(id string, req models.DesativarEstabelecimentoRequest) error {
+	if _, err := uc.confirmarSenha(id, req.SenhaAtual); err != nil {
+		return err
+	}
+
+	return uc.estabelecimentoRepository.Excluir(id)
+}
+
+// Listar lista todos os estabelecimentos, para administradores
+func (uc *EstabelecimentoUseCase) Listar() ([]models.EstabelecimentoResponse, error) {
+	estabelecimentos, err := uc.estabelecimentoRepository.Listar()
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.EstabelecimentoResponse, 0, len(estabelecimentos))
+	for _, estabelecimento := range estabelecimentos {
+		response = append(response, models.NovoEstabelecimentoResponse(estabelecimento))
+	}
+
+	return response, nil
+}
+
+// AlterarAtivo ativa ou desativa um estabelecimento, para administradores. A desativação
+// invalida imediatamente os tokens já emitidos.
+func (uc *EstabelecimentoUseCase) AlterarAtivo(id string, ativo bool) (models.EstabelecimentoResponse, error) {
+	if err := uc.estabelecimentoRepository.AlterarAtivo(id, ativo); err != nil {
+		return models.EstabelecimentoResponse{}, err
+	}
+
+	return uc.Consultar(id)
+}
+
+// confirmarSenha exige a senha atual do estabelecimento antes de alterações sensíveis
+func (uc *EstabelecimentoUseCase) confirmarSenha(id, senha string) (models.Estabelecimento, error) {
+	estabelecimento, err := uc.estabelecimentoRepository.BuscarPorID(id)
+	if err != nil {
+		return models.Estabelecimento{}, err
+	}
+
+	if err := uc.autenticacaoService.VerificarSenha(estabelecimento.Senha, senha); err != nil {
+		return models.Estabelecimento{}, models.ErrosValidacao{{Campo: "senha_atual", Mensagem: "senha atual incorreta"}}
+	}
+
+	return estabelecimento, nil
+}
@@ -0,0 +1,64 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstabelecimentoUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+	refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+	tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+	contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, &revogacaoMemoria{jtis: map[string]bool{}}, contaUseCase, novaProtecaoLogin(configuracaoProtecaoLoginTeste), novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+	uc := usecases.NewEstabelecimentoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, contaUseCase)
+
+	estabelecimento, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", Senha: "senha123"})
+	assert.NoError(t, err)
+
+	login := func(t *testing.T, senha string) models.LoginResponse {
+		resposta, err := autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "loja@teste.com", Senha: senha}, ipTeste)
+		assert.NoError(t, err)
+		return resposta
+	}
+
+	t.Run("AtualizarSemNovaSenhaMantemSessoes", func(t *testing.T) {
+		sessao := login(t, "senha123")
+
+		// Executar o método a ser testado
+		_, err := uc.Atualizar(estabelecimento.ID, models.AtualizarEstabelecimentoRequest{Nome: "Loja Centro", Email: "loja@teste.com", SenhaAtual: "senha123"})
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		_, err = autenticacaoUseCase.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("NovaSenhaEncerraSessoes", func(t *testing.T) {
+		sessao := login(t, "senha123")
+
+		// Executar o método a ser testado
+		_, err := uc.Atualizar(estabelecimento.ID, models.AtualizarEstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", SenhaAtual: "senha123", NovaSenha: "nova456"})
+
+		// Verificar resultados: os refresh tokens emitidos com a senha anterior deixam de valer
+		assert.NoError(t, err)
+		_, err = autenticacaoUseCase.Renovar(models.RefreshTokenRequest{RefreshToken: sessao.RefreshToken})
+		assert.ErrorIs(t, err, usecases.ErrRefreshTokenReutilizado)
+
+		login(t, "nova456")
+	})
+}
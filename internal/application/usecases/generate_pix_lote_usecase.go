@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Limites da geração em lote
+const (
+	// TamanhoMaximoLote é o maior número de linhas aceito em um lote
+	TamanhoMaximoLote = 5000
+
+	// trabalhadoresLote é o número de PIX gerados em paralelo
+	trabalhadoresLote = 8
+
+	// tamanhoTransacaoLote é o número de PIX salvos em cada transação
+	tamanhoTransacaoLote = 500
+)
+
+var (
+	// ErrLoteVazio indica que o lote não contém nenhuma linha
+	ErrLoteVazio = errors.New("o lote não contém nenhuma linha")
+
+	// ErrLoteMuitoGrande indica que o lote excede o número máximo de linhas
+	ErrLoteMuitoGrande = fmt.Errorf("o lote deve ter no máximo %d linhas", TamanhoMaximoLote)
+)
+
+// itemGerado guarda o PIX gerado para uma linha do lote até que seja salvo
+type itemGerado struct {
+	pix       models.Pix
+	resultado models.ResultadoItemLote
+}
+
+// ExecuteLote gera os PIX estáticos de um lote em nome do estabelecimento, em paralelo, e os
+// salva em transações. As linhas são completadas com o perfil do estabelecimento. Uma linha
+// inválida não interrompe as demais: o resultado de cada linha é retornado na ordem da entrada.
+// Se uma transação falhar, todas as linhas dela são marcadas com o erro.
+func (uc *GeneratePixUseCase) ExecuteLote(estabelecimentoID string, itens []models.ItemLote) (models.ResultadoLote, error) {
+	if len(itens) == 0 {
+		return models.ResultadoLote{}, ErrLoteVazio
+	}
+	if len(itens) > TamanhoMaximoLote {
+		return models.ResultadoLote{}, ErrLoteMuitoGrande
+	}
+
+	// O perfil é lido uma única vez e compartilhado por todas as linhas
+	perfil, err := uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+	if err != nil {
+		return models.ResultadoLote{}, err
+	}
+
+	gerados := make([]itemGerado, len(itens))
+
+	// Gerar os códigos em paralelo, com um número limitado de trabalhadores
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for t := 0; t < min(trabalhadoresLote, len(itens)); t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				gerados[i] = uc.gerarItemLote(estabelecimentoID, perfil, itens[i])
+			}
+		}()
+	}
+	for i := range itens {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	// Salvar as linhas geradas com sucesso, em transações de tamanho limitado
+	var pendentes []int
+	for i := range gerados {
+		if gerados[i].resultado.Sucesso {
+			pendentes = append(pendentes, i)
+		}
+	}
+
+	for inicio := 0; inicio < len(pendentes); inicio += tamanhoTransacaoLote {
+		transacao := pendentes[inicio:min(inicio+tamanhoTransacaoLote, len(pendentes))]
+
+		pixes := make([]models.Pix, len(transacao))
+		for j, i := range transacao {
+			pixes[j] = gerados[i].pix
+		}
+
+		ids, err := uc.pixRepository.SaveLote(pixes)
+		for j, i := range transacao {
+			if err != nil {
+				gerados[i].resultado = models.ResultadoItemLote{
+					Linha:         gerados[i].resultado.Linha,
+					Identificador: gerados[i].resultado.Identificador,
+					Erro:          "erro ao salvar o PIX: " + err.Error(),
+				}
+				continue
+			}
+			gerados[i].resultado.ID = ids[j]
+		}
+	}
+
+	resultado := models.ResultadoLote{
+		Total: len(itens),
+		Itens: make([]models.ResultadoItemLote, len(itens)),
+	}
+	for i := range gerados {
+		resultado.Itens[i] = gerados[i].resultado
+		if gerados[i].resultado.Sucesso {
+			resultado.Sucessos++
+		} else {
+			resultado.Falhas++
+		}
+	}
+
+	return resultado, nil
+}
+
+// gerarItemLote gera o PIX de uma linha do lote, registrando o erro no resultado
+func (uc *GeneratePixUseCase) gerarItemLote(estabelecimentoID string, perfil models.PerfilEstabelecimento, item models.ItemLote) itemGerado {
+	resultado := models.ResultadoItemLote{Linha: item.Linha}
+	if item.Requisicao.Identificador != nil {
+		resultado.Identificador = *item.Requisicao.Identificador
+	}
+
+	err := item.ErroLeitura
+	if err == nil {
+		var pix models.Pix
+		var pixResponse models.PixResponse
+
+		pix, pixResponse, err = uc.gerarPixEstatico(estabelecimentoID, perfil, item.Requisicao)
+		if err == nil {
+			resultado.Sucesso = true
+			resultado.CodigoPix = pixResponse.CodigoPix
+			resultado.Modalidade = pixResponse.Modalidade
+			resultado.Ajustes = pixResponse.Ajustes
+			resultado.QRCodePNG = pixResponse.QRCodePNG
+			return itemGerado{pix: pix, resultado: resultado}
+		}
+	}
+
+	resultado.Erro = err.Error()
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		resultado.Erros = errosValidacao
+	}
+
+	return itemGerado{resultado: resultado}
+}
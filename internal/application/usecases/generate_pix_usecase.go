@@ -8,10 +8,14 @@ import (
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
 )
 
+// expiracaoCobPadrao é o tempo de vida de uma cobrança imediata quando não informado
+const expiracaoCobPadrao = time.Hour
+
 // GeneratePixUseCase implementa o caso de uso para geração de PIX
 type GeneratePixUseCase struct {
-	pixService    *services.PixGeneratorService
-	pixRepository repositories.PixRepository
+	pixService       *services.PixGeneratorService
+	pixRepository    repositories.PixRepository
+	perfilRepository repositories.PerfilRepository
 }
 
 // GetRepository retorna o repositório usado pelo caso de uso
@@ -20,33 +24,129 @@ func (uc *GeneratePixUseCase) GetRepository() repositories.PixRepository {
 }
 
 // NewGeneratePixUseCase cria uma nova instância do caso de uso
-func NewGeneratePixUseCase(pixService *services.PixGeneratorService, pixRepository repositories.PixRepository) *GeneratePixUseCase {
+func NewGeneratePixUseCase(
+	pixService *services.PixGeneratorService,
+	pixRepository repositories.PixRepository,
+	perfilRepository repositories.PerfilRepository,
+) *GeneratePixUseCase {
 	return &GeneratePixUseCase{
-		pixService:    pixService,
-		pixRepository: pixRepository,
+		pixService:       pixService,
+		pixRepository:    pixRepository,
+		perfilRepository: perfilRepository,
+	}
+}
+
+// Execute executa o caso de uso para geração de PIX em nome do estabelecimento autenticado.
+// Os campos omitidos são preenchidos com o perfil do estabelecimento.
+func (uc *GeneratePixUseCase) Execute(estabelecimentoID string, req models.PixRequest) (models.PixResponse, error) {
+	perfil, err := uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	pix, pixResponse, err := uc.gerarPixEstatico(estabelecimentoID, perfil, req)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	// Persistir a entidade no banco de dados
+	_, err = uc.pixRepository.Save(pix)
+	if err != nil {
+		return models.PixResponse{}, err
 	}
+
+	return pixResponse, nil
 }
 
-// Execute executa o caso de uso para geração de PIX
-func (uc *GeneratePixUseCase) Execute(req models.PixRequest) (models.PixResponse, error) {
-	// Gerar o código PIX através do serviço de domínio
+// gerarPixEstatico completa a requisição com o perfil, gera o código PIX estático e monta
+// a entidade para persistência
+func (uc *GeneratePixUseCase) gerarPixEstatico(estabelecimentoID string, perfil models.PerfilEstabelecimento, req models.PixRequest) (models.Pix, models.PixResponse, error) {
+	req, err := services.AplicarPerfil(req, perfil)
+	if err != nil {
+		return models.Pix{}, models.PixResponse{}, err
+	}
+
+	// Gerar o código PIX através do serviço de domínio, que valida todos os campos de uma vez
 	pixResponse, err := uc.pixService.GerarPixEstatico(req)
+	if err != nil {
+		return models.Pix{}, models.PixResponse{}, err
+	}
+
+	// Persistir a chave já normalizada
+	chave, err := uc.pixService.ClassificarChave(req.Chave)
+	if err != nil {
+		return models.Pix{}, models.PixResponse{}, err
+	}
+	req.Chave = chave.Valor
+
+	// Criar a entidade PIX para persistência
+	pix := models.Pix{
+		EstabelecimentoID: estabelecimentoID,
+		Tipo:              models.TipoPixEstatico,
+		Modalidade:        pixResponse.Modalidade,
+		Nome:              req.Nome,
+		Chave:             req.Chave,
+		TipoChave:         chave.Tipo,
+		Cidade:            req.Cidade,
+		Valor:             req.Valor,
+		Identificador:     req.Identificador,
+		Descricao:         req.Descricao,
+		CodigoPix:         pixResponse.CodigoPix,
+		QRCodeSVG:         pixResponse.QRCodeSVG,
+		QRCodePNG:         pixResponse.QRCodePNG,
+		CriadoEm:          time.Now(),
+	}
+
+	return pix, pixResponse, nil
+}
+
+// ExecuteDinamico executa o caso de uso para geração de uma cobrança imediata (PIX dinâmico)
+// em nome do estabelecimento autenticado
+func (uc *GeneratePixUseCase) ExecuteDinamico(estabelecimentoID string, req models.CobRequest) (models.PixResponse, error) {
+	// Gerar o código PIX dinâmico através do serviço de domínio, que valida todos os campos de uma vez
+	pixResponse, err := uc.pixService.GerarPixDinamico(req)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	// Persistir a chave já normalizada
+	chave, err := uc.pixService.ClassificarChave(req.Chave)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+	req.Chave = chave.Valor
+
+	// Calcular a expiração da cobrança
+	expiracao := expiracaoCobPadrao
+	if req.Expiracao != nil {
+		expiracao = time.Duration(*req.Expiracao) * time.Second
+	}
+
+	agora := time.Now()
+	expiraEm := agora.Add(expiracao)
+
+	// Persistir a location no mesmo formato em que consta no BR Code
+	location, err := services.NormalizarLocation(req.Location)
 	if err != nil {
 		return models.PixResponse{}, err
 	}
 
 	// Criar a entidade PIX para persistência
 	pix := models.Pix{
-		Nome:          req.Nome,
-		Chave:         req.Chave,
-		Cidade:        req.Cidade,
-		Valor:         req.Valor,
-		Identificador: req.Identificador,
-		Descricao:     req.Descricao,
-		CodigoPix:     pixResponse.CodigoPix,
-		QRCodeSVG:     pixResponse.QRCodeSVG,
-		QRCodePNG:     pixResponse.QRCodePNG,
-		CriadoEm:      time.Now(),
+		EstabelecimentoID: estabelecimentoID,
+		Tipo:              models.TipoPixDinamico,
+		Nome:              req.Nome,
+		Chave:             req.Chave,
+		TipoChave:         chave.Tipo,
+		Cidade:            req.Cidade,
+		Valor:             req.Valor,
+		Txid:              &req.Txid,
+		Location:          &location,
+		ExpiraEm:          &expiraEm,
+		CodigoPix:         pixResponse.CodigoPix,
+		QRCodeSVG:         pixResponse.QRCodeSVG,
+		QRCodePNG:         pixResponse.QRCodePNG,
+		CriadoEm:          agora,
 	}
 
 	// Persistir a entidade no banco de dados
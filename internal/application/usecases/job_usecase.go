@@ -0,0 +1,365 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/queue"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// Formatos de resultado dos jobs
+const (
+	FormatoResultadoJSON = "json"
+	FormatoResultadoZIP  = "zip"
+)
+
+// tamanhoEtapaJob é o número de linhas processadas entre duas atualizações de progresso;
+// o cancelamento também é verificado a cada etapa
+const tamanhoEtapaJob = 100
+
+var (
+	// ErrJobFinalizado indica que o job já terminou e não pode mais ser cancelado
+	ErrJobFinalizado = errors.New("job já finalizado")
+
+	// ErrJobSemResultado indica que o job ainda não tem resultado para download
+	ErrJobSemResultado = errors.New("job ainda não foi concluído")
+
+	// ErrTemplateNaoEncontrado indica que o template informado não está registrado
+	ErrTemplateNaoEncontrado = errors.New("template não encontrado")
+)
+
+// JobUseCase implementa os casos de uso dos jobs executados em segundo plano
+type JobUseCase struct {
+	generatePixUseCase *GeneratePixUseCase
+	templateProcessor  *services.TemplateProcessor
+	jobRepository      repositories.JobRepository
+	fila               queue.QueueAdapter
+}
+
+// NewJobUseCase cria uma nova instância do caso de uso de jobs
+func NewJobUseCase(
+	generatePixUseCase *GeneratePixUseCase,
+	templateProcessor *services.TemplateProcessor,
+	jobRepository repositories.JobRepository,
+	fila queue.QueueAdapter,
+) *JobUseCase {
+	return &JobUseCase{
+		generatePixUseCase: generatePixUseCase,
+		templateProcessor:  templateProcessor,
+		jobRepository:      jobRepository,
+		fila:               fila,
+	}
+}
+
+// CriarLote registra um job de geração em lote do estabelecimento e o coloca na fila
+func (uc *JobUseCase) CriarLote(ctx context.Context, estabelecimentoID string, itens []models.ItemLote, formato, templateName string) (models.Job, error) {
+	if len(itens) == 0 {
+		return models.Job{}, ErrLoteVazio
+	}
+	if len(itens) > TamanhoMaximoLote {
+		return models.Job{}, ErrLoteMuitoGrande
+	}
+	if templateName != "" && !uc.templateProcessor.Existe(templateName) {
+		return models.Job{}, ErrTemplateNaoEncontrado
+	}
+
+	if formato == "" {
+		formato = FormatoResultadoJSON
+	}
+
+	entrada, err := json.Marshal(itens)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job := models.Job{
+		EstabelecimentoID: estabelecimentoID,
+		Tipo:              models.TipoJobLote,
+		Formato:           formato,
+		Template:          templateName,
+		Total:             len(itens),
+		Entrada:           entrada,
+	}
+
+	id, err := uc.jobRepository.Save(job)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	if err := uc.fila.Enqueue(ctx, id); err != nil {
+		// Sem lugar na fila, o job não seria executado
+		_, _ = uc.jobRepository.Cancelar(id)
+		return models.Job{}, err
+	}
+
+	return uc.jobRepository.FindByID(id)
+}
+
+// Consultar retorna a situação de um job do estabelecimento. O job de outro estabelecimento
+// é tratado como inexistente.
+func (uc *JobUseCase) Consultar(estabelecimentoID string, id string) (models.Job, error) {
+	job, err := uc.jobRepository.FindByID(id)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	if job.EstabelecimentoID != estabelecimentoID {
+		return models.Job{}, repositories.ErrJobNaoEncontrado
+	}
+
+	return job, nil
+}
+
+// Cancelar cancela um job pendente ou em processamento do estabelecimento. Um job em
+// processamento para ao fim da etapa atual; os PIX já salvos são mantidos.
+func (uc *JobUseCase) Cancelar(estabelecimentoID string, id string) (models.Job, error) {
+	if _, err := uc.Consultar(estabelecimentoID, id); err != nil {
+		return models.Job{}, err
+	}
+
+	cancelado, err := uc.jobRepository.Cancelar(id)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job, err := uc.jobRepository.FindByID(id)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	if !cancelado {
+		return job, ErrJobFinalizado
+	}
+
+	return job, nil
+}
+
+// Resultado retorna o resultado de um job concluído do estabelecimento e o seu Content-Type
+func (uc *JobUseCase) Resultado(estabelecimentoID string, id string) ([]byte, string, error) {
+	job, err := uc.Consultar(estabelecimentoID, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if job.Status != models.StatusJobConcluido {
+		return nil, "", ErrJobSemResultado
+	}
+
+	return uc.jobRepository.FindResultado(id)
+}
+
+// ReenfileirarPendentes coloca novamente na fila os jobs que ainda aguardam processamento,
+// usado na inicialização: a fila em memória não sobrevive a um reinício, e um job retirado
+// da fila do Redis por uma instância encerrada antes de iniciá-lo não volta para ela. Os IDs
+// repetidos na fila são ignorados por Processar. Com a fila cheia, aguarda os workers
+// liberarem espaço; os jobs que não puderam ser reenfileirados são registrados no log.
+func (uc *JobUseCase) ReenfileirarPendentes(ctx context.Context) error {
+	ids, err := uc.jobRepository.ListarPendentes()
+	if err != nil {
+		return err
+	}
+
+	naoReenfileirados := 0
+	for _, id := range ids {
+		if err := uc.reenfileirar(ctx, id); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("Job %s não foi reenfileirado: %v", id, err)
+			naoReenfileirados++
+		}
+	}
+
+	if naoReenfileirados > 0 {
+		return fmt.Errorf("%d de %d jobs pendentes não foram reenfileirados", naoReenfileirados, len(ids))
+	}
+	return nil
+}
+
+// reenfileirar coloca um job na fila, aguardando enquanto a fila estiver cheia
+func (uc *JobUseCase) reenfileirar(ctx context.Context, id string) error {
+	for {
+		err := uc.fila.Enqueue(ctx, id)
+		if !errors.Is(err, queue.ErrFilaCheia) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// RecuperarInterrompidos registra como falha os jobs em processamento sem progresso há mais
+// que o limite informado, cujo worker foi encerrado, por exemplo, por um reinício da instância.
+// O limite deve superar o tempo de uma etapa do lote e da montagem do resultado.
+func (uc *JobUseCase) RecuperarInterrompidos(limite time.Duration) error {
+	erro := fmt.Sprintf("processamento interrompido: o job ficou mais de %s sem progresso; envie o lote novamente", limite)
+
+	interrompidos, err := uc.jobRepository.FalharInterrompidos(time.Now().Add(-limite), erro)
+	if err != nil {
+		return err
+	}
+
+	if interrompidos > 0 {
+		log.Printf("%d jobs interrompidos durante o processamento foram registrados como falha", interrompidos)
+	}
+	return nil
+}
+
+// MonitorarInterrompidos executa RecuperarInterrompidos na inicialização e, depois, a cada
+// intervalo do limite, até que o contexto seja cancelado
+func (uc *JobUseCase) MonitorarInterrompidos(ctx context.Context, limite time.Duration) {
+	go func() {
+		ticker := time.NewTicker(limite)
+		defer ticker.Stop()
+
+		for {
+			if err := uc.RecuperarInterrompidos(limite); err != nil {
+				log.Printf("Erro ao recuperar os jobs interrompidos: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// IniciarWorkers inicia os workers que consomem a fila até que o contexto seja cancelado
+func (uc *JobUseCase) IniciarWorkers(ctx context.Context, quantidade int) {
+	for i := 0; i < quantidade; i++ {
+		go func() {
+			for {
+				id, err := uc.fila.Dequeue(ctx)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					log.Printf("Erro ao ler a fila de jobs: %v", err)
+					time.Sleep(time.Second)
+					continue
+				}
+
+				uc.Processar(id)
+			}
+		}()
+	}
+}
+
+// Processar executa um job da fila. Jobs cancelados ou já assumidos por outro worker são ignorados.
+func (uc *JobUseCase) Processar(id string) {
+	iniciado, err := uc.jobRepository.Iniciar(id)
+	if err != nil {
+		log.Printf("Erro ao iniciar o job %s: %v", id, err)
+		return
+	}
+	if !iniciado {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			uc.falhar(id, fmt.Errorf("erro inesperado: %v", r))
+		}
+	}()
+
+	job, err := uc.jobRepository.FindByID(id)
+	if err != nil {
+		uc.falhar(id, err)
+		return
+	}
+
+	resultado, cancelado, err := uc.processarLote(job)
+	if err != nil {
+		uc.falhar(id, err)
+		return
+	}
+	if cancelado {
+		return
+	}
+
+	var dados bytes.Buffer
+	resultadoTipo := "application/json"
+
+	if job.Formato == FormatoResultadoZIP {
+		arquivos, err := services.MontarImagensLote(resultado, uc.templateProcessor, job.Template)
+		if err != nil {
+			uc.falhar(id, err)
+			return
+		}
+		if err := services.EscreverZipLote(&dados, arquivos, resultado); err != nil {
+			uc.falhar(id, err)
+			return
+		}
+		resultadoTipo = "application/zip"
+	} else if err := json.NewEncoder(&dados).Encode(resultado); err != nil {
+		uc.falhar(id, err)
+		return
+	}
+
+	if _, err := uc.jobRepository.Concluir(id, dados.Bytes(), resultadoTipo); err != nil {
+		log.Printf("Erro ao concluir o job %s: %v", id, err)
+	}
+}
+
+// processarLote gera o lote do job em etapas, registrando o progresso e parando se o job
+// for cancelado
+func (uc *JobUseCase) processarLote(job models.Job) (models.ResultadoLote, bool, error) {
+	entrada, err := uc.jobRepository.FindEntrada(job.ID)
+	if err != nil {
+		return models.ResultadoLote{}, false, err
+	}
+
+	var itens []models.ItemLote
+	if err := json.Unmarshal(entrada, &itens); err != nil {
+		return models.ResultadoLote{}, false, err
+	}
+
+	resultado := models.ResultadoLote{Total: len(itens)}
+
+	for inicio := 0; inicio < len(itens); inicio += tamanhoEtapaJob {
+		atual, err := uc.jobRepository.FindByID(job.ID)
+		if err != nil {
+			return models.ResultadoLote{}, false, err
+		}
+		if atual.Status == models.StatusJobCancelado {
+			return models.ResultadoLote{}, true, nil
+		}
+
+		parcial, err := uc.generatePixUseCase.ExecuteLote(job.EstabelecimentoID, itens[inicio:min(inicio+tamanhoEtapaJob, len(itens))])
+		if err != nil {
+			return models.ResultadoLote{}, false, err
+		}
+
+		resultado.Sucessos += parcial.Sucessos
+		resultado.Falhas += parcial.Falhas
+		resultado.Itens = append(resultado.Itens, parcial.Itens...)
+
+		if err := uc.jobRepository.AtualizarProgresso(job.ID, len(resultado.Itens), resultado.Sucessos, resultado.Falhas); err != nil {
+			return models.ResultadoLote{}, false, err
+		}
+	}
+
+	return resultado, false, nil
+}
+
+// falhar registra o erro que interrompeu o job
+func (uc *JobUseCase) falhar(id string, err error) {
+	log.Printf("Job %s falhou: %v", id, err)
+	if _, err := uc.jobRepository.Falhar(id, err.Error()); err != nil {
+		log.Printf("Erro ao registrar a falha do job %s: %v", id, err)
+	}
+}
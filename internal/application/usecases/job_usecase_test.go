@@ -0,0 +1,449 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/queue"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+// pixRepositoryMemoria guarda os PIX em memória
+type pixRepositoryMemoria struct {
+	mu    sync.Mutex
+	pixes []models.Pix
+}
+
+func (r *pixRepositoryMemoria) Save(pix models.Pix) (uint, error) {
+	ids, err := r.SaveLote([]models.Pix{pix})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func (r *pixRepositoryMemoria) SaveLote(pixes []models.Pix) ([]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []uint
+	for _, pix := range pixes {
+		r.pixes = append(r.pixes, pix)
+		ids = append(ids, uint(len(r.pixes)))
+	}
+	return ids, nil
+}
+
+func (r *pixRepositoryMemoria) FindByID(estabelecimentoID string, id uint) (models.Pix, error) {
+	if int(id) > len(r.pixes) || r.pixes[id-1].EstabelecimentoID != estabelecimentoID {
+		return models.Pix{}, repositories.ErrPixNaoEncontrado
+	}
+	return r.pixes[id-1], nil
+}
+
+func (r *pixRepositoryMemoria) Listar(filtro models.FiltroPix) ([]models.Pix, error) {
+	var pixes []models.Pix
+	for _, pix := range r.pixes {
+		if pix.EstabelecimentoID == filtro.EstabelecimentoID && len(pixes) < filtro.Limite {
+			pixes = append(pixes, pix)
+		}
+	}
+	return pixes, nil
+}
+
+func (r *pixRepositoryMemoria) Contar(filtro models.FiltroPix) (int64, error) {
+	var total int64
+	for _, pix := range r.pixes {
+		if pix.EstabelecimentoID == filtro.EstabelecimentoID {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (r *pixRepositoryMemoria) FindByCodigoPix(estabelecimentoID, codigoPix string) (models.Pix, error) {
+	for _, pix := range r.pixes {
+		if pix.EstabelecimentoID == estabelecimentoID && pix.CodigoPix == codigoPix {
+			return pix, nil
+		}
+	}
+	return models.Pix{}, repositories.ErrPixNaoEncontrado
+}
+
+// perfilRepositoryMemoria devolve o mesmo perfil para qualquer estabelecimento
+type perfilRepositoryMemoria struct {
+	perfil models.PerfilEstabelecimento
+}
+
+func (r *perfilRepositoryMemoria) BuscarPerfil(estabelecimentoID string) (models.PerfilEstabelecimento, error) {
+	return r.perfil, nil
+}
+
+func (r *perfilRepositoryMemoria) AtualizarPerfil(estabelecimentoID string, perfil models.PerfilEstabelecimento) error {
+	r.perfil = perfil
+	return nil
+}
+
+func (r *perfilRepositoryMemoria) SalvarChave(estabelecimentoID string, chave models.ChaveCadastrada) (models.ChaveCadastrada, error) {
+	chave.ID = uint(len(r.perfil.Chaves) + 1)
+	r.perfil.Chaves = append(r.perfil.Chaves, chave)
+	return chave, nil
+}
+
+func (r *perfilRepositoryMemoria) AtualizarApelidoChave(estabelecimentoID string, id uint, apelido string) error {
+	return nil
+}
+
+func (r *perfilRepositoryMemoria) DefinirChavePadrao(estabelecimentoID string, id uint) error {
+	return nil
+}
+
+func (r *perfilRepositoryMemoria) ExcluirChave(estabelecimentoID string, id uint) error {
+	return nil
+}
+
+// jobRepositoryMemoria guarda os jobs em memória, com as mesmas transições de situação do MySQL
+type jobRepositoryMemoria struct {
+	mu          sync.Mutex
+	jobs        map[string]models.Job
+	atualizados map[string]time.Time
+}
+
+func (r *jobRepositoryMemoria) Save(job models.Job) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job.ID = fmt.Sprintf("job-%d", len(r.jobs)+1)
+	job.Status = models.StatusJobPendente
+	job.CriadoEm = time.Now()
+	r.jobs[job.ID] = job
+	return job.ID, nil
+}
+
+func (r *jobRepositoryMemoria) FindByID(id string) (models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, existe := r.jobs[id]
+	if !existe {
+		return models.Job{}, repositories.ErrJobNaoEncontrado
+	}
+	return job, nil
+}
+
+func (r *jobRepositoryMemoria) FindEntrada(id string) ([]byte, error) {
+	job, err := r.FindByID(id)
+	return job.Entrada, err
+}
+
+func (r *jobRepositoryMemoria) FindResultado(id string) ([]byte, string, error) {
+	job, err := r.FindByID(id)
+	return job.Resultado, job.ResultadoTipo, err
+}
+
+func (r *jobRepositoryMemoria) ListarPendentes() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	for id, job := range r.jobs {
+		if job.Status == models.StatusJobPendente {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (r *jobRepositoryMemoria) Iniciar(id string) (bool, error) {
+	return r.transicao(id, []string{models.StatusJobPendente}, func(job *models.Job) {
+		job.Status = models.StatusJobProcessando
+		r.atualizados[id] = time.Now()
+	})
+}
+
+func (r *jobRepositoryMemoria) AtualizarProgresso(id string, processados, sucessos, falhas int) error {
+	_, err := r.transicao(id, []string{models.StatusJobProcessando, models.StatusJobCancelado}, func(job *models.Job) {
+		job.Processados, job.Sucessos, job.Falhas = processados, sucessos, falhas
+		r.atualizados[id] = time.Now()
+	})
+	return err
+}
+
+func (r *jobRepositoryMemoria) Concluir(id string, resultado []byte, resultadoTipo string) (bool, error) {
+	return r.transicao(id, []string{models.StatusJobProcessando}, func(job *models.Job) {
+		job.Status = models.StatusJobConcluido
+		job.Resultado, job.ResultadoTipo = resultado, resultadoTipo
+	})
+}
+
+func (r *jobRepositoryMemoria) Falhar(id string, erro string) (bool, error) {
+	return r.transicao(id, []string{models.StatusJobProcessando}, func(job *models.Job) {
+		job.Status = models.StatusJobFalhou
+		job.Erro = erro
+	})
+}
+
+func (r *jobRepositoryMemoria) FalharInterrompidos(semProgressoDesde time.Time, erro string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var interrompidos int64
+	for id, job := range r.jobs {
+		if job.Status == models.StatusJobProcessando && r.atualizados[id].Before(semProgressoDesde) {
+			job.Status = models.StatusJobFalhou
+			job.Erro = erro
+			r.jobs[id] = job
+			interrompidos++
+		}
+	}
+	return interrompidos, nil
+}
+
+func (r *jobRepositoryMemoria) Cancelar(id string) (bool, error) {
+	return r.transicao(id, []string{models.StatusJobPendente, models.StatusJobProcessando}, func(job *models.Job) {
+		job.Status = models.StatusJobCancelado
+	})
+}
+
+func (r *jobRepositoryMemoria) transicao(id string, de []string, alterar func(job *models.Job)) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, existe := r.jobs[id]
+	if !existe {
+		return false, repositories.ErrJobNaoEncontrado
+	}
+	for _, status := range de {
+		if job.Status == status {
+			alterar(&job)
+			r.jobs[id] = job
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TestJobUseCase testa o ciclo de vida dos jobs de geração em lote com a fila em memória
+func TestJobUseCase(t *testing.T) {
+	novoCasoDeUsoComFila := func(fila queue.QueueAdapter) (*usecases.JobUseCase, *pixRepositoryMemoria, *jobRepositoryMemoria) {
+		pixRepository := &pixRepositoryMemoria{}
+		generatePixUseCase := usecases.NewGeneratePixUseCase(services.NewPixGeneratorService(), pixRepository, &perfilRepositoryMemoria{})
+		jobRepository := &jobRepositoryMemoria{jobs: make(map[string]models.Job), atualizados: make(map[string]time.Time)}
+		templateProcessor := services.NewTemplateProcessor(t.TempDir())
+
+		return usecases.NewJobUseCase(generatePixUseCase, templateProcessor, jobRepository, fila), pixRepository, jobRepository
+	}
+
+	novoCasoDeUso := func() (*usecases.JobUseCase, *pixRepositoryMemoria) {
+		uc, pixRepository, _ := novoCasoDeUsoComFila(queue.NewMemoryAdapter(10))
+		return uc, pixRepository
+	}
+
+	novosItens := func(quantidade int) []models.ItemLote {
+		itens := make([]models.ItemLote, quantidade)
+		for i := range itens {
+			valor := models.NovoDinheiro(int64(1000 + i))
+			itens[i] = models.ItemLote{
+				Linha: i + 1,
+				Requisicao: models.PixRequest{
+					Nome:   "JOSE DA SILVA",
+					Chave:  "josesilva@email.com",
+					Cidade: "SAO PAULO",
+					Valor:  &valor,
+				},
+			}
+		}
+		return itens
+	}
+
+	const estabelecimentoID = "123e4567-e89b-12d3-a456-426614174000"
+
+	aguardar := func(t *testing.T, uc *usecases.JobUseCase, id string) models.Job {
+		var job models.Job
+		assert.Eventually(t, func() bool {
+			job, _ = uc.Consultar(estabelecimentoID, id)
+			return job.Finalizado()
+		}, 30*time.Second, 10*time.Millisecond)
+		return job
+	}
+
+	t.Run("ProcessamentoPelosWorkers", func(t *testing.T) {
+		uc, pixRepository := novoCasoDeUso()
+		ctx, cancelar := context.WithCancel(context.Background())
+		defer cancelar()
+
+		itens := novosItens(150)
+		itens[10].Requisicao.Chave = "chave invalida"
+		itens[20].ErroLeitura = models.ErrosValidacao{{Campo: "valor", Mensagem: "valor inválido"}}
+
+		// Executar o método a ser testado
+		job, err := uc.CriarLote(ctx, estabelecimentoID, itens, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobPendente, job.Status)
+
+		uc.IniciarWorkers(ctx, 2)
+		job = aguardar(t, uc, job.ID)
+
+		// Verificar resultados
+		assert.Equal(t, models.StatusJobConcluido, job.Status)
+		assert.Equal(t, 150, job.Processados)
+		assert.Equal(t, 148, job.Sucessos)
+		assert.Equal(t, 2, job.Falhas)
+		assert.Len(t, pixRepository.pixes, 148)
+		assert.Equal(t, estabelecimentoID, pixRepository.pixes[0].EstabelecimentoID)
+
+		dados, tipo, err := uc.Resultado(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", tipo)
+
+		var resultado models.ResultadoLote
+		assert.NoError(t, json.Unmarshal(dados, &resultado))
+		assert.Len(t, resultado.Itens, 150)
+		assert.False(t, resultado.Itens[20].Sucesso)
+		assert.Equal(t, "valor", resultado.Itens[20].Erros[0].Campo)
+	})
+
+	t.Run("ResultadoZIP", func(t *testing.T) {
+		uc, _ := novoCasoDeUso()
+
+		job, err := uc.CriarLote(context.Background(), estabelecimentoID, novosItens(3), usecases.FormatoResultadoZIP, "")
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado, sem workers
+		uc.Processar(job.ID)
+
+		// Verificar resultados
+		dados, tipo, err := uc.Resultado(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/zip", tipo)
+		assert.Equal(t, []byte("PK"), dados[:2])
+	})
+
+	t.Run("Cancelamento", func(t *testing.T) {
+		uc, pixRepository := novoCasoDeUso()
+
+		job, err := uc.CriarLote(context.Background(), estabelecimentoID, novosItens(5), "", "")
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		job, err = uc.Cancelar(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobCancelado, job.Status)
+
+		// Um job cancelado não é processado nem pode ser cancelado novamente
+		uc.Processar(job.ID)
+		assert.Empty(t, pixRepository.pixes)
+
+		_, err = uc.Cancelar(estabelecimentoID, job.ID)
+		assert.ErrorIs(t, err, usecases.ErrJobFinalizado)
+
+		_, _, err = uc.Resultado(estabelecimentoID, job.ID)
+		assert.ErrorIs(t, err, usecases.ErrJobSemResultado)
+	})
+
+	t.Run("JobDeOutroEstabelecimento", func(t *testing.T) {
+		uc, _ := novoCasoDeUso()
+
+		job, err := uc.CriarLote(context.Background(), estabelecimentoID, novosItens(1), "", "")
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		outroEstabelecimentoID := "00000000-0000-0000-0000-000000000000"
+		_, errConsulta := uc.Consultar(outroEstabelecimentoID, job.ID)
+		_, errCancelamento := uc.Cancelar(outroEstabelecimentoID, job.ID)
+		_, _, errResultado := uc.Resultado(outroEstabelecimentoID, job.ID)
+
+		// Verificar resultados: o job não é exposto nem alterado
+		assert.ErrorIs(t, errConsulta, repositories.ErrJobNaoEncontrado)
+		assert.ErrorIs(t, errCancelamento, repositories.ErrJobNaoEncontrado)
+		assert.ErrorIs(t, errResultado, repositories.ErrJobNaoEncontrado)
+
+		job, err = uc.Consultar(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobPendente, job.Status)
+	})
+
+	t.Run("ReenfileirarComFilaCheia", func(t *testing.T) {
+		uc, pixRepository, jobRepository := novoCasoDeUsoComFila(queue.NewMemoryAdapter(1))
+		ctx, cancelar := context.WithCancel(context.Background())
+		defer cancelar()
+
+		// Jobs pendentes que não estão na fila, como após um reinício
+		entrada, err := json.Marshal(novosItens(1))
+		assert.NoError(t, err)
+		var ids []string
+		for i := 0; i < 3; i++ {
+			id, err := jobRepository.Save(models.Job{EstabelecimentoID: estabelecimentoID, Tipo: models.TipoJobLote, Formato: usecases.FormatoResultadoJSON, Total: 1, Entrada: entrada})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		// Executar o método a ser testado: a fila comporta um job por vez
+		uc.IniciarWorkers(ctx, 1)
+		assert.NoError(t, uc.ReenfileirarPendentes(ctx))
+
+		// Verificar resultados
+		for _, id := range ids {
+			assert.Equal(t, models.StatusJobConcluido, aguardar(t, uc, id).Status)
+		}
+		assert.Len(t, pixRepository.pixes, 3)
+	})
+
+	t.Run("RecuperarInterrompidos", func(t *testing.T) {
+		uc, _, jobRepository := novoCasoDeUsoComFila(queue.NewMemoryAdapter(10))
+
+		job, err := uc.CriarLote(context.Background(), estabelecimentoID, novosItens(1), "", "")
+		assert.NoError(t, err)
+		pendente, err := uc.CriarLote(context.Background(), estabelecimentoID, novosItens(1), "", "")
+		assert.NoError(t, err)
+
+		// O worker assume o job e é encerrado antes de concluí-lo
+		iniciado, err := jobRepository.Iniciar(job.ID)
+		assert.NoError(t, err)
+		assert.True(t, iniciado)
+
+		// Dentro do limite, o job continua em processamento
+		assert.NoError(t, uc.RecuperarInterrompidos(time.Minute))
+		job, err = uc.Consultar(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobProcessando, job.Status)
+
+		// Executar o método a ser testado
+		time.Sleep(10 * time.Millisecond)
+		err = uc.RecuperarInterrompidos(time.Millisecond)
+
+		// Verificar resultados: apenas o job em processamento é registrado como falha
+		assert.NoError(t, err)
+		job, err = uc.Consultar(estabelecimentoID, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobFalhou, job.Status)
+		assert.Contains(t, job.Erro, "processamento interrompido")
+
+		pendente, err = uc.Consultar(estabelecimentoID, pendente.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusJobPendente, pendente.Status)
+	})
+
+	t.Run("EntradaInvalida", func(t *testing.T) {
+		uc, _ := novoCasoDeUso()
+
+		_, err := uc.CriarLote(context.Background(), estabelecimentoID, nil, "", "")
+		assert.ErrorIs(t, err, usecases.ErrLoteVazio)
+
+		_, err = uc.CriarLote(context.Background(), estabelecimentoID, novosItens(1), usecases.FormatoResultadoZIP, "template_inexistente")
+		assert.ErrorIs(t, err, usecases.ErrTemplateNaoEncontrado)
+
+		_, err = uc.Consultar(estabelecimentoID, "job-inexistente")
+		assert.ErrorIs(t, err, repositories.ErrJobNaoEncontrado)
+	})
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// ListPixUseCase implementa o caso de uso de listagem do histórico de PIX
+type ListPixUseCase struct {
+	pixService    *services.PixGeneratorService
+	pixRepository repositories.PixRepository
+}
+
+// NewListPixUseCase cria uma nova instância do caso de uso de listagem de PIX
+func NewListPixUseCase(pixService *services.PixGeneratorService, pixRepository repositories.PixRepository) *ListPixUseCase {
+	return &ListPixUseCase{
+		pixService:    pixService,
+		pixRepository: pixRepository,
+	}
+}
+
+// Execute lista uma página dos PIX gerados pelo estabelecimento, com o total de PIX que
+// atendem aos filtros e o cursor da próxima página
+func (uc *ListPixUseCase) Execute(estabelecimentoID string, req models.ListarPixRequest) (models.PaginaPix, error) {
+	filtro, err := services.NovoFiltroPix(req)
+	if err != nil {
+		return models.PaginaPix{}, err
+	}
+
+	filtro.EstabelecimentoID = estabelecimentoID
+	filtro.Agora = time.Now()
+
+	// A chave é gravada normalizada; uma chave que não pode ser classificada é buscada como está
+	if filtro.Chave != "" {
+		if chave, err := uc.pixService.ClassificarChave(filtro.Chave); err == nil {
+			filtro.Chave = chave.Valor
+		}
+	}
+
+	total, err := uc.pixRepository.Contar(filtro)
+	if err != nil {
+		return models.PaginaPix{}, err
+	}
+
+	// Buscar um PIX além do limite para saber se existe uma próxima página
+	consulta := filtro
+	consulta.Limite++
+
+	pixes, err := uc.pixRepository.Listar(consulta)
+	if err != nil {
+		return models.PaginaPix{}, err
+	}
+
+	pagina := models.PaginaPix{
+		Itens: make([]models.PixResumo, 0, min(len(pixes), filtro.Limite)),
+		Total: total,
+	}
+
+	if len(pixes) > filtro.Limite {
+		pixes = pixes[:filtro.Limite]
+		pagina.ProximoCursor = services.CodificarCursorPix(filtro, pixes[len(pixes)-1])
+	}
+
+	for _, pix := range pixes {
+		pagina.Itens = append(pagina.Itens, models.NovoPixResumo(pix, filtro.Agora))
+	}
+
+	return pagina, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// PerfilUseCase implementa os casos de uso do perfil do estabelecimento e das suas chaves PIX
+type PerfilUseCase struct {
+	pixService       *services.PixGeneratorService
+	perfilRepository repositories.PerfilRepository
+}
+
+// NewPerfilUseCase cria uma nova instância do caso de uso de perfil
+func NewPerfilUseCase(pixService *services.PixGeneratorService, perfilRepository repositories.PerfilRepository) *PerfilUseCase {
+	return &PerfilUseCase{
+		pixService:       pixService,
+		perfilRepository: perfilRepository,
+	}
+}
+
+// Consultar retorna o perfil do estabelecimento com as chaves cadastradas
+func (uc *PerfilUseCase) Consultar(estabelecimentoID string) (models.PerfilEstabelecimento, error) {
+	return uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+}
+
+// Atualizar substitui os dados do perfil do estabelecimento, mantendo as chaves cadastradas
+func (uc *PerfilUseCase) Atualizar(estabelecimentoID string, req models.PerfilRequest) (models.PerfilEstabelecimento, error) {
+	perfil, err := services.NovoPerfil(req)
+	if err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	if err := uc.perfilRepository.AtualizarPerfil(estabelecimentoID, perfil); err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	return uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+}
+
+// CadastrarChave valida, normaliza e cadastra uma chave PIX no perfil do estabelecimento
+func (uc *PerfilUseCase) CadastrarChave(estabelecimentoID string, req models.ChaveCadastradaRequest) (models.ChaveCadastrada, error) {
+	apelido := strings.TrimSpace(req.Apelido)
+	if err := services.ValidarApelidoChave(apelido); err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+
+	chave, err := uc.pixService.ClassificarChave(req.Chave)
+	if err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+
+	return uc.perfilRepository.SalvarChave(estabelecimentoID, models.ChaveCadastrada{
+		Chave:     chave.Valor,
+		TipoChave: chave.Tipo,
+		Apelido:   apelido,
+		Padrao:    req.Padrao,
+	})
+}
+
+// AtualizarChave altera o apelido de uma chave cadastrada ou a torna a chave padrão
+func (uc *PerfilUseCase) AtualizarChave(estabelecimentoID string, id uint, req models.AtualizarChaveRequest) (models.PerfilEstabelecimento, error) {
+	perfil, err := uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+	if err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	chave, existe := perfil.Chave(id)
+	if !existe {
+		return models.PerfilEstabelecimento{}, repositories.ErrChaveNaoEncontrada
+	}
+
+	// Sempre existe uma chave padrão: para trocá-la, outra chave deve ser marcada como padrão
+	if req.Padrao != nil && !*req.Padrao && chave.Padrao {
+		return models.PerfilEstabelecimento{}, models.ErrosValidacao{{
+			Campo:    "padrao",
+			Mensagem: "para deixar de usar esta chave como padrão, defina outra chave como padrão",
+		}}
+	}
+
+	if req.Apelido != nil {
+		apelido := strings.TrimSpace(*req.Apelido)
+		if err := services.ValidarApelidoChave(apelido); err != nil {
+			return models.PerfilEstabelecimento{}, err
+		}
+		if err := uc.perfilRepository.AtualizarApelidoChave(estabelecimentoID, id, apelido); err != nil {
+			return models.PerfilEstabelecimento{}, err
+		}
+	}
+
+	if req.Padrao != nil && *req.Padrao && !chave.Padrao {
+		if err := uc.perfilRepository.DefinirChavePadrao(estabelecimentoID, id); err != nil {
+			return models.PerfilEstabelecimento{}, err
+		}
+	}
+
+	return uc.perfilRepository.BuscarPerfil(estabelecimentoID)
+}
+
+// ExcluirChave remove uma chave do perfil do estabelecimento
+func (uc *PerfilUseCase) ExcluirChave(estabelecimentoID string, id uint) error {
+	return uc.perfilRepository.ExcluirChave(estabelecimentoID, id)
+}
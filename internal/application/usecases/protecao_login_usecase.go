@@ -0,0 +1,323 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+const (
+	// prefixoProtecaoLogin é o prefixo das chaves da proteção do login no cache
+	prefixoProtecaoLogin = "login:"
+
+	// atrasoBaseLogin é a espera após a segunda senha incorreta seguida de um email, que dobra
+	// a cada nova tentativa incorreta até atrasoMaximoLogin
+	atrasoBaseLogin = time.Second
+
+	// atrasoMaximoLogin limita a espera entre as tentativas antes do bloqueio
+	atrasoMaximoLogin = 30 * time.Second
+)
+
+// ErroLoginBloqueado indica que o login foi recusado sem conferir a senha, por excesso de
+// tentativas incorretas para o email ou a partir do IP
+type ErroLoginBloqueado struct {
+	// TentarNovamenteEm é o tempo até o fim do bloqueio ou da espera
+	TentarNovamenteEm time.Duration
+}
+
+// Error implementa a interface error
+func (e *ErroLoginBloqueado) Error() string {
+	return fmt.Sprintf("muitas tentativas de login: tente novamente em %d segundos", e.Segundos())
+}
+
+// Segundos retorna o tempo até uma nova tentativa em segundos inteiros, arredondado para cima,
+// como no header Retry-After
+func (e *ErroLoginBloqueado) Segundos() int {
+	segundos := int((e.TentarNovamenteEm + time.Second - 1) / time.Second)
+	if segundos < 1 {
+		return 1
+	}
+	return segundos
+}
+
+// ConfiguracaoProtecaoLogin define os limites da proteção do login contra força bruta
+type ConfiguracaoProtecaoLogin struct {
+	// MaxTentativasEmail é o número de senhas incorretas para um email, dentro da janela, que
+	// bloqueia o login desse email
+	MaxTentativasEmail int64
+
+	// MaxTentativasIP é o número de senhas incorretas a partir de um IP, para qualquer email,
+	// dentro da janela, que bloqueia o login a partir desse IP
+	MaxTentativasIP int64
+
+	// Janela é o período em que as tentativas incorretas são contadas, a partir da primeira
+	Janela time.Duration
+
+	// Bloqueio é a duração do bloqueio
+	Bloqueio time.Duration
+}
+
+// ReservaLogin é uma tentativa de login reservada antes da conferência da senha, com a sua
+// posição entre as tentativas da janela do email e do IP
+type ReservaLogin struct {
+	email           string
+	ip              string
+	tentativasEmail int64
+	tentativasIP    int64
+}
+
+// ProtecaoLoginUseCase protege o login contra tentativas de senha em massa. As tentativas são
+// reservadas por email e por IP no cache compartilhado entre as instâncias antes da conferência
+// da senha, e continuam contando quando a senha está incorreta: cada nova tentativa incorreta
+// de um email exige uma espera maior antes da próxima, e o limite de tentativas bloqueia o
+// email ou o IP temporariamente.
+type ProtecaoLoginUseCase struct {
+	contador                cache.ContadorAdapter
+	bloqueioLoginRepository repositories.BloqueioLoginRepository
+	configuracao            ConfiguracaoProtecaoLogin
+}
+
+// NewProtecaoLoginUseCase cria uma nova instância da proteção do login
+func NewProtecaoLoginUseCase(
+	contador cache.ContadorAdapter,
+	bloqueioLoginRepository repositories.BloqueioLoginRepository,
+	configuracao ConfiguracaoProtecaoLogin,
+) *ProtecaoLoginUseCase {
+	return &ProtecaoLoginUseCase{
+		contador:                contador,
+		bloqueioLoginRepository: bloqueioLoginRepository,
+		configuracao:            configuracao,
+	}
+}
+
+// Reservar reserva uma tentativa de login antes da conferência da senha. Os contadores do email
+// e do IP são incrementados atomicamente, então requisições simultâneas recebem posições
+// distintas e no máximo o limite de tentativas da janela chega à conferência da senha. Retorna
+// um ErroLoginBloqueado quando o email ou o IP estão bloqueados, quando o email ainda está na
+// espera após uma tentativa incorreta ou quando a reserva excede o limite de tentativas.
+func (uc *ProtecaoLoginUseCase) Reservar(ctx context.Context, email, ip string) (ReservaLogin, error) {
+	agora := time.Now()
+
+	if err := uc.verificarRestricoes(ctx, email, ip, agora); err != nil {
+		return ReservaLogin{}, err
+	}
+
+	reserva := ReservaLogin{email: email, ip: ip}
+
+	chaveEmail := chaveProtecaoLogin("tentativas", models.BloqueioPorEmail, email)
+	tentativas, err := uc.contador.Incrementar(ctx, chaveEmail, uc.configuracao.Janela)
+	if err != nil {
+		return ReservaLogin{}, err
+	}
+	reserva.tentativasEmail = tentativas
+
+	if tentativas > uc.configuracao.MaxTentativasEmail {
+		return ReservaLogin{}, uc.limiteExcedido(ctx, models.BloqueioPorEmail, email, agora)
+	}
+
+	if ip == "" {
+		return reserva, nil
+	}
+
+	tentativas, err = uc.contador.Incrementar(ctx, chaveProtecaoLogin("tentativas", models.BloqueioPorIP, ip), uc.configuracao.Janela)
+	if err != nil {
+		return ReservaLogin{}, err
+	}
+	reserva.tentativasIP = tentativas
+
+	if tentativas > uc.configuracao.MaxTentativasIP {
+		// A tentativa recusada pelo limite do IP não conta para o email
+		if _, err := uc.contador.Decrementar(ctx, chaveEmail); err != nil {
+			return ReservaLogin{}, err
+		}
+		return ReservaLogin{}, uc.limiteExcedido(ctx, models.BloqueioPorIP, ip, agora)
+	}
+
+	return reserva, nil
+}
+
+// RegistrarFalha mantém a tentativa reservada com senha incorreta, definindo a espera do email
+// e bloqueando o email ou o IP cuja reserva atingiu o limite de tentativas
+func (uc *ProtecaoLoginUseCase) RegistrarFalha(ctx context.Context, reserva ReservaLogin) error {
+	agora := time.Now()
+
+	if reserva.tentativasEmail >= uc.configuracao.MaxTentativasEmail {
+		if err := uc.bloquear(ctx, models.BloqueioPorEmail, reserva.email, reserva.ip, reserva.tentativasEmail, agora); err != nil {
+			return err
+		}
+	} else if atraso := atrasoLogin(reserva.tentativasEmail); atraso > 0 {
+		chave := chaveProtecaoLogin("espera", models.BloqueioPorEmail, reserva.email)
+		if err := uc.contador.Set(ctx, chave, agora.Add(atraso).UnixMilli(), atraso); err != nil {
+			return err
+		}
+	}
+
+	if reserva.ip == "" || reserva.tentativasIP < uc.configuracao.MaxTentativasIP {
+		return nil
+	}
+	return uc.bloquear(ctx, models.BloqueioPorIP, reserva.ip, reserva.ip, reserva.tentativasIP, agora)
+}
+
+// RegistrarSucesso zera as tentativas e a espera do email após um login com a senha correta
+// e devolve a reserva do IP. As tentativas incorretas do IP continuam contando, para que o
+// login em uma conta própria não libere novas tentativas contra outras contas.
+func (uc *ProtecaoLoginUseCase) RegistrarSucesso(ctx context.Context, reserva ReservaLogin) error {
+	for _, chave := range []string{
+		chaveProtecaoLogin("tentativas", models.BloqueioPorEmail, reserva.email),
+		chaveProtecaoLogin("espera", models.BloqueioPorEmail, reserva.email),
+	} {
+		if err := uc.contador.Delete(ctx, chave); err != nil {
+			return err
+		}
+	}
+
+	return uc.liberarIP(ctx, reserva)
+}
+
+// Liberar devolve a tentativa reservada de um login encerrado sem que a senha fosse recusada,
+// como em uma falha ao consultar a conta, para que ela não conte para os limites
+func (uc *ProtecaoLoginUseCase) Liberar(ctx context.Context, reserva ReservaLogin) error {
+	if _, err := uc.contador.Decrementar(ctx, chaveProtecaoLogin("tentativas", models.BloqueioPorEmail, reserva.email)); err != nil {
+		return err
+	}
+
+	return uc.liberarIP(ctx, reserva)
+}
+
+// liberarIP devolve a tentativa reservada para o IP
+func (uc *ProtecaoLoginUseCase) liberarIP(ctx context.Context, reserva ReservaLogin) error {
+	if reserva.ip == "" {
+		return nil
+	}
+
+	_, err := uc.contador.Decrementar(ctx, chaveProtecaoLogin("tentativas", models.BloqueioPorIP, reserva.ip))
+	return err
+}
+
+// verificarRestricoes retorna um ErroLoginBloqueado quando o email ou o IP estão bloqueados ou
+// quando o email ainda está na espera após uma tentativa incorreta
+func (uc *ProtecaoLoginUseCase) verificarRestricoes(ctx context.Context, email, ip string, agora time.Time) error {
+	chaves := []string{
+		chaveProtecaoLogin("bloqueio", models.BloqueioPorEmail, email),
+		chaveProtecaoLogin("espera", models.BloqueioPorEmail, email),
+	}
+	if ip != "" {
+		chaves = append(chaves, chaveProtecaoLogin("bloqueio", models.BloqueioPorIP, ip))
+	}
+
+	// Com mais de uma restrição, vale a que termina por último
+	var liberadoEm time.Time
+	for _, chave := range chaves {
+		ate, err := uc.lerInstante(ctx, chave)
+		if err != nil {
+			return err
+		}
+		if ate.After(liberadoEm) {
+			liberadoEm = ate
+		}
+	}
+
+	if liberadoEm.After(agora) {
+		return &ErroLoginBloqueado{TentarNovamenteEm: liberadoEm.Sub(agora)}
+	}
+	return nil
+}
+
+// limiteExcedido recusa uma reserva além do limite de tentativas. Enquanto a tentativa que
+// atingiu o limite ainda confere a senha, o bloqueio não existe e a espera é a mínima.
+func (uc *ProtecaoLoginUseCase) limiteExcedido(ctx context.Context, tipo, identificador string, agora time.Time) error {
+	bloqueadoAte, err := uc.lerInstante(ctx, chaveProtecaoLogin("bloqueio", tipo, identificador))
+	if err != nil {
+		return err
+	}
+
+	if bloqueadoAte.After(agora) {
+		return &ErroLoginBloqueado{TentarNovamenteEm: bloqueadoAte.Sub(agora)}
+	}
+	return &ErroLoginBloqueado{TentarNovamenteEm: atrasoBaseLogin}
+}
+
+// bloquear bloqueia o email ou o IP e registra o bloqueio para auditoria. O contador de
+// tentativas passa a expirar com o bloqueio: até lá, as reservas simultâneas continuam além do
+// limite, e depois dele a contagem recomeça.
+func (uc *ProtecaoLoginUseCase) bloquear(ctx context.Context, tipo, identificador, ip string, tentativas int64, agora time.Time) error {
+	bloqueadoAte := agora.Add(uc.configuracao.Bloqueio)
+
+	chave := chaveProtecaoLogin("bloqueio", tipo, identificador)
+	if err := uc.contador.Set(ctx, chave, bloqueadoAte.UnixMilli(), uc.configuracao.Bloqueio); err != nil {
+		return err
+	}
+
+	if err := uc.contador.Set(ctx, chaveProtecaoLogin("tentativas", tipo, identificador), tentativas, uc.configuracao.Bloqueio); err != nil {
+		return err
+	}
+
+	log.Printf("Login bloqueado até %s por excesso de tentativas: %s %s (IP %s, %d tentativas)",
+		bloqueadoAte.Format(time.RFC3339), tipo, identificador, ip, tentativas)
+
+	// O bloqueio já vale mesmo sem o registro da auditoria
+	err := uc.bloqueioLoginRepository.Salvar(models.BloqueioLogin{
+		ID:            uuid.New().String(),
+		Tipo:          tipo,
+		Identificador: identificador,
+		IP:            ip,
+		Tentativas:    tentativas,
+		BloqueadoAte:  bloqueadoAte,
+		CriadoEm:      agora,
+	})
+	if err != nil {
+		log.Printf("Erro ao registrar o bloqueio de login de %s %s: %v", tipo, identificador, err)
+	}
+
+	return nil
+}
+
+// lerInstante lê o instante gravado em uma chave de bloqueio ou de espera; chaves inexistentes
+// ou expiradas retornam o instante zero
+func (uc *ProtecaoLoginUseCase) lerInstante(ctx context.Context, chave string) (time.Time, error) {
+	valor, err := uc.contador.Get(ctx, chave)
+	if errors.Is(err, cache.ErrChaveInexistente) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	milissegundos, err := strconv.ParseInt(string(valor), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(milissegundos), nil
+}
+
+// atrasoLogin retorna a espera após a tentativa incorreta de número informado: nenhuma após a
+// primeira e, a partir da segunda, atrasoBaseLogin dobrando a cada tentativa
+func atrasoLogin(falhas int64) time.Duration {
+	if falhas < 2 {
+		return 0
+	}
+
+	atraso := atrasoBaseLogin
+	for i := int64(2); i < falhas && atraso < atrasoMaximoLogin; i++ {
+		atraso *= 2
+	}
+	if atraso > atrasoMaximoLogin {
+		return atrasoMaximoLogin
+	}
+	return atraso
+}
+
+// chaveProtecaoLogin monta a chave do cache de um contador, espera ou bloqueio. Os emails são
+// comparados sem diferenciar maiúsculas.
+func chaveProtecaoLogin(categoria, tipo, identificador string) string {
+	return prefixoProtecaoLogin + categoria + ":" + tipo + ":" + strings.ToLower(strings.TrimSpace(identificador))
+}
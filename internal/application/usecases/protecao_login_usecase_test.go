@@ -0,0 +1,339 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+)
+
+// ipTeste é o IP do cliente nos logins dos testes
+const ipTeste = "127.0.0.1"
+
+// configuracaoProtecaoLoginTeste tem limites que os demais testes não atingem
+var configuracaoProtecaoLoginTeste = usecases.ConfiguracaoProtecaoLogin{
+	MaxTentativasEmail: 5,
+	MaxTentativasIP:    20,
+	Janela:             15 * time.Minute,
+	Bloqueio:           15 * time.Minute,
+}
+
+// valorContador é um valor do contadorMemoria com a sua expiração
+type valorContador struct {
+	valor    []byte
+	expiraEm time.Time
+}
+
+// contadorMemoria guarda o cache e os contadores em memória
+type contadorMemoria struct {
+	mu      sync.Mutex
+	valores map[string]valorContador
+}
+
+func (c *contadorMemoria) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, existe := c.valores[key]
+	if !existe || !time.Now().Before(item.expiraEm) {
+		return nil, cache.ErrChaveInexistente
+	}
+	return item.valor, nil
+}
+
+func (c *contadorMemoria) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	valor, ok := value.([]byte)
+	if !ok {
+		valor = []byte(fmt.Sprint(value))
+	}
+	c.valores[key] = valorContador{valor: valor, expiraEm: time.Now().Add(expiration)}
+	return nil
+}
+
+func (c *contadorMemoria) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.valores, key)
+	return nil
+}
+
+func (c *contadorMemoria) Decrementar(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, existe := c.valores[key]
+	if !existe || !time.Now().Before(item.expiraEm) {
+		return 0, nil
+	}
+
+	var valor int64
+	fmt.Sscan(string(item.valor), &valor)
+	valor--
+	item.valor = []byte(fmt.Sprint(valor))
+	c.valores[key] = item
+	return valor, nil
+}
+
+func (c *contadorMemoria) Incrementar(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, existe := c.valores[key]
+	if !existe || !time.Now().Before(item.expiraEm) {
+		item = valorContador{valor: []byte("0"), expiraEm: time.Now().Add(expiration)}
+	}
+
+	var valor int64
+	fmt.Sscan(string(item.valor), &valor)
+	valor++
+	item.valor = []byte(fmt.Sprint(valor))
+	c.valores[key] = item
+	return valor, nil
+}
+
+// bloqueioLoginRepositoryMemoria guarda a auditoria dos bloqueios em memória
+type bloqueioLoginRepositoryMemoria struct {
+	mu        sync.Mutex
+	bloqueios []models.BloqueioLogin
+}
+
+func (r *bloqueioLoginRepositoryMemoria) Salvar(bloqueio models.BloqueioLogin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bloqueios = append(r.bloqueios, bloqueio)
+	return nil
+}
+
+func (r *bloqueioLoginRepositoryMemoria) registrados() []models.BloqueioLogin {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]models.BloqueioLogin(nil), r.bloqueios...)
+}
+
+// novaProtecaoLogin cria a proteção do login com o cache e a auditoria em memória
+func novaProtecaoLogin(configuracao usecases.ConfiguracaoProtecaoLogin) *usecases.ProtecaoLoginUseCase {
+	return usecases.NewProtecaoLoginUseCase(
+		&contadorMemoria{valores: map[string]valorContador{}},
+		&bloqueioLoginRepositoryMemoria{},
+		configuracao,
+	)
+}
+
+func TestProtecaoLogin(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// novoLogin cria o caso de uso de autenticação com os limites informados e um estabelecimento
+	novoLogin := func(t *testing.T, configuracao usecases.ConfiguracaoProtecaoLogin) (*usecases.AutenticacaoUseCase, *bloqueioLoginRepositoryMemoria) {
+		estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+		usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+		refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+		tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+		bloqueioLoginRepository := &bloqueioLoginRepositoryMemoria{}
+		contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+		protecaoLogin := usecases.NewProtecaoLoginUseCase(&contadorMemoria{valores: map[string]valorContador{}}, bloqueioLoginRepository, configuracao)
+		uc := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, &revogacaoMemoria{jtis: map[string]bool{}}, contaUseCase, protecaoLogin, novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+
+		for _, email := range []string{"loja@teste.com", "outra@teste.com"} {
+			_, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: email, Senha: "senha123"})
+			assert.NoError(t, err)
+		}
+		return uc, bloqueioLoginRepository
+	}
+
+	t.Run("EsperaProgressiva", func(t *testing.T) {
+		uc, _ := novoLogin(t, configuracaoProtecaoLoginTeste)
+
+		// A primeira senha incorreta não exige espera
+		var bloqueado *usecases.ErroLoginBloqueado
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+		assert.Error(t, err)
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+		assert.False(t, errors.As(err, &bloqueado))
+
+		// Executar o método a ser testado: tentar de novo logo após a segunda senha incorreta
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "LOJA@teste.com", Senha: "senha123"}, "10.0.0.1")
+
+		// Verificar resultados: mesmo com a senha correta, de outro IP e com outra grafia, o email aguarda a espera
+		assert.True(t, errors.As(err, &bloqueado))
+		assert.LessOrEqual(t, bloqueado.TentarNovamenteEm, time.Second)
+		assert.Equal(t, 1, bloqueado.Segundos())
+	})
+
+	t.Run("BloqueioPorEmail", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasEmail = 2
+		uc, bloqueios := novoLogin(t, configuracao)
+
+		// Executar o método a ser testado
+		for i := 0; i < 2; i++ {
+			_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+			assert.Error(t, err)
+		}
+
+		// Verificar resultados: o email fica bloqueado pela duração configurada, com o registro da auditoria
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		var bloqueado *usecases.ErroLoginBloqueado
+		assert.True(t, errors.As(err, &bloqueado))
+		assert.InDelta(t, (15 * time.Minute).Seconds(), bloqueado.TentarNovamenteEm.Seconds(), 5)
+
+		registrados := bloqueios.registrados()
+		if assert.Len(t, registrados, 1) {
+			assert.Equal(t, models.BloqueioPorEmail, registrados[0].Tipo)
+			assert.Equal(t, "loja@teste.com", registrados[0].Identificador)
+			assert.Equal(t, ipTeste, registrados[0].IP)
+			assert.Equal(t, int64(2), registrados[0].Tentativas)
+		}
+
+		// Os demais emails não são afetados
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "outra@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+	})
+
+	t.Run("BloqueioPorIP", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasIP = 2
+		uc, bloqueios := novoLogin(t, configuracao)
+
+		// Executar o método a ser testado: uma senha incorreta para cada email, do mesmo IP
+		for _, email := range []string{"loja@teste.com", "desconhecido@teste.com"} {
+			_, err := uc.Login(ctx, models.LoginRequest{Email: email, Senha: "errada"}, ipTeste)
+			assert.Error(t, err)
+		}
+
+		// Verificar resultados: o IP fica bloqueado para qualquer email, e os demais IPs não
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "outra@teste.com", Senha: "senha123"}, ipTeste)
+		var bloqueado *usecases.ErroLoginBloqueado
+		assert.True(t, errors.As(err, &bloqueado))
+
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "outra@teste.com", Senha: "senha123"}, "10.0.0.1")
+		assert.NoError(t, err)
+
+		registrados := bloqueios.registrados()
+		if assert.Len(t, registrados, 1) {
+			assert.Equal(t, models.BloqueioPorIP, registrados[0].Tipo)
+			assert.Equal(t, ipTeste, registrados[0].Identificador)
+		}
+	})
+
+	t.Run("TentativasSimultaneas", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasEmail = 3
+		uc, bloqueios := novoLogin(t, configuracao)
+
+		// Executar o método a ser testado: uma rajada de senhas incorretas ao mesmo tempo
+		var wg sync.WaitGroup
+		erros := make(chan error, 20)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+				erros <- err
+			}()
+		}
+		wg.Wait()
+		close(erros)
+
+		// Verificar resultados: apenas as tentativas dentro do limite chegam à senha
+		conferidas := 0
+		for err := range erros {
+			var bloqueado *usecases.ErroLoginBloqueado
+			if !errors.As(err, &bloqueado) {
+				conferidas++
+			}
+		}
+		assert.LessOrEqual(t, conferidas, 3)
+
+		// O email fica bloqueado, mesmo com a senha correta
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		var bloqueado *usecases.ErroLoginBloqueado
+		assert.True(t, errors.As(err, &bloqueado))
+		assert.LessOrEqual(t, len(bloqueios.registrados()), 1)
+	})
+
+	t.Run("SucessoNaoContaParaOIP", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasIP = 2
+		uc, _ := novoLogin(t, configuracao)
+
+		// Executar o método a ser testado
+		for i := 0; i < 3; i++ {
+			_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+			assert.NoError(t, err)
+		}
+
+		// Verificar resultados: os logins corretos devolvem a reserva do IP
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "outra@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ContaDesativada", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasEmail = 2
+		estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+		usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+		refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+		tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+		contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+		uc := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, &revogacaoMemoria{jtis: map[string]bool{}}, contaUseCase, novaProtecaoLogin(configuracao), novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+
+		loja, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: "desativada@teste.com", Senha: "senha123"})
+		assert.NoError(t, err)
+		assert.NoError(t, estabelecimentoRepository.AlterarAtivo(loja.ID, false))
+
+		// Executar o método a ser testado
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "desativada@teste.com", Senha: "errada"}, ipTeste)
+
+		// Verificar resultados: sem a senha, a conta desativada não é revelada e a tentativa conta
+		assert.NotErrorIs(t, err, services.ErrContaDesativada)
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "desativada@teste.com", Senha: "errada"}, ipTeste)
+		assert.NotErrorIs(t, err, services.ErrContaDesativada)
+
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "desativada@teste.com", Senha: "senha123"}, ipTeste)
+		var bloqueado *usecases.ErroLoginBloqueado
+		assert.True(t, errors.As(err, &bloqueado))
+	})
+
+	t.Run("SucessoZeraTentativasDoEmail", func(t *testing.T) {
+		configuracao := configuracaoProtecaoLoginTeste
+		configuracao.MaxTentativasEmail = 2
+		uc, bloqueios := novoLogin(t, configuracao)
+
+		_, err := uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+		assert.Error(t, err)
+
+		// Executar o método a ser testado
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+
+		// Verificar resultados: uma nova senha incorreta volta a ser a primeira da janela
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "errada"}, ipTeste)
+		assert.Error(t, err)
+		assert.Empty(t, bloqueios.registrados())
+
+		_, err = uc.Login(ctx, models.LoginRequest{Email: "loja@teste.com", Senha: "senha123"}, ipTeste)
+		assert.NoError(t, err)
+	})
+}
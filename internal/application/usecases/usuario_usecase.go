@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+)
+
+// ErrConviteInvalido indica um convite inexistente, expirado, já aceito ou de um usuário desativado
+var ErrConviteInvalido = errors.New("convite inválido ou expirado")
+
+// duracaoConvite é a validade dos convites para a equipe
+const duracaoConvite = 7 * 24 * time.Hour
+
+// UsuarioUseCase implementa a gestão da equipe de cada estabelecimento: os convites, o aceite
+// e os papéis dos usuários
+type UsuarioUseCase struct {
+	usuarioRepository         repositories.UsuarioRepository
+	estabelecimentoRepository repositories.EstabelecimentoRepository
+}
+
+// NewUsuarioUseCase cria uma nova instância do caso de uso de usuários
+func NewUsuarioUseCase(usuarioRepository repositories.UsuarioRepository, estabelecimentoRepository repositories.EstabelecimentoRepository) *UsuarioUseCase {
+	return &UsuarioUseCase{
+		usuarioRepository:         usuarioRepository,
+		estabelecimentoRepository: estabelecimentoRepository,
+	}
+}
+
+// Listar lista os usuários da equipe do estabelecimento
+func (uc *UsuarioUseCase) Listar(estabelecimentoID string) ([]models.Usuario, error) {
+	return uc.usuarioRepository.Listar(estabelecimentoID)
+}
+
+// Convidar cria um usuário com o papel informado e o convite para ele definir o nome e a
+// senha. O convite só é retornado aqui.
+func (uc *UsuarioUseCase) Convidar(estabelecimentoID, papelAtor string, req models.ConvidarUsuarioRequest) (models.ConviteCriado, error) {
+	usuario, err := services.NovoConvite(req, papelAtor)
+	if err != nil {
+		return models.ConviteCriado{}, err
+	}
+
+	if err := verificarEmailDisponivel(uc.estabelecimentoRepository, uc.usuarioRepository, usuario.Email, ""); err != nil {
+		return models.ConviteCriado{}, err
+	}
+
+	convite, conviteHash, err := services.GerarConvite()
+	if err != nil {
+		return models.ConviteCriado{}, err
+	}
+
+	agora := time.Now()
+	expiraEm := agora.Add(duracaoConvite)
+
+	usuario.ID = uuid.New().String()
+	usuario.EstabelecimentoID = estabelecimentoID
+	usuario.ConviteHash = conviteHash
+	usuario.ConviteExpiraEm = &expiraEm
+	usuario.CriadoEm = agora
+	usuario.AtualizadoEm = agora
+
+	if err := uc.usuarioRepository.Salvar(usuario); err != nil {
+		return models.ConviteCriado{}, err
+	}
+
+	return models.ConviteCriado{Usuario: usuario, Convite: convite}, nil
+}
+
+// AceitarConvite define o nome e a senha do usuário convidado, que passa a fazer login
+func (uc *UsuarioUseCase) AceitarConvite(req models.AceitarConviteRequest) (models.Usuario, error) {
+	agora := time.Now()
+
+	usuario, err := uc.usuarioRepository.BuscarPorConvite(services.HashConvite(req.Convite))
+	if err != nil {
+		if errors.Is(err, repositories.ErrUsuarioNaoEncontrado) {
+			return models.Usuario{}, ErrConviteInvalido
+		}
+		return models.Usuario{}, err
+	}
+
+	if !usuario.Ativo || usuario.ConviteExpiraEm == nil || !agora.Before(*usuario.ConviteExpiraEm) {
+		return models.Usuario{}, ErrConviteInvalido
+	}
+
+	// Dois aceites simultâneos do mesmo convite: apenas o primeiro define a senha
+	aceito, err := uc.usuarioRepository.Aceitar(usuario.ID, req.Nome, req.Senha, agora)
+	if err != nil {
+		return models.Usuario{}, err
+	}
+	if !aceito {
+		return models.Usuario{}, ErrConviteInvalido
+	}
+
+	return uc.usuarioRepository.BuscarPorID(usuario.ID)
+}
+
+// AlterarPapel altera o papel de um usuário da equipe. Quem tem o papel papelAtor precisa
+// poder gerenciar tanto o papel atual quanto o novo papel do usuário.
+func (uc *UsuarioUseCase) AlterarPapel(estabelecimentoID, papelAtor, id string, req models.AlterarPapelRequest) (models.Usuario, error) {
+	usuario, err := uc.buscar(estabelecimentoID, id)
+	if err != nil {
+		return models.Usuario{}, err
+	}
+
+	if !services.PodeGerenciarPapel(papelAtor, usuario.Papel) {
+		return models.Usuario{}, services.ErrPapelNaoPermitido
+	}
+
+	if err := services.ValidarPapel(req.Papel, papelAtor); err != nil {
+		return models.Usuario{}, err
+	}
+
+	if err := uc.usuarioRepository.AlterarPapel(estabelecimentoID, id, req.Papel); err != nil {
+		return models.Usuario{}, err
+	}
+
+	return uc.usuarioRepository.BuscarPorID(id)
+}
+
+// Desativar desativa um usuário da equipe, que perde o acesso imediatamente
+func (uc *UsuarioUseCase) Desativar(estabelecimentoID, papelAtor, id string) (models.Usuario, error) {
+	usuario, err := uc.buscar(estabelecimentoID, id)
+	if err != nil {
+		return models.Usuario{}, err
+	}
+
+	if !services.PodeGerenciarPapel(papelAtor, usuario.Papel) {
+		return models.Usuario{}, services.ErrPapelNaoPermitido
+	}
+
+	if err := uc.usuarioRepository.Desativar(estabelecimentoID, id, time.Now()); err != nil {
+		return models.Usuario{}, err
+	}
+
+	return uc.usuarioRepository.BuscarPorID(id)
+}
+
+// buscar busca um usuário da equipe do estabelecimento; usuários de outros estabelecimentos
+// são tratados como inexistentes
+func (uc *UsuarioUseCase) buscar(estabelecimentoID, id string) (models.Usuario, error) {
+	usuario, err := uc.usuarioRepository.BuscarPorID(id)
+	if err != nil {
+		return models.Usuario{}, err
+	}
+
+	if usuario.EstabelecimentoID != estabelecimentoID {
+		return models.Usuario{}, repositories.ErrUsuarioNaoEncontrado
+	}
+
+	return usuario, nil
+}
+
+// verificarEmailDisponivel confere que o email, usado no login, não pertence a outro
+// estabelecimento nem a um usuário. O estabelecimento ignorarID pode manter o próprio email.
+func verificarEmailDisponivel(
+	estabelecimentoRepository repositories.EstabelecimentoRepository,
+	usuarioRepository repositories.UsuarioRepository,
+	email, ignorarID string,
+) error {
+	estabelecimento, err := estabelecimentoRepository.BuscarPorEmail(email)
+	if err == nil && estabelecimento.ID != ignorarID {
+		return ErrEmailJaCadastrado
+	}
+	if err != nil && !errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado) {
+		return err
+	}
+
+	_, err = usuarioRepository.BuscarPorEmail(email)
+	if err == nil {
+		return ErrEmailJaCadastrado
+	}
+	if !errors.Is(err, repositories.ErrUsuarioNaoEncontrado) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// usuarioRepositoryMemoria guarda os usuários em memória
+type usuarioRepositoryMemoria struct {
+	mu       sync.Mutex
+	usuarios map[string]models.Usuario
+}
+
+func (r *usuarioRepositoryMemoria) Salvar(usuario models.Usuario) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.usuarios[usuario.ID] = usuario
+	return nil
+}
+
+func (r *usuarioRepositoryMemoria) BuscarPorID(id string) (models.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usuario, existe := r.usuarios[id]
+	if !existe {
+		return models.Usuario{}, repositories.ErrUsuarioNaoEncontrado
+	}
+	return usuario, nil
+}
+
+func (r *usuarioRepositoryMemoria) BuscarPorEmail(email string) (models.Usuario, error) {
+	return r.buscar(func(usuario models.Usuario) bool { return usuario.Email == email })
+}
+
+func (r *usuarioRepositoryMemoria) BuscarPorConvite(conviteHash string) (models.Usuario, error) {
+	return r.buscar(func(usuario models.Usuario) bool {
+		return usuario.ConviteHash != "" && usuario.ConviteHash == conviteHash
+	})
+}
+
+func (r *usuarioRepositoryMemoria) Listar(estabelecimentoID string) ([]models.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usuarios := []models.Usuario{}
+	for _, usuario := range r.usuarios {
+		if usuario.EstabelecimentoID == estabelecimentoID {
+			usuarios = append(usuarios, usuario)
+		}
+	}
+	return usuarios, nil
+}
+
+func (r *usuarioRepositoryMemoria) Aceitar(id, nome, senha string, aceitoEm time.Time) (bool, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.MinCost)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usuario := r.usuarios[id]
+	if usuario.AceitoEm != nil || usuario.ConviteHash == "" {
+		return false, nil
+	}
+	usuario.Nome = nome
+	usuario.Senha = string(hash)
+	usuario.AceitoEm = &aceitoEm
+	usuario.ConviteHash = ""
+	usuario.ConviteExpiraEm = nil
+	r.usuarios[id] = usuario
+	return true, nil
+}
+
+func (r *usuarioRepositoryMemoria) AlterarPapel(estabelecimentoID, id, papel string) error {
+	return r.alterar(estabelecimentoID, id, func(usuario *models.Usuario) { usuario.Papel = papel })
+}
+
+func (r *usuarioRepositoryMemoria) AlterarSenha(id, senha string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usuario, existe := r.usuarios[id]
+	if !existe || usuario.AceitoEm == nil {
+		return nil
+	}
+	usuario.Senha = string(hash)
+	r.usuarios[id] = usuario
+	return nil
+}
+
+func (r *usuarioRepositoryMemoria) Desativar(estabelecimentoID, id string, desativadoEm time.Time) error {
+	return r.alterar(estabelecimentoID, id, func(usuario *models.Usuario) {
+		usuario.Ativo = false
+		usuario.TokensRevogadosEm = &desativadoEm
+		usuario.ConviteHash = ""
+	})
+}
+
+func (r *usuarioRepositoryMemoria) buscar(filtro func(models.Usuario) bool) (models.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, usuario := range r.usuarios {
+		if filtro(usuario) {
+			return usuario, nil
+		}
+	}
+	return models.Usuario{}, repositories.ErrUsuarioNaoEncontrado
+}
+
+func (r *usuarioRepositoryMemoria) alterar(estabelecimentoID, id string, alteracao func(*models.Usuario)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usuario, existe := r.usuarios[id]
+	if !existe || usuario.EstabelecimentoID != estabelecimentoID {
+		return repositories.ErrUsuarioNaoEncontrado
+	}
+	alteracao(&usuario)
+	r.usuarios[id] = usuario
+	return nil
+}
+
+func TestUsuarioUseCase(t *testing.T) {
+	os.Setenv("JWT_SECRET", "chave_secreta_para_testes")
+	defer os.Unsetenv("JWT_SECRET")
+
+	autenticacaoService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	estabelecimentoRepository := &estabelecimentoRepositoryMemoria{estabelecimentos: map[string]models.Estabelecimento{}}
+	usuarioRepository := &usuarioRepositoryMemoria{usuarios: map[string]models.Usuario{}}
+	refreshTokenRepository := &refreshTokenRepositoryMemoria{tokens: map[string]models.RefreshToken{}}
+	revogacao := &revogacaoMemoria{jtis: map[string]bool{}}
+	tokenContaRepository := &tokenContaRepositoryMemoria{tokens: map[string]models.TokenConta{}}
+	contaUseCase := usecases.NewContaUseCase(estabelecimentoRepository, usuarioRepository, tokenContaRepository, refreshTokenRepository, email.NewLogMailer(io.Discard), "http://localhost:8080", false)
+	autenticacaoUseCase := usecases.NewAutenticacaoUseCase(autenticacaoService, estabelecimentoRepository, usuarioRepository, refreshTokenRepository, revogacao, contaUseCase, novaProtecaoLogin(configuracaoProtecaoLoginTeste), novosDoisFatores(estabelecimentoRepository, usuarioRepository, autenticacaoService))
+	uc := usecases.NewUsuarioUseCase(usuarioRepository, estabelecimentoRepository)
+
+	estabelecimento, err := estabelecimentoRepository.Salvar(models.EstabelecimentoRequest{Nome: "Loja", Email: "loja@teste.com", Senha: "senha123"})
+	assert.NoError(t, err)
+
+	// convidar cria e aceita o convite de um usuário com o papel informado
+	convidar := func(t *testing.T, email, papel string) models.Usuario {
+		convite, err := uc.Convidar(estabelecimento.ID, models.PapelProprietario, models.ConvidarUsuarioRequest{Email: email, Papel: papel})
+		assert.NoError(t, err)
+
+		usuario, err := uc.AceitarConvite(models.AceitarConviteRequest{Convite: convite.Convite, Nome: "Maria", Senha: "senha456"})
+		assert.NoError(t, err)
+		return usuario
+	}
+
+	t.Run("ConviteELogin", func(t *testing.T) {
+		convite, err := uc.Convidar(estabelecimento.ID, models.PapelProprietario, models.ConvidarUsuarioRequest{Email: "caixa@teste.com", Papel: models.PapelCaixa})
+		assert.NoError(t, err)
+
+		// Antes do aceite, o usuário não faz login
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "caixa@teste.com", Senha: "senha456"}, ipTeste)
+		assert.Error(t, err)
+
+		// Executar o método a ser testado
+		usuario, err := uc.AceitarConvite(models.AceitarConviteRequest{Convite: convite.Convite, Nome: "Maria", Senha: "senha456"})
+		assert.NoError(t, err)
+
+		// Verificar resultados: o convite vale uma única vez e o login traz o papel no token
+		_, err = uc.AceitarConvite(models.AceitarConviteRequest{Convite: convite.Convite, Nome: "Outra", Senha: "senha789"})
+		assert.ErrorIs(t, err, usecases.ErrConviteInvalido)
+
+		resposta, err := autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "caixa@teste.com", Senha: "senha456"}, ipTeste)
+		assert.NoError(t, err)
+		assert.Equal(t, models.PapelCaixa, resposta.Papel)
+		assert.Equal(t, usuario.ID, resposta.Usuario.ID)
+
+		claims, err := autenticacaoService.ValidarToken(resposta.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, estabelecimento.ID, claims["id"])
+		assert.Equal(t, usuario.ID, claims["uid"])
+		assert.Equal(t, models.PapelCaixa, claims["papel"])
+
+		// A renovação mantém o usuário da sessão
+		renovada, err := autenticacaoUseCase.Renovar(models.RefreshTokenRequest{RefreshToken: resposta.RefreshToken})
+		assert.NoError(t, err)
+		assert.Equal(t, models.PapelCaixa, renovada.Papel)
+	})
+
+	t.Run("EmailJaCadastrado", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := uc.Convidar(estabelecimento.ID, models.PapelProprietario, models.ConvidarUsuarioRequest{Email: "loja@teste.com", Papel: models.PapelLeitura})
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, usecases.ErrEmailJaCadastrado)
+	})
+
+	t.Run("GerenteGerenciaApenasPapeisInferiores", func(t *testing.T) {
+		gerente := convidar(t, "gerente@teste.com", models.PapelGerente)
+		outroGerente := convidar(t, "gerente2@teste.com", models.PapelGerente)
+		leitura := convidar(t, "leitura@teste.com", models.PapelLeitura)
+
+		// Executar o método a ser testado
+		_, errConvite := uc.Convidar(estabelecimento.ID, gerente.Papel, models.ConvidarUsuarioRequest{Email: "novo@teste.com", Papel: models.PapelGerente})
+		_, errPromocao := uc.AlterarPapel(estabelecimento.ID, gerente.Papel, leitura.ID, models.AlterarPapelRequest{Papel: models.PapelGerente})
+		_, errDesativacao := uc.Desativar(estabelecimento.ID, gerente.Papel, outroGerente.ID)
+		alterado, errAlteracao := uc.AlterarPapel(estabelecimento.ID, gerente.Papel, leitura.ID, models.AlterarPapelRequest{Papel: models.PapelCaixa})
+
+		// Verificar resultados
+		assert.ErrorIs(t, errConvite, services.ErrPapelNaoPermitido)
+		assert.ErrorIs(t, errPromocao, services.ErrPapelNaoPermitido)
+		assert.ErrorIs(t, errDesativacao, services.ErrPapelNaoPermitido)
+		assert.NoError(t, errAlteracao)
+		assert.Equal(t, models.PapelCaixa, alterado.Papel)
+	})
+
+	t.Run("PapelInvalido", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := uc.Convidar(estabelecimento.ID, models.PapelProprietario, models.ConvidarUsuarioRequest{Email: "dono@teste.com", Papel: models.PapelProprietario})
+
+		// Verificar resultados: o papel de proprietário não pode ser atribuído
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Equal(t, "papel", erros[0].Campo)
+	})
+
+	t.Run("DesativacaoRevogaSessao", func(t *testing.T) {
+		usuario := convidar(t, "desativado@teste.com", models.PapelCaixa)
+		resposta, err := autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "desativado@teste.com", Senha: "senha456"}, ipTeste)
+		assert.NoError(t, err)
+		claims, err := autenticacaoService.ValidarToken(resposta.Token)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		_, err = uc.Desativar(estabelecimento.ID, models.PapelProprietario, usuario.ID)
+		assert.NoError(t, err)
+
+		// Verificar resultados: o token e o refresh token deixam de valer e o login é recusado
+		_, err = autenticacaoUseCase.VerificarSessao(context.Background(), estabelecimento.ID, usuario.ID, claims["jti"].(string), time.Now())
+		assert.ErrorIs(t, err, services.ErrContaDesativada)
+
+		_, err = autenticacaoUseCase.Renovar(models.RefreshTokenRequest{RefreshToken: resposta.RefreshToken})
+		assert.ErrorIs(t, err, services.ErrContaDesativada)
+
+		_, err = autenticacaoUseCase.Login(context.Background(), models.LoginRequest{Email: "desativado@teste.com", Senha: "senha456"}, ipTeste)
+		assert.ErrorIs(t, err, services.ErrContaDesativada)
+	})
+
+	t.Run("UsuarioDeOutroEstabelecimento", func(t *testing.T) {
+		usuario := convidar(t, "alheio@teste.com", models.PapelCaixa)
+
+		// Executar o método a ser testado
+		_, err := uc.Desativar("outro-estabelecimento", models.PapelProprietario, usuario.ID)
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, repositories.ErrUsuarioNaoEncontrado)
+	})
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Alvos dos bloqueios de login por excesso de tentativas
+const (
+	// BloqueioPorEmail bloqueia o login de um email, de qualquer IP
+	BloqueioPorEmail = "email"
+
+	// BloqueioPorIP bloqueia o login de qualquer email a partir de um IP
+	BloqueioPorIP = "ip"
+)
+
+// BloqueioLogin registra, para auditoria, um bloqueio temporário do login por excesso de
+// tentativas com senha incorreta
+type BloqueioLogin struct {
+	ID string
+	// Tipo é BloqueioPorEmail ou BloqueioPorIP
+	Tipo string
+	// Identificador é o email ou o IP bloqueado
+	Identificador string
+	// IP é o IP da tentativa que causou o bloqueio
+	IP           string
+	Tentativas   int64
+	BloqueadoAte time.Time
+	CriadoEm     time.Time
+}
@@ -0,0 +1,134 @@
+package models
+
+// DecodeRequest representa os dados de entrada para leitura de um BR Code
+// swagger:model
+type DecodeRequest struct {
+	// Código PIX "copia e cola" a ser decodificado
+	// required: true
+	// example: 00020101021126580014BR.GOV.BCB.PIX0136josesilva@email.com5204000053039865802BR5913JOSE DA SILVA6009SAO PAULO62070503***6304ABCD
+	CodigoPix string `json:"codigo_pix" binding:"required"`
+}
+
+// BRCodeCampo representa um campo TLV lido de um BR Code
+// swagger:model
+type BRCodeCampo struct {
+	// Identificador do campo
+	// example: 26
+	ID string `json:"id"`
+
+	// Nome do campo segundo o padrão EMV/BR Code
+	// example: Merchant Account Information
+	Nome string `json:"nome"`
+
+	// Tamanho declarado do valor
+	// example: 58
+	Tamanho int `json:"tamanho"`
+
+	// Valor bruto do campo
+	// example: 0014BR.GOV.BCB.PIX0136josesilva@email.com
+	Valor string `json:"valor"`
+
+	// Sub-campos, para os campos que são templates (26, 62, 64, 80-99)
+	Subcampos []BRCodeCampo `json:"subcampos,omitempty"`
+}
+
+// BRCodeErro representa um problema encontrado em um campo do BR Code
+// swagger:model
+type BRCodeErro struct {
+	// Caminho do campo com problema (ex: 26.01)
+	// example: 63
+	Campo string `json:"campo"`
+
+	// Descrição do problema
+	// example: CRC inválido: esperado 1D3D, encontrado ABCD
+	Mensagem string `json:"mensagem"`
+}
+
+// BRCodeDecodificado representa o resultado da leitura de um BR Code
+// swagger:model
+type BRCodeDecodificado struct {
+	// Indica se o BR Code está íntegro e contém todos os campos obrigatórios
+	// example: true
+	Valido bool `json:"valido"`
+
+	// Tipo do PIX (ESTATICO ou DINAMICO)
+	// example: ESTATICO
+	Tipo string `json:"tipo,omitempty"`
+
+	// Payload Format Indicator (00)
+	// example: 01
+	PayloadFormatIndicator string `json:"payload_format_indicator,omitempty"`
+
+	// Point of Initiation Method (01)
+	// example: 11
+	PontoIniciacao string `json:"ponto_iniciacao,omitempty"`
+
+	// GUI do arranjo de pagamento (26.00)
+	// example: BR.GOV.BCB.PIX
+	GUI string `json:"gui,omitempty"`
+
+	// Chave PIX do recebedor (26.01)
+	// example: josesilva@email.com
+	Chave string `json:"chave,omitempty"`
+
+	// Informação adicional do recebedor (26.02)
+	InfoAdicional string `json:"info_adicional,omitempty"`
+
+	// URL do payload da cobrança, para PIX dinâmico (26.25)
+	// example: pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25
+	Location string `json:"location,omitempty"`
+
+	// Merchant Category Code (52)
+	// example: 0000
+	MCC string `json:"mcc,omitempty"`
+
+	// Código da moeda (53)
+	// example: 986
+	Moeda string `json:"moeda,omitempty"`
+
+	// Valor da transação (54)
+	// example: 100.50
+	Valor string `json:"valor,omitempty"`
+
+	// Código do país (58)
+	// example: BR
+	Pais string `json:"pais,omitempty"`
+
+	// Nome do recebedor (59)
+	// example: JOSE DA SILVA
+	Nome string `json:"nome,omitempty"`
+
+	// Cidade do recebedor (60)
+	// example: SAO PAULO
+	Cidade string `json:"cidade,omitempty"`
+
+	// CEP do recebedor (61)
+	// example: 01310100
+	CEP string `json:"cep,omitempty"`
+
+	// Identificador da transação (62.05)
+	// example: FATURA123
+	Txid string `json:"txid,omitempty"`
+
+	// Modalidade do PIX Saque ou PIX Troco (62.50.01)
+	// example: TROCO
+	Modalidade string `json:"modalidade,omitempty"`
+
+	// CRC informado no BR Code (63)
+	// example: 1D3D
+	CRC string `json:"crc,omitempty"`
+
+	// CRC calculado sobre o payload
+	// example: 1D3D
+	CRCCalculado string `json:"crc_calculado,omitempty"`
+
+	// Indica se o CRC informado confere com o calculado
+	// example: true
+	CRCValido bool `json:"crc_valido"`
+
+	// Árvore completa de campos lidos
+	Campos []BRCodeCampo `json:"campos"`
+
+	// Problemas encontrados durante a leitura
+	Erros []BRCodeErro `json:"erros,omitempty"`
+}
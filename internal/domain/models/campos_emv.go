@@ -0,0 +1,50 @@
+package models
+
+// IdiomaAlternativoRequest representa o Merchant Information - Language Template (campo 64),
+// com o nome e a cidade do beneficiário em um idioma alternativo
+// swagger:model
+type IdiomaAlternativoRequest struct {
+	// Idioma no padrão ISO 639-1 (2 letras)
+	// required: true
+	// example: EN
+	Idioma string `json:"idioma"`
+
+	// Nome do beneficiário no idioma alternativo (até 25 caracteres)
+	// required: true
+	// example: JOSEPH SILVA
+	Nome string `json:"nome"`
+
+	// Cidade do beneficiário no idioma alternativo (até 15 caracteres, opcional)
+	// example: SAINT PAUL
+	Cidade string `json:"cidade,omitempty"`
+}
+
+// TemplateNaoReservadoRequest representa um template de uso livre (campos 80 a 99),
+// usado para anexar dados de parceiros ao BR Code
+// swagger:model
+type TemplateNaoReservadoRequest struct {
+	// ID do template, de 80 a 99
+	// required: true
+	// example: 80
+	ID string `json:"id"`
+
+	// Identificador único do parceiro, gravado no sub-campo 00 (até 32 caracteres)
+	// required: true
+	// example: BR.COM.PARCEIRO
+	GUI string `json:"gui"`
+
+	// Sub-campos com os dados do parceiro, de 01 a 99
+	Subcampos []SubcampoEMVRequest `json:"subcampos,omitempty"`
+}
+
+// SubcampoEMVRequest representa um sub-campo de um template do BR Code
+// swagger:model
+type SubcampoEMVRequest struct {
+	// ID do sub-campo com 2 dígitos
+	// example: 01
+	ID string `json:"id"`
+
+	// Conteúdo do sub-campo
+	// example: LOJA123
+	Valor string `json:"valor"`
+}
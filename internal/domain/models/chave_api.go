@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+// Escopos de acesso das chaves de API, no formato das APIs PIX do Banco Central
+const (
+	EscopoPixLeitura    = "pix.read"
+	EscopoPixEscrita    = "pix.write"
+	EscopoCobLeitura    = "cob.read"
+	EscopoCobEscrita    = "cob.write"
+	EscopoPerfilLeitura = "perfil.read"
+	EscopoPerfilEscrita = "perfil.write"
+)
+
+// EscoposValidos lista os escopos que podem ser concedidos a uma chave de API
+var EscoposValidos = []string{
+	EscopoPixLeitura,
+	EscopoPixEscrita,
+	EscopoCobLeitura,
+	EscopoCobEscrita,
+	EscopoPerfilLeitura,
+	EscopoPerfilEscrita,
+}
+
+// EscopoValido informa se o escopo pode ser concedido a uma chave de API
+func EscopoValido(escopo string) bool {
+	for _, valido := range EscoposValidos {
+		if escopo == valido {
+			return true
+		}
+	}
+	return false
+}
+
+// ChaveAPI representa uma chave de API de um estabelecimento, usada por integrações
+// servidor a servidor. Apenas o hash da chave é armazenado.
+type ChaveAPI struct {
+	// ID da chave de API
+	// example: 9b2f6c1e-4a7d-4e8b-9c3f-2d1e0a5b7c6d
+	ID string `json:"id"`
+
+	EstabelecimentoID string `json:"-"`
+
+	// Nome que identifica a integração
+	// example: ERP
+	Nome string `json:"nome"`
+
+	// Início da chave, para identificá-la sem expô-la
+	// example: pixk_3q2-7wS
+	Prefixo string `json:"prefixo"`
+
+	Hash string `json:"-"`
+
+	// Escopos concedidos; vazio concede todos
+	// example: ["pix.write","pix.read"]
+	Escopos []string `json:"escopos"`
+
+	// Expiração da chave (opcional)
+	ExpiraEm *time.Time `json:"expira_em,omitempty"`
+
+	// Último uso da chave, atualizado no máximo uma vez por minuto
+	UltimoUsoEm *time.Time `json:"ultimo_uso_em,omitempty"`
+
+	// Revogação da chave
+	RevogadaEm *time.Time `json:"revogada_em,omitempty"`
+
+	// Data de criação
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// PermiteEscopo informa se a chave concede o escopo; chaves sem escopos concedem todos
+func (c ChaveAPI) PermiteEscopo(escopo string) bool {
+	return EscopoConcedido(c.Escopos, escopo)
+}
+
+// EscopoConcedido informa se a lista de escopos de uma credencial concede o escopo; uma
+// lista vazia concede todos
+func EscopoConcedido(escopos []string, escopo string) bool {
+	if len(escopos) == 0 {
+		return true
+	}
+
+	for _, concedido := range escopos {
+		if concedido == escopo {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CriarChaveAPIRequest representa os dados de entrada para criar uma chave de API
+// swagger:model
+type CriarChaveAPIRequest struct {
+	// Nome que identifica a integração
+	// required: true
+	// example: ERP
+	Nome string `json:"nome" binding:"required"`
+
+	// Escopos concedidos (opcional); omitidos, a chave acessa todas as rotas de PIX, cobranças e perfil
+	// example: ["pix.write","pix.read"]
+	Escopos []string `json:"escopos,omitempty"`
+
+	// Expiração da chave (opcional)
+	// example: 2026-12-31T23:59:59Z
+	ExpiraEm *time.Time `json:"expira_em,omitempty"`
+}
+
+// ChaveAPICriada representa a resposta da criação de uma chave de API, a única que traz a chave
+// swagger:model
+type ChaveAPICriada struct {
+	ChaveAPI
+
+	// Chave de API, exibida apenas uma vez; envie-a no header X-API-Key
+	// example: pixk_3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	Chave string `json:"chave"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// Tipos de chave PIX reconhecidos pelo DICT
+const (
+	TipoChaveCPF      = "CPF"
+	TipoChaveCNPJ     = "CNPJ"
+	TipoChaveTelefone = "TELEFONE"
+	TipoChaveEmail    = "EMAIL"
+	TipoChaveEVP      = "EVP"
+)
+
+// ChavePix representa uma chave PIX já classificada e normalizada
+type ChavePix struct {
+	// Tipo da chave (CPF, CNPJ, TELEFONE, EMAIL ou EVP)
+	// example: EMAIL
+	Tipo string `json:"tipo"`
+
+	// Valor normalizado da chave, no formato aceito pelo DICT
+	// example: josesilva@email.com
+	Valor string `json:"valor"`
+}
@@ -0,0 +1,164 @@
+package models
+
+import "time"
+
+// Erros do endpoint de token e da introspecção, definidos pela RFC 6749 (seção 5.2)
+const (
+	ErroOAuthRequisicaoInvalida = "invalid_request"
+	ErroOAuthClienteInvalido    = "invalid_client"
+	ErroOAuthGrantNaoSuportado  = "unsupported_grant_type"
+	ErroOAuthEscopoInvalido     = "invalid_scope"
+	ErroOAuthServidor           = "server_error"
+)
+
+// GrantClientCredentials é o único grant aceito pelo endpoint de token
+const GrantClientCredentials = "client_credentials"
+
+// ClienteOAuth representa um cliente OAuth2 de um estabelecimento, usado por plataformas
+// parceiras para obter tokens de acesso com o grant client_credentials. Apenas o hash do
+// segredo é armazenado.
+type ClienteOAuth struct {
+	// Identificador do cliente (client_id)
+	// example: 4f1c2b7e-8d3a-4e5f-9a6b-1c2d3e4f5a6b
+	ID string `json:"client_id"`
+
+	EstabelecimentoID string `json:"-"`
+
+	// Nome que identifica a plataforma parceira
+	// example: Plataforma de pedidos
+	Nome string `json:"nome"`
+
+	SegredoHash string `json:"-"`
+
+	// Escopos que o cliente pode solicitar; vazio permite todos
+	// example: ["pix.write","pix.read"]
+	Escopos []string `json:"escopos"`
+
+	// Emissão do último token de acesso
+	UltimoUsoEm *time.Time `json:"ultimo_uso_em,omitempty"`
+
+	// Revogação do cliente
+	RevogadoEm *time.Time `json:"revogado_em,omitempty"`
+
+	// Data de criação
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// EscoposConcedidos retorna os escopos que o cliente pode solicitar; um cliente sem escopos
+// pode solicitar todos
+func (c ClienteOAuth) EscoposConcedidos() []string {
+	if len(c.Escopos) == 0 {
+		return EscoposValidos
+	}
+	return c.Escopos
+}
+
+// CriarClienteOAuthRequest representa os dados de entrada para criar um cliente OAuth2
+// swagger:model
+type CriarClienteOAuthRequest struct {
+	// Nome que identifica a plataforma parceira
+	// required: true
+	// example: Plataforma de pedidos
+	Nome string `json:"nome" binding:"required"`
+
+	// Escopos que o cliente pode solicitar (opcional); omitidos, o cliente pode solicitar todos
+	// example: ["pix.write","pix.read"]
+	Escopos []string `json:"escopos,omitempty"`
+}
+
+// ClienteOAuthCriado representa a resposta da criação de um cliente OAuth2, a única que traz o segredo
+// swagger:model
+type ClienteOAuthCriado struct {
+	ClienteOAuth
+
+	// Segredo do cliente (client_secret), exibido apenas uma vez
+	// example: pixs_Vb3kR9xQ1mZtY7wLc2NfHs8aJdPeUo4iGqK6rT0yXvA
+	Segredo string `json:"client_secret"`
+}
+
+// TokenOAuthRequest representa o corpo form-urlencoded do endpoint de token. As credenciais
+// do cliente também podem ser enviadas no header Authorization (HTTP Basic).
+type TokenOAuthRequest struct {
+	GrantType    string `form:"grant_type"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// TokenOAuthResponse representa o token de acesso emitido pelo endpoint de token (RFC 6749)
+// swagger:model
+type TokenOAuthResponse struct {
+	// Token de acesso JWT, enviado no header Authorization: Bearer
+	// example: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
+	AccessToken string `json:"access_token"`
+
+	// Tipo do token
+	// example: Bearer
+	TokenType string `json:"token_type"`
+
+	// Validade do token em segundos
+	// example: 900
+	ExpiresIn int64 `json:"expires_in"`
+
+	// Escopos concedidos, separados por espaço
+	// example: pix.read pix.write
+	Scope string `json:"scope"`
+}
+
+// ErroOAuthResponse representa um erro do endpoint de token ou da introspecção (RFC 6749)
+// swagger:model
+type ErroOAuthResponse struct {
+	// Código do erro
+	// example: invalid_client
+	Error string `json:"error"`
+
+	// Descrição do erro
+	// example: cliente desconhecido, revogado ou segredo incorreto
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// IntrospeccaoOAuthRequest representa o corpo form-urlencoded da introspecção de um token. As
+// credenciais do cliente também podem ser enviadas no header Authorization (HTTP Basic).
+type IntrospeccaoOAuthRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// IntrospeccaoOAuthResponse representa o resultado da introspecção de um token (RFC 7662).
+// Um token inválido, expirado, revogado ou de outro estabelecimento traz apenas active false.
+// swagger:model
+type IntrospeccaoOAuthResponse struct {
+	// Indica se o token dá acesso à API
+	// example: true
+	Active bool `json:"active"`
+
+	// Escopos do token, separados por espaço
+	// example: pix.read pix.write
+	Scope string `json:"scope,omitempty"`
+
+	// Cliente para o qual o token foi emitido
+	// example: 4f1c2b7e-8d3a-4e5f-9a6b-1c2d3e4f5a6b
+	ClientID string `json:"client_id,omitempty"`
+
+	// Tipo do token
+	// example: Bearer
+	TokenType string `json:"token_type,omitempty"`
+
+	// Estabelecimento dono dos recursos acessados com o token
+	// example: 123e4567-e89b-12d3-a456-426614174000
+	Sub string `json:"sub,omitempty"`
+
+	// Expiração do token (Unix)
+	// example: 1767225600
+	Exp int64 `json:"exp,omitempty"`
+
+	// Emissão do token (Unix)
+	// example: 1767224700
+	Iat int64 `json:"iat,omitempty"`
+
+	// Identificador do token
+	// example: 0b6f3c2a-9d8e-4f7a-b1c2-3d4e5f6a7b8c
+	Jti string `json:"jti,omitempty"`
+}
@@ -0,0 +1,222 @@
+package models
+
+import "time"
+
+// FormatoData é o formato das datas da cobrança com vencimento (AAAA-MM-DD)
+const FormatoData = "2006-01-02"
+
+// Modalidades de juros da cobrança com vencimento, aplicados sobre os dias corridos de atraso
+const (
+	// JurosSimplesDia aplica o percentual ao dia de forma simples
+	JurosSimplesDia = "SIMPLES_DIA"
+
+	// JurosSimplesMes aplica o percentual ao mês de forma simples, proporcional aos dias (mês de 30 dias)
+	JurosSimplesMes = "SIMPLES_MES"
+
+	// JurosCompostoDia capitaliza o percentual a cada dia de atraso
+	JurosCompostoDia = "COMPOSTO_DIA"
+
+	// JurosCompostoMes capitaliza o percentual a cada 30 dias, com a fração do mês proporcional
+	JurosCompostoMes = "COMPOSTO_MES"
+)
+
+// Modalidades de multa, desconto e abatimento
+const (
+	// ModalidadeValorFixo indica um valor em reais
+	ModalidadeValorFixo = "VALOR_FIXO"
+
+	// ModalidadePercentual indica um percentual sobre o valor da cobrança
+	ModalidadePercentual = "PERCENTUAL"
+)
+
+// JurosCobV define os juros cobrados por dia de atraso
+// swagger:model
+type JurosCobV struct {
+	// Modalidade: SIMPLES_DIA, SIMPLES_MES, COMPOSTO_DIA ou COMPOSTO_MES
+	// required: true
+	// example: SIMPLES_MES
+	Modalidade string `json:"modalidade"`
+
+	// Taxa percentual, com até duas casas decimais
+	// required: true
+	// example: 1.00
+	ValorPerc Dinheiro `json:"valor_perc" swaggertype:"number"`
+}
+
+// MultaCobV define a multa cobrada uma única vez após o vencimento
+// swagger:model
+type MultaCobV struct {
+	// Modalidade: VALOR_FIXO ou PERCENTUAL
+	// required: true
+	// example: PERCENTUAL
+	Modalidade string `json:"modalidade"`
+
+	// Valor em reais ou percentual, conforme a modalidade
+	// required: true
+	// example: 2.00
+	ValorPerc Dinheiro `json:"valor_perc" swaggertype:"number"`
+}
+
+// DescontoCobV define um desconto concedido para pagamentos até uma data
+// swagger:model
+type DescontoCobV struct {
+	// Último dia em que o desconto é concedido (AAAA-MM-DD), até o vencimento
+	// required: true
+	// example: 2025-01-20
+	Data string `json:"data"`
+
+	// Modalidade: VALOR_FIXO ou PERCENTUAL
+	// required: true
+	// example: PERCENTUAL
+	Modalidade string `json:"modalidade"`
+
+	// Valor em reais ou percentual, conforme a modalidade
+	// required: true
+	// example: 5.00
+	ValorPerc Dinheiro `json:"valor_perc" swaggertype:"number"`
+}
+
+// AbatimentoCobV define um abatimento concedido em qualquer data de pagamento
+// swagger:model
+type AbatimentoCobV struct {
+	// Modalidade: VALOR_FIXO ou PERCENTUAL
+	// required: true
+	// example: VALOR_FIXO
+	Modalidade string `json:"modalidade"`
+
+	// Valor em reais ou percentual, conforme a modalidade
+	// required: true
+	// example: 10.00
+	ValorPerc Dinheiro `json:"valor_perc" swaggertype:"number"`
+}
+
+// CobV representa uma cobrança com vencimento e as regras de cálculo do valor a pagar
+type CobV struct {
+	ID                     uint            `json:"id"`
+	PixID                  uint            `json:"pix_id"`
+	Txid                   string          `json:"txid"`
+	ValorOriginal          Dinheiro        `json:"valor_original" swaggertype:"number"`
+	Vencimento             time.Time       `json:"vencimento"`
+	ValidadeAposVencimento int             `json:"validade_apos_vencimento"`
+	Juros                  *JurosCobV      `json:"juros,omitempty"`
+	Multa                  *MultaCobV      `json:"multa,omitempty"`
+	Descontos              []DescontoCobV  `json:"descontos,omitempty"`
+	Abatimento             *AbatimentoCobV `json:"abatimento,omitempty"`
+	CriadoEm               time.Time       `json:"criado_em"`
+}
+
+// CobVRequest representa os dados de entrada para geração de uma cobrança com vencimento
+// swagger:model
+type CobVRequest struct {
+	// Identificador da transação junto ao PSP (26 a 35 caracteres alfanuméricos)
+	// required: true
+	// example: 7978c0c97ea847e78e8849634473c1f1
+	Txid string `json:"txid" binding:"required,min=26,max=35,alphanum"`
+
+	// URL do payload da cobrança no PSP, sem o prefixo https://
+	// required: true
+	// example: pix.example.com/qr/v2/cobv/9d36b84fc70b478fb95c12729b90ca25
+	Location string `json:"location" binding:"required"`
+
+	// Nome do beneficiário do PIX (obrigatório)
+	// required: true
+	// example: JOSE DA SILVA
+	Nome string `json:"nome" binding:"required"`
+
+	// Chave PIX à qual a cobrança está vinculada no PSP (obrigatório)
+	// required: true
+	// example: josesilva@email.com
+	Chave string `json:"chave" binding:"required"`
+
+	// Cidade do beneficiário (obrigatório)
+	// required: true
+	// example: SAO PAULO
+	Cidade string `json:"cidade" binding:"required"`
+
+	// Valor original da cobrança, com no máximo duas casas decimais
+	// required: true
+	// example: 100.00
+	Valor *Dinheiro `json:"valor" binding:"required" swaggertype:"number"`
+
+	// Data de vencimento (AAAA-MM-DD)
+	// required: true
+	// example: 2025-01-31
+	Vencimento string `json:"vencimento" binding:"required"`
+
+	// Dias corridos após o vencimento em que a cobrança ainda pode ser paga (opcional, padrão 30)
+	// example: 30
+	ValidadeAposVencimento *int `json:"validade_apos_vencimento,omitempty" binding:"omitempty,min=0"`
+
+	// Juros por atraso (opcional)
+	Juros *JurosCobV `json:"juros,omitempty"`
+
+	// Multa por atraso (opcional)
+	Multa *MultaCobV `json:"multa,omitempty"`
+
+	// Descontos por antecipação, em faixas de data (opcional)
+	Descontos []DescontoCobV `json:"descontos,omitempty"`
+
+	// Abatimento concedido em qualquer data de pagamento (opcional)
+	Abatimento *AbatimentoCobV `json:"abatimento,omitempty"`
+
+	// Opções de renderização do QR Code (opcional)
+	QRCode *QRCodeRequest `json:"qrcode,omitempty"`
+}
+
+// ValoresCobV detalha o cálculo do valor a pagar de uma cobrança com vencimento em uma data
+// swagger:model
+type ValoresCobV struct {
+	// Data de pagamento considerada no cálculo (AAAA-MM-DD)
+	// example: 2025-02-10
+	DataPagamento string `json:"data_pagamento"`
+
+	// Dias corridos de atraso em relação ao vencimento
+	// example: 10
+	DiasAtraso int `json:"dias_atraso"`
+
+	// Valor original da cobrança
+	// example: 100.00
+	Original Dinheiro `json:"original" swaggertype:"number"`
+
+	// Abatimento concedido
+	// example: 0.00
+	Abatimento Dinheiro `json:"abatimento" swaggertype:"number"`
+
+	// Desconto por antecipação concedido
+	// example: 0.00
+	Desconto Dinheiro `json:"desconto" swaggertype:"number"`
+
+	// Juros por atraso
+	// example: 0.33
+	Juros Dinheiro `json:"juros" swaggertype:"number"`
+
+	// Multa por atraso
+	// example: 2.00
+	Multa Dinheiro `json:"multa" swaggertype:"number"`
+
+	// Valor a pagar na data
+	// example: 102.33
+	Final Dinheiro `json:"final" swaggertype:"number"`
+}
+
+// CobVResponse representa uma cobrança com vencimento e o valor a pagar em uma data
+// swagger:model
+type CobVResponse struct {
+	// Identificador da transação junto ao PSP
+	// example: 7978c0c97ea847e78e8849634473c1f1
+	Txid string `json:"txid"`
+
+	// Data de vencimento (AAAA-MM-DD)
+	// example: 2025-01-31
+	Vencimento string `json:"vencimento"`
+
+	// Último dia em que a cobrança pode ser paga (AAAA-MM-DD)
+	// example: 2025-03-02
+	PagavelAte string `json:"pagavel_ate"`
+
+	// Valor a pagar na data consultada
+	Valores ValoresCobV `json:"valores"`
+
+	// Código PIX e QR Codes da cobrança
+	Pix PixResponse `json:"pix"`
+}
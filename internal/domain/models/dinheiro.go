@@ -0,0 +1,157 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dinheiro representa um valor monetário em centavos. Usar inteiros em vez de float64
+// garante que os totais de conciliação sempre conferem até o último centavo.
+type Dinheiro int64
+
+// DinheiroMaximo é o maior valor aceito, limitado pela coluna DECIMAL(10,2) do banco
+const DinheiroMaximo Dinheiro = 9999999999
+
+var (
+	// ErrValorFormato indica que o valor não é um número decimal simples
+	ErrValorFormato = errors.New("valor deve ser um número decimal, como 100.50")
+
+	// ErrValorCasasDecimais indica que o valor tem mais de duas casas decimais
+	ErrValorCasasDecimais = errors.New("valor deve ter no máximo duas casas decimais")
+
+	// ErrValorNegativo indica que o valor é negativo
+	ErrValorNegativo = errors.New("valor não pode ser negativo")
+
+	// ErrValorLimite indica que o valor excede o máximo aceito
+	ErrValorLimite = errors.New("valor excede o máximo de 99999999.99")
+)
+
+// NovoDinheiro cria um valor monetário a partir de uma quantidade de centavos
+func NovoDinheiro(centavos int64) Dinheiro {
+	return Dinheiro(centavos)
+}
+
+// ParseDinheiro converte um decimal com até duas casas (ex: "100.5") em Dinheiro,
+// sem passar por ponto flutuante
+func ParseDinheiro(texto string) (Dinheiro, error) {
+	texto = strings.TrimSpace(texto)
+	if strings.HasPrefix(texto, "-") {
+		return 0, ErrValorNegativo
+	}
+
+	inteiro, fracao, temFracao := strings.Cut(texto, ".")
+	if inteiro == "" || !apenasDigitos(inteiro) || (temFracao && (fracao == "" || !apenasDigitos(fracao))) {
+		return 0, ErrValorFormato
+	}
+	if len(fracao) > 2 {
+		return 0, ErrValorCasasDecimais
+	}
+
+	// Descartar zeros à esquerda antes de verificar o limite, evitando overflow
+	inteiro = strings.TrimLeft(inteiro, "0")
+	if len(inteiro) > 8 {
+		return 0, ErrValorLimite
+	}
+
+	reais, _ := strconv.ParseInt("0"+inteiro, 10, 64)
+	centavos, _ := strconv.ParseInt((fracao + "00")[:2], 10, 64)
+
+	valor := Dinheiro(reais*100 + centavos)
+	if valor > DinheiroMaximo {
+		return 0, ErrValorLimite
+	}
+
+	return valor, nil
+}
+
+// Centavos retorna o valor em centavos
+func (d Dinheiro) Centavos() int64 {
+	return int64(d)
+}
+
+// String formata o valor com duas casas decimais e ponto, como exigido pelo campo 54 do BR Code
+func (d Dinheiro) String() string {
+	sinal := ""
+	centavos := int64(d)
+	if centavos < 0 {
+		sinal = "-"
+		centavos = -centavos
+	}
+	return fmt.Sprintf("%s%d.%02d", sinal, centavos/100, centavos%100)
+}
+
+// MarshalJSON serializa o valor como número com duas casas decimais
+func (d Dinheiro) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON aceita o valor como número ou string, rejeitando mais de duas casas
+// decimais, valores negativos e acima do limite
+func (d *Dinheiro) UnmarshalJSON(data []byte) error {
+	texto := string(data)
+	if strings.HasPrefix(texto, `"`) {
+		if err := json.Unmarshal(data, &texto); err != nil {
+			return ErrValorFormato
+		}
+	}
+
+	valor, err := ParseDinheiro(texto)
+	if err != nil {
+		return err
+	}
+
+	*d = valor
+	return nil
+}
+
+// Value implementa driver.Valuer, gravando o valor como decimal exato
+func (d Dinheiro) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implementa sql.Scanner, lendo colunas DECIMAL sem passar por ponto flutuante
+func (d *Dinheiro) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return d.scanTexto(string(v))
+	case string:
+		return d.scanTexto(v)
+	case int64:
+		*d = Dinheiro(v * 100)
+		return nil
+	default:
+		return fmt.Errorf("tipo não suportado para Dinheiro: %T", src)
+	}
+}
+
+// scanTexto converte o texto de uma coluna DECIMAL, que pode vir com zeros à direita
+func (d *Dinheiro) scanTexto(texto string) error {
+	if inteiro, fracao, ok := strings.Cut(texto, "."); ok && len(fracao) > 2 {
+		texto = inteiro
+		if fracao = strings.TrimRight(fracao, "0"); fracao != "" {
+			texto += "." + fracao
+		}
+	}
+
+	valor, err := ParseDinheiro(texto)
+	if err != nil {
+		return err
+	}
+
+	*d = valor
+	return nil
+}
+
+// apenasDigitos indica se a string contém apenas dígitos ASCII
+func apenasDigitos(texto string) bool {
+	for _, r := range texto {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDinheiro testa o tipo monetário de ponto fixo
+func TestDinheiro(t *testing.T) {
+	t.Run("ParseValido", func(t *testing.T) {
+		casos := map[string]int64{
+			"100":         10000,
+			"100.5":       10050,
+			"100.50":      10050,
+			"0.10":        10,
+			"0.29":        29,
+			"99999999.99": 9999999999,
+			"007.01":      701,
+		}
+
+		for texto, centavos := range casos {
+			valor, err := models.ParseDinheiro(texto)
+			assert.NoError(t, err, texto)
+			assert.Equal(t, centavos, valor.Centavos(), texto)
+		}
+	})
+
+	t.Run("ParseInvalido", func(t *testing.T) {
+		casos := map[string]error{
+			"100.505":        models.ErrValorCasasDecimais,
+			"-1.00":          models.ErrValorNegativo,
+			"100000000.00":   models.ErrValorLimite,
+			"1e2":            models.ErrValorFormato,
+			"100,50":         models.ErrValorFormato,
+			".50":            models.ErrValorFormato,
+			"":               models.ErrValorFormato,
+			"99999999999999": models.ErrValorLimite,
+		}
+
+		for texto, esperado := range casos {
+			_, err := models.ParseDinheiro(texto)
+			assert.ErrorIs(t, err, esperado, texto)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var req models.PixRequest
+
+		// Número e string são aceitos, sem arredondamento por ponto flutuante
+		err := json.Unmarshal([]byte(`{"valor": 0.29}`), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(29), req.Valor.Centavos())
+
+		err = json.Unmarshal([]byte(`{"valor": "1234.56"}`), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(123456), req.Valor.Centavos())
+
+		// Mais de duas casas decimais é rejeitado
+		err = json.Unmarshal([]byte(`{"valor": 10.001}`), &req)
+		assert.ErrorIs(t, err, models.ErrValorCasasDecimais)
+
+		// A serialização mantém sempre duas casas decimais
+		data, err := json.Marshal(models.Pix{Valor: req.Valor})
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"valor":1234.56`)
+	})
+
+	t.Run("Banco", func(t *testing.T) {
+		var valor models.Dinheiro
+
+		// Colunas DECIMAL chegam como texto
+		assert.NoError(t, valor.Scan([]byte("100.50")))
+		assert.Equal(t, "100.50", valor.String())
+
+		assert.NoError(t, valor.Scan("7.1000"))
+		assert.Equal(t, int64(710), valor.Centavos())
+
+		gravado, err := models.NovoDinheiro(10005).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "100.05", gravado)
+	})
+}
@@ -0,0 +1,158 @@
+package models
+
+import "time"
+
+// DoisFatores representa o segundo fator (TOTP, RFC 6238) de um login. O login do
+// estabelecimento usa o ID do estabelecimento como LoginID; o de um usuário da equipe, o ID do
+// usuário. O cadastro só passa a valer no login depois de confirmado com um código do aplicativo.
+type DoisFatores struct {
+	LoginID           string
+	EstabelecimentoID string
+	Segredo           string
+
+	// UltimoPasso é o passo de 30 segundos do último código aceito, que não pode ser reutilizado
+	UltimoPasso int64
+
+	// AtivadoEm é o instante da confirmação do cadastro; nulo enquanto ele está pendente
+	AtivadoEm *time.Time
+	CriadoEm  time.Time
+}
+
+// Ativo informa se o cadastro foi confirmado e o segundo fator é exigido no login
+func (d DoisFatores) Ativo() bool {
+	return d.AtivadoEm != nil
+}
+
+// DesafioDoisFatores é o login pendente do segundo fator, guardado no cache até a expiração
+// do desafio
+type DesafioDoisFatores struct {
+	EstabelecimentoID string `json:"estabelecimento_id"`
+	UsuarioID         string `json:"usuario_id,omitempty"`
+	Email             string `json:"email"`
+
+	// Cadastro indica que o login ainda não tem o segundo fator e o estabelecimento o exige
+	Cadastro bool `json:"cadastro"`
+}
+
+// LoginID retorna o ID do login do desafio: o do usuário da equipe ou o do estabelecimento
+func (d DesafioDoisFatores) LoginID() string {
+	if d.UsuarioID != "" {
+		return d.UsuarioID
+	}
+	return d.EstabelecimentoID
+}
+
+// DesafioDoisFatoresResponse representa a resposta do login com a senha correta quando o
+// segundo fator é exigido
+// swagger:model
+type DesafioDoisFatoresResponse struct {
+	// Desafio a ser enviado com o código em /login/dois-fatores; vale para um único login
+	// example: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA
+	Desafio string `json:"desafio"`
+
+	// Validade do desafio, em segundos
+	// example: 300
+	ExpiraEmSegundos int64 `json:"expira_em_segundos"`
+
+	// Indica que o estabelecimento exige o segundo fator e o login ainda não o cadastrou: o
+	// cadastro é iniciado em /login/dois-fatores/cadastro e confirmado com o primeiro código
+	// example: false
+	CadastroObrigatorio bool `json:"cadastro_obrigatorio"`
+}
+
+// LoginDoisFatoresRequest representa os dados de entrada para concluir o login com o segundo fator
+// swagger:model
+type LoginDoisFatoresRequest struct {
+	// Desafio retornado pelo login
+	// required: true
+	// example: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA
+	Desafio string `json:"desafio" binding:"required"`
+
+	// Código de 6 dígitos do aplicativo autenticador ou um código de recuperação
+	// required: true
+	// example: 123456
+	Codigo string `json:"codigo" binding:"required"`
+}
+
+// CadastroDesafioRequest representa os dados de entrada para iniciar, durante o login, o
+// cadastro do segundo fator exigido pelo estabelecimento
+// swagger:model
+type CadastroDesafioRequest struct {
+	// Desafio retornado pelo login, com cadastro_obrigatorio
+	// required: true
+	// example: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA
+	Desafio string `json:"desafio" binding:"required"`
+}
+
+// CadastroDoisFatoresResponse representa o segredo de um novo cadastro do segundo fator, a ser
+// adicionado ao aplicativo autenticador
+// swagger:model
+type CadastroDoisFatoresResponse struct {
+	// Segredo em base32, para digitação manual no aplicativo
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Segredo string `json:"segredo"`
+
+	// URI otpauth:// com o segredo, o emissor e a conta
+	// example: otpauth://totp/Gerador%20de%20PIX:contato@lojadojose.com.br?algorithm=SHA1&digits=6&issuer=Gerador+de+PIX&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	URI string `json:"uri"`
+
+	// QR code da URI, em PNG (base64), para leitura pelo aplicativo
+	// example: data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAA...
+	QRCode string `json:"qrcode"`
+}
+
+// CodigoDoisFatoresRequest representa um código do aplicativo autenticador
+// swagger:model
+type CodigoDoisFatoresRequest struct {
+	// Código de 6 dígitos do aplicativo autenticador
+	// required: true
+	// example: 123456
+	Codigo string `json:"codigo" binding:"required"`
+}
+
+// DesativarDoisFatoresRequest representa a confirmação para remover o segundo fator do login
+// swagger:model
+type DesativarDoisFatoresRequest struct {
+	// Senha atual do login
+	// required: true
+	// example: senha123
+	Senha string `json:"senha" binding:"required"`
+
+	// Código de 6 dígitos do aplicativo autenticador ou um código de recuperação
+	// required: true
+	// example: 123456
+	Codigo string `json:"codigo" binding:"required"`
+}
+
+// CodigosRecuperacaoResponse representa os códigos de recuperação, exibidos apenas uma vez
+// swagger:model
+type CodigosRecuperacaoResponse struct {
+	// Códigos de uso único que substituem o aplicativo autenticador no login
+	// example: ["k7m2q-x9p4t","a3b8c-d2e6f"]
+	CodigosRecuperacao []string `json:"codigos_recuperacao"`
+}
+
+// StatusDoisFatoresResponse representa a situação do segundo fator do login autenticado
+// swagger:model
+type StatusDoisFatoresResponse struct {
+	// Indica se o segundo fator está ativo no login
+	// example: true
+	Ativo bool `json:"ativo"`
+
+	// Indica se o estabelecimento exige o segundo fator de todos os logins
+	// example: false
+	Obrigatorio bool `json:"obrigatorio"`
+
+	// Códigos de recuperação ainda não usados
+	// example: 10
+	CodigosRecuperacaoRestantes int `json:"codigos_recuperacao_restantes"`
+}
+
+// PoliticaDoisFatoresRequest representa a exigência do segundo fator nos logins do estabelecimento
+// swagger:model
+type PoliticaDoisFatoresRequest struct {
+	// Exige o segundo fator no login do estabelecimento e dos usuários da equipe
+	// required: true
+	// example: true
+	Obrigatorio *bool `json:"obrigatorio" binding:"required"`
+}
@@ -10,8 +10,18 @@ type Estabelecimento struct {
 	Email        string    `json:"email"`
 	Senha        string    `json:"-"` // Nunca retornar a senha no JSON
 	Ativo        bool      `json:"ativo"`
+	Admin        bool      `json:"admin"`
 	CriadoEm     time.Time `json:"criado_em"`
 	AtualizadoEm time.Time `json:"atualizado_em"`
+
+	// EmailVerificadoEm é o instante da confirmação do email; nulo enquanto ele não é confirmado
+	EmailVerificadoEm *time.Time `json:"email_verificado_em,omitempty"`
+
+	// DoisFatoresObrigatorio exige o segundo fator no login do estabelecimento e dos usuários da equipe
+	DoisFatoresObrigatorio bool `json:"dois_fatores_obrigatorio"`
+
+	// TokensRevogadosEm invalida os tokens emitidos antes desse instante (definido na desativação)
+	TokensRevogadosEm *time.Time `json:"-"`
 }
 
 // EstabelecimentoRequest representa os dados de entrada para criação de um estabelecimento
@@ -61,6 +71,18 @@ type EstabelecimentoResponse struct {
 	// example: true
 	Ativo bool `json:"ativo"`
 
+	// Indica se o estabelecimento administra os demais
+	// example: false
+	Admin bool `json:"admin"`
+
+	// Data da confirmação do email, ausente enquanto ele não é confirmado
+	// example: 2023-01-01T12:30:00Z
+	EmailVerificadoEm *time.Time `json:"email_verificado_em,omitempty"`
+
+	// Indica se o segundo fator é exigido no login do estabelecimento e dos usuários da equipe
+	// example: false
+	DoisFatoresObrigatorio bool `json:"dois_fatores_obrigatorio"`
+
 	// Data de criação
 	// example: 2023-01-01T12:00:00Z
 	CriadoEm time.Time `json:"criado_em"`
@@ -70,15 +92,88 @@ type EstabelecimentoResponse struct {
 	AtualizadoEm time.Time `json:"atualizado_em"`
 }
 
+// NovoEstabelecimentoResponse monta a resposta de um estabelecimento sem expor a senha
+func NovoEstabelecimentoResponse(estabelecimento Estabelecimento) EstabelecimentoResponse {
+	return EstabelecimentoResponse{
+		ID:                     estabelecimento.ID,
+		Nome:                   estabelecimento.Nome,
+		Descricao:              estabelecimento.Descricao,
+		Email:                  estabelecimento.Email,
+		Ativo:                  estabelecimento.Ativo,
+		Admin:                  estabelecimento.Admin,
+		EmailVerificadoEm:      estabelecimento.EmailVerificadoEm,
+		DoisFatoresObrigatorio: estabelecimento.DoisFatoresObrigatorio,
+		CriadoEm:               estabelecimento.CriadoEm,
+		AtualizadoEm:           estabelecimento.AtualizadoEm,
+	}
+}
+
+// AtualizarEstabelecimentoRequest representa os dados de entrada para o estabelecimento
+// autenticado alterar o próprio cadastro
+// swagger:model
+type AtualizarEstabelecimentoRequest struct {
+	// Nome do estabelecimento
+	// required: true
+	// example: Loja do José
+	Nome string `json:"nome" binding:"required"`
+
+	// Descrição do estabelecimento (opcional; omitida, é removida)
+	// example: Loja de produtos diversos
+	Descricao *string `json:"descricao,omitempty"`
+
+	// Email do estabelecimento (usado para login); alterado, precisa ser confirmado novamente
+	// required: true
+	// example: contato@lojadojose.com.br
+	Email string `json:"email" binding:"required,email"`
+
+	// Senha atual, exigida para confirmar a alteração
+	// required: true
+	// example: senha123
+	SenhaAtual string `json:"senha_atual" binding:"required"`
+
+	// Nova senha (opcional, mínimo 6 caracteres); omitida, a senha atual é mantida
+	// example: novaSenha456
+	NovaSenha string `json:"nova_senha,omitempty" binding:"omitempty,min=6"`
+}
+
+// DesativarEstabelecimentoRequest representa a confirmação para o estabelecimento
+// autenticado desativar a própria conta
+// swagger:model
+type DesativarEstabelecimentoRequest struct {
+	// Senha atual, exigida para confirmar a desativação
+	// required: true
+	// example: senha123
+	SenhaAtual string `json:"senha_atual" binding:"required"`
+}
+
+// SessaoEstabelecimento reúne os dados do estabelecimento conferidos a cada requisição autenticada
+type SessaoEstabelecimento struct {
+	Ativo             bool
+	Admin             bool
+	Papel             string
+	TokensRevogadosEm *time.Time
+}
+
+// Sessao retorna os dados do estabelecimento conferidos a cada requisição autenticada com o
+// login do estabelecimento, que tem o papel de proprietário
+func (e Estabelecimento) Sessao() SessaoEstabelecimento {
+	return SessaoEstabelecimento{
+		Ativo:             e.Ativo,
+		Admin:             e.Admin,
+		Papel:             PapelProprietario,
+		TokensRevogadosEm: e.TokensRevogadosEm,
+	}
+}
+
 // LoginRequest representa os dados de entrada para login
 // swagger:model
 type LoginRequest struct {
-	// Email do estabelecimento
+	// Email do estabelecimento ou de um usuário da equipe
 	// required: true
 	// example: contato@lojadojose.com.br
 	Email string `json:"email" binding:"required,email"`
 
-	// Senha do estabelecimento
+	// Senha do estabelecimento ou do usuário
 	// required: true
 	// example: senha123
 	Senha string `json:"senha" binding:"required"`
@@ -87,10 +182,33 @@ type LoginRequest struct {
 // LoginResponse representa a resposta após o login bem-sucedido
 // swagger:model
 type LoginResponse struct {
-	// Token JWT para autenticação nas rotas protegidas
+	// Token JWT de curta duração para autenticação nas rotas protegidas
 	// example: eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...
 	Token string `json:"token"`
 
+	// Validade do token JWT, em segundos
+	// example: 900
+	ExpiraEmSegundos int64 `json:"expira_em_segundos"`
+
+	// Refresh token para obter um novo token JWT em /token/refresh; é trocado a cada uso
+	// example: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	RefreshToken string `json:"refresh_token"`
+
+	// Papel de quem fez login: proprietario, no login do estabelecimento, ou o papel do usuário da equipe
+	// example: proprietario
+	Papel string `json:"papel"`
+
 	// Informações do estabelecimento autenticado
 	Estabelecimento EstabelecimentoResponse `json:"estabelecimento"`
+
+	// Informações do usuário da equipe, ausentes no login do estabelecimento
+	Usuario *Usuario `json:"usuario,omitempty"`
+
+	// Códigos de recuperação do segundo fator cadastrado durante o login, exibidos apenas nesta resposta
+	// example: ["k7m2q-x9p4t","a3b8c-d2e6f"]
+	CodigosRecuperacao []string `json:"codigos_recuperacao,omitempty"`
+
+	// DoisFatores é o desafio do login com a senha correta que ainda exige o segundo fator; nesse
+	// caso, os tokens não são emitidos
+	DoisFatores *DesafioDoisFatoresResponse `json:"-"`
 }
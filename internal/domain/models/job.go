@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// TipoJobLote identifica um job de geração de PIX em lote
+const TipoJobLote = "GERACAO_LOTE"
+
+// Situações de um job
+const (
+	// StatusJobPendente indica que o job aguarda na fila
+	StatusJobPendente = "PENDENTE"
+
+	// StatusJobProcessando indica que o job está sendo executado por um worker
+	StatusJobProcessando = "PROCESSANDO"
+
+	// StatusJobConcluido indica que o job terminou e o resultado está disponível
+	StatusJobConcluido = "CONCLUIDO"
+
+	// StatusJobFalhou indica que o job foi interrompido por um erro
+	StatusJobFalhou = "FALHOU"
+
+	// StatusJobCancelado indica que o job foi cancelado antes de terminar
+	StatusJobCancelado = "CANCELADO"
+)
+
+// Job representa uma tarefa executada em segundo plano pelos workers da API
+type Job struct {
+	ID                string     `json:"id"`
+	EstabelecimentoID string     `json:"estabelecimento_id"`
+	Tipo              string     `json:"tipo"`
+	Status            string     `json:"status"`
+	Formato           string     `json:"formato"`
+	Template          string     `json:"template,omitempty"`
+	Total             int        `json:"total"`
+	Processados       int        `json:"processados"`
+	Sucessos          int        `json:"sucessos"`
+	Falhas            int        `json:"falhas"`
+	Erro              string     `json:"erro,omitempty"`
+	Entrada           []byte     `json:"-"`
+	Resultado         []byte     `json:"-"`
+	ResultadoTipo     string     `json:"-"`
+	CriadoEm          time.Time  `json:"criado_em"`
+	IniciadoEm        *time.Time `json:"iniciado_em,omitempty"`
+	ConcluidoEm       *time.Time `json:"concluido_em,omitempty"`
+}
+
+// Finalizado indica se o job já terminou, com ou sem sucesso
+func (j Job) Finalizado() bool {
+	return j.Status == StatusJobConcluido || j.Status == StatusJobFalhou || j.Status == StatusJobCancelado
+}
+
+// JobResponse representa a situação de um job retornada pela API
+// swagger:model
+type JobResponse struct {
+	// Identificador do job
+	// example: 3f1c2a9e-8b7d-4e6f-9a1b-2c3d4e5f6a7b
+	ID string `json:"id"`
+
+	// Tipo do job
+	// example: GERACAO_LOTE
+	Tipo string `json:"tipo"`
+
+	// Situação: PENDENTE, PROCESSANDO, CONCLUIDO, FALHOU ou CANCELADO
+	// example: PROCESSANDO
+	Status string `json:"status"`
+
+	// Formato do resultado: json ou zip
+	// example: zip
+	Formato string `json:"formato"`
+
+	// Template aplicado às imagens do ZIP
+	// example: template_pix_1
+	Template string `json:"template,omitempty"`
+
+	// Total de linhas do lote
+	// example: 5000
+	Total int `json:"total"`
+
+	// Linhas já processadas
+	// example: 1200
+	Processados int `json:"processados"`
+
+	// Linhas geradas e salvas com sucesso
+	// example: 1195
+	Sucessos int `json:"sucessos"`
+
+	// Linhas com erro
+	// example: 5
+	Falhas int `json:"falhas"`
+
+	// Percentual de linhas processadas
+	// example: 24
+	Progresso int `json:"progresso"`
+
+	// Mensagem de erro, quando o job falhou
+	Erro string `json:"erro,omitempty"`
+
+	// Caminho para download do resultado, quando o job foi concluído
+	// example: /api/jobs/3f1c2a9e-8b7d-4e6f-9a1b-2c3d4e5f6a7b/result
+	ResultadoURL string `json:"resultado_url,omitempty"`
+
+	// Data de criação
+	CriadoEm time.Time `json:"criado_em"`
+
+	// Início do processamento
+	IniciadoEm *time.Time `json:"iniciado_em,omitempty"`
+
+	// Fim do processamento
+	ConcluidoEm *time.Time `json:"concluido_em,omitempty"`
+}
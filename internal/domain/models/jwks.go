@@ -0,0 +1,42 @@
+package models
+
+// JWK representa uma chave pública de verificação dos tokens JWT no formato JSON Web Key (RFC 7517)
+type JWK struct {
+	// Tipo da chave: RSA ou EC
+	// example: RSA
+	Kty string `json:"kty"`
+
+	// Uso da chave; sig indica assinatura
+	// example: sig
+	Use string `json:"use"`
+
+	// Algoritmo de assinatura
+	// example: RS256
+	Alg string `json:"alg"`
+
+	// Identificador da chave, enviado no header kid dos tokens
+	// example: 3t2Hk9xQ1m0pZ7vR
+	Kid string `json:"kid"`
+
+	// Módulo das chaves RSA
+	N string `json:"n,omitempty"`
+
+	// Expoente das chaves RSA
+	// example: AQAB
+	E string `json:"e,omitempty"`
+
+	// Curva das chaves EC
+	// example: P-256
+	Crv string `json:"crv,omitempty"`
+
+	// Coordenada x das chaves EC
+	X string `json:"x,omitempty"`
+
+	// Coordenada y das chaves EC
+	Y string `json:"y,omitempty"`
+}
+
+// JWKS representa o conjunto de chaves públicas publicado em /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
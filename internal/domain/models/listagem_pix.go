@@ -0,0 +1,186 @@
+package models
+
+import "time"
+
+// Situações de um PIX na listagem
+const (
+	// StatusPixAtivo indica um PIX sem expiração ou que ainda não expirou
+	StatusPixAtivo = "ATIVO"
+
+	// StatusPixExpirado indica um PIX cuja data de expiração já passou
+	StatusPixExpirado = "EXPIRADO"
+)
+
+// Campos de ordenação da listagem de PIX
+const (
+	// OrdenacaoPixCriadoEm ordena pela data de criação
+	OrdenacaoPixCriadoEm = "criado_em"
+
+	// OrdenacaoPixValor ordena pelo valor; PIX sem valor vêm antes na ordem crescente
+	OrdenacaoPixValor = "valor"
+)
+
+// Status retorna a situação do PIX no instante informado
+func (p Pix) Status(agora time.Time) string {
+	if p.ExpiraEm != nil && !p.ExpiraEm.After(agora) {
+		return StatusPixExpirado
+	}
+	return StatusPixAtivo
+}
+
+// ListarPixRequest representa os filtros da listagem de PIX, recebidos como parâmetros de consulta
+type ListarPixRequest struct {
+	// Data ou instante inicial da criação (AAAA-MM-DD ou RFC 3339)
+	CriadoDe string `form:"criado_de"`
+
+	// Data ou instante final da criação, inclusive (AAAA-MM-DD ou RFC 3339)
+	CriadoAte string `form:"criado_ate"`
+
+	// Valor mínimo
+	ValorMinimo string `form:"valor_min"`
+
+	// Valor máximo
+	ValorMaximo string `form:"valor_max"`
+
+	// Identificador da transação
+	Identificador string `form:"identificador"`
+
+	// Chave PIX do beneficiário
+	Chave string `form:"chave"`
+
+	// Situação: ATIVO ou EXPIRADO
+	Status string `form:"status"`
+
+	// Campo de ordenação: criado_em ou valor
+	Ordenar string `form:"ordenar"`
+
+	// Direção da ordenação: asc ou desc
+	Ordem string `form:"ordem"`
+
+	// Número de itens por página
+	Limite string `form:"limite"`
+
+	// Cursor da próxima página, retornado pela página anterior
+	Cursor string `form:"cursor"`
+}
+
+// CursorPix identifica o último PIX de uma página, a partir do qual a próxima página começa
+type CursorPix struct {
+	Ordenacao   string
+	Decrescente bool
+	ID          uint
+	CriadoEm    time.Time
+	Valor       *Dinheiro
+}
+
+// FiltroPix representa os filtros já validados da listagem de PIX de um estabelecimento
+type FiltroPix struct {
+	EstabelecimentoID string
+	CriadoDe          *time.Time
+	CriadoAte         *time.Time
+	ValorMinimo       *Dinheiro
+	ValorMaximo       *Dinheiro
+	Identificador     string
+	Chave             string
+	Status            string
+	Ordenacao         string
+	Decrescente       bool
+	Limite            int
+	Apos              *CursorPix
+	Agora             time.Time // Instante de referência para a situação ATIVO ou EXPIRADO
+}
+
+// PixResumo representa um PIX na listagem, sem as imagens do QR Code
+// swagger:model
+type PixResumo struct {
+	// ID do PIX
+	// example: 42
+	ID uint `json:"id"`
+
+	// Tipo: ESTATICO, DINAMICO ou COBV
+	// example: ESTATICO
+	Tipo string `json:"tipo"`
+
+	// Modalidade do PIX estático
+	// example: COMPRA
+	Modalidade string `json:"modalidade"`
+
+	// Nome do beneficiário
+	// example: JOSE DA SILVA
+	Nome string `json:"nome"`
+
+	// Chave PIX do beneficiário
+	// example: josesilva@email.com
+	Chave string `json:"chave"`
+
+	// Tipo da chave PIX
+	// example: EMAIL
+	TipoChave string `json:"tipo_chave,omitempty"`
+
+	// Cidade do beneficiário
+	// example: SAO PAULO
+	Cidade string `json:"cidade"`
+
+	// Valor da transação
+	// example: 100.50
+	Valor *Dinheiro `json:"valor,omitempty" swaggertype:"number"`
+
+	// Identificador da transação
+	// example: FATURA123
+	Identificador *string `json:"identificador,omitempty"`
+
+	// Descrição da transação
+	Descricao *string `json:"descricao,omitempty"`
+
+	// Identificador da cobrança
+	Txid *string `json:"txid,omitempty"`
+
+	// Data de expiração da cobrança
+	ExpiraEm *time.Time `json:"expira_em,omitempty"`
+
+	// Situação: ATIVO ou EXPIRADO
+	// example: ATIVO
+	Status string `json:"status"`
+
+	// Código PIX "copia e cola"
+	CodigoPix string `json:"codigo_pix"`
+
+	// Data de criação
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// NovoPixResumo cria o resumo de um PIX com a situação no instante informado
+func NovoPixResumo(pix Pix, agora time.Time) PixResumo {
+	return PixResumo{
+		ID:            pix.ID,
+		Tipo:          pix.Tipo,
+		Modalidade:    pix.Modalidade,
+		Nome:          pix.Nome,
+		Chave:         pix.Chave,
+		TipoChave:     pix.TipoChave,
+		Cidade:        pix.Cidade,
+		Valor:         pix.Valor,
+		Identificador: pix.Identificador,
+		Descricao:     pix.Descricao,
+		Txid:          pix.Txid,
+		ExpiraEm:      pix.ExpiraEm,
+		Status:        pix.Status(agora),
+		CodigoPix:     pix.CodigoPix,
+		CriadoEm:      pix.CriadoEm,
+	}
+}
+
+// PaginaPix representa uma página da listagem de PIX
+// swagger:model
+type PaginaPix struct {
+	// PIX da página
+	Itens []PixResumo `json:"itens"`
+
+	// Total de PIX que atendem aos filtros, em todas as páginas
+	// example: 1250
+	Total int64 `json:"total"`
+
+	// Cursor para a próxima página; ausente na última página
+	// example: eyJvIjoiY3JpYWRvX2VtIiwiZCI6dHJ1ZSwiaSI6NDJ9
+	ProximoCursor string `json:"proximo_cursor,omitempty"`
+}
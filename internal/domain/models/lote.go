@@ -0,0 +1,113 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ItemLote representa uma linha de um lote de geração de PIX, já lida da entrada
+type ItemLote struct {
+	// Linha na entrada original (posição no array JSON ou linha do CSV, a partir de 1)
+	Linha int
+
+	// Dados para geração do PIX
+	Requisicao PixRequest
+
+	// Erro encontrado ao ler a linha; quando preenchido, o PIX não é gerado
+	ErroLeitura error
+}
+
+// itemLoteJSON é a forma serializada de ItemLote, usada para guardar a entrada dos jobs
+type itemLoteJSON struct {
+	Linha      int            `json:"linha"`
+	Requisicao PixRequest     `json:"requisicao"`
+	Erro       string         `json:"erro,omitempty"`
+	Erros      ErrosValidacao `json:"erros,omitempty"`
+}
+
+// MarshalJSON serializa o item preservando o erro de leitura e os erros por campo
+func (i ItemLote) MarshalJSON() ([]byte, error) {
+	item := itemLoteJSON{Linha: i.Linha, Requisicao: i.Requisicao}
+	if i.ErroLeitura != nil {
+		item.Erro = i.ErroLeitura.Error()
+		item.Erros, _ = ExtrairErrosValidacao(i.ErroLeitura)
+	}
+	return json.Marshal(item)
+}
+
+// UnmarshalJSON restaura o item serializado por MarshalJSON
+func (i *ItemLote) UnmarshalJSON(data []byte) error {
+	var item itemLoteJSON
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+
+	*i = ItemLote{Linha: item.Linha, Requisicao: item.Requisicao}
+	switch {
+	case len(item.Erros) > 0:
+		i.ErroLeitura = item.Erros
+	case item.Erro != "":
+		i.ErroLeitura = errors.New(item.Erro)
+	}
+	return nil
+}
+
+// ResultadoItemLote representa o resultado da geração de uma linha do lote
+// swagger:model
+type ResultadoItemLote struct {
+	// Linha na entrada original, a partir de 1
+	// example: 1
+	Linha int `json:"linha"`
+
+	// Identificador da transação informado na linha
+	// example: FATURA123
+	Identificador string `json:"identificador,omitempty"`
+
+	// Indica se o PIX foi gerado e salvo
+	// example: true
+	Sucesso bool `json:"sucesso"`
+
+	// ID do PIX salvo
+	// example: 42
+	ID uint `json:"id,omitempty"`
+
+	// Código PIX gerado
+	// example: 00020101021126580014BR.GOV.BCB.PIX0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913FULANO DE TAL6008BRASILIA62070503***63041D3D
+	CodigoPix string `json:"codigo_pix,omitempty"`
+
+	// Modalidade do PIX: COMPRA, SAQUE ou TROCO
+	// example: COMPRA
+	Modalidade string `json:"modalidade,omitempty"`
+
+	// Campos de texto ajustados para caber no padrão do BR Code
+	Ajustes []AjusteCampo `json:"ajustes,omitempty"`
+
+	// Mensagem de erro, quando a linha falhou
+	// example: chave: chave PIX inválida
+	Erro string `json:"erro,omitempty"`
+
+	// Erros de validação por campo, quando a linha falhou na validação
+	Erros []ErroValidacao `json:"erros,omitempty"`
+
+	// QR Code em PNG (base64), usado na montagem do arquivo ZIP
+	QRCodePNG string `json:"-"`
+}
+
+// ResultadoLote representa o resultado da geração de um lote de PIX
+// swagger:model
+type ResultadoLote struct {
+	// Total de linhas recebidas
+	// example: 3
+	Total int `json:"total"`
+
+	// Linhas geradas e salvas com sucesso
+	// example: 2
+	Sucessos int `json:"sucessos"`
+
+	// Linhas com erro
+	// example: 1
+	Falhas int `json:"falhas"`
+
+	// Resultado de cada linha, na ordem da entrada
+	Itens []ResultadoItemLote `json:"itens"`
+}
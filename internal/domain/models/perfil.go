@@ -0,0 +1,122 @@
+package models
+
+import "time"
+
+// PerfilEstabelecimento reúne os dados do estabelecimento usados como padrão na geração de PIX
+// swagger:model
+type PerfilEstabelecimento struct {
+	// Razão social, usada como nome do beneficiário
+	// example: JOSE DA SILVA COMERCIO LTDA
+	RazaoSocial string `json:"razao_social,omitempty"`
+
+	// Cidade do beneficiário
+	// example: SAO PAULO
+	Cidade string `json:"cidade,omitempty"`
+
+	// Merchant Category Code com 4 dígitos
+	// example: 5812
+	MCC string `json:"mcc,omitempty"`
+
+	// CEP com 8 dígitos, sem hífen
+	// example: 01310100
+	CEP string `json:"cep,omitempty"`
+
+	// Chaves PIX cadastradas; a chave padrão é usada quando a requisição não informa uma
+	Chaves []ChaveCadastrada `json:"chaves"`
+}
+
+// ChavePadrao retorna a chave padrão do perfil, se houver
+func (p PerfilEstabelecimento) ChavePadrao() (ChaveCadastrada, bool) {
+	for _, chave := range p.Chaves {
+		if chave.Padrao {
+			return chave, true
+		}
+	}
+	return ChaveCadastrada{}, false
+}
+
+// Chave retorna a chave cadastrada com o ID informado, se houver
+func (p PerfilEstabelecimento) Chave(id uint) (ChaveCadastrada, bool) {
+	for _, chave := range p.Chaves {
+		if chave.ID == id {
+			return chave, true
+		}
+	}
+	return ChaveCadastrada{}, false
+}
+
+// ChaveCadastrada representa uma chave PIX registrada no perfil do estabelecimento
+// swagger:model
+type ChaveCadastrada struct {
+	// ID da chave, usado em chave_id na geração
+	// example: 1
+	ID uint `json:"id"`
+
+	// Chave PIX normalizada
+	// example: josesilva@email.com
+	Chave string `json:"chave"`
+
+	// Tipo da chave: CPF, CNPJ, EMAIL, TELEFONE ou ALEATORIA
+	// example: EMAIL
+	TipoChave string `json:"tipo_chave"`
+
+	// Apelido para identificar a chave
+	// example: Conta principal
+	Apelido string `json:"apelido,omitempty"`
+
+	// Indica a chave usada quando nenhuma é informada
+	// example: true
+	Padrao bool `json:"padrao"`
+
+	// Data de cadastro
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// PerfilRequest representa os dados do perfil do estabelecimento; campos omitidos são removidos do perfil
+// swagger:model
+type PerfilRequest struct {
+	// Razão social, usada como nome do beneficiário (opcional)
+	// example: JOSE DA SILVA COMERCIO LTDA
+	RazaoSocial string `json:"razao_social,omitempty"`
+
+	// Cidade do beneficiário (opcional)
+	// example: SAO PAULO
+	Cidade string `json:"cidade,omitempty"`
+
+	// Merchant Category Code com 4 dígitos (opcional)
+	// example: 5812
+	MCC string `json:"mcc,omitempty"`
+
+	// CEP com 8 dígitos, com ou sem hífen (opcional)
+	// example: 01310-100
+	CEP string `json:"cep,omitempty"`
+}
+
+// ChaveCadastradaRequest representa os dados para cadastrar uma chave PIX no perfil
+// swagger:model
+type ChaveCadastradaRequest struct {
+	// Chave PIX (obrigatório)
+	// required: true
+	// example: josesilva@email.com
+	Chave string `json:"chave" binding:"required"`
+
+	// Apelido para identificar a chave (opcional)
+	// example: Conta principal
+	Apelido string `json:"apelido,omitempty"`
+
+	// Torna a chave a padrão do perfil (opcional; a primeira chave cadastrada é sempre a padrão)
+	// example: false
+	Padrao bool `json:"padrao,omitempty"`
+}
+
+// AtualizarChaveRequest representa as alterações de uma chave cadastrada; campos omitidos não são alterados
+// swagger:model
+type AtualizarChaveRequest struct {
+	// Novo apelido da chave
+	// example: Conta da filial
+	Apelido *string `json:"apelido,omitempty"`
+
+	// true torna a chave a padrão do perfil
+	// example: true
+	Padrao *bool `json:"padrao,omitempty"`
+}
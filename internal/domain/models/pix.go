@@ -2,30 +2,115 @@ package models
 
 import "time"
 
+// Tipos de PIX suportados
+const (
+	// TipoPixEstatico identifica um PIX estático (ponto de iniciação 11)
+	TipoPixEstatico = "ESTATICO"
+
+	// TipoPixDinamico identifica um PIX dinâmico de uso único (ponto de iniciação 12)
+	TipoPixDinamico = "DINAMICO"
+
+	// TipoPixCobV identifica uma cobrança com vencimento (PIX dinâmico com juros, multa e descontos)
+	TipoPixCobV = "COBV"
+)
+
 // Pix representa a entidade principal do sistema
 type Pix struct {
-	ID            uint      `json:"id"`
-	Nome          string    `json:"nome"`
-	Chave         string    `json:"chave"`
-	Cidade        string    `json:"cidade"`
-	Valor         *float64  `json:"valor,omitempty"`
-	Identificador *string   `json:"identificador,omitempty"`
-	Descricao     *string   `json:"descricao,omitempty"`
-	CodigoPix     string    `json:"codigo_pix"`
-	QRCodeSVG     string    `json:"qrcode_svg"`
-	QRCodePNG     string    `json:"qrcode_png"`
-	CriadoEm      time.Time `json:"criado_em"`
+	ID                uint       `json:"id"`
+	EstabelecimentoID string     `json:"estabelecimento_id"`
+	Tipo              string     `json:"tipo"`
+	Modalidade        string     `json:"modalidade"`
+	Nome              string     `json:"nome"`
+	Chave             string     `json:"chave"`
+	TipoChave         string     `json:"tipo_chave,omitempty"`
+	Cidade            string     `json:"cidade"`
+	Valor             *Dinheiro  `json:"valor,omitempty" swaggertype:"number"`
+	Identificador     *string    `json:"identificador,omitempty"`
+	Descricao         *string    `json:"descricao,omitempty"`
+	Txid              *string    `json:"txid,omitempty"`
+	Location          *string    `json:"location,omitempty"`
+	ExpiraEm          *time.Time `json:"expira_em,omitempty"`
+	CodigoPix         string     `json:"codigo_pix"`
+	QRCodeSVG         string     `json:"qrcode_svg"`
+	QRCodePNG         string     `json:"qrcode_png"`
+	CriadoEm          time.Time  `json:"criado_em"`
 }
 
 // PixRequest representa os dados de entrada para geração de um PIX
 // swagger:model
 type PixRequest struct {
+	// Nome do beneficiário do PIX (padrão: razão social do perfil)
+	// example: JOSE DA SILVA
+	Nome string `json:"nome,omitempty"`
+
+	// Chave PIX do beneficiário (padrão: chave padrão do perfil)
+	// example: josesilva@email.com
+	Chave string `json:"chave,omitempty"`
+
+	// ID de uma chave cadastrada no perfil, em vez da chave (opcional)
+	// example: 1
+	ChaveID *uint `json:"chave_id,omitempty"`
+
+	// Cidade do beneficiário (padrão: cidade do perfil)
+	// example: SAO PAULO
+	Cidade string `json:"cidade,omitempty"`
+
+	// Valor da transação com no máximo duas casas decimais (opcional)
+	// example: 100.50
+	Valor *Dinheiro `json:"valor,omitempty" swaggertype:"number"`
+
+	// Identificador único da transação, com até 25 letras e números (opcional)
+	// example: FATURA123
+	Identificador *string `json:"identificador,omitempty"`
+
+	// Descrição da transação (opcional)
+	// example: PAGAMENTO DE SERVICOS
+	Descricao *string `json:"descricao,omitempty"`
+
+	// Merchant Category Code com 4 dígitos (opcional, padrão: MCC do perfil ou 0000)
+	// example: 5812
+	MCC *string `json:"mcc,omitempty"`
+
+	// CEP do beneficiário com 8 dígitos (opcional, padrão: CEP do perfil)
+	// example: 01310-100
+	CEP *string `json:"cep,omitempty"`
+
+	// Nome e cidade do beneficiário em um idioma alternativo (opcional)
+	IdiomaAlternativo *IdiomaAlternativoRequest `json:"idioma_alternativo,omitempty"`
+
+	// Templates de uso livre (80 a 99) com dados de parceiros (opcional)
+	Templates []TemplateNaoReservadoRequest `json:"templates,omitempty"`
+
+	// Modalidade do PIX: COMPRA, SAQUE ou TROCO (opcional, padrão COMPRA)
+	// example: TROCO
+	Modalidade string `json:"modalidade,omitempty"`
+
+	// Dados do agente de saque, obrigatórios nas modalidades SAQUE e TROCO
+	SaqueTroco *SaqueTrocoRequest `json:"saque_troco,omitempty"`
+
+	// Opções de renderização do QR Code (opcional)
+	QRCode *QRCodeRequest `json:"qrcode,omitempty"`
+}
+
+// CobRequest representa os dados de entrada para geração de uma cobrança imediata (PIX dinâmico)
+// swagger:model
+type CobRequest struct {
+	// Identificador da transação junto ao PSP (26 a 35 caracteres alfanuméricos)
+	// required: true
+	// example: 7978c0c97ea847e78e8849634473c1f1
+	Txid string `json:"txid" binding:"required,min=26,max=35,alphanum"`
+
+	// URL do payload da cobrança no PSP, sem o prefixo https://
+	// required: true
+	// example: pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25
+	Location string `json:"location" binding:"required"`
+
 	// Nome do beneficiário do PIX (obrigatório)
 	// required: true
 	// example: JOSE DA SILVA
 	Nome string `json:"nome" binding:"required"`
 
-	// Chave PIX do beneficiário (obrigatório)
+	// Chave PIX à qual a cobrança está vinculada no PSP (obrigatório)
 	// required: true
 	// example: josesilva@email.com
 	Chave string `json:"chave" binding:"required"`
@@ -35,17 +120,16 @@ type PixRequest struct {
 	// example: SAO PAULO
 	Cidade string `json:"cidade" binding:"required"`
 
-	// Valor da transação (opcional)
+	// Valor da cobrança com no máximo duas casas decimais (opcional)
 	// example: 100.50
-	Valor *float64 `json:"valor,omitempty"`
+	Valor *Dinheiro `json:"valor,omitempty" swaggertype:"number"`
 
-	// Identificador único da transação (opcional)
-	// example: FATURA123
-	Identificador *string `json:"identificador,omitempty"`
+	// Tempo de vida da cobrança em segundos (opcional, padrão 3600)
+	// example: 3600
+	Expiracao *int `json:"expiracao,omitempty" binding:"omitempty,min=1"`
 
-	// Descrição da transação (opcional)
-	// example: PAGAMENTO DE SERVICOS
-	Descricao *string `json:"descricao,omitempty"`
+	// Opções de renderização do QR Code (opcional)
+	QRCode *QRCodeRequest `json:"qrcode,omitempty"`
 }
 
 // PixResponse representa a resposta após a geração de um PIX
@@ -55,11 +139,38 @@ type PixResponse struct {
 	// example: 00020101021126580014BR.GOV.BCB.PIX0136josesilva@email.com5204000053039865802BR5913JOSE DA SILVA6009SAO PAULO62150511FATURA12308103100.506304E5B1
 	CodigoPix string `json:"codigo_pix"`
 
+	// Tipo da chave PIX detectado (CPF, CNPJ, TELEFONE, EMAIL ou EVP)
+	// example: EMAIL
+	TipoChave string `json:"tipo_chave,omitempty"`
+
+	// Modalidade do PIX (COMPRA, SAQUE ou TROCO)
+	// example: COMPRA
+	Modalidade string `json:"modalidade,omitempty"`
+
 	// QR Code em formato SVG
-	// example: <svg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 250 250'>...</svg>
+	// example: <svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 41 41" shape-rendering="crispEdges">...</svg>
 	QRCodeSVG string `json:"qrcode_svg"`
 
 	// QR Code em formato PNG (base64)
 	// example: data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAA...
 	QRCodePNG string `json:"qrcode_png"`
+
+	// Campos de texto que foram alterados para caber no BR Code (acentos, caracteres e tamanho)
+	Ajustes []AjusteCampo `json:"ajustes,omitempty"`
+}
+
+// AjusteCampo descreve a alteração feita em um campo de texto para adequá-lo ao BR Code
+// swagger:model
+type AjusteCampo struct {
+	// Campo alterado
+	// example: cidade
+	Campo string `json:"campo"`
+
+	// Valor informado na requisição
+	// example: São Paulo
+	Original string `json:"original"`
+
+	// Valor que consta no BR Code e que será exibido ao pagador
+	// example: SAO PAULO
+	Utilizado string `json:"utilizado"`
 }
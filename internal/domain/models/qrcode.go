@@ -0,0 +1,44 @@
+package models
+
+// Níveis de correção de erro do QR Code
+const (
+	// NivelCorrecaoL recupera até 7% dos módulos
+	NivelCorrecaoL = "L"
+
+	// NivelCorrecaoM recupera até 15% dos módulos
+	NivelCorrecaoM = "M"
+
+	// NivelCorrecaoQ recupera até 25% dos módulos
+	NivelCorrecaoQ = "Q"
+
+	// NivelCorrecaoH recupera até 30% dos módulos, indicado para materiais impressos
+	NivelCorrecaoH = "H"
+)
+
+// QRCodeRequest representa as opções de renderização do QR Code; os campos omitidos usam o padrão
+// swagger:model
+type QRCodeRequest struct {
+	// Nível de correção de erro: L, M, Q ou H (padrão M)
+	// example: H
+	Nivel string `json:"nivel,omitempty"`
+
+	// Largura e altura do PNG em pixels, de 64 a 2048 (padrão 256)
+	// example: 1024
+	Tamanho *int `json:"tamanho,omitempty"`
+
+	// Zona de silêncio ao redor do QR Code em módulos, de 0 a 16 (padrão 4)
+	// example: 4
+	Margem *int `json:"margem,omitempty"`
+
+	// Cor dos módulos em hexadecimal, #RGB ou #RRGGBB (padrão #000000)
+	// example: #1A1A1A
+	Cor string `json:"cor,omitempty"`
+
+	// Cor de fundo em hexadecimal, #RGB ou #RRGGBB (padrão #FFFFFF)
+	// example: #FFFFFF
+	Fundo string `json:"fundo,omitempty"`
+
+	// Gera o fundo transparente, ignorando a cor de fundo
+	// example: false
+	Transparente bool `json:"transparente,omitempty"`
+}
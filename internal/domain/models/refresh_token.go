@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// RefreshToken representa um refresh token emitido para o login de um estabelecimento ou, com
+// UsuarioID, para um usuário da equipe. Apenas o hash
+// do token é armazenado. Os tokens trocados a partir de um mesmo login formam uma família:
+// a reutilização de um token já trocado revoga a família inteira.
+type RefreshToken struct {
+	ID                string
+	FamiliaID         string
+	EstabelecimentoID string
+	UsuarioID         string
+	TokenHash         string
+	ExpiraEm          time.Time
+	UsadoEm           *time.Time
+	RevogadoEm        *time.Time
+	CriadoEm          time.Time
+}
+
+// RefreshTokenRequest representa os dados de entrada para renovar o token de acesso
+// swagger:model
+type RefreshTokenRequest struct {
+	// Refresh token recebido no login ou na última renovação
+	// required: true
+	// example: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest representa os dados de entrada para encerrar a sessão
+// swagger:model
+type LogoutRequest struct {
+	// Refresh token da sessão (opcional); informado, os refresh tokens da sessão também são revogados
+	// example: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// Modalidades de PIX
+const (
+	// ModalidadeCompra identifica um pagamento comum, sem retirada de dinheiro
+	ModalidadeCompra = "COMPRA"
+
+	// ModalidadeSaque identifica um PIX Saque: o pagador transfere o valor e recebe o mesmo valor em espécie
+	ModalidadeSaque = "SAQUE"
+
+	// ModalidadeTroco identifica um PIX Troco: o pagador paga a compra mais o troco e recebe o troco em espécie
+	ModalidadeTroco = "TROCO"
+)
+
+// Modalidades do agente que entrega o dinheiro no PIX Saque e no PIX Troco
+const (
+	// AgenteEstabelecimentoComercial é um estabelecimento comercial (AGTEC)
+	AgenteEstabelecimentoComercial = "AGTEC"
+
+	// AgenteOutraPessoaJuridica é outra espécie de pessoa jurídica ou correspondente no país (AGTOT)
+	AgenteOutraPessoaJuridica = "AGTOT"
+
+	// AgenteFacilitadorSaque é um facilitador de serviço de saque (AGPSS)
+	AgenteFacilitadorSaque = "AGPSS"
+)
+
+// SaqueTrocoRequest representa os dados do agente de saque no PIX Saque e no PIX Troco
+// swagger:model
+type SaqueTrocoRequest struct {
+	// Modalidade do agente: AGTEC (estabelecimento comercial), AGTOT (outra pessoa jurídica) ou AGPSS (facilitador de serviço de saque)
+	// required: true
+	// example: AGTEC
+	ModalidadeAgente string `json:"modalidade_agente"`
+
+	// ISPB do prestador do serviço de saque, com 8 dígitos
+	// required: true
+	// example: 12345678
+	PrestadorISPB string `json:"prestador_ispb"`
+
+	// Valor da compra no PIX Troco; o troco é a diferença entre o valor do PIX e o da compra
+	// example: 80.00
+	ValorCompra *Dinheiro `json:"valor_compra,omitempty" swaggertype:"number"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Tipos de token enviados por email para a gestão da conta
+const (
+	// TokenVerificacaoEmail confirma o email de um estabelecimento recém-registrado
+	TokenVerificacaoEmail = "verificacao_email"
+
+	// TokenRedefinicaoSenha permite definir uma nova senha sem a senha atual
+	TokenRedefinicaoSenha = "redefinicao_senha"
+)
+
+// TokenConta representa um token de uso único enviado por email para verificar o email ou
+// redefinir a senha do login de um estabelecimento ou, com UsuarioID, de um usuário da
+// equipe. Apenas o hash do token é armazenado.
+type TokenConta struct {
+	ID                string
+	Tipo              string
+	EstabelecimentoID string
+	UsuarioID         string
+	TokenHash         string
+	ExpiraEm          time.Time
+	UsadoEm           *time.Time
+	CriadoEm          time.Time
+}
+
+// MensagemEmail representa um email a ser enviado em texto simples
+type MensagemEmail struct {
+	Para    string
+	Assunto string
+	Corpo   string
+}
+
+// EsqueciSenhaRequest representa os dados de entrada para solicitar a redefinição da senha
+// swagger:model
+type EsqueciSenhaRequest struct {
+	// Email do estabelecimento ou do usuário da equipe
+	// required: true
+	// example: contato@lojadojose.com.br
+	Email string `json:"email" binding:"required,email"`
+}
+
+// RedefinirSenhaRequest representa os dados de entrada para redefinir a senha com o token
+// recebido por email
+// swagger:model
+type RedefinirSenhaRequest struct {
+	// Token recebido no email de redefinição de senha
+	// required: true
+	// example: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA
+	Token string `json:"token" binding:"required"`
+
+	// Nova senha (mínimo 6 caracteres)
+	// required: true
+	// example: novaSenha456
+	// min length: 6
+	NovaSenha string `json:"nova_senha" binding:"required,min=6"`
+}
+
+// VerificarEmailRequest representa os dados de entrada para confirmar o email com o token
+// recebido por email
+// swagger:model
+type VerificarEmailRequest struct {
+	// Token recebido no email de verificação
+	// required: true
+	// example: Vn3rM0c2dQ8xKpYz1bLwTgHs7aJfEuRiOqNkXyDl5cA
+	Token string `json:"token" binding:"required"`
+}
+
+// ReenviarVerificacaoRequest representa os dados de entrada para reenviar o email de verificação
+// swagger:model
+type ReenviarVerificacaoRequest struct {
+	// Email do estabelecimento
+	// required: true
+	// example: contato@lojadojose.com.br
+	Email string `json:"email" binding:"required,email"`
+}
@@ -0,0 +1,167 @@
+package models
+
+import "time"
+
+// Papéis dos usuários de um estabelecimento. O proprietário é o próprio login do
+// estabelecimento; os demais papéis são dos usuários convidados para a equipe.
+const (
+	PapelProprietario = "proprietario"
+	PapelGerente      = "gerente"
+	PapelCaixa        = "caixa"
+	PapelLeitura      = "leitura"
+)
+
+// PapeisConvidaveis lista os papéis que podem ser atribuídos aos usuários convidados
+var PapeisConvidaveis = []string{PapelGerente, PapelCaixa, PapelLeitura}
+
+// Permissões das rotas que não correspondem a um escopo das chaves de API e, por isso,
+// são exclusivas dos usuários com login
+const (
+	PermissaoGerenciarChavesAPI     = "chaves_api.gerenciar"
+	PermissaoGerenciarClientesOAuth = "clientes_oauth.gerenciar"
+	PermissaoGerenciarUsuarios      = "usuarios.gerenciar"
+	PermissaoGerenciarConta         = "conta.gerenciar"
+)
+
+// permissoesPapel são as permissões de cada papel. Os escopos das chaves de API também são
+// permissões, e as rotas de PIX, cobranças e perfil são protegidas por eles.
+var permissoesPapel = map[string][]string{
+	PapelProprietario: {
+		EscopoPixLeitura, EscopoPixEscrita, EscopoCobLeitura, EscopoCobEscrita, EscopoPerfilLeitura, EscopoPerfilEscrita,
+		PermissaoGerenciarChavesAPI, PermissaoGerenciarClientesOAuth, PermissaoGerenciarUsuarios, PermissaoGerenciarConta,
+	},
+	PapelGerente: {
+		EscopoPixLeitura, EscopoPixEscrita, EscopoCobLeitura, EscopoCobEscrita, EscopoPerfilLeitura, EscopoPerfilEscrita,
+		PermissaoGerenciarChavesAPI, PermissaoGerenciarClientesOAuth, PermissaoGerenciarUsuarios,
+	},
+	PapelCaixa: {
+		EscopoPixEscrita, EscopoCobEscrita, EscopoPerfilLeitura,
+	},
+	PapelLeitura: {
+		EscopoPixLeitura, EscopoCobLeitura, EscopoPerfilLeitura,
+	},
+}
+
+// PapelPermite informa se o papel concede a permissão; papéis desconhecidos não concedem nenhuma
+func PapelPermite(papel, permissao string) bool {
+	for _, concedida := range permissoesPapel[papel] {
+		if concedida == permissao {
+			return true
+		}
+	}
+	return false
+}
+
+// Usuario representa um usuário da equipe de um estabelecimento, com login próprio e um
+// papel que limita as rotas que ele acessa. O usuário é criado por um convite e só faz
+// login depois de aceitá-lo.
+type Usuario struct {
+	// ID do usuário
+	// example: 5f0c2b7e-8d1a-4c3e-9b6f-7a2d4e1c0b9a
+	ID string `json:"id"`
+
+	EstabelecimentoID string `json:"-"`
+
+	// Nome do usuário, informado ao aceitar o convite
+	// example: Maria Souza
+	Nome string `json:"nome"`
+
+	// Email do usuário (usado para login)
+	// example: maria@lojadojose.com.br
+	Email string `json:"email"`
+
+	Senha string `json:"-"`
+
+	// Papel do usuário: gerente, caixa ou leitura
+	// example: caixa
+	Papel string `json:"papel"`
+
+	// Indica se o usuário pode acessar a API
+	// example: true
+	Ativo bool `json:"ativo"`
+
+	ConviteHash string `json:"-"`
+
+	// Expiração do convite ainda não aceito
+	ConviteExpiraEm *time.Time `json:"convite_expira_em,omitempty"`
+
+	// Aceite do convite; vazio enquanto o convite está pendente
+	AceitoEm *time.Time `json:"aceito_em,omitempty"`
+
+	// TokensRevogadosEm invalida os tokens do usuário emitidos antes desse instante (definido na desativação)
+	TokensRevogadosEm *time.Time `json:"-"`
+
+	// Data de criação
+	CriadoEm time.Time `json:"criado_em"`
+
+	// Data da última atualização
+	AtualizadoEm time.Time `json:"atualizado_em"`
+}
+
+// Sessao retorna os dados conferidos a cada requisição autenticada do usuário: o acesso
+// depende do usuário e do estabelecimento estarem ativos, e a revogação mais recente vale
+func (u Usuario) Sessao(estabelecimento Estabelecimento) SessaoEstabelecimento {
+	sessao := estabelecimento.Sessao()
+	sessao.Ativo = sessao.Ativo && u.Ativo && u.AceitoEm != nil
+	sessao.Admin = false
+	sessao.Papel = u.Papel
+
+	if u.TokensRevogadosEm != nil && (sessao.TokensRevogadosEm == nil || u.TokensRevogadosEm.After(*sessao.TokensRevogadosEm)) {
+		sessao.TokensRevogadosEm = u.TokensRevogadosEm
+	}
+
+	return sessao
+}
+
+// ConvidarUsuarioRequest representa os dados de entrada para convidar um usuário para a equipe
+// swagger:model
+type ConvidarUsuarioRequest struct {
+	// Email do usuário convidado
+	// required: true
+	// example: maria@lojadojose.com.br
+	Email string `json:"email" binding:"required,email"`
+
+	// Papel do usuário: gerente, caixa ou leitura
+	// required: true
+	// example: caixa
+	Papel string `json:"papel" binding:"required"`
+}
+
+// ConviteCriado representa a resposta do convite de um usuário, a única que traz o convite
+// swagger:model
+type ConviteCriado struct {
+	Usuario
+
+	// Convite a ser entregue ao usuário, exibido apenas uma vez; é aceito em /usuarios/convites/aceitar
+	// example: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	Convite string `json:"convite"`
+}
+
+// AceitarConviteRequest representa os dados de entrada para aceitar um convite e definir a senha
+// swagger:model
+type AceitarConviteRequest struct {
+	// Convite recebido
+	// required: true
+	// example: 3q2-7wS1bQx0vJkYl8m4ZcRtUa9nHfEiOpLgDsWkXyA
+	Convite string `json:"convite" binding:"required"`
+
+	// Nome do usuário
+	// required: true
+	// example: Maria Souza
+	Nome string `json:"nome" binding:"required"`
+
+	// Senha do usuário (mínimo 6 caracteres)
+	// required: true
+	// example: senha123
+	// min length: 6
+	Senha string `json:"senha" binding:"required,min=6"`
+}
+
+// AlterarPapelRequest representa os dados de entrada para alterar o papel de um usuário
+// swagger:model
+type AlterarPapelRequest struct {
+	// Novo papel: gerente, caixa ou leitura
+	// required: true
+	// example: leitura
+	Papel string `json:"papel" binding:"required"`
+}
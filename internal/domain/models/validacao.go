@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErroValidacao representa um erro de validação associado a um campo da requisição
+// swagger:model
+type ErroValidacao struct {
+	// Campo que falhou na validação
+	// example: chave
+	Campo string `json:"campo"`
+
+	// Descrição do problema encontrado
+	// example: CPF inválido: dígitos verificadores não conferem
+	Mensagem string `json:"mensagem"`
+}
+
+// Error implementa a interface error
+func (e ErroValidacao) Error() string {
+	return e.Campo + ": " + e.Mensagem
+}
+
+// ErrosValidacao agrupa todos os erros de validação encontrados em uma requisição
+type ErrosValidacao []ErroValidacao
+
+// Error implementa a interface error
+func (e ErrosValidacao) Error() string {
+	mensagens := make([]string, len(e))
+	for i, erro := range e {
+		mensagens[i] = erro.Error()
+	}
+	return strings.Join(mensagens, "; ")
+}
+
+// ExtrairErrosValidacao retorna os erros de validação contidos em err, seja um erro
+// isolado ou uma lista, indicando se err é de fato um erro de validação
+func ExtrairErrosValidacao(err error) (ErrosValidacao, bool) {
+	var erros ErrosValidacao
+	if errors.As(err, &erros) {
+		return erros, len(erros) > 0
+	}
+
+	var erro ErroValidacao
+	if errors.As(err, &erro) {
+		return ErrosValidacao{erro}, true
+	}
+
+	return nil, false
+}
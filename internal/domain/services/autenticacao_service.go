@@ -1,32 +1,150 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	// ErrContaDesativada indica que o estabelecimento foi desativado e não pode acessar a API
+	ErrContaDesativada = errors.New("conta desativada")
+
+	// ErrTokenRevogado indica que o token foi emitido antes da revogação dos tokens do estabelecimento
+	ErrTokenRevogado = errors.New("token revogado")
+)
+
+// Durações padrão dos tokens: o token de acesso tem vida curta e é renovado com o refresh
+// token, que é trocado a cada uso
+const (
+	duracaoTokenPadrao        = 15 * time.Minute
+	duracaoRefreshTokenPadrao = 30 * 24 * time.Hour
+)
+
+// segredoPadrao é o segredo HMAC usado quando JWT_SECRET não é informado. Por ser público,
+// serve apenas para desenvolvimento e não é aceito em produção.
+const segredoPadrao = "c8b7a3e5f9d2b6a1c4d7e9f3b2a5c8d9e6f3a2b5c8d7e9f3b6a5c8d9e6f3b2a5"
+
+// ErrSegredoPadraoEmProducao indica que a API foi iniciada em produção assinando os tokens
+// com o segredo padrão
+var ErrSegredoPadraoEmProducao = errors.New("JWT_SECRET não configurado: o segredo padrão não pode ser usado em produção (GIN_MODE=release); defina JWT_SECRET ou JWT_SIGNING_KEY_FILE")
+
 // AutenticacaoService contém a lógica para autenticação
 type AutenticacaoService struct {
-	jwtChaveSecreta []byte
+	jwtChaveSecreta     []byte
+	chaveAssinatura     *ChaveAssinatura
+	chavesVerificacao   map[string]ChaveAssinatura
+	duracaoToken        time.Duration
+	duracaoRefreshToken time.Duration
 }
 
-// NewAutenticacaoService cria uma nova instância do serviço de autenticação
-func NewAutenticacaoService() *AutenticacaoService {
-	// Obter a chave secreta para JWT do ambiente (ou criar uma padrão)
+// NewAutenticacaoService cria uma nova instância do serviço de autenticação. Com
+// JWT_SIGNING_KEY_FILE, os tokens são assinados com a chave RSA (RS256) ou ECDSA P-256 (ES256)
+// do arquivo PEM, e as chaves públicas de JWT_VERIFICATION_KEY_FILES, separadas por vírgula,
+// continuam aceitas durante a rotação. Sem chave, os tokens são assinados com o segredo HMAC
+// de JWT_SECRET (HS256).
+func NewAutenticacaoService() (*AutenticacaoService, error) {
+	service := &AutenticacaoService{
+		duracaoToken:        duracaoAmbiente("JWT_ACCESS_TTL", duracaoTokenPadrao),
+		duracaoRefreshToken: duracaoAmbiente("JWT_REFRESH_TTL", duracaoRefreshTokenPadrao),
+	}
+
+	if caminho := os.Getenv("JWT_SIGNING_KEY_FILE"); caminho != "" {
+		chaveAssinatura, err := CarregarChaveAssinatura(caminho)
+		if err != nil {
+			return nil, err
+		}
+
+		var chavesVerificacao []ChaveAssinatura
+		for _, caminho := range strings.Split(os.Getenv("JWT_VERIFICATION_KEY_FILES"), ",") {
+			if caminho = strings.TrimSpace(caminho); caminho == "" {
+				continue
+			}
+
+			chave, err := CarregarChaveAssinatura(caminho)
+			if err != nil {
+				return nil, err
+			}
+			chavesVerificacao = append(chavesVerificacao, chave)
+		}
+
+		if err := service.configurarChaves(chaveAssinatura, chavesVerificacao...); err != nil {
+			return nil, err
+		}
+		return service, nil
+	}
+
+	// Obter a chave secreta para JWT do ambiente (ou usar a padrão, fora de produção)
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
-		jwtSecret = "c8b7a3e5f9d2b6a1c4d7e9f3b2a5c8d9e6f3a2b5c8d7e9f3b6a5c8d9e6f3b2a5"
+		jwtSecret = segredoPadrao
+	}
+	if jwtSecret == segredoPadrao && os.Getenv("GIN_MODE") == "release" {
+		return nil, ErrSegredoPadraoEmProducao
 	}
+	service.jwtChaveSecreta = []byte(jwtSecret)
 
-	return &AutenticacaoService{
-		jwtChaveSecreta: []byte(jwtSecret),
+	return service, nil
+}
+
+// configurarChaves passa a assinar os tokens com a chave de assinatura e a aceitar também os
+// tokens das chaves de verificação
+func (s *AutenticacaoService) configurarChaves(chaveAssinatura ChaveAssinatura, chavesVerificacao ...ChaveAssinatura) error {
+	if chaveAssinatura.Privada == nil {
+		return fmt.Errorf("a chave de assinatura %s deve ser uma chave privada", chaveAssinatura.ID)
+	}
+
+	s.chaveAssinatura = &chaveAssinatura
+	s.chavesVerificacao = map[string]ChaveAssinatura{chaveAssinatura.ID: chaveAssinatura}
+	for _, chave := range chavesVerificacao {
+		// A chave de verificação só precisa da parte pública
+		chave.Privada = nil
+		if _, existe := s.chavesVerificacao[chave.ID]; !existe {
+			s.chavesVerificacao[chave.ID] = chave
+		}
 	}
+
+	return nil
+}
+
+// duracaoAmbiente lê uma duração do ambiente (ex.: 15m, 720h), usando o padrão quando
+// ausente ou inválida
+func duracaoAmbiente(variavel string, padrao time.Duration) time.Duration {
+	valor := os.Getenv(variavel)
+	if valor == "" {
+		return padrao
+	}
+
+	duracao, err := time.ParseDuration(valor)
+	if err != nil || duracao <= 0 {
+		log.Printf("Aviso: %s inválido (%q), usando %s", variavel, valor, padrao)
+		return padrao
+	}
+
+	return duracao
+}
+
+// DuracaoToken retorna a validade dos tokens de acesso
+func (s *AutenticacaoService) DuracaoToken() time.Duration {
+	return s.duracaoToken
+}
+
+// DuracaoRefreshToken retorna a validade dos refresh tokens
+func (s *AutenticacaoService) DuracaoRefreshToken() time.Duration {
+	return s.duracaoRefreshToken
 }
 
 // VerificarSenha verifica se a senha fornecida corresponde ao hash armazenado
@@ -34,41 +152,78 @@ func (s *AutenticacaoService) VerificarSenha(hashSenha, senha string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashSenha), []byte(senha))
 }
 
-// GerarToken gera um token JWT para um estabelecimento
-func (s *AutenticacaoService) GerarToken(estabelecimento models.Estabelecimento) (string, error) {
+// GerarToken gera um token JWT para o login do estabelecimento ou, quando informado, para um
+// usuário da equipe do estabelecimento
+func (s *AutenticacaoService) GerarToken(estabelecimento models.Estabelecimento, usuario *models.Usuario) (string, error) {
 	// Definir o tempo de expiração
-	tempoExpiracao := time.Now().Add(24 * time.Hour)
+	agora := time.Now()
+	tempoExpiracao := agora.Add(s.duracaoToken)
 
-	// Criar as claims (payload) do token
+	// Criar as claims (payload) do token; o jti identifica o token na lista de revogação
 	claims := jwt.MapClaims{
 		"id":    estabelecimento.ID,
 		"email": estabelecimento.Email,
 		"nome":  estabelecimento.Nome,
+		"papel": models.PapelProprietario,
+		"jti":   uuid.New().String(),
+		"iat":   agora.Unix(),
 		"exp":   tempoExpiracao.Unix(),
 	}
 
-	// Criar o token com as claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	// O id continua sendo o do estabelecimento, dono dos recursos; o uid identifica o usuário
+	if usuario != nil {
+		claims["uid"] = usuario.ID
+		claims["email"] = usuario.Email
+		claims["nome"] = usuario.Nome
+		claims["papel"] = usuario.Papel
+	}
+
+	return s.assinarToken(claims)
+}
 
-	// Assinar o token com a chave secreta
-	tokenString, err := token.SignedString(s.jwtChaveSecreta)
+// GerarTokenClienteOAuth gera o token de acesso JWT de um cliente OAuth2 do estabelecimento,
+// com o cliente na claim cid e os escopos concedidos, separados por espaço, na claim scope.
+// Retorna também a validade do token.
+func (s *AutenticacaoService) GerarTokenClienteOAuth(estabelecimento models.Estabelecimento, cliente models.ClienteOAuth, escopos []string) (string, time.Duration, error) {
+	agora := time.Now()
+
+	claims := jwt.MapClaims{
+		"id":    estabelecimento.ID,
+		"email": estabelecimento.Email,
+		"nome":  estabelecimento.Nome,
+		"cid":   cliente.ID,
+		"scope": strings.Join(escopos, " "),
+		"jti":   uuid.New().String(),
+		"iat":   agora.Unix(),
+		"exp":   agora.Add(s.duracaoToken).Unix(),
+	}
+
+	token, err := s.assinarToken(claims)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
-	return tokenString, nil
+	return token, s.duracaoToken, nil
+}
+
+// assinarToken assina as claims com a chave de assinatura ou, sem ela, com o segredo HMAC
+func (s *AutenticacaoService) assinarToken(claims jwt.MapClaims) (string, error) {
+	// Sem chave assimétrica, assinar o token com a chave secreta
+	if s.chaveAssinatura == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.jwtChaveSecreta)
+	}
+
+	// O kid indica qual das chaves publicadas no JWKS verifica o token
+	token := jwt.NewWithClaims(s.chaveAssinatura.Metodo, claims)
+	token.Header["kid"] = s.chaveAssinatura.ID
+
+	return token.SignedString(s.chaveAssinatura.Privada)
 }
 
 // ValidarToken valida um token JWT e retorna as claims
 func (s *AutenticacaoService) ValidarToken(tokenString string) (jwt.MapClaims, error) {
 	// Analisar o token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Verificar se o método de assinatura é o esperado
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("método de assinatura inesperado: %v", token.Header["alg"])
-		}
-		return s.jwtChaveSecreta, nil
-	})
+	token, err := jwt.Parse(tokenString, s.chaveVerificacao)
 
 	if err != nil {
 		return nil, err
@@ -81,3 +236,88 @@ func (s *AutenticacaoService) ValidarToken(tokenString string) (jwt.MapClaims, e
 
 	return nil, errors.New("token inválido")
 }
+
+// chaveVerificacao escolhe a chave que verifica o token. Com chaves assimétricas, a chave é
+// identificada pelo kid e os tokens HMAC não são aceitos.
+func (s *AutenticacaoService) chaveVerificacao(token *jwt.Token) (interface{}, error) {
+	if s.chaveAssinatura == nil {
+		// Verificar se o método de assinatura é o esperado
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("método de assinatura inesperado: %v", token.Header["alg"])
+		}
+		return s.jwtChaveSecreta, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	chave, existe := s.chavesVerificacao[kid]
+	if !existe {
+		return nil, fmt.Errorf("chave de assinatura desconhecida: %q", kid)
+	}
+
+	// O algoritmo do token deve ser o da chave, impedindo a troca de algoritmo pelo emissor
+	if token.Method.Alg() != chave.Metodo.Alg() {
+		return nil, fmt.Errorf("método de assinatura inesperado: %v", token.Header["alg"])
+	}
+
+	return chave.Publica, nil
+}
+
+// JWKS retorna as chaves públicas que verificam os tokens, para que outros serviços os validem
+// sem compartilhar segredos. Com o segredo HMAC, nenhuma chave é publicada.
+func (s *AutenticacaoService) JWKS() models.JWKS {
+	jwks := models.JWKS{Keys: []models.JWK{}}
+	if s.chaveAssinatura == nil {
+		return jwks
+	}
+
+	// A chave de assinatura vem primeiro, seguida das chaves da rotação em ordem de kid
+	jwks.Keys = append(jwks.Keys, s.chaveAssinatura.JWK())
+
+	ids := make([]string, 0, len(s.chavesVerificacao))
+	for id := range s.chavesVerificacao {
+		if id != s.chaveAssinatura.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		jwks.Keys = append(jwks.Keys, s.chavesVerificacao[id].JWK())
+	}
+
+	return jwks
+}
+
+// GerarRefreshToken gera um refresh token aleatório e o hash com que ele é armazenado.
+// O token em si só é entregue ao cliente e nunca é persistido.
+func (s *AutenticacaoService) GerarRefreshToken() (token string, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(bytes)
+	return token, s.HashRefreshToken(token), nil
+}
+
+// HashRefreshToken calcula o hash SHA-256 de um refresh token. Por ser aleatório e longo,
+// o token não precisa de um hash lento como o das senhas.
+func (s *AutenticacaoService) HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// ValidarSessao confere se um token emitido em emitidoEm ainda dá acesso ao estabelecimento:
+// a conta deve estar ativa e o token não pode ser anterior à última revogação
+func (s *AutenticacaoService) ValidarSessao(sessao models.SessaoEstabelecimento, emitidoEm time.Time) error {
+	if !sessao.Ativo {
+		return ErrContaDesativada
+	}
+
+	// O iat tem precisão de segundos: tokens emitidos no mesmo segundo da revogação continuam válidos
+	if sessao.TokensRevogadosEm != nil && emitidoEm.Before(sessao.TokensRevogadosEm.Truncate(time.Second)) {
+		return ErrTokenRevogado
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Limites da cobrança com vencimento
+const (
+	// validadePadraoCobV é o número de dias corridos após o vencimento em que a cobrança
+	// ainda pode ser paga quando nada é informado
+	validadePadraoCobV = 30
+
+	// validadeMaximaCobV é o maior número de dias após o vencimento aceito, que também limita
+	// os dias de juros calculados
+	validadeMaximaCobV = 365
+
+	// precisaoJurosCompostos é a precisão, em bits, da potência dos juros compostos, suficiente
+	// para o centavo em qualquer valor aceito
+	precisaoJurosCompostos = 256
+
+	// maximoDescontosCobV é o número máximo de faixas de desconto por data
+	maximoDescontosCobV = 3
+
+	// diasMesJuros é o número de dias de um mês comercial, usado nos juros mensais
+	diasMesJuros = 30
+
+	// cemPorCento é o percentual máximo de multa, desconto, abatimento e da taxa de juros (100.00)
+	cemPorCento = models.Dinheiro(10000)
+)
+
+// ErrCobVExpirada indica que a data de pagamento excede a validade da cobrança após o vencimento
+var ErrCobVExpirada = errors.New("cobrança expirada: a data de pagamento excede a validade após o vencimento")
+
+// modalidadesJuros lista as modalidades de juros aceitas
+var modalidadesJuros = map[string]bool{
+	models.JurosSimplesDia:  true,
+	models.JurosSimplesMes:  true,
+	models.JurosCompostoDia: true,
+	models.JurosCompostoMes: true,
+}
+
+// CalculadoraCobVService valida cobranças com vencimento e calcula o valor a pagar em uma data
+type CalculadoraCobVService struct{}
+
+// NewCalculadoraCobVService cria uma nova instância da calculadora de cobranças com vencimento
+func NewCalculadoraCobVService() *CalculadoraCobVService {
+	return &CalculadoraCobVService{}
+}
+
+// NovaCobV valida as regras de cálculo da requisição e cria a cobrança correspondente.
+// O vencimento não pode ser anterior a hoje. Todos os problemas são devolvidos em models.ErrosValidacao.
+func (c *CalculadoraCobVService) NovaCobV(req models.CobVRequest, hoje time.Time) (models.CobV, error) {
+	var v validador
+
+	cobv := models.CobV{
+		Txid:                   req.Txid,
+		ValidadeAposVencimento: validadePadraoCobV,
+		Juros:                  req.Juros,
+		Multa:                  req.Multa,
+		Abatimento:             req.Abatimento,
+	}
+
+	if req.Valor == nil || *req.Valor <= 0 {
+		v.campo("valor", "valor original é obrigatório e deve ser maior que zero")
+	} else {
+		cobv.ValorOriginal = *req.Valor
+	}
+
+	vencimento, err := time.Parse(models.FormatoData, req.Vencimento)
+	if err != nil {
+		v.campo("vencimento", "vencimento deve estar no formato AAAA-MM-DD")
+	} else if vencimento.Before(dia(hoje)) {
+		v.campo("vencimento", "vencimento não pode ser anterior a hoje")
+	}
+	cobv.Vencimento = vencimento
+
+	if req.ValidadeAposVencimento != nil {
+		if *req.ValidadeAposVencimento < 0 || *req.ValidadeAposVencimento > validadeMaximaCobV {
+			v.campo("validade_apos_vencimento", fmt.Sprintf("validade após o vencimento deve estar entre 0 e %d dias", validadeMaximaCobV))
+		}
+		cobv.ValidadeAposVencimento = *req.ValidadeAposVencimento
+	}
+
+	if req.Juros != nil {
+		if !modalidadesJuros[req.Juros.Modalidade] {
+			v.campo("juros.modalidade", "modalidade de juros deve ser SIMPLES_DIA, SIMPLES_MES, COMPOSTO_DIA ou COMPOSTO_MES")
+		}
+		if req.Juros.ValorPerc <= 0 || req.Juros.ValorPerc > cemPorCento {
+			v.campo("juros.valor_perc", "taxa de juros deve ser maior que zero e no máximo 100")
+		}
+	}
+
+	if req.Multa != nil {
+		validarValorPerc(&v, "multa", req.Multa.Modalidade, req.Multa.ValorPerc, cobv.ValorOriginal)
+	}
+
+	if req.Abatimento != nil {
+		validarValorPerc(&v, "abatimento", req.Abatimento.Modalidade, req.Abatimento.ValorPerc, cobv.ValorOriginal)
+	}
+
+	if len(req.Descontos) > maximoDescontosCobV {
+		v.campo("descontos", fmt.Sprintf("são aceitas no máximo %d faixas de desconto", maximoDescontosCobV))
+	}
+
+	datas := make(map[string]bool)
+	for i, desconto := range req.Descontos {
+		prefixo := fmt.Sprintf("descontos[%d]", i)
+
+		data, err := time.Parse(models.FormatoData, desconto.Data)
+		switch {
+		case err != nil:
+			v.campo(prefixo+".data", "data deve estar no formato AAAA-MM-DD")
+		case data.After(vencimento):
+			v.campo(prefixo+".data", "desconto deve ser concedido até o vencimento")
+		case datas[desconto.Data]:
+			v.campo(prefixo+".data", "já existe uma faixa de desconto para esta data")
+		}
+		datas[desconto.Data] = true
+
+		validarValorPerc(&v, prefixo, desconto.Modalidade, desconto.ValorPerc, cobv.ValorOriginal)
+	}
+
+	// As faixas ficam em ordem crescente de data, a ordem em que são avaliadas
+	cobv.Descontos = append([]models.DescontoCobV(nil), req.Descontos...)
+	sort.Slice(cobv.Descontos, func(i, j int) bool {
+		return cobv.Descontos[i].Data < cobv.Descontos[j].Data
+	})
+
+	if err := v.erro(); err != nil {
+		return models.CobV{}, err
+	}
+
+	return cobv, nil
+}
+
+// PagavelAte retorna o último dia em que a cobrança pode ser paga
+func (c *CalculadoraCobVService) PagavelAte(cobv models.CobV) time.Time {
+	return dia(cobv.Vencimento).AddDate(0, 0, cobv.ValidadeAposVencimento)
+}
+
+// Calcular calcula o valor a pagar em uma data. Até o vencimento vale o desconto da primeira
+// faixa cuja data ainda não passou; após o vencimento incidem a multa, uma única vez, e os juros
+// sobre os dias corridos de atraso. O abatimento reduz a base de cálculo em qualquer data.
+// Cada componente é arredondado para o centavo mais próximo. Um valor a pagar acima do máximo
+// aceito é devolvido como erro de validação da data.
+func (c *CalculadoraCobVService) Calcular(cobv models.CobV, data time.Time) (models.ValoresCobV, error) {
+	pagamento := dia(data)
+	vencimento := dia(cobv.Vencimento)
+
+	if pagamento.After(c.PagavelAte(cobv)) {
+		return models.ValoresCobV{}, ErrCobVExpirada
+	}
+
+	valores := models.ValoresCobV{
+		DataPagamento: pagamento.Format(models.FormatoData),
+		Original:      cobv.ValorOriginal,
+	}
+
+	// Abatimento: reduz a base sobre a qual incidem desconto, multa e juros
+	if cobv.Abatimento != nil {
+		valores.Abatimento = min(aplicarValorPerc(cobv.Abatimento.Modalidade, cobv.Abatimento.ValorPerc, cobv.ValorOriginal), cobv.ValorOriginal)
+	}
+	base := cobv.ValorOriginal - valores.Abatimento
+
+	if !pagamento.After(vencimento) {
+		// Desconto: primeira faixa (em ordem de data) que ainda vale na data de pagamento
+		for _, desconto := range cobv.Descontos {
+			limite, err := time.Parse(models.FormatoData, desconto.Data)
+			if err != nil || pagamento.After(limite) {
+				continue
+			}
+			valores.Desconto = min(aplicarValorPerc(desconto.Modalidade, desconto.ValorPerc, base), base)
+			break
+		}
+	} else {
+		valores.DiasAtraso = int(pagamento.Sub(vencimento).Hours() / 24)
+
+		if cobv.Multa != nil {
+			valores.Multa = aplicarValorPerc(cobv.Multa.Modalidade, cobv.Multa.ValorPerc, base)
+		}
+
+		if cobv.Juros != nil {
+			juros, ok := calcularJuros(*cobv.Juros, base, valores.DiasAtraso)
+			if !ok {
+				return models.ValoresCobV{}, errValorCobVExcedido()
+			}
+			valores.Juros = juros
+		}
+	}
+
+	valores.Final = base - valores.Desconto + valores.Juros + valores.Multa
+	if valores.Final > models.DinheiroMaximo {
+		return models.ValoresCobV{}, errValorCobVExcedido()
+	}
+
+	return valores, nil
+}
+
+// errValorCobVExcedido indica que o valor a pagar na data excede o maior valor aceito
+func errValorCobVExcedido() error {
+	return models.ErrosValidacao{{Campo: "data", Mensagem: fmt.Sprintf("valor a pagar na data excede o máximo de R$ %s", models.DinheiroMaximo)}}
+}
+
+// calcularJuros calcula os juros sobre a base para os dias corridos de atraso. Retorna false
+// quando os juros excedem o maior valor aceito.
+func calcularJuros(juros models.JurosCobV, base models.Dinheiro, dias int) (models.Dinheiro, bool) {
+	taxa := new(big.Rat).SetFrac64(juros.ValorPerc.Centavos(), 10000)
+	fator := new(big.Rat)
+
+	switch juros.Modalidade {
+	case models.JurosSimplesDia:
+		// base × taxa × dias
+		fator.Mul(taxa, big.NewRat(int64(dias), 1))
+	case models.JurosSimplesMes:
+		// base × taxa × dias/30
+		fator.Mul(taxa, big.NewRat(int64(dias), diasMesJuros))
+	case models.JurosCompostoDia:
+		// base × ((1 + taxa)^dias − 1)
+		fator.Sub(potencia(taxa, dias), big.NewRat(1, 1))
+	case models.JurosCompostoMes:
+		// base × ((1 + taxa)^meses × (1 + taxa × resto/30) − 1)
+		proporcional := new(big.Rat).Mul(taxa, big.NewRat(int64(dias%diasMesJuros), diasMesJuros))
+		proporcional.Add(proporcional, big.NewRat(1, 1))
+		fator.Mul(potencia(taxa, dias/diasMesJuros), proporcional)
+		fator.Sub(fator, big.NewRat(1, 1))
+	}
+
+	centavos := fator.Mul(fator, big.NewRat(base.Centavos(), 1))
+	if centavos.Cmp(big.NewRat(models.DinheiroMaximo.Centavos(), 1)) > 0 {
+		return 0, false
+	}
+
+	return arredondar(centavos), true
+}
+
+// potencia calcula (1 + taxa)^n por quadrados sucessivos, com precisão limitada, para que o
+// custo não cresça com o número de dias
+func potencia(taxa *big.Rat, n int) *big.Rat {
+	fator := new(big.Float).SetPrec(precisaoJurosCompostos).SetRat(new(big.Rat).Add(big.NewRat(1, 1), taxa))
+	resultado := new(big.Float).SetPrec(precisaoJurosCompostos).SetInt64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			resultado.Mul(resultado, fator)
+		}
+		fator.Mul(fator, fator)
+	}
+
+	potencia, _ := resultado.Rat(nil)
+	return potencia
+}
+
+// aplicarValorPerc retorna o valor fixo ou o percentual sobre a base, conforme a modalidade
+func aplicarValorPerc(modalidade string, valorPerc, base models.Dinheiro) models.Dinheiro {
+	if modalidade == models.ModalidadeValorFixo {
+		return valorPerc
+	}
+	return arredondar(big.NewRat(base.Centavos()*valorPerc.Centavos(), 10000))
+}
+
+// arredondar converte um valor em centavos para Dinheiro, arredondando metade para cima
+func arredondar(centavos *big.Rat) models.Dinheiro {
+	dobro := new(big.Int).Mul(centavos.Num(), big.NewInt(2))
+	dobro.Add(dobro, centavos.Denom())
+	arredondado := new(big.Int).Div(dobro, new(big.Int).Mul(centavos.Denom(), big.NewInt(2)))
+	return models.NovoDinheiro(arredondado.Int64())
+}
+
+// validarValorPerc confere a modalidade e o valor de multa, desconto ou abatimento
+func validarValorPerc(v *validador, campo, modalidade string, valorPerc, valorOriginal models.Dinheiro) {
+	switch modalidade {
+	case models.ModalidadeValorFixo:
+		if valorPerc <= 0 || (valorOriginal > 0 && valorPerc >= valorOriginal) {
+			v.campo(campo+".valor_perc", "valor deve ser maior que zero e menor que o valor original")
+		}
+	case models.ModalidadePercentual:
+		if valorPerc <= 0 || valorPerc > cemPorCento {
+			v.campo(campo+".valor_perc", "percentual deve ser maior que zero e no máximo 100")
+		}
+	default:
+		v.campo(campo+".modalidade", "modalidade deve ser VALOR_FIXO ou PERCENTUAL")
+	}
+}
+
+// dia descarta o horário, para que as datas sejam comparadas em dias corridos
+func dia(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
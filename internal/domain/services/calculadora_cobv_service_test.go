@@ -0,0 +1,238 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalculadoraCobV testa a validação e o cálculo do valor a pagar das cobranças com vencimento
+func TestCalculadoraCobV(t *testing.T) {
+	// Inicializar o serviço
+	calculadora := services.NewCalculadoraCobVService()
+	hoje := time.Date(2025, 1, 10, 14, 30, 0, 0, time.UTC)
+
+	data := func(texto string) time.Time {
+		d, _ := time.Parse(models.FormatoData, texto)
+		return d
+	}
+
+	novaRequisicao := func() models.CobVRequest {
+		valor := models.NovoDinheiro(10000)
+		return models.CobVRequest{
+			Txid:       "7978c0c97ea847e78e8849634473c1f1",
+			Location:   "pix.example.com/qr/v2/cobv/9d36b84fc70b478fb95c12729b90ca25",
+			Nome:       "JOSE DA SILVA",
+			Chave:      "josesilva@email.com",
+			Cidade:     "SAO PAULO",
+			Valor:      &valor,
+			Vencimento: "2025-01-31",
+		}
+	}
+
+	t.Run("DescontoPorFaixa", func(t *testing.T) {
+		req := novaRequisicao()
+		// Faixas fora de ordem: a calculadora as avalia em ordem de data
+		req.Descontos = []models.DescontoCobV{
+			{Data: "2025-01-25", Modalidade: models.ModalidadeValorFixo, ValorPerc: models.NovoDinheiro(200)},
+			{Data: "2025-01-15", Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(500)},
+		}
+
+		cobv, err := calculadora.NovaCobV(req, hoje)
+		assert.NoError(t, err)
+
+		casos := map[string]struct {
+			desconto models.Dinheiro
+			final    models.Dinheiro
+		}{
+			"2025-01-10": {desconto: 500, final: 9500},
+			"2025-01-15": {desconto: 500, final: 9500},
+			"2025-01-20": {desconto: 200, final: 9800},
+			"2025-01-31": {desconto: 0, final: 10000},
+		}
+
+		for pagamento, esperado := range casos {
+			// Executar o método a ser testado
+			valores, err := calculadora.Calcular(cobv, data(pagamento))
+
+			// Verificar resultados
+			assert.NoError(t, err)
+			assert.Equal(t, esperado.desconto, valores.Desconto, pagamento)
+			assert.Equal(t, esperado.final, valores.Final, pagamento)
+			assert.Equal(t, 0, valores.DiasAtraso, pagamento)
+		}
+	})
+
+	t.Run("JurosEMulta", func(t *testing.T) {
+		casos := []struct {
+			nome      string
+			juros     models.JurosCobV
+			pagamento string
+			esperado  models.Dinheiro
+		}{
+			// 100,00 × 1% × 10/30
+			{"SimplesMes", models.JurosCobV{Modalidade: models.JurosSimplesMes, ValorPerc: 100}, "2025-02-10", 33},
+			// 100,00 × 0,1% × 10
+			{"SimplesDia", models.JurosCobV{Modalidade: models.JurosSimplesDia, ValorPerc: 10}, "2025-02-10", 100},
+			// 100,00 × (1,01^10 − 1)
+			{"CompostoDia", models.JurosCobV{Modalidade: models.JurosCompostoDia, ValorPerc: 100}, "2025-02-10", 1046},
+			// 100,00 × (1,01 × (1 + 1% × 10/30) − 1), 40 dias de atraso
+			{"CompostoMes", models.JurosCobV{Modalidade: models.JurosCompostoMes, ValorPerc: 100}, "2025-03-12", 134},
+		}
+
+		for _, caso := range casos {
+			t.Run(caso.nome, func(t *testing.T) {
+				validade := 60
+				juros := caso.juros
+
+				req := novaRequisicao()
+				req.ValidadeAposVencimento = &validade
+				req.Juros = &juros
+				req.Multa = &models.MultaCobV{Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(200)}
+				req.Descontos = []models.DescontoCobV{
+					{Data: "2025-01-31", Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(500)},
+				}
+
+				cobv, err := calculadora.NovaCobV(req, hoje)
+				assert.NoError(t, err)
+
+				// Executar o método a ser testado
+				valores, err := calculadora.Calcular(cobv, data(caso.pagamento))
+
+				// Verificar resultados: sem desconto após o vencimento, multa de 2% uma única vez
+				assert.NoError(t, err)
+				assert.Equal(t, caso.esperado, valores.Juros)
+				assert.Equal(t, models.Dinheiro(200), valores.Multa)
+				assert.Equal(t, models.Dinheiro(0), valores.Desconto)
+				assert.Equal(t, 10000+200+caso.esperado, valores.Final)
+			})
+		}
+	})
+
+	t.Run("MultaFixaNoDiaSeguinte", func(t *testing.T) {
+		req := novaRequisicao()
+		req.Multa = &models.MultaCobV{Modalidade: models.ModalidadeValorFixo, ValorPerc: models.NovoDinheiro(500)}
+
+		cobv, err := calculadora.NovaCobV(req, hoje)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		noVencimento, err := calculadora.Calcular(cobv, data("2025-01-31"))
+		assert.NoError(t, err)
+		aposVencimento, err := calculadora.Calcular(cobv, data("2025-02-01"))
+		assert.NoError(t, err)
+
+		// Verificar resultados
+		assert.Equal(t, models.Dinheiro(10000), noVencimento.Final)
+		assert.Equal(t, 1, aposVencimento.DiasAtraso)
+		assert.Equal(t, models.Dinheiro(10500), aposVencimento.Final)
+	})
+
+	t.Run("Abatimento", func(t *testing.T) {
+		req := novaRequisicao()
+		req.Abatimento = &models.AbatimentoCobV{Modalidade: models.ModalidadeValorFixo, ValorPerc: models.NovoDinheiro(1000)}
+		req.Descontos = []models.DescontoCobV{
+			{Data: "2025-01-20", Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(500)},
+		}
+
+		cobv, err := calculadora.NovaCobV(req, hoje)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		valores, err := calculadora.Calcular(cobv, hoje)
+
+		// Verificar resultados: o desconto de 5% incide sobre 90,00
+		assert.NoError(t, err)
+		assert.Equal(t, models.Dinheiro(1000), valores.Abatimento)
+		assert.Equal(t, models.Dinheiro(450), valores.Desconto)
+		assert.Equal(t, models.Dinheiro(8550), valores.Final)
+		assert.Equal(t, "2025-01-10", valores.DataPagamento)
+	})
+
+	t.Run("Expirada", func(t *testing.T) {
+		cobv, err := calculadora.NovaCobV(novaRequisicao(), hoje)
+		assert.NoError(t, err)
+
+		// Verificar resultados: validade padrão de 30 dias após o vencimento
+		assert.Equal(t, "2025-03-02", calculadora.PagavelAte(cobv).Format(models.FormatoData))
+
+		_, err = calculadora.Calcular(cobv, data("2025-03-02"))
+		assert.NoError(t, err)
+
+		_, err = calculadora.Calcular(cobv, data("2025-03-03"))
+		assert.ErrorIs(t, err, services.ErrCobVExpirada)
+	})
+
+	t.Run("ValorExcedido", func(t *testing.T) {
+		validade := 365
+		valor := models.NovoDinheiro(1000000000)
+
+		req := novaRequisicao()
+		req.Valor = &valor
+		req.ValidadeAposVencimento = &validade
+		req.Juros = &models.JurosCobV{Modalidade: models.JurosCompostoDia, ValorPerc: models.NovoDinheiro(10000)}
+
+		cobv, err := calculadora.NovaCobV(req, hoje)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		_, err = calculadora.Calcular(cobv, data("2026-01-31"))
+
+		// Verificar resultados: o valor a pagar não é truncado
+		var errosValidacao models.ErrosValidacao
+		if assert.ErrorAs(t, err, &errosValidacao) {
+			assert.Equal(t, "data", errosValidacao[0].Campo)
+		}
+	})
+
+	t.Run("DadosInvalidos", func(t *testing.T) {
+		casos := map[string]func(req *models.CobVRequest){
+			"validade_apos_vencimento": func(req *models.CobVRequest) {
+				validade := 366
+				req.ValidadeAposVencimento = &validade
+			},
+			"juros.valor_perc": func(req *models.CobVRequest) {
+				req.Juros = &models.JurosCobV{Modalidade: models.JurosCompostoDia, ValorPerc: models.NovoDinheiro(10001)}
+			},
+			"vencimento": func(req *models.CobVRequest) {
+				req.Vencimento = "2025-01-09"
+			},
+			"juros.modalidade": func(req *models.CobVRequest) {
+				req.Juros = &models.JurosCobV{Modalidade: "ANUAL", ValorPerc: models.NovoDinheiro(100)}
+			},
+			"multa.valor_perc": func(req *models.CobVRequest) {
+				req.Multa = &models.MultaCobV{Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(10001)}
+			},
+			"abatimento.valor_perc": func(req *models.CobVRequest) {
+				req.Abatimento = &models.AbatimentoCobV{Modalidade: models.ModalidadeValorFixo, ValorPerc: models.NovoDinheiro(10000)}
+			},
+			"descontos[0].data": func(req *models.CobVRequest) {
+				req.Descontos = []models.DescontoCobV{
+					{Data: "2025-02-01", Modalidade: models.ModalidadePercentual, ValorPerc: models.NovoDinheiro(500)},
+				}
+			},
+			"descontos": func(req *models.CobVRequest) {
+				for _, d := range []string{"2025-01-11", "2025-01-12", "2025-01-13", "2025-01-14"} {
+					req.Descontos = append(req.Descontos, models.DescontoCobV{Data: d, Modalidade: models.ModalidadeValorFixo, ValorPerc: models.NovoDinheiro(100)})
+				}
+			},
+		}
+
+		for campo, alterar := range casos {
+			req := novaRequisicao()
+			alterar(&req)
+
+			// Executar o método a ser testado
+			_, err := calculadora.NovaCobV(req, hoje)
+
+			// Verificar resultados
+			var errosValidacao models.ErrosValidacao
+			if assert.ErrorAs(t, err, &errosValidacao, campo) {
+				assert.Equal(t, campo, errosValidacao[0].Campo)
+			}
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Limites dos campos opcionais do BR Code
+const (
+	// mccPadrao é o Merchant Category Code usado quando o estabelecimento não informa um
+	mccPadrao = "0000"
+
+	// tamanhoMaximoCampoEMV é o maior conteúdo que cabe em um campo, limitado pelos 2 dígitos do tamanho
+	tamanhoMaximoCampoEMV = 99
+
+	// tamanhoMaximoGUITemplate é o maior GUI aceito no sub-campo 00 de um template
+	tamanhoMaximoGUITemplate = 32
+)
+
+var (
+	cepRegex     = regexp.MustCompile(`^\d{5}-?\d{3}$`)
+	idiomaRegex  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+	idCampoRegex = regexp.MustCompile(`^\d{2}$`)
+)
+
+// camposOpcionais reúne os campos opcionais do BR Code já validados e formatados
+type camposOpcionais struct {
+	mcc               string     // Merchant Category Code (52)
+	cep               string     // Postal Code (61)
+	idiomaAlternativo string     // Conteúdo do Merchant Information - Language Template (64)
+	templates         []campoEMV // Templates de uso livre (80 a 99), em ordem crescente de ID
+}
+
+// campoEMV representa um campo do BR Code ainda não serializado
+type campoEMV struct {
+	id    string
+	valor string
+}
+
+// montarCamposOpcionais valida os campos opcionais da requisição e os converte para o
+// formato do BR Code, registrando em ajustes os textos que precisaram ser alterados
+func montarCamposOpcionais(v *validador, req models.PixRequest, ajustes *ajustesTexto) camposOpcionais {
+	campos := camposOpcionais{mcc: mccPadrao}
+
+	if req.MCC != nil && strings.TrimSpace(*req.MCC) != "" {
+		mcc := strings.TrimSpace(*req.MCC)
+		if mccRegex.MatchString(mcc) {
+			campos.mcc = mcc
+		} else {
+			v.campo("mcc", "MCC deve conter 4 dígitos")
+		}
+	}
+
+	if req.CEP != nil && strings.TrimSpace(*req.CEP) != "" {
+		cep := strings.TrimSpace(*req.CEP)
+		if cepRegex.MatchString(cep) {
+			campos.cep = strings.ReplaceAll(cep, "-", "")
+		} else {
+			v.campo("cep", "CEP deve conter 8 dígitos")
+		}
+	}
+
+	if req.IdiomaAlternativo != nil {
+		campos.idiomaAlternativo = montarIdiomaAlternativo(v, *req.IdiomaAlternativo, ajustes)
+	}
+
+	ids := make(map[string]bool)
+	for i, template := range req.Templates {
+		prefixo := fmt.Sprintf("templates[%d]", i)
+
+		campo, valido := montarTemplateNaoReservado(v, prefixo, template)
+		if !valido {
+			continue
+		}
+		if ids[campo.id] {
+			v.campo(prefixo+".id", "template "+campo.id+" informado mais de uma vez")
+			continue
+		}
+		ids[campo.id] = true
+
+		campos.templates = append(campos.templates, campo)
+	}
+
+	// Os campos do BR Code são gravados em ordem crescente de ID
+	sort.Slice(campos.templates, func(i, j int) bool {
+		return campos.templates[i].id < campos.templates[j].id
+	})
+
+	return campos
+}
+
+// montarIdiomaAlternativo monta o conteúdo do template 64: idioma (00), nome (01) e cidade (02)
+func montarIdiomaAlternativo(v *validador, req models.IdiomaAlternativoRequest, ajustes *ajustesTexto) string {
+	idioma := strings.TrimSpace(req.Idioma)
+	if !idiomaRegex.MatchString(idioma) {
+		v.campo("idioma_alternativo.idioma", "idioma deve ter 2 letras no padrão ISO 639-1")
+	}
+
+	nome := ajustes.normalizar("idioma_alternativo.nome", req.Nome, tamanhoMaximoNome, caractereBRCode)
+	if nome == "" {
+		v.campo("idioma_alternativo.nome", "nome no idioma alternativo é obrigatório")
+	}
+
+	var cidade string
+	if req.Cidade != "" {
+		cidade = ajustes.normalizar("idioma_alternativo.cidade", req.Cidade, tamanhoMaximoCidade, caractereBRCode)
+	}
+
+	var template strings.Builder
+	addCampo(&template, "00", strings.ToUpper(idioma))
+	addCampo(&template, "01", nome)
+	addCampo(&template, "02", cidade)
+
+	return template.String()
+}
+
+// montarTemplateNaoReservado valida e serializa um template de uso livre (80 a 99). Os dados
+// de parceiros não são transliterados: qualquer caractere fora do conjunto do BR Code é rejeitado.
+func montarTemplateNaoReservado(v *validador, prefixo string, req models.TemplateNaoReservadoRequest) (campoEMV, bool) {
+	errosAntes := len(v.erros)
+
+	if numero, err := strconv.Atoi(req.ID); !idCampoRegex.MatchString(req.ID) || err != nil || numero < 80 {
+		v.campo(prefixo+".id", "ID do template deve estar entre 80 e 99")
+	}
+
+	if req.GUI == "" || len(req.GUI) > tamanhoMaximoGUITemplate {
+		v.campo(prefixo+".gui", fmt.Sprintf("GUI é obrigatório e deve ter no máximo %d caracteres", tamanhoMaximoGUITemplate))
+	} else if !textoBRCode(req.GUI) {
+		v.campo(prefixo+".gui", "GUI deve conter apenas caracteres ASCII imprimíveis")
+	}
+
+	var template strings.Builder
+	addCampo(&template, "00", req.GUI)
+
+	ids := make(map[string]bool)
+	for i, subcampo := range req.Subcampos {
+		campo := fmt.Sprintf("%s.subcampos[%d]", prefixo, i)
+
+		if !idCampoRegex.MatchString(subcampo.ID) || subcampo.ID == "00" {
+			v.campo(campo+".id", "ID do sub-campo deve estar entre 01 e 99")
+		} else if ids[subcampo.ID] {
+			v.campo(campo+".id", "sub-campo "+subcampo.ID+" informado mais de uma vez")
+		}
+		ids[subcampo.ID] = true
+
+		if subcampo.Valor == "" || !textoBRCode(subcampo.Valor) {
+			v.campo(campo+".valor", "valor é obrigatório e deve conter apenas caracteres ASCII imprimíveis")
+		}
+
+		addCampo(&template, subcampo.ID, subcampo.Valor)
+	}
+
+	if template.Len() > tamanhoMaximoCampoEMV {
+		v.campo(prefixo, fmt.Sprintf("template excede %d caracteres (%d)", tamanhoMaximoCampoEMV, template.Len()))
+	}
+
+	return campoEMV{id: req.ID, valor: template.String()}, len(v.erros) == errosAntes
+}
+
+// textoBRCode indica se todos os caracteres pertencem ao conjunto aceito pelo BR Code
+func textoBRCode(texto string) bool {
+	for _, r := range texto {
+		if !caractereBRCode(r) {
+			return false
+		}
+	}
+	return true
+}
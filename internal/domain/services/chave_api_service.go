@@ -0,0 +1,97 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrChaveAPIInvalida indica uma chave de API inexistente, revogada ou expirada
+var ErrChaveAPIInvalida = errors.New("chave de API inválida, revogada ou expirada")
+
+const (
+	// prefixoChaveAPI identifica as chaves de API, facilitando a detecção de chaves vazadas em código
+	prefixoChaveAPI = "pixk_"
+
+	// tamanhoPrefixoExibido é quantos caracteres da chave são guardados para identificá-la nas listagens
+	tamanhoPrefixoExibido = 12
+
+	// tamanhoMaximoNomeChaveAPI é o limite do nome da chave, definido pela coluna do banco
+	tamanhoMaximoNomeChaveAPI = 100
+)
+
+// NovaChaveAPI valida os dados de uma nova chave de API e normaliza os escopos
+func NovaChaveAPI(req models.CriarChaveAPIRequest, agora time.Time) (models.ChaveAPI, error) {
+	var v validador
+
+	chave := models.ChaveAPI{
+		Nome:     strings.TrimSpace(req.Nome),
+		ExpiraEm: req.ExpiraEm,
+	}
+
+	if chave.Nome == "" {
+		v.campo("nome", "nome é obrigatório")
+	} else if utf8.RuneCountInString(chave.Nome) > tamanhoMaximoNomeChaveAPI {
+		v.campo("nome", fmt.Sprintf("nome deve ter no máximo %d caracteres", tamanhoMaximoNomeChaveAPI))
+	}
+
+	chave.Escopos = validarEscopos(&v, req.Escopos)
+
+	if chave.ExpiraEm != nil && !chave.ExpiraEm.After(agora) {
+		v.campo("expira_em", "expiração deve ser uma data futura")
+	}
+
+	if err := v.erro(); err != nil {
+		return models.ChaveAPI{}, err
+	}
+
+	return chave, nil
+}
+
+// validarEscopos valida os escopos concedidos a uma credencial e os retorna ordenados e sem
+// repetições
+func validarEscopos(v *validador, escopos []string) []string {
+	validos := []string{}
+	concedidos := map[string]bool{}
+	for _, escopo := range escopos {
+		escopo = strings.TrimSpace(escopo)
+		if !models.EscopoValido(escopo) {
+			v.campo("escopos", fmt.Sprintf("escopo %q desconhecido; use %s", escopo, strings.Join(models.EscoposValidos, ", ")))
+			continue
+		}
+		if !concedidos[escopo] {
+			concedidos[escopo] = true
+			validos = append(validos, escopo)
+		}
+	}
+	sort.Strings(validos)
+
+	return validos
+}
+
+// GerarChaveAPI gera uma chave de API aleatória, o prefixo exibido nas listagens e o hash
+// com que ela é armazenada. A chave em si só é entregue ao estabelecimento.
+func GerarChaveAPI() (chave, prefixo, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", "", err
+	}
+
+	chave = prefixoChaveAPI + base64.RawURLEncoding.EncodeToString(bytes)
+	return chave, chave[:tamanhoPrefixoExibido], HashChaveAPI(chave), nil
+}
+
+// HashChaveAPI calcula o hash SHA-256 de uma chave de API
+func HashChaveAPI(chave string) string {
+	hash := sha256.Sum256([]byte(chave))
+	return hex.EncodeToString(hash[:])
+}
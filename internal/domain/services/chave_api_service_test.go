@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestChaveAPI testa a validação e a geração das chaves de API
+func TestChaveAPI(t *testing.T) {
+	agora := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("NovaChaveAPI", func(t *testing.T) {
+		// Executar o método a ser testado
+		chave, err := services.NovaChaveAPI(models.CriarChaveAPIRequest{
+			Nome:    " ERP ",
+			Escopos: []string{models.EscopoPixLeitura, models.EscopoPixEscrita, models.EscopoPixLeitura},
+		}, agora)
+
+		// Verificar resultados: os escopos são ordenados e sem repetição
+		assert.NoError(t, err)
+		assert.Equal(t, "ERP", chave.Nome)
+		assert.Equal(t, []string{models.EscopoPixLeitura, models.EscopoPixEscrita}, chave.Escopos)
+		assert.True(t, chave.PermiteEscopo(models.EscopoPixEscrita))
+		assert.False(t, chave.PermiteEscopo(models.EscopoPerfilEscrita))
+	})
+
+	t.Run("SemEscopos", func(t *testing.T) {
+		// Executar o método a ser testado
+		chave, err := services.NovaChaveAPI(models.CriarChaveAPIRequest{Nome: "ERP"}, agora)
+
+		// Verificar resultados: a chave sem escopos concede todos
+		assert.NoError(t, err)
+		assert.Empty(t, chave.Escopos)
+		assert.True(t, chave.PermiteEscopo(models.EscopoPerfilEscrita))
+	})
+
+	t.Run("DadosInvalidos", func(t *testing.T) {
+		passado := agora.Add(-time.Hour)
+
+		// Executar o método a ser testado
+		_, err := services.NovaChaveAPI(models.CriarChaveAPIRequest{
+			Nome:     " ",
+			Escopos:  []string{"pix.admin"},
+			ExpiraEm: &passado,
+		}, agora)
+
+		// Verificar resultados
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Len(t, erros, 3)
+	})
+
+	t.Run("GerarChaveAPI", func(t *testing.T) {
+		// Executar o método a ser testado
+		chave, prefixo, hash, err := services.GerarChaveAPI()
+		outra, _, _, _ := services.GerarChaveAPI()
+
+		// Verificar resultados: apenas o prefixo e o hash identificam a chave armazenada
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(chave, "pixk_"))
+		assert.True(t, strings.HasPrefix(chave, prefixo))
+		assert.Len(t, prefixo, 12)
+		assert.Equal(t, services.HashChaveAPI(chave), hash)
+		assert.NotEqual(t, chave, outra)
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// tamanhoMinimoChaveRSA é o menor módulo aceito para as chaves RSA, em bits
+const tamanhoMinimoChaveRSA = 2048
+
+// ChaveAssinatura é uma chave assimétrica dos tokens JWT. A chave privada só existe na chave
+// de assinatura; as chaves antigas, mantidas durante a rotação, apenas verificam os tokens.
+type ChaveAssinatura struct {
+	// ID identifica a chave no header kid dos tokens e no JWKS. É o thumbprint da chave
+	// pública (RFC 7638), então não muda enquanto a chave for a mesma.
+	ID      string
+	Metodo  jwt.SigningMethod
+	Privada crypto.Signer
+	Publica crypto.PublicKey
+	jwk     models.JWK
+}
+
+// CarregarChaveAssinatura lê uma chave RSA ou ECDSA P-256 de um arquivo PEM. Chaves privadas
+// servem para assinar e verificar; chaves públicas, apenas para verificar.
+func CarregarChaveAssinatura(caminho string) (ChaveAssinatura, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return ChaveAssinatura{}, fmt.Errorf("erro ao ler a chave %s: %w", caminho, err)
+	}
+
+	chave, err := lerChavePEM(conteudo)
+	if err != nil {
+		return ChaveAssinatura{}, fmt.Errorf("chave %s: %w", caminho, err)
+	}
+
+	chaveAssinatura, err := NovaChaveAssinatura(chave)
+	if err != nil {
+		return ChaveAssinatura{}, fmt.Errorf("chave %s: %w", caminho, err)
+	}
+
+	return chaveAssinatura, nil
+}
+
+// NovaChaveAssinatura cria a chave de assinatura a partir de uma chave já decodificada: RSA,
+// assinada com RS256, ou ECDSA P-256, assinada com ES256
+func NovaChaveAssinatura(chave any) (ChaveAssinatura, error) {
+	var chaveAssinatura ChaveAssinatura
+
+	if privada, ok := chave.(crypto.Signer); ok {
+		chaveAssinatura.Privada = privada
+		chave = privada.Public()
+	}
+
+	switch publica := chave.(type) {
+	case *rsa.PublicKey:
+		if publica.N.BitLen() < tamanhoMinimoChaveRSA {
+			return ChaveAssinatura{}, fmt.Errorf("chaves RSA devem ter pelo menos %d bits", tamanhoMinimoChaveRSA)
+		}
+
+		chaveAssinatura.Metodo = jwt.SigningMethodRS256
+		chaveAssinatura.jwk = models.JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(publica.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publica.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if publica.Curve != elliptic.P256() {
+			return ChaveAssinatura{}, errors.New("chaves ECDSA devem usar a curva P-256")
+		}
+
+		// O formato não comprimido é 0x04 seguido das coordenadas x e y, com 32 bytes cada
+		pontoECDH, err := publica.ECDH()
+		if err != nil {
+			return ChaveAssinatura{}, err
+		}
+		ponto := pontoECDH.Bytes()
+
+		chaveAssinatura.Metodo = jwt.SigningMethodES256
+		chaveAssinatura.jwk = models.JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(ponto[1:33]),
+			Y:   base64.RawURLEncoding.EncodeToString(ponto[33:]),
+		}
+	default:
+		return ChaveAssinatura{}, fmt.Errorf("tipo de chave não suportado: %T; use RSA ou ECDSA P-256", chave)
+	}
+
+	chaveAssinatura.Publica = chave
+	chaveAssinatura.ID = thumbprintJWK(chaveAssinatura.jwk)
+	chaveAssinatura.jwk.Use = "sig"
+	chaveAssinatura.jwk.Alg = chaveAssinatura.Metodo.Alg()
+	chaveAssinatura.jwk.Kid = chaveAssinatura.ID
+
+	return chaveAssinatura, nil
+}
+
+// JWK retorna a chave pública no formato publicado em /.well-known/jwks.json
+func (c ChaveAssinatura) JWK() models.JWK {
+	return c.jwk
+}
+
+// lerChavePEM decodifica o primeiro bloco PEM com uma chave privada (PKCS#8, PKCS#1 ou SEC 1)
+// ou pública (PKIX ou PKCS#1)
+func lerChavePEM(conteudo []byte) (any, error) {
+	bloco, _ := pem.Decode(conteudo)
+	if bloco == nil {
+		return nil, errors.New("conteúdo PEM inválido")
+	}
+
+	switch bloco.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(bloco.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(bloco.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(bloco.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(bloco.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(bloco.Bytes)
+	default:
+		return nil, fmt.Errorf("bloco PEM %q não suportado", bloco.Type)
+	}
+}
+
+// thumbprintJWK calcula o thumbprint SHA-256 da chave pública (RFC 7638), a partir dos membros
+// obrigatórios do JWK em ordem alfabética
+func thumbprintJWK(jwk models.JWK) string {
+	var membros string
+	if jwk.Kty == "RSA" {
+		membros = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	} else {
+		membros = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.Crv, jwk.X, jwk.Y)
+	}
+
+	hash := sha256.Sum256([]byte(membros))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// salvarChavePEM grava a chave privada em PKCS#8 e a pública em PKIX e retorna os caminhos
+func salvarChavePEM(t *testing.T, chave crypto.Signer, nome string) (privada, publica string) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(chave)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privada = filepath.Join(t.TempDir(), nome+".pem")
+	if err := os.WriteFile(privada, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	der, err = x509.MarshalPKIXPublicKey(chave.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publica = filepath.Join(t.TempDir(), nome+".pub.pem")
+	if err := os.WriteFile(publica, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return privada, publica
+}
+
+// TestChaveAssinatura testa a assinatura dos tokens com chaves assimétricas, a rotação e o JWKS
+func TestChaveAssinatura(t *testing.T) {
+	chaveRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	chaveEC, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	rsaPrivada, rsaPublica := salvarChavePEM(t, chaveRSA, "rsa")
+	ecPrivada, _ := salvarChavePEM(t, chaveEC, "ec")
+
+	estabelecimento := models.Estabelecimento{ID: "123e4567-e89b-12d3-a456-426614174000", Email: "loja@teste.com", Nome: "Loja Teste"}
+
+	t.Run("AssinaturaRS256", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", rsaPrivada)
+
+		service, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		token, err := service.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+		claims, err := service.ValidarToken(token)
+
+		// Verificar resultados: o kid do token é o da chave publicada no JWKS
+		assert.NoError(t, err)
+		assert.Equal(t, estabelecimento.ID, claims["id"])
+
+		cabecalho, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+		assert.NoError(t, err)
+		jwks := service.JWKS()
+		assert.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "RS256", cabecalho.Header["alg"])
+		assert.Equal(t, jwks.Keys[0].Kid, cabecalho.Header["kid"])
+		assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+		assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	})
+
+	t.Run("AssinaturaES256", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", ecPrivada)
+
+		service, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		token, err := service.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+		_, err = service.ValidarToken(token)
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		jwks := service.JWKS()
+		assert.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "ES256", jwks.Keys[0].Alg)
+		assert.Equal(t, "P-256", jwks.Keys[0].Crv)
+	})
+
+	t.Run("Rotacao", func(t *testing.T) {
+		// Tokens emitidos com a chave RSA antes da rotação
+		t.Setenv("JWT_SIGNING_KEY_FILE", rsaPrivada)
+		antigo, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+		tokenAntigo, err := antigo.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+
+		// A nova chave assina; a chave antiga apenas verifica
+		t.Setenv("JWT_SIGNING_KEY_FILE", ecPrivada)
+		t.Setenv("JWT_VERIFICATION_KEY_FILES", rsaPublica)
+		novo, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		_, err = novo.ValidarToken(tokenAntigo)
+
+		// Verificar resultados: as duas chaves são publicadas, a de assinatura primeiro
+		assert.NoError(t, err)
+		jwks := novo.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "ES256", jwks.Keys[0].Alg)
+		assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+
+		// Após a remoção da chave antiga, os tokens dela deixam de ser aceitos
+		t.Setenv("JWT_VERIFICATION_KEY_FILES", "")
+		semRotacao, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+		_, err = semRotacao.ValidarToken(tokenAntigo)
+		assert.ErrorContains(t, err, "chave de assinatura desconhecida")
+	})
+
+	t.Run("TokenHMACRecusado", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", rsaPrivada)
+		service, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+
+		kid := service.JWKS().Keys[0].Kid
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": estabelecimento.ID})
+		token.Header["kid"] = kid
+		tokenString, err := token.SignedString([]byte("segredo"))
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		_, err = service.ValidarToken(tokenString)
+
+		// Verificar resultados
+		assert.ErrorContains(t, err, "método de assinatura inesperado")
+	})
+
+	t.Run("ChavePublicaNaoAssina", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", rsaPublica)
+
+		// Executar o método a ser testado
+		_, err := services.NewAutenticacaoService()
+
+		// Verificar resultados
+		assert.ErrorContains(t, err, "deve ser uma chave privada")
+	})
+
+	t.Run("ChaveRSACurta", func(t *testing.T) {
+		curta, err := rsa.GenerateKey(rand.Reader, 1024)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		_, err = services.NovaChaveAssinatura(curta)
+
+		// Verificar resultados
+		assert.ErrorContains(t, err, "2048 bits")
+	})
+
+	t.Run("SegredoPadraoEmProducao", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEY_FILE", "")
+		t.Setenv("JWT_SECRET", "")
+		t.Setenv("GIN_MODE", "release")
+
+		// Executar o método a ser testado
+		_, err := services.NewAutenticacaoService()
+
+		// Verificar resultados: apenas um segredo próprio é aceito em produção
+		assert.ErrorIs(t, err, services.ErrSegredoPadraoEmProducao)
+
+		t.Setenv("JWT_SECRET", "segredo_de_producao")
+		service, err := services.NewAutenticacaoService()
+		assert.NoError(t, err)
+		assert.Empty(t, service.JWKS().Keys)
+	})
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// tamanhoMaximoEmail é o maior e-mail aceito como chave PIX pelo DICT
+const tamanhoMaximoEmail = 77
+
+var (
+	emailRegex    = regexp.MustCompile("^[a-z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?(?:\\.[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)+$")
+	telefoneRegex = regexp.MustCompile(`^\+55[1-9]{2}(9\d{8}|[2-5]\d{7})$`)
+	evpRegex      = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+	// pontuacaoDocumento remove a máscara de CPF, CNPJ e telefone
+	pontuacaoDocumento = strings.NewReplacer(".", "", "-", "", "/", "", " ", "", "(", "", ")", "")
+)
+
+// ClassificarChave identifica o tipo de uma chave PIX, valida o seu formato
+// (incluindo os dígitos verificadores de CPF e CNPJ) e retorna o valor normalizado
+func (s *PixGeneratorService) ClassificarChave(chave string) (models.ChavePix, error) {
+	chave = strings.TrimSpace(chave)
+	if chave == "" {
+		return models.ChavePix{}, erroChave("chave PIX é obrigatória")
+	}
+
+	// E-mail
+	if strings.Contains(chave, "@") {
+		email := strings.ToLower(chave)
+		if len(email) > tamanhoMaximoEmail {
+			return models.ChavePix{}, erroChave("e-mail deve ter no máximo 77 caracteres")
+		}
+		if !emailRegex.MatchString(email) {
+			return models.ChavePix{}, erroChave("e-mail em formato inválido")
+		}
+		return models.ChavePix{Tipo: models.TipoChaveEmail, Valor: email}, nil
+	}
+
+	// Telefone no formato E.164
+	if strings.HasPrefix(chave, "+") {
+		telefone := pontuacaoDocumento.Replace(chave)
+		if !strings.HasPrefix(telefone, "+55") {
+			return models.ChavePix{}, erroChave("apenas telefones brasileiros (+55) são aceitos")
+		}
+		if !telefoneRegex.MatchString(telefone) {
+			return models.ChavePix{}, erroChave("telefone deve estar no formato +55DDDNUMERO")
+		}
+		return models.ChavePix{Tipo: models.TipoChaveTelefone, Valor: telefone}, nil
+	}
+
+	// Chave aleatória (EVP)
+	if evp := strings.ToLower(chave); evpRegex.MatchString(evp) {
+		return models.ChavePix{Tipo: models.TipoChaveEVP, Valor: evp}, nil
+	}
+
+	// CPF e CNPJ, com ou sem máscara
+	digitos := pontuacaoDocumento.Replace(chave)
+	if !somenteDigitos(digitos) {
+		return models.ChavePix{}, erroChave("formato de chave não reconhecido: use CPF, CNPJ, telefone (+55), e-mail ou chave aleatória")
+	}
+
+	switch len(digitos) {
+	case 11:
+		if cpfValido(digitos) {
+			return models.ChavePix{Tipo: models.TipoChaveCPF, Valor: digitos}, nil
+		}
+		// Sem a máscara de CPF, 11 dígitos também podem ser um celular digitado sem o +55
+		if !strings.Contains(chave, ".") && telefoneRegex.MatchString("+55"+digitos) {
+			return models.ChavePix{}, erroChave("CPF inválido; se a chave for um telefone, ele deve estar no formato +55DDDNUMERO")
+		}
+		return models.ChavePix{}, erroChave("CPF inválido: dígitos verificadores não conferem")
+	case 14:
+		if cnpjValido(digitos) {
+			return models.ChavePix{Tipo: models.TipoChaveCNPJ, Valor: digitos}, nil
+		}
+		return models.ChavePix{}, erroChave("CNPJ inválido: dígitos verificadores não conferem")
+	case 10, 12, 13:
+		return models.ChavePix{}, erroChave("telefone deve estar no formato +55DDDNUMERO")
+	default:
+		return models.ChavePix{}, erroChave("formato de chave não reconhecido: use CPF, CNPJ, telefone (+55), e-mail ou chave aleatória")
+	}
+}
+
+// erroChave cria um erro de validação para o campo chave
+func erroChave(mensagem string) models.ErroValidacao {
+	return models.ErroValidacao{Campo: "chave", Mensagem: mensagem}
+}
+
+// cpfValido confere os dígitos verificadores de um CPF com 11 dígitos
+func cpfValido(cpf string) bool {
+	if todosIguais(cpf) {
+		return false
+	}
+
+	return digitoVerificador(cpf[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[9] &&
+		digitoVerificador(cpf[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[10]
+}
+
+// cnpjValido confere os dígitos verificadores de um CNPJ com 14 dígitos
+func cnpjValido(cnpj string) bool {
+	if todosIguais(cnpj) {
+		return false
+	}
+
+	return digitoVerificador(cnpj[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[12] &&
+		digitoVerificador(cnpj[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[13]
+}
+
+// digitoVerificador calcula o dígito verificador módulo 11 usado por CPF e CNPJ
+func digitoVerificador(base string, pesos []int) byte {
+	soma := 0
+	for i, peso := range pesos {
+		soma += int(base[i]-'0') * peso
+	}
+
+	resto := soma % 11
+	if resto < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - resto)
+}
+
+// todosIguais indica se todos os dígitos são iguais (ex: 111.111.111-11), o que é inválido
+func todosIguais(digitos string) bool {
+	return strings.Count(digitos, digitos[:1]) == len(digitos)
+}
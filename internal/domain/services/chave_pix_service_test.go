@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClassificarChave testa a identificação e validação de chaves PIX
+func TestClassificarChave(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	t.Run("ChavesValidas", func(t *testing.T) {
+		casos := []struct {
+			chave string
+			tipo  string
+			valor string
+		}{
+			{"529.982.247-25", models.TipoChaveCPF, "52998224725"},
+			{"52998224725", models.TipoChaveCPF, "52998224725"},
+			{"11.222.333/0001-81", models.TipoChaveCNPJ, "11222333000181"},
+			{"+55 (11) 98765-4321", models.TipoChaveTelefone, "+5511987654321"},
+			{"+551133334444", models.TipoChaveTelefone, "+551133334444"},
+			{" JoseSilva@Email.com ", models.TipoChaveEmail, "josesilva@email.com"},
+			{"123E4567-E89B-12D3-A456-426614174000", models.TipoChaveEVP, "123e4567-e89b-12d3-a456-426614174000"},
+		}
+
+		for _, caso := range casos {
+			chave, err := service.ClassificarChave(caso.chave)
+			assert.NoError(t, err, caso.chave)
+			assert.Equal(t, caso.tipo, chave.Tipo, caso.chave)
+			assert.Equal(t, caso.valor, chave.Valor, caso.chave)
+		}
+	})
+
+	t.Run("ChavesInvalidas", func(t *testing.T) {
+		casos := []struct {
+			chave    string
+			mensagem string
+		}{
+			{"529.982.247-26", "CPF inválido"},
+			{"111.111.111-11", "CPF inválido"},
+			{"11.222.333/0001-82", "CNPJ inválido"},
+			{"11987654321", "telefone, ele deve estar no formato +55DDDNUMERO"},
+			{"+1 202 555 0143", "apenas telefones brasileiros"},
+			{"+55119876", "telefone deve estar no formato +55DDDNUMERO"},
+			{"jose@@email", "e-mail em formato inválido"},
+			{"chave qualquer", "formato de chave não reconhecido"},
+			{"", "chave PIX é obrigatória"},
+		}
+
+		for _, caso := range casos {
+			_, err := service.ClassificarChave(caso.chave)
+
+			var erroValidacao models.ErroValidacao
+			if assert.ErrorAs(t, err, &erroValidacao, caso.chave) {
+				assert.Equal(t, "chave", erroValidacao.Campo)
+				assert.Contains(t, erroValidacao.Mensagem, caso.mensagem, caso.chave)
+			}
+		}
+	})
+
+	t.Run("GeracaoComChaveInvalida", func(t *testing.T) {
+		// A geração não deve produzir códigos com chaves que o banco rejeitaria
+		_, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:   "JOSE DA SILVA",
+			Chave:  "529.982.247-26",
+			Cidade: "SAO PAULO",
+		})
+
+		assert.ErrorAs(t, err, new(models.ErrosValidacao))
+	})
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+var (
+	// ErrClienteOAuthInvalido indica um cliente OAuth2 inexistente, revogado ou com o segredo incorreto
+	ErrClienteOAuthInvalido = errors.New("cliente desconhecido, revogado ou segredo incorreto")
+
+	// ErrEscopoOAuthInvalido indica a solicitação de um escopo desconhecido ou não concedido ao cliente
+	ErrEscopoOAuthInvalido = errors.New("escopo desconhecido ou não concedido ao cliente")
+)
+
+const (
+	// prefixoSegredoClienteOAuth identifica os segredos dos clientes OAuth2, facilitando a
+	// detecção de segredos vazados em código
+	prefixoSegredoClienteOAuth = "pixs_"
+
+	// tamanhoMaximoNomeClienteOAuth é o limite do nome do cliente, definido pela coluna do banco
+	tamanhoMaximoNomeClienteOAuth = 100
+)
+
+// NovoClienteOAuth valida os dados de um novo cliente OAuth2 e normaliza os escopos
+func NovoClienteOAuth(req models.CriarClienteOAuthRequest) (models.ClienteOAuth, error) {
+	var v validador
+
+	cliente := models.ClienteOAuth{
+		Nome:    strings.TrimSpace(req.Nome),
+		Escopos: validarEscopos(&v, req.Escopos),
+	}
+
+	if cliente.Nome == "" {
+		v.campo("nome", "nome é obrigatório")
+	} else if utf8.RuneCountInString(cliente.Nome) > tamanhoMaximoNomeClienteOAuth {
+		v.campo("nome", fmt.Sprintf("nome deve ter no máximo %d caracteres", tamanhoMaximoNomeClienteOAuth))
+	}
+
+	if err := v.erro(); err != nil {
+		return models.ClienteOAuth{}, err
+	}
+
+	return cliente, nil
+}
+
+// GerarSegredoClienteOAuth gera um segredo aleatório para um cliente OAuth2 e o hash com que
+// ele é armazenado. O segredo em si só é entregue ao estabelecimento.
+func GerarSegredoClienteOAuth() (segredo, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	segredo = prefixoSegredoClienteOAuth + base64.RawURLEncoding.EncodeToString(bytes)
+	return segredo, HashSegredoClienteOAuth(segredo), nil
+}
+
+// HashSegredoClienteOAuth calcula o hash SHA-256 do segredo de um cliente OAuth2
+func HashSegredoClienteOAuth(segredo string) string {
+	hash := sha256.Sum256([]byte(segredo))
+	return hex.EncodeToString(hash[:])
+}
+
+// EscoposSolicitados valida o parâmetro scope do endpoint de token, com os escopos separados
+// por espaço, e retorna os escopos do token ordenados e sem repetições. Sem scope, o token
+// recebe todos os escopos que o cliente pode solicitar.
+func EscoposSolicitados(cliente models.ClienteOAuth, scope string) ([]string, error) {
+	permitidos := cliente.EscoposConcedidos()
+
+	solicitados := strings.Fields(scope)
+	if len(solicitados) == 0 {
+		solicitados = permitidos
+	}
+
+	escopos := []string{}
+	incluidos := map[string]bool{}
+	for _, escopo := range solicitados {
+		if !models.EscopoValido(escopo) || !models.EscopoConcedido(permitidos, escopo) {
+			return nil, fmt.Errorf("%w: %s", ErrEscopoOAuthInvalido, escopo)
+		}
+		if !incluidos[escopo] {
+			incluidos[escopo] = true
+			escopos = append(escopos, escopo)
+		}
+	}
+	sort.Strings(escopos)
+
+	return escopos, nil
+}
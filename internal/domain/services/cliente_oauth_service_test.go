@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClienteOAuth testa o segredo dos clientes OAuth2 e os escopos solicitados no endpoint de token
+func TestClienteOAuth(t *testing.T) {
+	t.Run("GerarSegredo", func(t *testing.T) {
+		// Executar o método a ser testado
+		segredo, hash, err := services.GerarSegredoClienteOAuth()
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(segredo, "pixs_"))
+		assert.Equal(t, services.HashSegredoClienteOAuth(segredo), hash)
+		assert.Len(t, hash, 64)
+	})
+
+	t.Run("EscoposSolicitados", func(t *testing.T) {
+		restrito := models.ClienteOAuth{Escopos: []string{models.EscopoPixEscrita, models.EscopoPixLeitura}}
+
+		casos := []struct {
+			nome     string
+			cliente  models.ClienteOAuth
+			scope    string
+			esperado []string
+			invalido bool
+		}{
+			{"SubconjuntoOrdenado", restrito, "pix.write  pix.read pix.write", []string{"pix.read", "pix.write"}, false},
+			{"SemScopeRecebeOsDoCliente", restrito, "", []string{"pix.read", "pix.write"}, false},
+			{"SemScopeClienteSemEscopos", models.ClienteOAuth{}, "", []string{"cob.read", "cob.write", "perfil.read", "perfil.write", "pix.read", "pix.write"}, false},
+			{"NaoConcedido", restrito, "cob.read", nil, true},
+			{"Desconhecido", models.ClienteOAuth{}, "admin", nil, true},
+		}
+
+		for _, caso := range casos {
+			// Executar o método a ser testado
+			escopos, err := services.EscoposSolicitados(caso.cliente, caso.scope)
+
+			// Verificar resultados
+			if caso.invalido {
+				assert.ErrorIs(t, err, services.ErrEscopoOAuthInvalido, caso.nome)
+				continue
+			}
+			assert.NoError(t, err, caso.nome)
+			assert.Equal(t, caso.esperado, escopos, caso.nome)
+		}
+	})
+}
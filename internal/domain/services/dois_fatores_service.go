@@ -0,0 +1,186 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Parâmetros do TOTP aceitos por todos os aplicativos autenticadores: HMAC-SHA1, códigos de 6
+// dígitos e passos de 30 segundos
+const (
+	digitosTOTP = 6
+	passoTOTP   = 30
+
+	// toleranciaTOTP é o número de passos aceitos antes e depois do atual, para compensar a
+	// diferença entre o relógio do servidor e o do celular
+	toleranciaTOTP = 1
+
+	// quantidadeCodigosRecuperacao é o número de códigos de recuperação gerados por vez
+	quantidadeCodigosRecuperacao = 10
+)
+
+var (
+	// ErrCodigoDoisFatoresInvalido indica um código do aplicativo ou de recuperação incorreto,
+	// expirado ou já usado
+	ErrCodigoDoisFatoresInvalido = errors.New("código de verificação inválido")
+
+	// base32SemPreenchimento é a codificação dos segredos nas URIs otpauth
+	base32SemPreenchimento = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	// alfabetoCodigoRecuperacao evita caracteres confundíveis, como 0 e o ou 1 e l
+	alfabetoCodigoRecuperacao = []byte("23456789abcdefghjkmnpqrstuvwxyz")
+)
+
+// GerarSegredoTOTP gera um segredo aleatório de 160 bits, o tamanho recomendado pela RFC 4226,
+// codificado em base32
+func GerarSegredoTOTP() (string, error) {
+	bytes := make([]byte, 20)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base32SemPreenchimento.EncodeToString(bytes), nil
+}
+
+// PassoTOTP retorna o passo de 30 segundos do instante informado
+func PassoTOTP(instante time.Time) int64 {
+	return instante.Unix() / passoTOTP
+}
+
+// CodigoTOTP calcula o código do segredo no passo informado (RFC 6238)
+func CodigoTOTP(segredo string, passo int64) (string, error) {
+	chave, err := base32SemPreenchimento.DecodeString(strings.ToUpper(strings.TrimRight(segredo, "=")))
+	if err != nil {
+		return "", fmt.Errorf("segredo TOTP inválido: %w", err)
+	}
+
+	var contador [8]byte
+	binary.BigEndian.PutUint64(contador[:], uint64(passo))
+
+	mac := hmac.New(sha1.New, chave)
+	mac.Write(contador[:])
+	soma := mac.Sum(nil)
+
+	// Truncamento dinâmico da RFC 4226
+	deslocamento := soma[len(soma)-1] & 0x0f
+	valor := binary.BigEndian.Uint32(soma[deslocamento:deslocamento+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digitosTOTP, valor%1000000), nil
+}
+
+// ValidarCodigoTOTP confere o código no passo do instante informado e nos passos vizinhos e
+// retorna o passo em que ele foi aceito, para impedir a reutilização do mesmo código
+func ValidarCodigoTOTP(segredo, codigo string, agora time.Time) (int64, error) {
+	codigo = strings.ReplaceAll(strings.TrimSpace(codigo), " ", "")
+	if !CodigoTOTPValido(codigo) {
+		return 0, ErrCodigoDoisFatoresInvalido
+	}
+
+	atual := PassoTOTP(agora)
+	for passo := atual - toleranciaTOTP; passo <= atual+toleranciaTOTP; passo++ {
+		esperado, err := CodigoTOTP(segredo, passo)
+		if err != nil {
+			return 0, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(esperado), []byte(codigo)) == 1 {
+			return passo, nil
+		}
+	}
+
+	return 0, ErrCodigoDoisFatoresInvalido
+}
+
+// CodigoTOTPValido informa se o código tem o formato de um código do aplicativo: 6 dígitos,
+// que o diferenciam de um código de recuperação
+func CodigoTOTPValido(codigo string) bool {
+	if len(codigo) != digitosTOTP {
+		return false
+	}
+
+	for _, c := range codigo {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// URIOTPAuth monta a URI otpauth:// lida pelos aplicativos autenticadores, com o emissor e o
+// email do login como rótulo
+func URIOTPAuth(emissor, conta, segredo string) string {
+	parametros := url.Values{}
+	parametros.Set("secret", segredo)
+	parametros.Set("issuer", emissor)
+	parametros.Set("algorithm", "SHA1")
+	parametros.Set("digits", fmt.Sprint(digitosTOTP))
+	parametros.Set("period", fmt.Sprint(passoTOTP))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + emissor + ":" + conta,
+		RawQuery: parametros.Encode(),
+	}
+	return uri.String()
+}
+
+// QRCodeOTPAuth gera o QR code da URI otpauth:// em formato PNG (base64)
+func QRCodeOTPAuth(uri string) (string, error) {
+	png, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
+
+// GerarCodigosRecuperacao gera os códigos de recuperação, no formato xxxxx-xxxxx, e os hashes
+// com que eles são armazenados. Os códigos em si só são exibidos uma vez.
+func GerarCodigosRecuperacao() (codigos, hashes []string, err error) {
+	for i := 0; i < quantidadeCodigosRecuperacao; i++ {
+		caracteres := make([]byte, 10)
+		for j := range caracteres {
+			indice, err := rand.Int(rand.Reader, big.NewInt(int64(len(alfabetoCodigoRecuperacao))))
+			if err != nil {
+				return nil, nil, err
+			}
+			caracteres[j] = alfabetoCodigoRecuperacao[indice.Int64()]
+		}
+
+		codigo := string(caracteres[:5]) + "-" + string(caracteres[5:])
+		codigos = append(codigos, codigo)
+		hashes = append(hashes, HashCodigoRecuperacao(codigo))
+	}
+
+	return codigos, hashes, nil
+}
+
+// HashCodigoRecuperacao calcula o hash SHA-256 de um código de recuperação, sem diferenciar
+// maiúsculas e ignorando o hífen e os espaços
+func HashCodigoRecuperacao(codigo string) string {
+	normalizado := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(codigo)))
+	hash := sha256.Sum256([]byte(normalizado))
+	return hex.EncodeToString(hash[:])
+}
+
+// GerarDesafioDoisFatores gera o desafio do login pendente do segundo fator e o hash com que
+// ele é guardado no cache
+func GerarDesafioDoisFatores() (desafio, hash string, err error) {
+	return GerarTokenConta()
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDoisFatores testa o cálculo dos códigos TOTP e os códigos de recuperação
+func TestDoisFatores(t *testing.T) {
+	// Segredo dos vetores de teste SHA-1 da RFC 6238
+	segredoRFC := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	t.Run("VetoresRFC6238", func(t *testing.T) {
+		// Os códigos de 6 dígitos são os últimos dígitos dos códigos de 8 dígitos da RFC
+		casos := []struct {
+			instante int64
+			codigo   string
+		}{
+			{59, "287082"},
+			{1111111109, "081804"},
+			{1111111111, "050471"},
+			{1234567890, "005924"},
+			{2000000000, "279037"},
+		}
+
+		for _, caso := range casos {
+			// Executar o método a ser testado
+			codigo, err := services.CodigoTOTP(segredoRFC, services.PassoTOTP(time.Unix(caso.instante, 0)))
+
+			// Verificar resultados
+			assert.NoError(t, err)
+			assert.Equal(t, caso.codigo, codigo, "instante %d", caso.instante)
+		}
+	})
+
+	t.Run("ToleranciaDoRelogio", func(t *testing.T) {
+		agora := time.Unix(1111111111, 0)
+		anterior, err := services.CodigoTOTP(segredoRFC, services.PassoTOTP(agora)-1)
+		assert.NoError(t, err)
+		antigo, err := services.CodigoTOTP(segredoRFC, services.PassoTOTP(agora)-2)
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		passo, err := services.ValidarCodigoTOTP(segredoRFC, anterior, agora)
+
+		// Verificar resultados: o passo anterior é aceito, e os mais antigos não
+		assert.NoError(t, err)
+		assert.Equal(t, services.PassoTOTP(agora)-1, passo)
+
+		_, err = services.ValidarCodigoTOTP(segredoRFC, antigo, agora)
+		assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+
+		_, err = services.ValidarCodigoTOTP(segredoRFC, "12345a", agora)
+		assert.ErrorIs(t, err, services.ErrCodigoDoisFatoresInvalido)
+	})
+
+	t.Run("URIOTPAuth", func(t *testing.T) {
+		segredo, err := services.GerarSegredoTOTP()
+		assert.NoError(t, err)
+		assert.Len(t, segredo, 32)
+
+		// Executar o método a ser testado
+		uri, err := url.Parse(services.URIOTPAuth("Gerador de PIX", "contato@lojadojose.com.br", segredo))
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, "otpauth", uri.Scheme)
+		assert.Equal(t, "totp", uri.Host)
+		assert.Equal(t, "/Gerador de PIX:contato@lojadojose.com.br", uri.Path)
+		assert.Equal(t, segredo, uri.Query().Get("secret"))
+		assert.Equal(t, "Gerador de PIX", uri.Query().Get("issuer"))
+
+		qrcode, err := services.QRCodeOTPAuth(uri.String())
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(qrcode, "data:image/png;base64,"))
+	})
+
+	t.Run("CodigosRecuperacao", func(t *testing.T) {
+		// Executar o método a ser testado
+		codigos, hashes, err := services.GerarCodigosRecuperacao()
+
+		// Verificar resultados: códigos distintos, e o hash ignora maiúsculas, hífen e espaços
+		assert.NoError(t, err)
+		assert.Len(t, codigos, 10)
+		assert.Len(t, hashes, 10)
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, codigos[0])
+		assert.NotEqual(t, codigos[0], codigos[1])
+
+		digitado := " " + strings.ToUpper(strings.Replace(codigos[0], "-", "", 1)) + " "
+		assert.Equal(t, hashes[0], services.HashCodigoRecuperacao(digitado))
+	})
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Limites da listagem de PIX
+const (
+	// limitePadraoListagemPix é o número de itens por página quando nada é informado
+	limitePadraoListagemPix = 50
+
+	// LimiteMaximoListagemPix é o maior número de itens por página
+	LimiteMaximoListagemPix = 200
+)
+
+// cursorPixJSON é a forma serializada do cursor, enviada ao cliente em base64
+type cursorPixJSON struct {
+	Ordenacao   string           `json:"o"`
+	Decrescente bool             `json:"d"`
+	ID          uint             `json:"i"`
+	CriadoEm    time.Time        `json:"c"`
+	Valor       *models.Dinheiro `json:"v,omitempty"`
+}
+
+// NovoFiltroPix valida os filtros da listagem de PIX e aplica os padrões: ordenação pela
+// data de criação, da mais recente para a mais antiga, com 50 itens por página
+func NovoFiltroPix(req models.ListarPixRequest) (models.FiltroPix, error) {
+	var v validador
+
+	filtro := models.FiltroPix{
+		CriadoDe:      lerDataFiltro(&v, "criado_de", req.CriadoDe, false),
+		CriadoAte:     lerDataFiltro(&v, "criado_ate", req.CriadoAte, true),
+		ValorMinimo:   lerValorFiltro(&v, "valor_min", req.ValorMinimo),
+		ValorMaximo:   lerValorFiltro(&v, "valor_max", req.ValorMaximo),
+		Identificador: strings.TrimSpace(req.Identificador),
+		Chave:         strings.TrimSpace(req.Chave),
+		Status:        strings.ToUpper(strings.TrimSpace(req.Status)),
+		Ordenacao:     strings.ToLower(strings.TrimSpace(req.Ordenar)),
+		Limite:        limitePadraoListagemPix,
+	}
+
+	if filtro.CriadoDe != nil && filtro.CriadoAte != nil && filtro.CriadoDe.After(*filtro.CriadoAte) {
+		v.campo("criado_ate", "criado_ate deve ser igual ou posterior a criado_de")
+	}
+
+	if filtro.ValorMinimo != nil && filtro.ValorMaximo != nil && *filtro.ValorMinimo > *filtro.ValorMaximo {
+		v.campo("valor_max", "valor_max deve ser igual ou maior que valor_min")
+	}
+
+	if filtro.Identificador != "" && !referenciaRegex.MatchString(filtro.Identificador) {
+		v.campo("identificador", fmt.Sprintf("identificador deve ter até %d letras e números, sem espaços, acentos ou símbolos", tamanhoMaximoIdentificador))
+	}
+
+	if filtro.Status != "" && filtro.Status != models.StatusPixAtivo && filtro.Status != models.StatusPixExpirado {
+		v.campo("status", "status deve ser ATIVO ou EXPIRADO")
+	}
+
+	switch filtro.Ordenacao {
+	case "":
+		filtro.Ordenacao = models.OrdenacaoPixCriadoEm
+	case models.OrdenacaoPixCriadoEm, models.OrdenacaoPixValor:
+	default:
+		v.campo("ordenar", "ordenar deve ser criado_em ou valor")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(req.Ordem)) {
+	case "", "desc":
+		filtro.Decrescente = true
+	case "asc":
+	default:
+		v.campo("ordem", "ordem deve ser asc ou desc")
+	}
+
+	if req.Limite != "" {
+		limite, err := strconv.Atoi(req.Limite)
+		if err != nil || limite < 1 || limite > LimiteMaximoListagemPix {
+			v.campo("limite", fmt.Sprintf("limite deve ser um número de 1 a %d", LimiteMaximoListagemPix))
+		} else {
+			filtro.Limite = limite
+		}
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodificarCursorPix(req.Cursor)
+		switch {
+		case err != nil:
+			v.campo("cursor", "cursor inválido")
+		case cursor.Ordenacao != filtro.Ordenacao || cursor.Decrescente != filtro.Decrescente:
+			// Um cursor só vale para a ordenação da página que o gerou
+			v.campo("cursor", "cursor não corresponde à ordenação informada")
+		default:
+			filtro.Apos = &cursor
+		}
+	}
+
+	if err := v.erro(); err != nil {
+		return models.FiltroPix{}, err
+	}
+
+	return filtro, nil
+}
+
+// CodificarCursorPix gera o cursor da página seguinte a partir do último PIX da página atual
+func CodificarCursorPix(filtro models.FiltroPix, ultimo models.Pix) string {
+	cursor := cursorPixJSON{
+		Ordenacao:   filtro.Ordenacao,
+		Decrescente: filtro.Decrescente,
+		ID:          ultimo.ID,
+		CriadoEm:    ultimo.CriadoEm,
+		Valor:       ultimo.Valor,
+	}
+
+	dados, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(dados)
+}
+
+// decodificarCursorPix converte o cursor recebido do cliente
+func decodificarCursorPix(texto string) (models.CursorPix, error) {
+	dados, err := base64.RawURLEncoding.DecodeString(texto)
+	if err != nil {
+		return models.CursorPix{}, err
+	}
+
+	var cursor cursorPixJSON
+	if err := json.Unmarshal(dados, &cursor); err != nil {
+		return models.CursorPix{}, err
+	}
+
+	if cursor.ID == 0 {
+		return models.CursorPix{}, errors.New("cursor sem ID")
+	}
+
+	return models.CursorPix{
+		Ordenacao:   cursor.Ordenacao,
+		Decrescente: cursor.Decrescente,
+		ID:          cursor.ID,
+		CriadoEm:    cursor.CriadoEm,
+		Valor:       cursor.Valor,
+	}, nil
+}
+
+// lerDataFiltro converte uma data (AAAA-MM-DD) ou um instante (RFC 3339) de um filtro.
+// Como limite final, uma data inclui o dia inteiro.
+func lerDataFiltro(v *validador, campo, texto string, final bool) *time.Time {
+	texto = strings.TrimSpace(texto)
+	if texto == "" {
+		return nil
+	}
+
+	if instante, err := time.Parse(time.RFC3339, texto); err == nil {
+		return &instante
+	}
+
+	data, err := time.ParseInLocation(models.FormatoData, texto, time.Local)
+	if err != nil {
+		v.campo(campo, campo+" deve estar no formato AAAA-MM-DD ou RFC 3339")
+		return nil
+	}
+
+	if final {
+		data = data.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	return &data
+}
+
+// lerValorFiltro converte um valor monetário de um filtro
+func lerValorFiltro(v *validador, campo, texto string) *models.Dinheiro {
+	if strings.TrimSpace(texto) == "" {
+		return nil
+	}
+
+	valor, err := models.ParseDinheiro(texto)
+	if err != nil {
+		v.campo(campo, campo+": "+err.Error())
+		return nil
+	}
+
+	return &valor
+}
@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNovoFiltroPix testa a validação dos filtros e do cursor da listagem de PIX
+func TestNovoFiltroPix(t *testing.T) {
+	t.Run("Padroes", func(t *testing.T) {
+		// Executar o método a ser testado
+		filtro, err := services.NovoFiltroPix(models.ListarPixRequest{})
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrdenacaoPixCriadoEm, filtro.Ordenacao)
+		assert.True(t, filtro.Decrescente)
+		assert.Equal(t, 50, filtro.Limite)
+		assert.Nil(t, filtro.Apos)
+	})
+
+	t.Run("FiltrosValidos", func(t *testing.T) {
+		// Executar o método a ser testado
+		filtro, err := services.NovoFiltroPix(models.ListarPixRequest{
+			CriadoDe:      "2025-01-01",
+			CriadoAte:     "2025-01-31",
+			ValorMinimo:   "10",
+			ValorMaximo:   "100.50",
+			Identificador: "FATURA123",
+			Status:        "expirado",
+			Ordenar:       "valor",
+			Ordem:         "asc",
+			Limite:        "200",
+		})
+
+		// Verificar resultados: a data final inclui o dia inteiro
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), *filtro.CriadoDe)
+		assert.Equal(t, time.Date(2025, 1, 31, 23, 59, 59, 0, time.Local), *filtro.CriadoAte)
+		assert.Equal(t, models.NovoDinheiro(1000), *filtro.ValorMinimo)
+		assert.Equal(t, models.NovoDinheiro(10050), *filtro.ValorMaximo)
+		assert.Equal(t, models.StatusPixExpirado, filtro.Status)
+		assert.Equal(t, models.OrdenacaoPixValor, filtro.Ordenacao)
+		assert.False(t, filtro.Decrescente)
+		assert.Equal(t, 200, filtro.Limite)
+	})
+
+	t.Run("FiltrosInvalidos", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := services.NovoFiltroPix(models.ListarPixRequest{
+			CriadoDe:      "2025-02-01",
+			CriadoAte:     "2025-01-01",
+			ValorMinimo:   "abc",
+			Identificador: "FATURA 123",
+			Status:        "PAGO",
+			Ordenar:       "nome",
+			Ordem:         "aleatoria",
+			Limite:        "500",
+			Cursor:        "???",
+		})
+
+		// Verificar resultados: todos os erros são devolvidos de uma vez
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+
+		var campos []string
+		for _, erro := range erros {
+			campos = append(campos, erro.Campo)
+		}
+		assert.ElementsMatch(t, []string{"criado_ate", "valor_min", "identificador", "status", "ordenar", "ordem", "limite", "cursor"}, campos)
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		valor := models.NovoDinheiro(2500)
+		ultimo := models.Pix{
+			ID:       42,
+			Valor:    &valor,
+			CriadoEm: time.Date(2025, 1, 10, 14, 30, 0, 0, time.UTC),
+		}
+
+		filtro, err := services.NovoFiltroPix(models.ListarPixRequest{Ordenar: "valor"})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		cursor := services.CodificarCursorPix(filtro, ultimo)
+		proxima, err := services.NovoFiltroPix(models.ListarPixRequest{Ordenar: "valor", Cursor: cursor})
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), proxima.Apos.ID)
+		assert.Equal(t, valor, *proxima.Apos.Valor)
+		assert.True(t, ultimo.CriadoEm.Equal(proxima.Apos.CriadoEm))
+
+		// O cursor não pode ser reaproveitado com outra ordenação
+		_, err = services.NovoFiltroPix(models.ListarPixRequest{Ordenar: "valor", Ordem: "asc", Cursor: cursor})
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Equal(t, "cursor", erros[0].Campo)
+	})
+}
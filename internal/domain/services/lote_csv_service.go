@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrCSVCabecalho indica que o cabeçalho do CSV do lote está ausente ou é inválido
+var ErrCSVCabecalho = errors.New("cabeçalho do CSV inválido")
+
+// colunasLoteCSV lista as colunas aceitas no CSV do lote e como cada uma preenche a requisição
+var colunasLoteCSV = map[string]func(req *models.PixRequest, valor string) error{
+	"nome":   func(req *models.PixRequest, valor string) error { req.Nome = valor; return nil },
+	"chave":  func(req *models.PixRequest, valor string) error { req.Chave = valor; return nil },
+	"cidade": func(req *models.PixRequest, valor string) error { req.Cidade = valor; return nil },
+	"chave_id": func(req *models.PixRequest, valor string) error {
+		id, err := strconv.ParseUint(valor, 10, 32)
+		if err != nil || id == 0 {
+			return errors.New("chave_id deve ser o ID de uma chave cadastrada")
+		}
+		chaveID := uint(id)
+		req.ChaveID = &chaveID
+		return nil
+	},
+	"valor": func(req *models.PixRequest, valor string) error {
+		dinheiro, err := models.ParseDinheiro(strings.Replace(valor, ",", ".", 1))
+		if err != nil {
+			return err
+		}
+		req.Valor = &dinheiro
+		return nil
+	},
+	"identificador": func(req *models.PixRequest, valor string) error { req.Identificador = &valor; return nil },
+	"descricao":     func(req *models.PixRequest, valor string) error { req.Descricao = &valor; return nil },
+	"mcc":           func(req *models.PixRequest, valor string) error { req.MCC = &valor; return nil },
+	"cep":           func(req *models.PixRequest, valor string) error { req.CEP = &valor; return nil },
+	"modalidade":    func(req *models.PixRequest, valor string) error { req.Modalidade = valor; return nil },
+}
+
+// LerLoteCSV lê um lote de PIX em CSV. A primeira linha é o cabeçalho com os nomes das colunas;
+// nome, chave e cidade podem ser omitidos para usar o perfil do estabelecimento. O separador pode
+// ser vírgula ou ponto e vírgula. Problemas em uma linha são registrados no item correspondente,
+// sem interromper a leitura das demais.
+func LerLoteCSV(r io.Reader) ([]models.ItemLote, error) {
+	leitor := bufio.NewReader(r)
+
+	// O separador é identificado pelo cabeçalho: planilhas em português costumam usar ";"
+	primeiraLinha, err := leitor.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	primeiraLinha = strings.TrimPrefix(primeiraLinha, "\ufeff")
+
+	csvReader := csv.NewReader(io.MultiReader(strings.NewReader(primeiraLinha), leitor))
+	if strings.Count(primeiraLinha, ";") > strings.Count(primeiraLinha, ",") {
+		csvReader.Comma = ';'
+	}
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	cabecalho, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCSVCabecalho, err)
+	}
+
+	presentes := make(map[string]bool)
+	for i, coluna := range cabecalho {
+		coluna = strings.ToLower(strings.TrimSpace(coluna))
+		if _, existe := colunasLoteCSV[coluna]; !existe {
+			return nil, fmt.Errorf("%w: coluna desconhecida %q", ErrCSVCabecalho, coluna)
+		}
+		if presentes[coluna] {
+			return nil, fmt.Errorf("%w: coluna %q repetida", ErrCSVCabecalho, coluna)
+		}
+		presentes[coluna] = true
+		cabecalho[i] = coluna
+	}
+
+	if presentes["chave"] && presentes["chave_id"] {
+		return nil, fmt.Errorf("%w: informe a coluna chave ou chave_id, não ambas", ErrCSVCabecalho)
+	}
+
+	var itens []models.ItemLote
+	for {
+		registro, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido: %w", err)
+		}
+
+		linha, _ := csvReader.FieldPos(0)
+		item := models.ItemLote{Linha: linha}
+
+		if len(registro) != len(cabecalho) {
+			item.ErroLeitura = fmt.Errorf("linha com %d colunas, esperadas %d", len(registro), len(cabecalho))
+			itens = append(itens, item)
+			continue
+		}
+
+		var v validador
+		for i, valor := range registro {
+			valor = strings.TrimSpace(valor)
+			if valor == "" {
+				continue
+			}
+			if err := colunasLoteCSV[cabecalho[i]](&item.Requisicao, valor); err != nil {
+				v.campo(cabecalho[i], err.Error())
+			}
+		}
+		item.ErroLeitura = v.erro()
+
+		itens = append(itens, item)
+	}
+
+	return itens, nil
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLerLoteCSV testa a leitura de lotes de PIX em CSV
+func TestLerLoteCSV(t *testing.T) {
+	t.Run("SeparadorVirgula", func(t *testing.T) {
+		csv := "nome,chave,cidade,valor,identificador\n" +
+			"JOSE DA SILVA,josesilva@email.com,SAO PAULO,100.50,FATURA1\n" +
+			"MARIA SOUZA,+5511999998888,,,\n"
+
+		// Executar o método a ser testado
+		itens, err := services.LerLoteCSV(strings.NewReader(csv))
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Len(t, itens, 2)
+
+		assert.Equal(t, 2, itens[0].Linha)
+		assert.NoError(t, itens[0].ErroLeitura)
+		assert.Equal(t, "JOSE DA SILVA", itens[0].Requisicao.Nome)
+		assert.Equal(t, models.NovoDinheiro(10050), *itens[0].Requisicao.Valor)
+		assert.Equal(t, "FATURA1", *itens[0].Requisicao.Identificador)
+
+		assert.Equal(t, 3, itens[1].Linha)
+		assert.Nil(t, itens[1].Requisicao.Valor)
+		assert.Nil(t, itens[1].Requisicao.Identificador)
+		assert.Empty(t, itens[1].Requisicao.Cidade)
+	})
+
+	t.Run("SeparadorPontoEVirgula", func(t *testing.T) {
+		csv := "\ufeffNome;Chave;Valor\r\nJOSE DA SILVA;josesilva@email.com;99,90\r\n"
+
+		// Executar o método a ser testado
+		itens, err := services.LerLoteCSV(strings.NewReader(csv))
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Len(t, itens, 1)
+		assert.Equal(t, models.NovoDinheiro(9990), *itens[0].Requisicao.Valor)
+	})
+
+	t.Run("ChaveCadastrada", func(t *testing.T) {
+		csv := "chave_id,valor\n1,10.00\nabc,20.00\n"
+
+		// Executar o método a ser testado
+		itens, err := services.LerLoteCSV(strings.NewReader(csv))
+
+		// Verificar resultados: nome e chave ficam em branco para serem lidos do perfil
+		assert.NoError(t, err)
+		assert.Len(t, itens, 2)
+		assert.NoError(t, itens[0].ErroLeitura)
+		assert.Equal(t, uint(1), *itens[0].Requisicao.ChaveID)
+		assert.Empty(t, itens[0].Requisicao.Nome)
+		assert.Error(t, itens[1].ErroLeitura)
+	})
+
+	t.Run("ErrosPorLinha", func(t *testing.T) {
+		csv := "nome,chave,valor\n" +
+			"JOSE DA SILVA,josesilva@email.com,abc\n" +
+			"MARIA SOUZA,maria@email.com\n" +
+			"ANA LIMA,ana@email.com,10.00\n"
+
+		// Executar o método a ser testado
+		itens, err := services.LerLoteCSV(strings.NewReader(csv))
+
+		// Verificar resultados: as linhas com problema não interrompem a leitura
+		assert.NoError(t, err)
+		assert.Len(t, itens, 3)
+
+		var errosValidacao models.ErrosValidacao
+		if assert.ErrorAs(t, itens[0].ErroLeitura, &errosValidacao) {
+			assert.Equal(t, "valor", errosValidacao[0].Campo)
+		}
+		assert.Error(t, itens[1].ErroLeitura)
+		assert.NoError(t, itens[2].ErroLeitura)
+	})
+
+	t.Run("CabecalhoInvalido", func(t *testing.T) {
+		casos := map[string]string{
+			"Vazio":              "",
+			"ColunaDesconhecida": "nome,chave,telefone\n",
+			"ColunaRepetida":     "nome,chave,nome\n",
+			"ChaveEChaveID":      "nome,chave,chave_id\n",
+		}
+
+		for nome, csv := range casos {
+			// Executar o método a ser testado
+			_, err := services.LerLoteCSV(strings.NewReader(csv))
+
+			// Verificar resultados
+			assert.ErrorIs(t, err, services.ErrCSVCabecalho, nome)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ArquivoLote representa uma imagem do ZIP de um lote
+type ArquivoLote struct {
+	Nome  string
+	Dados []byte
+}
+
+// MontarImagensLote monta a imagem PNG de cada linha gerada do lote, aplicando o template
+// quando informado. Os arquivos são nomeados pelo identificador ou pelo número da linha.
+func MontarImagensLote(resultado models.ResultadoLote, templateProcessor *TemplateProcessor, templateName string) ([]ArquivoLote, error) {
+	var arquivos []ArquivoLote
+	nomes := make(map[string]bool)
+
+	for _, item := range resultado.Itens {
+		if !item.Sucesso {
+			continue
+		}
+
+		var dados []byte
+		var err error
+		if templateName != "" {
+			dados, err = templateProcessor.ApplyTemplate(item.QRCodePNG, templateName)
+		} else {
+			dados, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(item.QRCodePNG, "data:image/png;base64,"))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar a imagem da linha %d: %w", item.Linha, err)
+		}
+
+		arquivos = append(arquivos, ArquivoLote{Nome: nomeArquivoLote(item, nomes), Dados: dados})
+	}
+
+	return arquivos, nil
+}
+
+// EscreverZipLote escreve um ZIP com as imagens do lote e o arquivo resultado.json
+func EscreverZipLote(w io.Writer, arquivos []ArquivoLote, resultado models.ResultadoLote) error {
+	relatorio, err := json.MarshalIndent(resultado, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	zipWriter := zip.NewWriter(w)
+	for _, arquivo := range arquivos {
+		// Os PNG já são comprimidos e são apenas armazenados no ZIP
+		escritor, err := zipWriter.CreateHeader(&zip.FileHeader{Name: arquivo.Nome, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if _, err := escritor.Write(arquivo.Dados); err != nil {
+			return err
+		}
+	}
+
+	escritor, err := zipWriter.Create("resultado.json")
+	if err != nil {
+		return err
+	}
+	if _, err := escritor.Write(relatorio); err != nil {
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+// nomeArquivoLote retorna o nome da imagem de uma linha: o identificador, quando informado,
+// ou o número da linha. Nomes repetidos recebem o número da linha como sufixo.
+func nomeArquivoLote(item models.ResultadoItemLote, usados map[string]bool) string {
+	nome := item.Identificador
+	if nome == "" || nome == referenciaEstatico {
+		nome = fmt.Sprintf("linha_%d", item.Linha)
+	}
+	if usados[nome+".png"] {
+		nome = fmt.Sprintf("%s_linha_%d", nome, item.Linha)
+	}
+
+	usados[nome+".png"] = true
+	return nome + ".png"
+}
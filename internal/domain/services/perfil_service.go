@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Limites do perfil do estabelecimento, definidos pelas colunas do banco. Nome e cidade
+// mais longos que o BR Code comporta são ajustados na geração, como nas requisições.
+const (
+	tamanhoMaximoRazaoSocial  = 100
+	tamanhoMaximoCidadePerfil = 50
+	tamanhoMaximoApelidoChave = 50
+)
+
+// cidadePadrao é usada quando nem a requisição nem o perfil informam a cidade
+const cidadePadrao = "São Paulo"
+
+// NovoPerfil valida os dados do perfil do estabelecimento e os normaliza para persistência
+func NovoPerfil(req models.PerfilRequest) (models.PerfilEstabelecimento, error) {
+	var v validador
+
+	perfil := models.PerfilEstabelecimento{
+		RazaoSocial: strings.TrimSpace(req.RazaoSocial),
+		Cidade:      strings.TrimSpace(req.Cidade),
+		MCC:         strings.TrimSpace(req.MCC),
+		CEP:         strings.ReplaceAll(strings.TrimSpace(req.CEP), "-", ""),
+	}
+
+	if utf8.RuneCountInString(perfil.RazaoSocial) > tamanhoMaximoRazaoSocial {
+		v.campo("razao_social", fmt.Sprintf("razão social deve ter no máximo %d caracteres", tamanhoMaximoRazaoSocial))
+	}
+
+	if utf8.RuneCountInString(perfil.Cidade) > tamanhoMaximoCidadePerfil {
+		v.campo("cidade", fmt.Sprintf("cidade deve ter no máximo %d caracteres", tamanhoMaximoCidadePerfil))
+	}
+
+	if perfil.MCC != "" && !mccRegex.MatchString(perfil.MCC) {
+		v.campo("mcc", "MCC deve conter 4 dígitos")
+	}
+
+	if perfil.CEP != "" && !cepRegex.MatchString(perfil.CEP) {
+		v.campo("cep", "CEP deve conter 8 dígitos")
+	}
+
+	if err := v.erro(); err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	return perfil, nil
+}
+
+// ValidarApelidoChave confere o apelido de uma chave cadastrada
+func ValidarApelidoChave(apelido string) error {
+	if utf8.RuneCountInString(apelido) > tamanhoMaximoApelidoChave {
+		return models.ErrosValidacao{{
+			Campo:    "apelido",
+			Mensagem: fmt.Sprintf("apelido deve ter no máximo %d caracteres", tamanhoMaximoApelidoChave),
+		}}
+	}
+	return nil
+}
+
+// AplicarPerfil completa a requisição com os dados do perfil do estabelecimento: a chave
+// referenciada por chave_id ou a chave padrão, a razão social, a cidade, o MCC e o CEP.
+// Os campos informados na requisição têm precedência sobre o perfil.
+func AplicarPerfil(req models.PixRequest, perfil models.PerfilEstabelecimento) (models.PixRequest, error) {
+	var v validador
+
+	switch {
+	case req.ChaveID != nil && strings.TrimSpace(req.Chave) != "":
+		v.campo("chave_id", "informe chave ou chave_id, não ambos")
+	case req.ChaveID != nil:
+		if chave, existe := perfil.Chave(*req.ChaveID); existe {
+			req.Chave = chave.Chave
+		} else {
+			v.campo("chave_id", "chave cadastrada não encontrada no perfil")
+		}
+	case strings.TrimSpace(req.Chave) == "":
+		if chave, existe := perfil.ChavePadrao(); existe {
+			req.Chave = chave.Chave
+		} else {
+			v.campo("chave", "chave PIX é obrigatória quando o perfil não tem uma chave padrão")
+		}
+	}
+
+	if strings.TrimSpace(req.Nome) == "" {
+		if perfil.RazaoSocial != "" {
+			req.Nome = perfil.RazaoSocial
+		} else {
+			v.campo("nome", "nome é obrigatório quando o perfil não tem razão social")
+		}
+	}
+
+	if strings.TrimSpace(req.Cidade) == "" {
+		req.Cidade = perfil.Cidade
+		if req.Cidade == "" {
+			req.Cidade = cidadePadrao
+		}
+	}
+
+	if req.MCC == nil && perfil.MCC != "" {
+		mcc := perfil.MCC
+		req.MCC = &mcc
+	}
+
+	if req.CEP == nil && perfil.CEP != "" {
+		cep := perfil.CEP
+		req.CEP = &cep
+	}
+
+	if err := v.erro(); err != nil {
+		return models.PixRequest{}, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPerfil testa a validação do perfil e o preenchimento das requisições com os seus dados
+func TestPerfil(t *testing.T) {
+	perfil := models.PerfilEstabelecimento{
+		RazaoSocial: "JOSE DA SILVA COMERCIO",
+		Cidade:      "CAMPINAS",
+		MCC:         "5812",
+		CEP:         "01310100",
+		Chaves: []models.ChaveCadastrada{
+			{ID: 1, Chave: "josesilva@email.com", TipoChave: models.TipoChaveEmail, Padrao: true},
+			{ID: 2, Chave: "+5511999998888", TipoChave: models.TipoChaveTelefone},
+		},
+	}
+
+	t.Run("NovoPerfil", func(t *testing.T) {
+		// Executar o método a ser testado
+		novo, err := services.NovoPerfil(models.PerfilRequest{RazaoSocial: " LOJA ", MCC: "5812", CEP: "01310-100"})
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, "LOJA", novo.RazaoSocial)
+		assert.Equal(t, "01310100", novo.CEP)
+
+		_, err = services.NovoPerfil(models.PerfilRequest{MCC: "58", CEP: "123"})
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Len(t, erros, 2)
+	})
+
+	t.Run("CamposOmitidos", func(t *testing.T) {
+		// Executar o método a ser testado
+		req, err := services.AplicarPerfil(models.PixRequest{}, perfil)
+
+		// Verificar resultados: a chave padrão e os dados do perfil são usados
+		assert.NoError(t, err)
+		assert.Equal(t, "josesilva@email.com", req.Chave)
+		assert.Equal(t, "JOSE DA SILVA COMERCIO", req.Nome)
+		assert.Equal(t, "CAMPINAS", req.Cidade)
+		assert.Equal(t, "5812", *req.MCC)
+		assert.Equal(t, "01310100", *req.CEP)
+	})
+
+	t.Run("CamposInformados", func(t *testing.T) {
+		mcc := "0000"
+
+		// Executar o método a ser testado
+		req, err := services.AplicarPerfil(models.PixRequest{Nome: "MARIA", Chave: "maria@email.com", Cidade: "SANTOS", MCC: &mcc}, perfil)
+
+		// Verificar resultados: a requisição tem precedência sobre o perfil
+		assert.NoError(t, err)
+		assert.Equal(t, "MARIA", req.Nome)
+		assert.Equal(t, "maria@email.com", req.Chave)
+		assert.Equal(t, "SANTOS", req.Cidade)
+		assert.Equal(t, "0000", *req.MCC)
+	})
+
+	t.Run("ChaveID", func(t *testing.T) {
+		id, inexistente := uint(2), uint(9)
+
+		// Executar o método a ser testado
+		req, err := services.AplicarPerfil(models.PixRequest{ChaveID: &id}, perfil)
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, "+5511999998888", req.Chave)
+
+		_, err = services.AplicarPerfil(models.PixRequest{ChaveID: &inexistente}, perfil)
+		erros, _ := models.ExtrairErrosValidacao(err)
+		assert.Equal(t, "chave_id", erros[0].Campo)
+
+		_, err = services.AplicarPerfil(models.PixRequest{ChaveID: &id, Chave: "maria@email.com"}, perfil)
+		erros, _ = models.ExtrairErrosValidacao(err)
+		assert.Equal(t, "chave_id", erros[0].Campo)
+	})
+
+	t.Run("PerfilVazio", func(t *testing.T) {
+		// Executar o método a ser testado
+		req, err := services.AplicarPerfil(models.PixRequest{Nome: "MARIA"}, models.PerfilEstabelecimento{})
+
+		// Verificar resultados: sem chave padrão, a chave é obrigatória
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok)
+		assert.Equal(t, "chave", erros[0].Campo)
+
+		req, err = services.AplicarPerfil(models.PixRequest{Nome: "MARIA", Chave: "maria@email.com"}, models.PerfilEstabelecimento{})
+		assert.NoError(t, err)
+		assert.Equal(t, "São Paulo", req.Cidade)
+		assert.Nil(t, req.MCC)
+	})
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// nomesCampos mapeia os IDs de nível raiz do BR Code para seus nomes no padrão EMV
+var nomesCampos = map[string]string{
+	"00": "Payload Format Indicator",
+	"01": "Point of Initiation Method",
+	"26": "Merchant Account Information",
+	"52": "Merchant Category Code",
+	"53": "Transaction Currency",
+	"54": "Transaction Amount",
+	"55": "Tip or Convenience Indicator",
+	"56": "Value of Convenience Fee Fixed",
+	"57": "Value of Convenience Fee Percentage",
+	"58": "Country Code",
+	"59": "Merchant Name",
+	"60": "Merchant City",
+	"61": "Postal Code",
+	"62": "Additional Data Field Template",
+	"63": "CRC",
+	"64": "Merchant Information - Language Template",
+}
+
+// nomesSubcamposConta mapeia os sub-campos do Merchant Account Information do PIX
+var nomesSubcamposConta = map[string]string{
+	"00": "GUI",
+	"01": "Chave",
+	"02": "Informação Adicional",
+	"03": "Modalidade do Agente de Saque",
+	"04": "ISPB do Prestador de Saque",
+	"25": "URL",
+}
+
+// nomesSubcamposAdicionais mapeia os sub-campos do Additional Data Field Template
+var nomesSubcamposAdicionais = map[string]string{
+	"01": "Bill Number",
+	"02": "Mobile Number",
+	"03": "Store Label",
+	"04": "Loyalty Number",
+	"05": "Reference Label",
+	"06": "Customer Label",
+	"07": "Terminal Label",
+	"08": "Purpose of Transaction",
+	"09": "Additional Consumer Data Request",
+}
+
+// nomesSubcamposIdioma mapeia os sub-campos do Merchant Information - Language Template
+var nomesSubcamposIdioma = map[string]string{
+	"00": "Language Preference",
+	"01": "Merchant Name - Alternate Language",
+	"02": "Merchant City - Alternate Language",
+}
+
+// camposObrigatorios lista os campos que todo BR Code PIX deve conter
+var camposObrigatorios = []string{"00", "26", "52", "53", "58", "59", "60", "62", "63"}
+
+var (
+	valorRegex = regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+	mccRegex   = regexp.MustCompile(`^\d{4}$`)
+)
+
+// DecodificarBRCode lê um BR Code (código "copia e cola"), percorre a árvore TLV,
+// confere o CRC e reporta os problemas encontrados em cada campo
+func (s *PixGeneratorService) DecodificarBRCode(codigoPix string) models.BRCodeDecodificado {
+	codigoPix = strings.TrimSpace(codigoPix)
+
+	resultado := models.BRCodeDecodificado{}
+
+	campos, erros := lerTLV(codigoPix, "")
+	resultado.Campos = campos
+	resultado.Erros = erros
+
+	// Verificar campos obrigatórios e duplicados
+	ocorrencias := make(map[string]int)
+	for _, campo := range campos {
+		ocorrencias[campo.ID]++
+		if ocorrencias[campo.ID] == 2 {
+			resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "campo repetido"))
+		}
+	}
+	for _, id := range camposObrigatorios {
+		if ocorrencias[id] == 0 {
+			resultado.Erros = append(resultado.Erros, erroCampo(id, "campo obrigatório ausente: %s", nomesCampos[id]))
+		}
+	}
+
+	// Interpretar cada campo
+	for i, campo := range campos {
+		switch campo.ID {
+		case "00":
+			resultado.PayloadFormatIndicator = campo.Valor
+			if i != 0 {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "deve ser o primeiro campo do BR Code"))
+			}
+			if campo.Valor != "01" {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "valor deve ser 01, encontrado %s", campo.Valor))
+			}
+		case "01":
+			resultado.PontoIniciacao = campo.Valor
+			switch campo.Valor {
+			case pontoIniciacaoEstatico:
+				resultado.Tipo = models.TipoPixEstatico
+			case pontoIniciacaoDinamico:
+				resultado.Tipo = models.TipoPixDinamico
+			default:
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "valor deve ser 11 ou 12, encontrado %s", campo.Valor))
+			}
+		case "26":
+			s.interpretarContaPix(campo, &resultado)
+		case "52":
+			resultado.MCC = campo.Valor
+			if !mccRegex.MatchString(campo.Valor) {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "deve conter 4 dígitos"))
+			}
+		case "53":
+			resultado.Moeda = campo.Valor
+			if campo.Valor != "986" {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "moeda deve ser 986 (BRL), encontrado %s", campo.Valor))
+			}
+		case "54":
+			resultado.Valor = campo.Valor
+			if len(campo.Valor) > 13 || !valorRegex.MatchString(campo.Valor) {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "valor em formato inválido: %s", campo.Valor))
+			}
+		case "58":
+			resultado.Pais = campo.Valor
+			if campo.Valor != "BR" {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "país deve ser BR, encontrado %s", campo.Valor))
+			}
+		case "59":
+			resultado.Nome = campo.Valor
+			if campo.Tamanho > 25 {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "nome excede 25 caracteres"))
+			}
+		case "60":
+			resultado.Cidade = campo.Valor
+			if campo.Tamanho > 15 {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "cidade excede 15 caracteres"))
+			}
+		case "61":
+			resultado.CEP = campo.Valor
+		case "62":
+			for _, sub := range campo.Subcampos {
+				switch sub.ID {
+				case "05":
+					resultado.Txid = sub.Valor
+				case subcampoSaqueTroco:
+					interpretarSaqueTroco(sub, &resultado)
+				}
+			}
+			if resultado.Txid == "" {
+				resultado.Erros = append(resultado.Erros, erroCampo("62.05", "Reference Label obrigatório ausente"))
+			}
+		case "63":
+			resultado.CRC = campo.Valor
+			if i != len(campos)-1 {
+				resultado.Erros = append(resultado.Erros, erroCampo(campo.ID, "deve ser o último campo do BR Code"))
+			}
+		}
+	}
+
+	// Conferir o CRC sobre todo o conteúdo até o cabeçalho "6304", inclusive
+	if indice := strings.LastIndex(codigoPix, "6304"); indice >= 0 && indice+8 == len(codigoPix) {
+		resultado.CRCCalculado = s.calcularCRC(codigoPix[:indice+4])
+		resultado.CRCValido = strings.EqualFold(resultado.CRCCalculado, resultado.CRC)
+		if !resultado.CRCValido {
+			resultado.Erros = append(resultado.Erros, erroCampo("63", "CRC inválido: esperado %s, encontrado %s", resultado.CRCCalculado, resultado.CRC))
+		}
+	} else if ocorrencias["63"] > 0 {
+		resultado.Erros = append(resultado.Erros, erroCampo("63", "CRC deve ter 4 caracteres e encerrar o BR Code"))
+	}
+
+	resultado.Valido = len(resultado.Erros) == 0
+	return resultado
+}
+
+// interpretarContaPix extrai os dados do Merchant Account Information do PIX
+func (s *PixGeneratorService) interpretarContaPix(campo models.BRCodeCampo, resultado *models.BRCodeDecodificado) {
+	for _, sub := range campo.Subcampos {
+		switch sub.ID {
+		case "00":
+			resultado.GUI = sub.Valor
+		case "01":
+			resultado.Chave = sub.Valor
+		case "02":
+			resultado.InfoAdicional = sub.Valor
+		case "25":
+			resultado.Location = sub.Valor
+		}
+	}
+
+	if !strings.EqualFold(resultado.GUI, "BR.GOV.BCB.PIX") {
+		resultado.Erros = append(resultado.Erros, erroCampo("26.00", "GUI deve ser BR.GOV.BCB.PIX, encontrado %q", resultado.GUI))
+	}
+
+	switch {
+	case resultado.Chave == "" && resultado.Location == "":
+		resultado.Erros = append(resultado.Erros, erroCampo("26", "deve conter a chave (01) ou a URL do payload (25)"))
+	case resultado.Chave != "" && resultado.Location != "":
+		resultado.Erros = append(resultado.Erros, erroCampo("26", "chave (01) e URL do payload (25) são mutuamente exclusivas"))
+	}
+}
+
+// interpretarSaqueTroco extrai a modalidade do PIX Saque ou PIX Troco do template 62.50
+func interpretarSaqueTroco(template models.BRCodeCampo, resultado *models.BRCodeDecodificado) {
+	var gui string
+	for _, sub := range template.Subcampos {
+		switch sub.ID {
+		case "00":
+			gui = sub.Valor
+		case "01":
+			resultado.Modalidade = sub.Valor
+		}
+	}
+
+	if !strings.EqualFold(gui, "BR.GOV.BCB.PIX") {
+		resultado.Modalidade = ""
+	}
+}
+
+// lerTLV percorre uma sequência de campos ID-Tamanho-Valor, descendo nos templates
+func lerTLV(dados, prefixo string) ([]models.BRCodeCampo, []models.BRCodeErro) {
+	var campos []models.BRCodeCampo
+	var erros []models.BRCodeErro
+
+	for pos := 0; pos < len(dados); {
+		if len(dados)-pos < 4 {
+			erros = append(erros, erroCampo(prefixo+dados[pos:], "cabeçalho do campo incompleto"))
+			break
+		}
+
+		id := dados[pos : pos+2]
+		caminho := prefixo + id
+		if !somenteDigitos(id) {
+			erros = append(erros, erroCampo(caminho, "ID do campo deve ser numérico"))
+			break
+		}
+
+		if !somenteDigitos(dados[pos+2 : pos+4]) {
+			erros = append(erros, erroCampo(caminho, "tamanho do campo deve ter 2 dígitos, encontrado %q", dados[pos+2:pos+4]))
+			break
+		}
+		tamanho, _ := strconv.Atoi(dados[pos+2 : pos+4])
+
+		inicio := pos + 4
+		if inicio+tamanho > len(dados) {
+			erros = append(erros, erroCampo(caminho, "tamanho declarado (%d) excede o restante do conteúdo (%d)", tamanho, len(dados)-inicio))
+			break
+		}
+		if tamanho == 0 {
+			erros = append(erros, erroCampo(caminho, "campo vazio"))
+		}
+
+		campo := models.BRCodeCampo{
+			ID:      id,
+			Tamanho: tamanho,
+			Valor:   dados[inicio : inicio+tamanho],
+		}
+
+		nome, conhecido, template := nomeCampo(prefixo, id)
+		campo.Nome = nome
+		if !conhecido {
+			erros = append(erros, erroCampo(caminho, "ID desconhecido"))
+		}
+
+		if template {
+			subcampos, subErros := lerTLV(campo.Valor, caminho+".")
+			campo.Subcampos = subcampos
+			erros = append(erros, subErros...)
+		}
+
+		campos = append(campos, campo)
+		pos = inicio + tamanho
+	}
+
+	return campos, erros
+}
+
+// nomeCampo identifica um campo a partir do template em que se encontra, informando
+// se o ID é conhecido e se o seu valor é, por sua vez, um template
+func nomeCampo(prefixo, id string) (string, bool, bool) {
+	numero, _ := strconv.Atoi(id)
+
+	switch {
+	case prefixo == "":
+		if nome, ok := nomesCampos[id]; ok {
+			return nome, true, id == "26" || id == "62" || id == "64"
+		}
+		if numero >= 27 && numero <= 51 {
+			return "Merchant Account Information", true, true
+		}
+		if numero >= 80 {
+			return "Unreserved Template", true, true
+		}
+		return "", false, false
+
+	case prefixo == "26.":
+		nome, ok := nomesSubcamposConta[id]
+		return nome, ok, false
+
+	case prefixo == "62.":
+		if nome, ok := nomesSubcamposAdicionais[id]; ok {
+			return nome, true, false
+		}
+		if numero >= 50 {
+			return "Payment System Specific Template", true, true
+		}
+		return "", false, false
+
+	case prefixo == "64.":
+		nome, ok := nomesSubcamposIdioma[id]
+		return nome, ok, false
+
+	default:
+		// Sub-campos de templates livres (27-51, 80-99, 62.50-99): 00 é o GUI, os demais são livres
+		if id == "00" {
+			return "Globally Unique Identifier", true, false
+		}
+		return "Context Specific Data", true, false
+	}
+}
+
+// erroCampo cria um erro de leitura associado a um campo
+func erroCampo(campo, formato string, args ...interface{}) models.BRCodeErro {
+	return models.BRCodeErro{
+		Campo:    campo,
+		Mensagem: fmt.Sprintf(formato, args...),
+	}
+}
+
+// somenteDigitos indica se a string contém apenas dígitos ASCII
+func somenteDigitos(texto string) bool {
+	for _, r := range texto {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return texto != ""
+}
@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDecodificarBRCode testa a leitura de BR Codes
+func TestDecodificarBRCode(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	t.Run("CodigoGeradoPeloServico", func(t *testing.T) {
+		// Gerar um código PIX estático completo
+		valor := models.NovoDinheiro(10050)
+		identificador := "FATURA123"
+
+		gerado, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:          "JOSE DA SILVA",
+			Chave:         "josesilva@email.com",
+			Cidade:        "SAO PAULO",
+			Valor:         &valor,
+			Identificador: &identificador,
+		})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		resultado := service.DecodificarBRCode(gerado.CodigoPix)
+
+		// Verificar resultados
+		assert.True(t, resultado.Valido, "erros: %v", resultado.Erros)
+		assert.True(t, resultado.CRCValido)
+		assert.Equal(t, models.TipoPixEstatico, resultado.Tipo)
+		assert.Equal(t, "BR.GOV.BCB.PIX", resultado.GUI)
+		assert.Equal(t, "josesilva@email.com", resultado.Chave)
+		assert.Equal(t, "100.50", resultado.Valor)
+		assert.Equal(t, "JOSE DA SILVA", resultado.Nome)
+		assert.Equal(t, "SAO PAULO", resultado.Cidade)
+		assert.Equal(t, "FATURA123", resultado.Txid)
+		assert.Empty(t, resultado.Erros)
+	})
+
+	t.Run("CodigoDinamico", func(t *testing.T) {
+		gerado, err := service.GerarPixDinamico(models.CobRequest{
+			Txid:     "7978c0c97ea847e78e8849634473c1f1",
+			Location: "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+			Nome:     "JOSE DA SILVA",
+			Chave:    "josesilva@email.com",
+			Cidade:   "SAO PAULO",
+		})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		resultado := service.DecodificarBRCode(gerado.CodigoPix)
+
+		// Verificar resultados
+		assert.True(t, resultado.Valido, "erros: %v", resultado.Erros)
+		assert.Equal(t, models.TipoPixDinamico, resultado.Tipo)
+		assert.Equal(t, "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25", resultado.Location)
+		assert.Empty(t, resultado.Chave)
+	})
+
+	t.Run("CRCInvalido", func(t *testing.T) {
+		gerado, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:   "MARIA OLIVEIRA",
+			Chave:  "maria@email.com",
+			Cidade: "RIO DE JANEIRO",
+		})
+		assert.NoError(t, err)
+
+		// Alterar o CRC do código gerado
+		codigo := gerado.CodigoPix[:len(gerado.CodigoPix)-4] + "0000"
+		if codigo == gerado.CodigoPix {
+			codigo = gerado.CodigoPix[:len(gerado.CodigoPix)-4] + "FFFF"
+		}
+
+		// Executar o método a ser testado
+		resultado := service.DecodificarBRCode(codigo)
+
+		// Verificar resultados
+		assert.False(t, resultado.Valido)
+		assert.False(t, resultado.CRCValido)
+		assert.Equal(t, gerado.CodigoPix[len(gerado.CodigoPix)-4:], resultado.CRCCalculado)
+		assert.Contains(t, resultado.Erros, models.BRCodeErro{
+			Campo:    "63",
+			Mensagem: "CRC inválido: esperado " + resultado.CRCCalculado + ", encontrado " + resultado.CRC,
+		})
+	})
+
+	t.Run("TamanhoExcedente", func(t *testing.T) {
+		// O campo 59 declara 30 caracteres, mais do que resta no conteúdo
+		resultado := service.DecodificarBRCode("000201010211593012345")
+
+		// Verificar resultados
+		assert.False(t, resultado.Valido)
+		assert.Equal(t, "59", resultado.Erros[0].Campo)
+		assert.Contains(t, resultado.Erros[0].Mensagem, "tamanho declarado")
+	})
+
+	t.Run("CamposObrigatoriosEIdDesconhecido", func(t *testing.T) {
+		// Apenas o Payload Format Indicator e um campo inexistente no padrão
+		resultado := service.DecodificarBRCode("0002017002AB")
+
+		// Verificar resultados
+		assert.False(t, resultado.Valido)
+
+		campos := make(map[string]bool)
+		for _, erro := range resultado.Erros {
+			campos[erro.Campo] = true
+		}
+		assert.True(t, campos["70"], "ID desconhecido deveria ser reportado")
+		assert.True(t, campos["26"], "campo 26 ausente deveria ser reportado")
+		assert.True(t, campos["59"], "campo 59 ausente deveria ser reportado")
+		assert.True(t, campos["63"], "campo 63 ausente deveria ser reportado")
+	})
+}
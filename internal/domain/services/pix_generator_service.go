@@ -1,17 +1,27 @@
 package services
 
 import (
-	"encoding/base64"
+	"errors"
 	"fmt"
-	"math"
-	"regexp"
-	"strconv"
+	"net/url"
 	"strings"
 
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
-	"github.com/skip2/go-qrcode"
 )
 
+// Valores do campo Point of Initiation Method (01)
+const (
+	pontoIniciacaoEstatico = "11"
+	pontoIniciacaoDinamico = "12"
+)
+
+// tamanhoMaximoLocation é o maior tamanho de URL que cabe no template 26
+// junto com o GUI do PIX (99 - 18 do GUI - 4 do cabeçalho do sub-campo 25)
+const tamanhoMaximoLocation = 77
+
+// ErrLocationInvalida indica que a URL de payload da cobrança não é válida
+var ErrLocationInvalida = errors.New("location inválida: informe a URL do payload no PSP sem o prefixo https://")
+
 // PixGeneratorService contém a lógica para geração de códigos PIX
 type PixGeneratorService struct{}
 
@@ -20,92 +30,177 @@ func NewPixGeneratorService() *PixGeneratorService {
 	return &PixGeneratorService{}
 }
 
-// GerarPixEstatico gera um código PIX estático com base nos parâmetros fornecidos
+// GerarPixEstatico gera um código PIX estático com base nos parâmetros fornecidos.
+// Todos os problemas encontrados na requisição são devolvidos juntos em models.ErrosValidacao.
 func (s *PixGeneratorService) GerarPixEstatico(req models.PixRequest) (models.PixResponse, error) {
-	// Validações básicas
-	nome := removerCaracteresEspeciais(limitarTamanho(req.Nome, 25))
-	cidade := limitarTamanho(removerCaracteresEspeciais(req.Cidade), 15)
-	chave := req.Chave
+	var v validador
 
-	// Tratar identificador e descrição
-	var identificador, descricao string
-	if req.Identificador != nil {
-		identificador = limitarTamanho(removerCaracteresEspeciais(*req.Identificador), 25)
-	}
+	// Validar e normalizar a chave PIX
+	chave, err := s.ClassificarChave(req.Chave)
+	v.adicionar(err)
+
+	// Normalizar os campos de texto, registrando o que precisou ser alterado
+	var ajustes ajustesTexto
+	nome := ajustes.normalizar("nome", req.Nome, tamanhoMaximoNome, caractereBRCode)
+	cidade := ajustes.normalizar("cidade", req.Cidade, tamanhoMaximoCidade, caractereBRCode)
+	validarNomeCidade(&v, nome, cidade)
+
+	// O identificador não é normalizado: ou segue a regra do Reference Label, ou é rejeitado
+	identificador := validarIdentificador(&v, req.Identificador)
+
+	var descricao string
 	if req.Descricao != nil {
-		descricao = limitarTamanho(removerCaracteresEspeciais(*req.Descricao), 50)
+		descricao = ajustes.normalizar("descricao", *req.Descricao, tamanhoMaximoDescricao, caractereBRCode)
 	}
 
 	// Formatar valor (se fornecido)
 	var valorFormatado string
 	if req.Valor != nil && *req.Valor > 0 {
-		valorFormatado = formatarValor(*req.Valor)
+		valorFormatado = req.Valor.String()
+	}
+
+	// Validar os campos opcionais (MCC, CEP, idioma alternativo e templates livres)
+	opcionais := montarCamposOpcionais(&v, req, &ajustes)
+
+	// Validar a modalidade (compra, saque ou troco) e os dados do agente de saque
+	saqueTroco := montarSaqueTroco(&v, req)
+
+	// Opções de renderização do QR Code
+	opcoes, err := NovasOpcoesQRCode(req.QRCode)
+	v.adicionar(err)
+
+	if err := v.erro(); err != nil {
+		return models.PixResponse{}, err
 	}
 
 	// Construir o payload PIX
-	payload := s.construirPayloadPix(nome, chave, cidade, valorFormatado, identificador, descricao)
+	infoConta := s.pixGUI(chave.Valor) + saqueTroco.subcamposConta()
+	campoAdicional := s.adicionarCampoAdicional(identificador, descricao, saqueTroco.template())
+	payload, err := s.construirPayloadPix(pontoIniciacaoEstatico, infoConta, nome, cidade, valorFormatado, campoAdicional, opcionais)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	response, err := s.gerarResposta(payload, chave.Tipo, ajustes, opcoes)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
 
+	response.Modalidade = saqueTroco.modalidade
+	return response, nil
+}
+
+// GerarPixDinamico gera um código PIX dinâmico de uso único, que aponta para a
+// URL de payload da cobrança no PSP em vez de carregar a chave
+func (s *PixGeneratorService) GerarPixDinamico(req models.CobRequest) (models.PixResponse, error) {
+	location, err := NormalizarLocation(req.Location)
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	var v validador
+
+	// A chave não consta no BR Code dinâmico, mas identifica a cobrança no PSP
+	chave, err := s.ClassificarChave(req.Chave)
+	v.adicionar(err)
+
+	validarTxid(&v, req.Txid)
+
+	var ajustes ajustesTexto
+	nome := ajustes.normalizar("nome", req.Nome, tamanhoMaximoNome, caractereBRCode)
+	cidade := ajustes.normalizar("cidade", req.Cidade, tamanhoMaximoCidade, caractereBRCode)
+	validarNomeCidade(&v, nome, cidade)
+
+	var valorFormatado string
+	if req.Valor != nil && *req.Valor > 0 {
+		valorFormatado = req.Valor.String()
+	}
+
+	opcoes, err := NovasOpcoesQRCode(req.QRCode)
+	v.adicionar(err)
+
+	if err := v.erro(); err != nil {
+		return models.PixResponse{}, err
+	}
+
+	// No PIX dinâmico o txid fica no payload do PSP, por isso o Reference Label vai como ***
+	payload, err := s.construirPayloadPix(pontoIniciacaoDinamico, s.pixGUIDinamico(location), nome, cidade, valorFormatado, s.adicionarCampoAdicional(referenciaEstatico, "", ""), camposOpcionais{})
+	if err != nil {
+		return models.PixResponse{}, err
+	}
+
+	return s.gerarResposta(payload, chave.Tipo, ajustes, opcoes)
+}
+
+// gerarResposta adiciona o CRC ao payload e gera os QR Codes correspondentes
+func (s *PixGeneratorService) gerarResposta(payload, tipoChave string, ajustes ajustesTexto, opcoes OpcoesQRCode) (models.PixResponse, error) {
 	// Adicionar CRC
 	codigoPix := payload + "6304" + s.calcularCRC(payload+"6304")
 
 	// Gerar QR Codes
-	qrSVG, err := s.gerarQRCodeSVG(codigoPix)
+	qrSVG, err := s.GerarQRCodeSVG(codigoPix, opcoes)
 	if err != nil {
 		return models.PixResponse{}, err
 	}
 
-	qrPNG, err := s.GerarQRCodePNG(codigoPix)
+	qrPNG, err := s.GerarQRCodePNG(codigoPix, opcoes)
 	if err != nil {
 		return models.PixResponse{}, err
 	}
 
 	return models.PixResponse{
 		CodigoPix: codigoPix,
+		TipoChave: tipoChave,
 		QRCodeSVG: qrSVG,
 		QRCodePNG: qrPNG,
+		Ajustes:   ajustes,
 	}, nil
 }
 
-// construirPayloadPix monta o payload do PIX conforme especificações do Banco Central
-func (s *PixGeneratorService) construirPayloadPix(nome, chave, cidade, valor, identificador, descricao string) string {
-	var payload strings.Builder
-
-	// Payload Format Indicator (obrigatório)
-	addCampo(&payload, "00", "01")
-
-	// Point of Initiation Method - 11 para estático, 12 para dinâmico
-	addCampo(&payload, "01", "11")
-
-	// Merchant Account Information (obrigatório)
-	addCampo(&payload, "26", s.pixGUI(chave))
-
-	// Merchant Category Code (obrigatório)
-	addCampo(&payload, "52", "0000")
-
-	// Transaction Currency (BRL = 986) (obrigatório)
-	addCampo(&payload, "53", "986")
+// construirPayloadPix monta o payload do PIX conforme especificações do Banco Central,
+// com os campos em ordem crescente de ID, conferindo o tamanho de cada campo e do total
+func (s *PixGeneratorService) construirPayloadPix(pontoIniciacao, infoConta, nome, cidade, valor, campoAdicional string, opcionais camposOpcionais) (string, error) {
+	mcc := opcionais.mcc
+	if mcc == "" {
+		mcc = mccPadrao
+	}
 
-	// Country Code (obrigatório)
-	addCampo(&payload, "58", "BR")
+	campos := []campoEMV{
+		{"00", "01"},                        // Payload Format Indicator (obrigatório)
+		{"01", pontoIniciacao},              // Point of Initiation Method - 11 para estático, 12 para dinâmico
+		{"26", infoConta},                   // Merchant Account Information (obrigatório)
+		{"52", mcc},                         // Merchant Category Code (obrigatório, 0000 quando não informado)
+		{"53", "986"},                       // Transaction Currency (BRL = 986) (obrigatório)
+		{"54", valor},                       // Transaction Amount (opcional)
+		{"58", "BR"},                        // Country Code (obrigatório)
+		{"59", nome},                        // Merchant Name (obrigatório)
+		{"60", cidade},                      // Merchant City (obrigatório)
+		{"61", opcionais.cep},               // Postal Code (opcional)
+		{"62", campoAdicional},              // Additional Data Field
+		{"64", opcionais.idiomaAlternativo}, // Merchant Information - Language Template (opcional)
+	}
 
-	// Merchant Name (obrigatório)
-	addCampo(&payload, "59", nome)
+	// Unreserved Templates (opcionais)
+	campos = append(campos, opcionais.templates...)
 
-	// Merchant City (obrigatório)
-	addCampo(&payload, "60", cidade)
+	var v validador
+	validarCampos(&v, campos)
+	if err := v.erro(); err != nil {
+		return "", err
+	}
 
-	// Transaction Amount (opcional)
-	if valor != "" {
-		addCampo(&payload, "54", valor)
+	// Campos vazios são omitidos por addCampo
+	var payload strings.Builder
+	for _, campo := range campos {
+		addCampo(&payload, campo.id, campo.valor)
 	}
 
-	// Additional Data Field (obrigatório para algumas implementações)
-	campoAdicional := s.adicionarCampoAdicional(identificador, descricao)
-	if campoAdicional != "" {
-		addCampo(&payload, "62", campoAdicional)
+	validarTamanhoPayload(&v, payload.String())
+	if err := v.erro(); err != nil {
+		return "", err
 	}
 
-	return payload.String()
+	return payload.String(), nil
 }
 
 // pixGUI gera o GUI do PIX
@@ -121,15 +216,28 @@ func (s *PixGeneratorService) pixGUI(chave string) string {
 	return gui.String()
 }
 
+// pixGUIDinamico gera o GUI do PIX com a URL de payload da cobrança
+func (s *PixGeneratorService) pixGUIDinamico(location string) string {
+	var gui strings.Builder
+
+	// GUI do PIX
+	addCampo(&gui, "00", "BR.GOV.BCB.PIX")
+
+	// URL do payload (location)
+	addCampo(&gui, "25", location)
+
+	return gui.String()
+}
+
 // adicionarCampoAdicional adiciona campos adicionais ao PIX
-func (s *PixGeneratorService) adicionarCampoAdicional(identificador, descricao string) string {
+func (s *PixGeneratorService) adicionarCampoAdicional(identificador, descricao, saqueTroco string) string {
 	var campoAdicional strings.Builder
 
-	// Adiciona Reference Label (05) se fornecido
+	// Adiciona Reference Label (05), usando *** quando não fornecido
 	if identificador != "" {
 		addCampo(&campoAdicional, "05", identificador)
 	} else {
-		addCampo(&campoAdicional, "05", "***")
+		addCampo(&campoAdicional, "05", referenciaEstatico)
 	}
 
 	// Adiciona Purpose of Transaction (08) se fornecido
@@ -137,6 +245,9 @@ func (s *PixGeneratorService) adicionarCampoAdicional(identificador, descricao s
 		addCampo(&campoAdicional, "08", descricao)
 	}
 
+	// Adiciona a modalidade e os valores do PIX Saque ou PIX Troco (50) se fornecidos
+	addCampo(&campoAdicional, subcampoSaqueTroco, saqueTroco)
+
 	return campoAdicional.String()
 }
 
@@ -160,25 +271,6 @@ func (s *PixGeneratorService) calcularCRC(payload string) string {
 	return fmt.Sprintf("%04X", crc)
 }
 
-// gerarQRCodeSVG gera um QR code em formato SVG
-func (s *PixGeneratorService) gerarQRCodeSVG(codigoPix string) (string, error) {
-	// Implementação simplificada - em produção você precisaria de uma biblioteca mais completa para SVG
-	// Esta é uma simulação
-	svg := fmt.Sprintf("<svg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 250 250'><text x='10' y='100'>QR Code SVG para: %s</text></svg>", codigoPix)
-	return svg, nil
-}
-
-// GerarQRCodePNG gera um QR code em formato PNG (base64)
-func (s *PixGeneratorService) GerarQRCodePNG(codigoPix string) (string, error) {
-	qrCode, err := qrcode.Encode(codigoPix, qrcode.Medium, 256)
-	if err != nil {
-		return "", err
-	}
-
-	base64PNG := "data:image/png;base64," + base64.StdEncoding.EncodeToString(qrCode)
-	return base64PNG, nil
-}
-
 // Funções auxiliares
 
 // addCampo adiciona um campo EMV ao payload
@@ -193,29 +285,25 @@ func addCampo(builder *strings.Builder, id, valor string) {
 	builder.WriteString(valor)
 }
 
-// removerCaracteresEspeciais remove acentos e caracteres especiais
-func removerCaracteresEspeciais(texto string) string {
-	// Remover caracteres não alfanuméricos exceto espaços
-	reg := regexp.MustCompile(`[^a-zA-Z0-9\s]`)
-	texto = reg.ReplaceAllString(texto, "")
-
-	// Converter para maiúsculas e trim
-	return strings.ToUpper(strings.TrimSpace(texto))
-}
+// NormalizarLocation valida a URL de payload e remove o esquema, que não deve constar no BR Code
+func NormalizarLocation(location string) (string, error) {
+	location = strings.TrimSpace(location)
+	esquema := strings.ToLower(location)
+	if strings.HasPrefix(esquema, "http://") {
+		return "", ErrLocationInvalida
+	}
+	if strings.HasPrefix(esquema, "https://") {
+		location = location[len("https://"):]
+	}
 
-// limitarTamanho limita o tamanho de uma string
-func limitarTamanho(texto string, tamanhoMax int) string {
-	if len(texto) <= tamanhoMax {
-		return texto
+	if location == "" || len(location) > tamanhoMaximoLocation {
+		return "", ErrLocationInvalida
 	}
-	return texto[:tamanhoMax]
-}
 
-// formatarValor formata um valor float para o formato exigido pelo PIX
-func formatarValor(valor float64) string {
-	// Arredondar para 2 casas decimais
-	valorArredondado := math.Round(valor*100) / 100
+	parsed, err := url.Parse("https://" + location)
+	if err != nil || parsed.Host == "" || parsed.Path == "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", ErrLocationInvalida
+	}
 
-	// Converter para string com 2 casas decimais
-	return strconv.FormatFloat(valorArredondado, 'f', 2, 64)
+	return location, nil
 }
@@ -1,6 +1,12 @@
 package services_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
@@ -15,7 +21,7 @@ func TestGerarPixEstatico(t *testing.T) {
 
 	t.Run("DadosCompletos", func(t *testing.T) {
 		// Preparar os dados de entrada
-		valor := 100.50
+		valor := models.NovoDinheiro(10050)
 		identificador := "FATURA123"
 		descricao := "PAGAMENTO DE SERVICOS"
 
@@ -94,3 +100,614 @@ func TestGerarPixEstatico(t *testing.T) {
 		assert.Contains(t, response.CodigoPix, nomeTruncado)
 	})
 }
+
+// TestNormalizacaoTexto testa a transliteração e o truncamento dos campos de texto
+func TestNormalizacaoTexto(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	t.Run("Acentos", func(t *testing.T) {
+		descricao := "Açaí & Cia."
+
+		req := models.PixRequest{
+			Nome:      "José Conceição",
+			Chave:     "jose@email.com",
+			Cidade:    "São Paulo",
+			Descricao: &descricao,
+		}
+
+		// Executar o método a ser testado
+		response, err := service.GerarPixEstatico(req)
+
+		// Verificar resultados: acentos transliterados em vez de removidos
+		assert.NoError(t, err)
+		assert.Contains(t, response.CodigoPix, "5914JOSE CONCEICAO")
+		assert.Contains(t, response.CodigoPix, "6009SAO PAULO")
+		assert.Contains(t, response.CodigoPix, "0811ACAI & CIA.")
+
+		// Os ajustes são informados ao estabelecimento
+		assert.Contains(t, response.Ajustes, models.AjusteCampo{Campo: "nome", Original: "José Conceição", Utilizado: "JOSE CONCEICAO"})
+		assert.Contains(t, response.Ajustes, models.AjusteCampo{Campo: "cidade", Original: "São Paulo", Utilizado: "SAO PAULO"})
+	})
+
+	t.Run("TruncamentoPorCaractere", func(t *testing.T) {
+		req := models.PixRequest{
+			Nome:   "Pão de Açúcar Comércio de Alimentos",
+			Chave:  "pao@email.com",
+			Cidade: "Santa Bárbara d'Oeste",
+		}
+
+		// Executar o método a ser testado
+		response, err := service.GerarPixEstatico(req)
+
+		// Verificar resultados: limites de 25 e 15 caracteres sem cortar bytes no meio
+		assert.NoError(t, err)
+		assert.Contains(t, response.CodigoPix, "5925PAO DE ACUCAR COMERCIO DE")
+		assert.Contains(t, response.CodigoPix, "6015SANTA BARBARA D")
+	})
+
+	t.Run("SemAjustes", func(t *testing.T) {
+		req := models.PixRequest{
+			Nome:   "maria oliveira",
+			Chave:  "maria@email.com",
+			Cidade: "RIO DE JANEIRO",
+		}
+
+		// Executar o método a ser testado
+		response, err := service.GerarPixEstatico(req)
+
+		// Verificar resultados: apenas a conversão para maiúsculas não é considerada ajuste
+		assert.NoError(t, err)
+		assert.Empty(t, response.Ajustes)
+	})
+}
+
+// TestGerarPixDinamico testa a geração de PIX dinâmico (cobrança imediata)
+func TestGerarPixDinamico(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	t.Run("LocationComEsquema", func(t *testing.T) {
+		valor := models.NovoDinheiro(2500)
+
+		req := models.CobRequest{
+			Txid:     "7978c0c97ea847e78e8849634473c1f1",
+			Location: "https://pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+			Nome:     "JOSE DA SILVA",
+			Chave:    "josesilva@email.com",
+			Cidade:   "SAO PAULO",
+			Valor:    &valor,
+		}
+
+		// Executar o método a ser testado
+		response, err := service.GerarPixDinamico(req)
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.QRCodePNG)
+
+		// Ponto de iniciação 12 e location no sub-campo 25, sem o esquema
+		assert.Contains(t, response.CodigoPix, "010212")
+		assert.Contains(t, response.CodigoPix, "2554pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25")
+		assert.NotContains(t, response.CodigoPix, "https://")
+
+		// A chave não deve constar no BR Code dinâmico
+		assert.NotContains(t, response.CodigoPix, "josesilva@email.com")
+
+		// O txid fica no PSP, o Reference Label vai como ***
+		assert.Contains(t, response.CodigoPix, "62070503***")
+	})
+
+	t.Run("LocationInvalida", func(t *testing.T) {
+		req := models.CobRequest{
+			Txid:     "7978c0c97ea847e78e8849634473c1f1",
+			Location: "http://pix.example.com/qr/v2/cobranca",
+			Nome:     "JOSE DA SILVA",
+			Chave:    "josesilva@email.com",
+			Cidade:   "SAO PAULO",
+		}
+
+		// Executar o método a ser testado
+		_, err := service.GerarPixDinamico(req)
+
+		// Verificar resultados
+		assert.ErrorIs(t, err, services.ErrLocationInvalida)
+	})
+}
+
+// TestGerarQRCodeSVG testa a renderização vetorial do QR Code
+func TestGerarQRCodeSVG(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	response, err := service.GerarPixEstatico(models.PixRequest{
+		Nome:   "JOSE DA SILVA",
+		Chave:  "josesilva@email.com",
+		Cidade: "SAO PAULO",
+	})
+	assert.NoError(t, err)
+
+	t.Run("SVGPadrao", func(t *testing.T) {
+		// O SVG retornado na geração deve ser um QR Code real, não um texto
+		assert.True(t, strings.HasPrefix(response.QRCodeSVG, `<svg xmlns="http://www.w3.org/2000/svg"`))
+		assert.Contains(t, response.QRCodeSVG, `<path fill="#000000" d="M`)
+		assert.Contains(t, response.QRCodeSVG, `fill="#FFFFFF"`)
+		assert.NotContains(t, response.QRCodeSVG, "<text")
+	})
+
+	t.Run("OpcoesPersonalizadas", func(t *testing.T) {
+		opcoes := services.OpcoesQRCodePadrao()
+		opcoes.Margem = 0
+		opcoes.CorFrente = "#123456"
+		opcoes.Transparente = true
+
+		// Executar o método a ser testado
+		svg, err := service.GerarQRCodeSVG(response.CodigoPix, opcoes)
+
+		// Verificar resultados: sem margem o primeiro módulo (finder pattern) fica na origem
+		assert.NoError(t, err)
+		assert.Contains(t, svg, `<path fill="#123456" d="M0 0h7v1h-7z`)
+		assert.NotContains(t, svg, "<rect")
+	})
+
+	t.Run("CorInvalida", func(t *testing.T) {
+		opcoes := services.OpcoesQRCodePadrao()
+		opcoes.CorFrente = "preto"
+
+		// Executar o método a ser testado
+		_, err := service.GerarQRCodeSVG(response.CodigoPix, opcoes)
+
+		// Verificar resultados
+		assert.Error(t, err)
+	})
+}
+
+// TestGerarQRCodePNG testa as opções de renderização do QR Code em PNG
+func TestGerarQRCodePNG(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	tamanho := 1024
+	response, err := service.GerarPixEstatico(models.PixRequest{
+		Nome:   "JOSE DA SILVA",
+		Chave:  "josesilva@email.com",
+		Cidade: "SAO PAULO",
+		QRCode: &models.QRCodeRequest{Nivel: "h", Tamanho: &tamanho, Cor: "1A1A1A"},
+	})
+	assert.NoError(t, err)
+
+	// decodificar converte o data URI retornado em imagem
+	decodificar := func(qrPNG string) image.Image {
+		dados, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(qrPNG, "data:image/png;base64,"))
+		assert.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(dados))
+		assert.NoError(t, err)
+		return img
+	}
+
+	t.Run("OpcoesNaGeracao", func(t *testing.T) {
+		img := decodificar(response.QRCodePNG)
+
+		// Verificar resultados: o tamanho é respeitado e o canto fica na zona de silêncio
+		assert.Equal(t, image.Rect(0, 0, 1024, 1024), img.Bounds())
+		r, g, b, _ := img.At(0, 0).RGBA()
+		assert.Equal(t, []uint32{0xFFFF, 0xFFFF, 0xFFFF}, []uint32{r, g, b})
+		assert.Contains(t, response.QRCodeSVG, `<path fill="#1A1A1A"`)
+	})
+
+	t.Run("NivelCorrecao", func(t *testing.T) {
+		opcoesL := services.OpcoesQRCodePadrao()
+		opcoesL.Nivel = models.NivelCorrecaoL
+		svgL, err := service.GerarQRCodeSVG(response.CodigoPix, opcoesL)
+		assert.NoError(t, err)
+
+		opcoesH := services.OpcoesQRCodePadrao()
+		opcoesH.Nivel = models.NivelCorrecaoH
+		svgH, err := service.GerarQRCodeSVG(response.CodigoPix, opcoesH)
+		assert.NoError(t, err)
+
+		// Verificar resultados: mais correção de erro exige mais módulos
+		assert.NotEqual(t, svgL[:80], svgH[:80])
+	})
+
+	t.Run("FundoTransparente", func(t *testing.T) {
+		opcoes := services.OpcoesQRCodePadrao()
+		opcoes.Transparente = true
+
+		// Executar o método a ser testado
+		qrPNG, err := service.GerarQRCodePNG(response.CodigoPix, opcoes)
+		assert.NoError(t, err)
+
+		// Verificar resultados
+		_, _, _, a := decodificar(qrPNG).At(0, 0).RGBA()
+		assert.Equal(t, uint32(0), a)
+	})
+
+	t.Run("TamanhoMenorQueMatriz", func(t *testing.T) {
+		tamanhoMinimo := 64
+		opcoes, err := services.NovasOpcoesQRCode(&models.QRCodeRequest{Tamanho: &tamanhoMinimo, Margem: &tamanhoMinimo})
+		assert.Error(t, err)
+		assert.Equal(t, services.OpcoesQRCode{}, opcoes)
+
+		margem := 16
+		opcoes, err = services.NovasOpcoesQRCode(&models.QRCodeRequest{Tamanho: &tamanhoMinimo, Margem: &margem})
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		qrPNG, err := service.GerarQRCodePNG(response.CodigoPix, opcoes)
+		assert.NoError(t, err)
+
+		// Verificar resultados: a imagem é ampliada para comportar um pixel por módulo
+		assert.Greater(t, decodificar(qrPNG).Bounds().Dx(), 64)
+	})
+
+	t.Run("OpcoesInvalidas", func(t *testing.T) {
+		grande := 4096
+		casos := map[string]models.QRCodeRequest{
+			"qrcode.nivel":   {Nivel: "X"},
+			"qrcode.tamanho": {Tamanho: &grande},
+			"qrcode.cor":     {Cor: "preto"},
+			"qrcode.fundo":   {Fundo: "#12345"},
+		}
+
+		for campo, req := range casos {
+			_, err := service.GerarPixEstatico(models.PixRequest{
+				Nome:   "JOSE DA SILVA",
+				Chave:  "josesilva@email.com",
+				Cidade: "SAO PAULO",
+				QRCode: &req,
+			})
+
+			var errosValidacao models.ErrosValidacao
+			if assert.ErrorAs(t, err, &errosValidacao, campo) {
+				assert.Equal(t, campo, errosValidacao[0].Campo)
+			}
+		}
+	})
+
+	t.Run("ChaveCache", func(t *testing.T) {
+		opcoes := services.OpcoesQRCodePadrao()
+		outras := opcoes
+		outras.Tamanho = 512
+
+		assert.NotEqual(t, opcoes.ChaveCache(), outras.ChaveCache())
+	})
+}
+
+// TestCamposOpcionais testa os campos opcionais do BR Code: MCC, CEP, idioma alternativo e templates livres
+func TestCamposOpcionais(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	mcc := "5812"
+	cep := "01310-100"
+
+	// novaRequisicao cria uma requisição válida com todos os campos opcionais
+	novaRequisicao := func() models.PixRequest {
+		return models.PixRequest{
+			Nome:   "JOSE DA SILVA",
+			Chave:  "josesilva@email.com",
+			Cidade: "SAO PAULO",
+			MCC:    &mcc,
+			CEP:    &cep,
+			IdiomaAlternativo: &models.IdiomaAlternativoRequest{
+				Idioma: "en",
+				Nome:   "Joseph Silva",
+				Cidade: "Saint Paul",
+			},
+			Templates: []models.TemplateNaoReservadoRequest{
+				{ID: "91", GUI: "BR.COM.OUTRO", Subcampos: []models.SubcampoEMVRequest{{ID: "01", Valor: "X"}}},
+				{ID: "80", GUI: "BR.COM.PARCEIRO", Subcampos: []models.SubcampoEMVRequest{{ID: "01", Valor: "LOJA123"}}},
+			},
+		}
+	}
+
+	t.Run("TodosOsCampos", func(t *testing.T) {
+		// Executar o método a ser testado
+		response, err := service.GerarPixEstatico(novaRequisicao())
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Contains(t, response.CodigoPix, "52045812")
+		assert.Contains(t, response.CodigoPix, "61080131010062070503***")
+		assert.Contains(t, response.CodigoPix, "64360002EN0112JOSEPH SILVA0210SAINT PAUL")
+		assert.Contains(t, response.CodigoPix, "80300015BR.COM.PARCEIRO0107LOJA1239121")
+
+		// O código gerado deve ser lido sem erros, com os campos em ordem crescente
+		decodificado := service.DecodificarBRCode(response.CodigoPix)
+		assert.True(t, decodificado.Valido, decodificado.Erros)
+		assert.Equal(t, "5812", decodificado.MCC)
+		assert.Equal(t, "01310100", decodificado.CEP)
+
+		ids := make([]string, 0, len(decodificado.Campos))
+		for _, campo := range decodificado.Campos {
+			ids = append(ids, campo.ID)
+		}
+		assert.Equal(t, []string{"00", "01", "26", "52", "53", "58", "59", "60", "61", "62", "64", "80", "91", "63"}, ids)
+	})
+
+	t.Run("MCCPadrao", func(t *testing.T) {
+		response, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:   "JOSE DA SILVA",
+			Chave:  "josesilva@email.com",
+			Cidade: "SAO PAULO",
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, response.CodigoPix, "52040000")
+		assert.NotContains(t, response.CodigoPix, "6108")
+	})
+
+	t.Run("CamposInvalidos", func(t *testing.T) {
+		casos := map[string]func(req *models.PixRequest){
+			"mcc": func(req *models.PixRequest) {
+				invalido := "58A2"
+				req.MCC = &invalido
+			},
+			"cep": func(req *models.PixRequest) {
+				invalido := "1310-100"
+				req.CEP = &invalido
+			},
+			"idioma_alternativo.idioma": func(req *models.PixRequest) {
+				req.IdiomaAlternativo.Idioma = "ENG"
+			},
+			"idioma_alternativo.nome": func(req *models.PixRequest) {
+				req.IdiomaAlternativo.Nome = "  "
+			},
+			"templates[0].id": func(req *models.PixRequest) {
+				req.Templates[0].ID = "79"
+			},
+			"templates[1].id": func(req *models.PixRequest) {
+				req.Templates[1].ID = "91"
+			},
+			"templates[0].gui": func(req *models.PixRequest) {
+				req.Templates[0].GUI = strings.Repeat("A", 33)
+			},
+			"templates[0].subcampos[0].valor": func(req *models.PixRequest) {
+				req.Templates[0].Subcampos[0].Valor = "ação"
+			},
+			"templates[0]": func(req *models.PixRequest) {
+				req.Templates[0].Subcampos = []models.SubcampoEMVRequest{
+					{ID: "01", Valor: strings.Repeat("A", 50)},
+					{ID: "02", Valor: strings.Repeat("B", 50)},
+				}
+			},
+		}
+
+		for campo, alterar := range casos {
+			req := novaRequisicao()
+			alterar(&req)
+
+			// Executar o método a ser testado
+			_, err := service.GerarPixEstatico(req)
+
+			// Verificar resultados
+			var errosValidacao models.ErrosValidacao
+			if assert.ErrorAs(t, err, &errosValidacao, campo) {
+				assert.Equal(t, campo, errosValidacao[0].Campo)
+			}
+		}
+	})
+}
+
+// TestValidacaoPayload testa a validação estrita dos campos e do tamanho do BR Code
+func TestValidacaoPayload(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	// campos extrai os campos com erro, na ordem em que foram informados
+	campos := func(err error) []string {
+		erros, ok := models.ExtrairErrosValidacao(err)
+		assert.True(t, ok, err)
+
+		resultado := make([]string, len(erros))
+		for i, erro := range erros {
+			resultado[i] = erro.Campo
+		}
+		return resultado
+	}
+
+	t.Run("TodosOsErrosDeUmaVez", func(t *testing.T) {
+		identificador := "FATURA 123"
+		mcc := "ABCD"
+
+		// Executar o método a ser testado
+		_, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:          "JOSE DA SILVA",
+			Chave:         "529.982.247-26",
+			Cidade:        "SAO PAULO",
+			Identificador: &identificador,
+			MCC:           &mcc,
+		})
+
+		// Verificar resultados
+		assert.Equal(t, []string{"chave", "identificador", "mcc"}, campos(err))
+	})
+
+	t.Run("IdentificadorAlfanumerico", func(t *testing.T) {
+		invalidos := []string{"FATURA-123", "FATURAÇÃO", "ABCDEFGHIJKLMNOPQRSTUVWXYZ"}
+
+		for _, identificador := range invalidos {
+			_, err := service.GerarPixEstatico(models.PixRequest{
+				Nome:          "JOSE DA SILVA",
+				Chave:         "josesilva@email.com",
+				Cidade:        "SAO PAULO",
+				Identificador: &identificador,
+			})
+
+			assert.Equal(t, []string{"identificador"}, campos(err), identificador)
+		}
+
+		// Identificadores válidos são gravados exatamente como informados
+		identificador := "Fatura123"
+		response, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:          "JOSE DA SILVA",
+			Chave:         "josesilva@email.com",
+			Cidade:        "SAO PAULO",
+			Identificador: &identificador,
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, response.CodigoPix, "0509Fatura123")
+	})
+
+	t.Run("NomeECidadeVaziosAposNormalizacao", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:   "   ",
+			Chave:  "josesilva@email.com",
+			Cidade: "東京",
+		})
+
+		// Verificar resultados: os campos 59 e 60 são obrigatórios no BR Code
+		assert.Equal(t, []string{"nome", "cidade"}, campos(err))
+
+		_, err = service.GerarPixDinamico(models.CobRequest{
+			Txid:     "7978c0c97ea847e78e8849634473c1f1",
+			Location: "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+			Nome:     "東京",
+			Chave:    "josesilva@email.com",
+			Cidade:   "SAO PAULO",
+		})
+		assert.Equal(t, []string{"nome"}, campos(err))
+	})
+
+	t.Run("TamanhoTotal", func(t *testing.T) {
+		req := models.PixRequest{
+			Nome:   "JOSE DA SILVA",
+			Chave:  "josesilva@email.com",
+			Cidade: "SAO PAULO",
+		}
+
+		// Cinco templates de 99 caracteres ultrapassam o limite de 512 do BR Code
+		for id := 80; id < 85; id++ {
+			req.Templates = append(req.Templates, models.TemplateNaoReservadoRequest{
+				ID:        strconv.Itoa(id),
+				GUI:       "BR.COM.PARCEIRO",
+				Subcampos: []models.SubcampoEMVRequest{{ID: "01", Valor: strings.Repeat("A", 76)}},
+			})
+		}
+
+		// Executar o método a ser testado
+		_, err := service.GerarPixEstatico(req)
+
+		// Verificar resultados
+		assert.Equal(t, []string{"brcode"}, campos(err))
+		assert.Contains(t, err.Error(), "excede 512 caracteres")
+	})
+
+	t.Run("TxidDinamico", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := service.GerarPixDinamico(models.CobRequest{
+			Txid:     "txid com espacos e simbolos!!",
+			Location: "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+			Nome:     "JOSE DA SILVA",
+			Chave:    "josesilva@email.com",
+			Cidade:   "SAO PAULO",
+		})
+
+		// Verificar resultados
+		assert.Equal(t, []string{"txid"}, campos(err))
+	})
+}
+
+// TestSaqueTroco testa a geração de PIX Saque e PIX Troco
+func TestSaqueTroco(t *testing.T) {
+	// Inicializar o serviço
+	service := services.NewPixGeneratorService()
+
+	// novaRequisicao cria uma requisição na modalidade informada
+	novaRequisicao := func(modalidade string, valor int64, compra *models.Dinheiro) models.PixRequest {
+		total := models.NovoDinheiro(valor)
+		return models.PixRequest{
+			Nome:       "MERCADO CENTRAL",
+			Chave:      "mercado@email.com",
+			Cidade:     "SAO PAULO",
+			Valor:      &total,
+			Modalidade: modalidade,
+			SaqueTroco: &models.SaqueTrocoRequest{
+				ModalidadeAgente: models.AgenteEstabelecimentoComercial,
+				PrestadorISPB:    "12345678",
+				ValorCompra:      compra,
+			},
+		}
+	}
+
+	t.Run("Saque", func(t *testing.T) {
+		// Executar o método a ser testado
+		response, err := service.GerarPixEstatico(novaRequisicao("saque", 5000, nil))
+
+		// Verificar resultados: agente no template 26 e modalidade no 62.50
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModalidadeSaque, response.Modalidade)
+		assert.Contains(t, response.CodigoPix, "0305AGTEC040812345678")
+		assert.Contains(t, response.CodigoPix, "50360014BR.GOV.BCB.PIX0105SAQUE020550.00")
+
+		decodificado := service.DecodificarBRCode(response.CodigoPix)
+		assert.True(t, decodificado.Valido, decodificado.Erros)
+		assert.Equal(t, models.ModalidadeSaque, decodificado.Modalidade)
+		assert.Equal(t, "50.00", decodificado.Valor)
+	})
+
+	t.Run("Troco", func(t *testing.T) {
+		compra := models.NovoDinheiro(8000)
+
+		// Executar o método a ser testado: compra de 80,00 com 20,00 de troco
+		response, err := service.GerarPixEstatico(novaRequisicao(models.ModalidadeTroco, 10000, &compra))
+
+		// Verificar resultados
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModalidadeTroco, response.Modalidade)
+		assert.Contains(t, response.CodigoPix, "5406100.00")
+		assert.Contains(t, response.CodigoPix, "0105TROCO020520.00030580.00")
+		assert.True(t, service.DecodificarBRCode(response.CodigoPix).Valido)
+	})
+
+	t.Run("CompraPadrao", func(t *testing.T) {
+		response, err := service.GerarPixEstatico(models.PixRequest{
+			Nome:   "MERCADO CENTRAL",
+			Chave:  "mercado@email.com",
+			Cidade: "SAO PAULO",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModalidadeCompra, response.Modalidade)
+		assert.NotContains(t, response.CodigoPix, "BR.GOV.BCB.PIX0105")
+	})
+
+	t.Run("DadosInvalidos", func(t *testing.T) {
+		compraMaior := models.NovoDinheiro(20000)
+
+		casos := map[string]models.PixRequest{
+			"modalidade":               novaRequisicao("DEPOSITO", 5000, nil),
+			"saque_troco.valor_compra": novaRequisicao(models.ModalidadeTroco, 10000, &compraMaior),
+			"saque_troco.modalidade_agente": func() models.PixRequest {
+				req := novaRequisicao(models.ModalidadeSaque, 5000, nil)
+				req.SaqueTroco.ModalidadeAgente = "LOJA"
+				return req
+			}(),
+			"saque_troco.prestador_ispb": func() models.PixRequest {
+				req := novaRequisicao(models.ModalidadeSaque, 5000, nil)
+				req.SaqueTroco.PrestadorISPB = "1234"
+				return req
+			}(),
+			"valor": func() models.PixRequest {
+				req := novaRequisicao(models.ModalidadeSaque, 0, nil)
+				req.Valor = nil
+				return req
+			}(),
+			"saque_troco": novaRequisicao(models.ModalidadeCompra, 5000, nil),
+		}
+
+		for campo, req := range casos {
+			// Executar o método a ser testado
+			_, err := service.GerarPixEstatico(req)
+
+			// Verificar resultados
+			var errosValidacao models.ErrosValidacao
+			if assert.ErrorAs(t, err, &errosValidacao, campo) {
+				assert.Equal(t, campo, errosValidacao[0].Campo)
+			}
+		}
+	})
+}
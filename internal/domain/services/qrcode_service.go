@@ -0,0 +1,259 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/skip2/go-qrcode"
+)
+
+// Limites das opções de renderização do QR Code
+const (
+	// margemPadraoQRCode é a zona de silêncio recomendada pela especificação do QR Code, em módulos
+	margemPadraoQRCode = 4
+
+	// margemMaximaQRCode limita a zona de silêncio para evitar imagens desproporcionais
+	margemMaximaQRCode = 16
+
+	// tamanhoPadraoQRCode é a largura do PNG quando nada é informado, em pixels
+	tamanhoPadraoQRCode = 256
+
+	// tamanhoMinimoQRCode e tamanhoMaximoQRCode limitam a largura do PNG, em pixels
+	tamanhoMinimoQRCode = 64
+	tamanhoMaximoQRCode = 2048
+)
+
+var corRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// niveisCorrecao mapeia os níveis de correção de erro para os da biblioteca de QR Code
+var niveisCorrecao = map[string]qrcode.RecoveryLevel{
+	models.NivelCorrecaoL: qrcode.Low,
+	models.NivelCorrecaoM: qrcode.Medium,
+	models.NivelCorrecaoQ: qrcode.High,
+	models.NivelCorrecaoH: qrcode.Highest,
+}
+
+// OpcoesQRCode define as opções de renderização do QR Code em PNG e SVG
+type OpcoesQRCode struct {
+	Nivel        string // Nível de correção de erro (L, M, Q ou H)
+	Tamanho      int    // Largura e altura do PNG, em pixels
+	Margem       int    // Zona de silêncio ao redor do QR Code, em módulos
+	CorFrente    string // Cor dos módulos escuros (#RGB ou #RRGGBB)
+	CorFundo     string // Cor de fundo (#RGB ou #RRGGBB)
+	Transparente bool   // Gera o fundo transparente, ignorando CorFundo
+}
+
+// OpcoesQRCodePadrao retorna as opções de renderização usadas quando nada é informado
+func OpcoesQRCodePadrao() OpcoesQRCode {
+	return OpcoesQRCode{
+		Nivel:     models.NivelCorrecaoM,
+		Tamanho:   tamanhoPadraoQRCode,
+		Margem:    margemPadraoQRCode,
+		CorFrente: "#000000",
+		CorFundo:  "#FFFFFF",
+	}
+}
+
+// NovasOpcoesQRCode aplica as opções informadas sobre o padrão e valida o resultado
+func NovasOpcoesQRCode(req *models.QRCodeRequest) (OpcoesQRCode, error) {
+	opcoes := OpcoesQRCodePadrao()
+	if req == nil {
+		return opcoes, nil
+	}
+
+	if req.Nivel != "" {
+		opcoes.Nivel = strings.ToUpper(req.Nivel)
+	}
+	if req.Tamanho != nil {
+		opcoes.Tamanho = *req.Tamanho
+	}
+	if req.Margem != nil {
+		opcoes.Margem = *req.Margem
+	}
+	if req.Cor != "" {
+		opcoes.CorFrente = normalizarCor(req.Cor)
+	}
+	if req.Fundo != "" {
+		opcoes.CorFundo = normalizarCor(req.Fundo)
+	}
+	opcoes.Transparente = req.Transparente
+
+	if err := opcoes.Validar(); err != nil {
+		return OpcoesQRCode{}, err
+	}
+
+	return opcoes, nil
+}
+
+// Validar verifica se as opções de renderização estão dentro dos limites aceitos
+func (o OpcoesQRCode) Validar() error {
+	if _, existe := niveisCorrecao[o.Nivel]; !existe {
+		return erroQRCode("nivel", "nível de correção deve ser L, M, Q ou H")
+	}
+	if o.Tamanho < tamanhoMinimoQRCode || o.Tamanho > tamanhoMaximoQRCode {
+		return erroQRCode("tamanho", fmt.Sprintf("tamanho deve estar entre %d e %d pixels", tamanhoMinimoQRCode, tamanhoMaximoQRCode))
+	}
+	if o.Margem < 0 || o.Margem > margemMaximaQRCode {
+		return erroQRCode("margem", fmt.Sprintf("margem deve estar entre 0 e %d módulos", margemMaximaQRCode))
+	}
+	if !corRegex.MatchString(o.CorFrente) {
+		return erroQRCode("cor", "cor de frente deve estar no formato #RGB ou #RRGGBB")
+	}
+	if !corRegex.MatchString(o.CorFundo) {
+		return erroQRCode("fundo", "cor de fundo deve estar no formato #RGB ou #RRGGBB")
+	}
+	return nil
+}
+
+// ChaveCache identifica a combinação de opções, para que cada variação seja cacheada separadamente
+func (o OpcoesQRCode) ChaveCache() string {
+	return fmt.Sprintf("%s:%d:%d:%s:%s:%t", o.Nivel, o.Tamanho, o.Margem, strings.ToUpper(o.CorFrente), strings.ToUpper(o.CorFundo), o.Transparente)
+}
+
+// GerarQRCodeSVG gera um QR code vetorial em formato SVG a partir da mesma matriz
+// de módulos usada na geração do PNG
+func (s *PixGeneratorService) GerarQRCodeSVG(codigoPix string, opcoes OpcoesQRCode) (string, error) {
+	bitmap, err := gerarMatriz(codigoPix, opcoes)
+	if err != nil {
+		return "", err
+	}
+
+	return renderizarSVG(bitmap, opcoes), nil
+}
+
+// GerarQRCodePNG gera um QR code em formato PNG (base64)
+func (s *PixGeneratorService) GerarQRCodePNG(codigoPix string, opcoes OpcoesQRCode) (string, error) {
+	bitmap, err := gerarMatriz(codigoPix, opcoes)
+	if err != nil {
+		return "", err
+	}
+
+	qrCode, err := renderizarPNG(bitmap, opcoes)
+	if err != nil {
+		return "", err
+	}
+
+	base64PNG := "data:image/png;base64," + base64.StdEncoding.EncodeToString(qrCode)
+	return base64PNG, nil
+}
+
+// gerarMatriz valida as opções e calcula a matriz de módulos do QR code, sem zona de silêncio
+func gerarMatriz(codigoPix string, opcoes OpcoesQRCode) ([][]bool, error) {
+	if err := opcoes.Validar(); err != nil {
+		return nil, err
+	}
+
+	qr, err := qrcode.New(codigoPix, niveisCorrecao[opcoes.Nivel])
+	if err != nil {
+		return nil, err
+	}
+
+	// A zona de silêncio é desenhada pelos renderizadores conforme as opções
+	qr.DisableBorder = true
+
+	return qr.Bitmap(), nil
+}
+
+// renderizarSVG converte a matriz de módulos em um SVG, unindo os módulos escuros
+// consecutivos de cada linha em um único segmento de path
+func renderizarSVG(bitmap [][]bool, opcoes OpcoesQRCode) string {
+	tamanho := len(bitmap) + 2*opcoes.Margem
+
+	var path strings.Builder
+	for y, linha := range bitmap {
+		for x := 0; x < len(linha); {
+			if !linha[x] {
+				x++
+				continue
+			}
+
+			inicio := x
+			for x < len(linha) && linha[x] {
+				x++
+			}
+
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", inicio+opcoes.Margem, y+opcoes.Margem, x-inicio, x-inicio)
+		}
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, tamanho, tamanho)
+	if !opcoes.Transparente {
+		fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="%s"/>`, tamanho, tamanho, opcoes.CorFundo)
+	}
+	fmt.Fprintf(&svg, `<path fill="%s" d="%s"/>`, opcoes.CorFrente, path.String())
+	svg.WriteString(`</svg>`)
+
+	return svg.String()
+}
+
+// renderizarPNG converte a matriz de módulos em um PNG com o tamanho pedido. Cada módulo
+// ocupa um número inteiro de pixels para manter a leitura nítida; a sobra é distribuída
+// nas bordas. Quando o tamanho não comporta um pixel por módulo, a imagem é ampliada.
+func renderizarPNG(bitmap [][]bool, opcoes OpcoesQRCode) ([]byte, error) {
+	modulos := len(bitmap) + 2*opcoes.Margem
+	tamanho := max(opcoes.Tamanho, modulos)
+	escala := tamanho / modulos
+	deslocamento := (tamanho-modulos*escala)/2 + opcoes.Margem*escala
+
+	fundo := color.Color(color.Transparent)
+	if !opcoes.Transparente {
+		fundo = parseCor(opcoes.CorFundo)
+	}
+
+	// A imagem indexada com duas cores mantém o arquivo pequeno mesmo em 2048 pixels
+	img := image.NewPaletted(image.Rect(0, 0, tamanho, tamanho), color.Palette{fundo, parseCor(opcoes.CorFrente)})
+	for y, linha := range bitmap {
+		for x, escuro := range linha {
+			if !escuro {
+				continue
+			}
+
+			px, py := deslocamento+x*escala, deslocamento+y*escala
+			for i := py; i < py+escala; i++ {
+				for j := px; j < px+escala; j++ {
+					img.SetColorIndex(j, i, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// normalizarCor aceita cores com ou sem o prefixo #, que precisa ser codificado na URL
+func normalizarCor(cor string) string {
+	if strings.HasPrefix(cor, "#") {
+		return cor
+	}
+	return "#" + cor
+}
+
+// parseCor converte uma cor já validada no formato #RGB ou #RRGGBB
+func parseCor(cor string) color.NRGBA {
+	hex := strings.TrimPrefix(cor, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	rgb, _ := strconv.ParseUint(hex, 16, 32)
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
+
+// erroQRCode cria um erro de validação para uma opção de renderização do QR Code
+func erroQRCode(campo, mensagem string) models.ErroValidacao {
+	return models.ErroValidacao{Campo: "qrcode." + campo, Mensagem: mensagem}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Sub-campos usados pelo PIX Saque e pelo PIX Troco
+const (
+	// subcampoContaAgente e subcampoContaISPB identificam o agente de saque no Merchant Account Information (26)
+	subcampoContaAgente = "03"
+	subcampoContaISPB   = "04"
+
+	// subcampoSaqueTroco é o template específico do arranjo PIX no Additional Data Field (62)
+	subcampoSaqueTroco = "50"
+)
+
+var ispbRegex = regexp.MustCompile(`^\d{8}$`)
+
+// modalidadesAgente lista as modalidades aceitas para o agente de saque
+var modalidadesAgente = map[string]bool{
+	models.AgenteEstabelecimentoComercial: true,
+	models.AgenteOutraPessoaJuridica:      true,
+	models.AgenteFacilitadorSaque:         true,
+}
+
+// dadosSaqueTroco reúne os dados já validados de um PIX Saque ou PIX Troco
+type dadosSaqueTroco struct {
+	modalidade   string // COMPRA, SAQUE ou TROCO
+	agente       string // Modalidade do agente de saque
+	ispb         string // ISPB do prestador do serviço de saque
+	valorEspecie string // Valor entregue em espécie (saque ou troco)
+	valorCompra  string // Valor da compra, apenas no PIX Troco
+}
+
+// montarSaqueTroco valida a modalidade do PIX e os dados do agente de saque. No PIX Saque
+// todo o valor é entregue em espécie; no PIX Troco, apenas o que excede o valor da compra.
+func montarSaqueTroco(v *validador, req models.PixRequest) dadosSaqueTroco {
+	modalidade := strings.ToUpper(strings.TrimSpace(req.Modalidade))
+	if modalidade == "" {
+		modalidade = models.ModalidadeCompra
+	}
+
+	dados := dadosSaqueTroco{modalidade: modalidade}
+
+	switch modalidade {
+	case models.ModalidadeCompra:
+		if req.SaqueTroco != nil {
+			v.campo("saque_troco", "dados de saque e troco só são aceitos nas modalidades SAQUE e TROCO")
+		}
+		return dados
+	case models.ModalidadeSaque, models.ModalidadeTroco:
+	default:
+		v.campo("modalidade", "modalidade deve ser COMPRA, SAQUE ou TROCO")
+		return dados
+	}
+
+	if req.SaqueTroco == nil {
+		v.campo("saque_troco", "dados do agente de saque são obrigatórios nas modalidades SAQUE e TROCO")
+		return dados
+	}
+
+	dados.agente = strings.ToUpper(strings.TrimSpace(req.SaqueTroco.ModalidadeAgente))
+	if !modalidadesAgente[dados.agente] {
+		v.campo("saque_troco.modalidade_agente", "modalidade do agente deve ser AGTEC, AGTOT ou AGPSS")
+	}
+
+	dados.ispb = strings.TrimSpace(req.SaqueTroco.PrestadorISPB)
+	if !ispbRegex.MatchString(dados.ispb) {
+		v.campo("saque_troco.prestador_ispb", "ISPB do prestador deve conter 8 dígitos")
+	}
+
+	if req.Valor == nil || *req.Valor <= 0 {
+		v.campo("valor", "valor é obrigatório nas modalidades SAQUE e TROCO")
+		return dados
+	}
+
+	compra := req.SaqueTroco.ValorCompra
+	if modalidade == models.ModalidadeSaque {
+		if compra != nil && *compra > 0 {
+			v.campo("saque_troco.valor_compra", "valor da compra só é aceito na modalidade TROCO")
+		}
+		dados.valorEspecie = req.Valor.String()
+		return dados
+	}
+
+	if compra == nil || *compra <= 0 || *compra >= *req.Valor {
+		v.campo("saque_troco.valor_compra", "valor da compra é obrigatório no PIX Troco e deve ser menor que o valor do PIX")
+		return dados
+	}
+	dados.valorEspecie = (*req.Valor - *compra).String()
+	dados.valorCompra = compra.String()
+
+	return dados
+}
+
+// subcamposConta retorna os sub-campos do agente de saque para o Merchant Account Information
+func (d dadosSaqueTroco) subcamposConta() string {
+	var subcampos strings.Builder
+	addCampo(&subcampos, subcampoContaAgente, d.agente)
+	addCampo(&subcampos, subcampoContaISPB, d.ispb)
+	return subcampos.String()
+}
+
+// template retorna o template do Additional Data Field com a modalidade e os valores
+// do saque ou troco; vazio em uma compra comum
+func (d dadosSaqueTroco) template() string {
+	if d.modalidade == "" || d.modalidade == models.ModalidadeCompra {
+		return ""
+	}
+
+	var template strings.Builder
+	addCampo(&template, "00", "BR.GOV.BCB.PIX")
+	addCampo(&template, "01", d.modalidade)
+	addCampo(&template, "02", d.valorEspecie)
+	addCampo(&template, "03", d.valorCompra)
+	return template.String()
+}
@@ -52,6 +52,12 @@ func NewTemplateProcessor(templatesDir string) *TemplateProcessor {
 	}
 }
 
+// Existe indica se o template está registrado
+func (p *TemplateProcessor) Existe(templateName string) bool {
+	_, existe := p.templates[templateName]
+	return existe
+}
+
 // ApplyTemplate aplica um template a um código QR existente
 func (p *TemplateProcessor) ApplyTemplate(qrCodePNG string, templateName string) ([]byte, error) {
 	// Verificar se o template existe
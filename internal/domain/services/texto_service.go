@@ -0,0 +1,116 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Tamanhos máximos dos campos de texto do BR Code
+const (
+	tamanhoMaximoNome          = 25
+	tamanhoMaximoCidade        = 15
+	tamanhoMaximoIdentificador = 25
+	tamanhoMaximoDescricao     = 50
+)
+
+// transliteracoes mapeia letras latinas acentuadas (já em maiúsculas) para o equivalente ASCII
+var transliteracoes = func() map[rune]string {
+	grupos := map[string]string{
+		"ÀÁÂÃÄÅĀĂĄª": "A",
+		"Æ":          "AE",
+		"ÇĆĈĊČ":      "C",
+		"ĎĐ":         "D",
+		"ÈÉÊËĒĔĖĘĚ":  "E",
+		"ĜĞĠĢ":       "G",
+		"ĤĦ":         "H",
+		"ÌÍÎÏĨĪĬĮİ":  "I",
+		"Ĵ":          "J",
+		"Ķ":          "K",
+		"ĹĻĽĿŁ":      "L",
+		"ÑŃŅŇ":       "N",
+		"ÒÓÔÕÖØŌŎŐº": "O",
+		"Œ":          "OE",
+		"ŔŖŘ":        "R",
+		"ŚŜŞŠ":       "S",
+		"ß":          "SS",
+		"ŢŤŦ":        "T",
+		"ÙÚÛÜŨŪŬŮŰŲ": "U",
+		"Ŵ":          "W",
+		"ÝŶŸ":        "Y",
+		"ŹŻŽ":        "Z",
+	}
+
+	mapa := make(map[rune]string)
+	for letras, ascii := range grupos {
+		for _, letra := range letras {
+			mapa[letra] = ascii
+		}
+	}
+	return mapa
+}()
+
+// caractereBRCode indica se o caractere pertence ao conjunto "ans" do padrão EMV
+// (ASCII imprimível), aceito nos campos de texto do BR Code
+func caractereBRCode(r rune) bool {
+	return r >= 0x20 && r <= 0x7E
+}
+
+// ajustesTexto acumula os campos cujo valor precisou ser alterado para caber no BR Code
+type ajustesTexto []models.AjusteCampo
+
+// normalizar translitera, filtra e limita o tamanho de um campo de texto, registrando
+// o ajuste quando o valor utilizado difere do informado (ignorando maiúsculas e espaços nas pontas)
+func (a *ajustesTexto) normalizar(campo, texto string, tamanhoMax int, permitido func(rune) bool) string {
+	normalizado := limitarTamanho(transliterar(texto, permitido), tamanhoMax)
+
+	if normalizado != strings.ToUpper(strings.TrimSpace(texto)) {
+		*a = append(*a, models.AjusteCampo{
+			Campo:     campo,
+			Original:  texto,
+			Utilizado: normalizado,
+		})
+	}
+
+	return normalizado
+}
+
+// transliterar converte o texto para maiúsculas, troca letras acentuadas pelo equivalente
+// ASCII (ã → A, ç → C), remove os caracteres não permitidos e colapsa espaços repetidos
+func transliterar(texto string, permitido func(rune) bool) string {
+	var resultado strings.Builder
+	espacoPendente := false
+
+	for _, r := range strings.ToUpper(texto) {
+		if unicode.IsSpace(r) {
+			espacoPendente = resultado.Len() > 0
+			continue
+		}
+
+		substituto, existe := transliteracoes[r]
+		if !existe {
+			if !permitido(r) {
+				continue
+			}
+			substituto = string(r)
+		}
+
+		if espacoPendente {
+			resultado.WriteByte(' ')
+			espacoPendente = false
+		}
+		resultado.WriteString(substituto)
+	}
+
+	return resultado.String()
+}
+
+// limitarTamanho limita o tamanho de uma string em caracteres, sem cortar caracteres multi-byte
+func limitarTamanho(texto string, tamanhoMax int) string {
+	runas := []rune(texto)
+	if len(runas) <= tamanhoMax {
+		return texto
+	}
+	return strings.TrimSpace(string(runas[:tamanhoMax]))
+}
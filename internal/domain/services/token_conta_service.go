@@ -0,0 +1,82 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrEmailNaoVerificado indica que o login foi recusado porque o estabelecimento ainda não
+// confirmou o email, quando a verificação é obrigatória
+var ErrEmailNaoVerificado = errors.New("email não verificado: confirme o email pelo link enviado")
+
+// GerarTokenConta gera um token de conta aleatório e o hash com que ele é armazenado. O token
+// em si só é enviado por email.
+func GerarTokenConta() (token, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(bytes)
+	return token, HashTokenConta(token), nil
+}
+
+// HashTokenConta calcula o hash SHA-256 de um token de conta
+func HashTokenConta(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// MensagemVerificacaoEmail monta o email com o link de confirmação do email do estabelecimento
+func MensagemVerificacaoEmail(estabelecimento models.Estabelecimento, urlBase, token string, validade time.Duration) models.MensagemEmail {
+	return models.MensagemEmail{
+		Para:    estabelecimento.Email,
+		Assunto: "Confirme o email do seu cadastro no Gerador de PIX",
+		Corpo: fmt.Sprintf(
+			"Olá, %s.\n\nConfirme o email do seu cadastro acessando o link abaixo:\n\n%s\n\n"+
+				"Ou envie o token %s para POST /api/email/verificar.\n\n"+
+				"O link vale por %s e pode ser usado uma única vez. Se você não fez este cadastro, ignore este email.\n",
+			estabelecimento.Nome, linkTokenConta(urlBase, "/verificar-email", token), token, descreverValidade(validade),
+		),
+	}
+}
+
+// MensagemRedefinicaoSenha monta o email com o link de redefinição da senha de um login
+func MensagemRedefinicaoSenha(nome, email, urlBase, token string, validade time.Duration) models.MensagemEmail {
+	return models.MensagemEmail{
+		Para:    email,
+		Assunto: "Redefinição de senha do Gerador de PIX",
+		Corpo: fmt.Sprintf(
+			"Olá, %s.\n\nRecebemos um pedido para redefinir a sua senha. Defina uma nova senha acessando o link abaixo:\n\n%s\n\n"+
+				"Ou envie o token %s com a nova senha para POST /api/senha/redefinir.\n\n"+
+				"O link vale por %s e pode ser usado uma única vez. Se você não pediu a redefinição, ignore este email; a senha atual continua valendo.\n",
+			nome, linkTokenConta(urlBase, "/redefinir-senha", token), token, descreverValidade(validade),
+		),
+	}
+}
+
+// linkTokenConta monta o link da aplicação que recebe o token
+func linkTokenConta(urlBase, caminho, token string) string {
+	return strings.TrimRight(urlBase, "/") + caminho + "?token=" + url.QueryEscape(token)
+}
+
+// descreverValidade descreve a validade do token em horas ou minutos
+func descreverValidade(validade time.Duration) string {
+	if validade >= time.Hour && validade%time.Hour == 0 {
+		horas := int(validade / time.Hour)
+		if horas == 1 {
+			return "1 hora"
+		}
+		return fmt.Sprintf("%d horas", horas)
+	}
+	return fmt.Sprintf("%d minutos", int(validade/time.Minute))
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrPapelNaoPermitido indica que o papel de quem faz a requisição não permite gerenciar o
+// papel do usuário: gerentes só gerenciam caixas e usuários de leitura
+var ErrPapelNaoPermitido = errors.New("seu papel não permite gerenciar usuários com esse papel")
+
+// NovoConvite valida o convite de um usuário para a equipe por quem tem o papel papelAtor
+func NovoConvite(req models.ConvidarUsuarioRequest, papelAtor string) (models.Usuario, error) {
+	usuario := models.Usuario{
+		Email: strings.TrimSpace(req.Email),
+		Papel: strings.TrimSpace(req.Papel),
+		Ativo: true,
+	}
+
+	if err := ValidarPapel(usuario.Papel, papelAtor); err != nil {
+		return models.Usuario{}, err
+	}
+
+	return usuario, nil
+}
+
+// ValidarPapel confere se o papel pode ser atribuído a um usuário convidado por quem tem o
+// papel papelAtor
+func ValidarPapel(papel, papelAtor string) error {
+	var v validador
+
+	if !papelConvidavel(papel) {
+		v.campo("papel", fmt.Sprintf("papel %q inválido; use %s", papel, strings.Join(models.PapeisConvidaveis, ", ")))
+		return v.erro()
+	}
+
+	if !PodeGerenciarPapel(papelAtor, papel) {
+		return ErrPapelNaoPermitido
+	}
+
+	return nil
+}
+
+// PodeGerenciarPapel informa se quem tem o papel papelAtor pode convidar, alterar ou desativar
+// usuários com o papel informado. O proprietário gerencia todos os usuários; o gerente, apenas
+// os caixas e os usuários de leitura.
+func PodeGerenciarPapel(papelAtor, papel string) bool {
+	switch papelAtor {
+	case models.PapelProprietario:
+		return true
+	case models.PapelGerente:
+		return papel == models.PapelCaixa || papel == models.PapelLeitura
+	default:
+		return false
+	}
+}
+
+// GerarConvite gera um convite aleatório e o hash com que ele é armazenado. O convite em si
+// só é entregue a quem convidou.
+func GerarConvite() (convite, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	convite = base64.RawURLEncoding.EncodeToString(bytes)
+	return convite, HashConvite(convite), nil
+}
+
+// HashConvite calcula o hash SHA-256 de um convite
+func HashConvite(convite string) string {
+	hash := sha256.Sum256([]byte(convite))
+	return hex.EncodeToString(hash[:])
+}
+
+// papelConvidavel informa se o papel pode ser atribuído a um usuário convidado
+func papelConvidavel(papel string) bool {
+	for _, convidavel := range models.PapeisConvidaveis {
+		if papel == convidavel {
+			return true
+		}
+	}
+	return false
+}
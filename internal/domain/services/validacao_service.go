@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// tamanhoMaximoPayload é o maior BR Code aceito pelo padrão EMV, o que também garante
+// que o código caiba no QR Code em qualquer nível de correção de erro
+const tamanhoMaximoPayload = 512
+
+// referenciaEstatico é o Reference Label usado quando nenhum identificador é informado
+const referenciaEstatico = "***"
+
+var (
+	// referenciaRegex valida o Reference Label (62.05), que aceita apenas letras e números
+	referenciaRegex = regexp.MustCompile(`^[A-Za-z0-9]{1,25}$`)
+
+	// txidRegex valida o txid de uma cobrança imediata
+	txidRegex = regexp.MustCompile(`^[A-Za-z0-9]{26,35}$`)
+)
+
+// tamanhosMaximosCampos lista os campos do BR Code com limite menor que os 99 caracteres do TLV
+var tamanhosMaximosCampos = map[string]int{
+	"52": 4,
+	"53": 3,
+	"54": 13,
+	"58": 2,
+	"59": tamanhoMaximoNome,
+	"60": tamanhoMaximoCidade,
+}
+
+// validador acumula os erros de validação de uma requisição, para que todos sejam
+// devolvidos de uma só vez em vez de um por tentativa
+type validador struct {
+	erros models.ErrosValidacao
+	falha error // Erro que não é de validação, devolvido imediatamente
+}
+
+// adicionar registra um erro; erros que não são de validação são preservados como estão
+func (v *validador) adicionar(err error) {
+	if err == nil {
+		return
+	}
+
+	if erros, ok := models.ExtrairErrosValidacao(err); ok {
+		v.erros = append(v.erros, erros...)
+		return
+	}
+
+	if v.falha == nil {
+		v.falha = err
+	}
+}
+
+// campo registra um erro de validação para o campo informado
+func (v *validador) campo(campo, mensagem string) {
+	v.erros = append(v.erros, models.ErroValidacao{Campo: campo, Mensagem: mensagem})
+}
+
+// erro retorna nil quando não houve erros, ou a lista de erros de validação encontrados
+func (v *validador) erro() error {
+	if v.falha != nil {
+		return v.falha
+	}
+	if len(v.erros) == 0 {
+		return nil
+	}
+	return v.erros
+}
+
+// validarIdentificador confere o Reference Label: até 25 letras e números, sem espaços
+// nem acentos. O valor não é alterado, pois identifica a transação na conciliação.
+func validarIdentificador(v *validador, identificador *string) string {
+	if identificador == nil || *identificador == "" || *identificador == referenciaEstatico {
+		return referenciaEstatico
+	}
+
+	if !referenciaRegex.MatchString(*identificador) {
+		v.campo("identificador", fmt.Sprintf("identificador deve ter até %d letras e números, sem espaços, acentos ou símbolos", tamanhoMaximoIdentificador))
+		return ""
+	}
+
+	return *identificador
+}
+
+// validarTxid confere o txid de uma cobrança imediata: de 26 a 35 letras e números
+func validarTxid(v *validador, txid string) {
+	if !txidRegex.MatchString(txid) {
+		v.campo("txid", "txid deve ter de 26 a 35 letras e números, sem espaços ou símbolos")
+	}
+}
+
+// validarNomeCidade confere que o nome e a cidade do recebedor, obrigatórios no BR Code
+// (campos 59 e 60), não ficaram vazios após a normalização, como um texto só de espaços
+// ou de caracteres sem equivalente ASCII
+func validarNomeCidade(v *validador, nome, cidade string) {
+	if nome == "" {
+		v.campo("nome", "nome do recebedor deve ter ao menos uma letra ou número representável no BR Code")
+	}
+	if cidade == "" {
+		v.campo("cidade", "cidade do recebedor deve ter ao menos uma letra ou número representável no BR Code")
+	}
+}
+
+// validarCampos confere o tamanho de cada campo do BR Code antes da serialização,
+// já que o TLV comporta no máximo 99 caracteres e alguns campos têm limites menores
+func validarCampos(v *validador, campos []campoEMV) {
+	for _, campo := range campos {
+		maximo, existe := tamanhosMaximosCampos[campo.id]
+		if !existe {
+			maximo = tamanhoMaximoCampoEMV
+		}
+
+		if len(campo.valor) > maximo {
+			nome, _, _ := nomeCampo("", campo.id)
+			v.campo("brcode."+campo.id, fmt.Sprintf("%s excede %d caracteres (%d)", nome, maximo, len(campo.valor)))
+		}
+	}
+}
+
+// validarTamanhoPayload confere o tamanho total do BR Code, já incluindo o CRC
+func validarTamanhoPayload(v *validador, payload string) {
+	if tamanho := len(payload) + len("6304XXXX"); tamanho > tamanhoMaximoPayload {
+		v.campo("brcode", fmt.Sprintf("código PIX excede %d caracteres (%d); reduza os campos opcionais", tamanhoMaximoPayload, tamanho))
+	}
+}
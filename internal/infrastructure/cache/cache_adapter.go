@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"time"
+
+	"github.com/go-redis/redis/v8"
 )
 
+// ErrChaveInexistente é retornado por Get quando a chave não existe ou expirou
+var ErrChaveInexistente = redis.Nil
+
 // CacheAdapter define a interface para o sistema de cache
 type CacheAdapter interface {
 	// Get recupera um valor do cache
@@ -18,6 +23,20 @@ type CacheAdapter interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// ContadorAdapter estende CacheAdapter com contadores que expiram, compartilhados entre as
+// instâncias da API, como os de tentativas de login
+type ContadorAdapter interface {
+	CacheAdapter
+
+	// Incrementar soma 1 ao contador e retorna o novo valor. A expiração é definida apenas
+	// quando o contador é criado, então ele zera ao fim da janela iniciada pelo primeiro incremento.
+	Incrementar(ctx context.Context, key string, expiration time.Duration) (int64, error)
+
+	// Decrementar subtrai 1 do contador e retorna o novo valor, sem alterar a expiração. Um
+	// contador inexistente ou expirado não é criado, e o valor retornado é 0.
+	Decrementar(ctx context.Context, key string) (int64, error)
+}
+
 // GetObject recupera e desserializa um objeto do cache
 func GetObject(adapter CacheAdapter, ctx context.Context, key string, obj interface{}) error {
 	data, err := adapter.Get(ctx, key)
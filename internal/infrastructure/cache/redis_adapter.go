@@ -7,7 +7,24 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-// RedisAdapter implementa CacheAdapter usando Redis
+// scriptIncrementar incrementa o contador e define a expiração na criação, atomicamente
+var scriptIncrementar = redis.NewScript(`
+local valor = redis.call("INCR", KEYS[1])
+if valor == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return valor
+`)
+
+// scriptDecrementar decrementa um contador existente, sem criar um contador sem expiração
+var scriptDecrementar = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+return redis.call("DECR", KEYS[1])
+`)
+
+// RedisAdapter implementa CacheAdapter e ContadorAdapter usando Redis
 type RedisAdapter struct {
 	client *redis.Client
 }
@@ -40,6 +57,16 @@ func (r *RedisAdapter) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// Incrementar implementa a interface ContadorAdapter
+func (r *RedisAdapter) Incrementar(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return scriptIncrementar.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64()
+}
+
+// Decrementar implementa a interface ContadorAdapter
+func (r *RedisAdapter) Decrementar(ctx context.Context, key string) (int64, error) {
+	return scriptDecrementar.Run(ctx, r.client, []string{key}).Int64()
+}
+
 // Close fecha a conexão com o Redis
 func (r *RedisAdapter) Close() error {
 	return r.client.Close()
@@ -52,7 +52,55 @@ func TestRedisAdapter(t *testing.T) {
 		// Tentar recuperar uma chave que não existe
 		key := "test:nonexistent"
 		_, err := adapter.Get(ctx, key)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, cache.ErrChaveInexistente)
+	})
+
+	t.Run("Incrementar", func(t *testing.T) {
+		key := "test:contador"
+
+		// Incrementar o contador na mesma janela
+		for esperado := int64(1); esperado <= 3; esperado++ {
+			valor, err := adapter.Incrementar(ctx, key, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, esperado, valor)
+		}
+
+		// A expiração conta a partir do primeiro incremento e não é renovada pelos seguintes
+		mr.FastForward(30 * time.Second)
+		_, err := adapter.Incrementar(ctx, key, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, mr.TTL(key))
+
+		// Ao fim da janela, o contador recomeça
+		mr.FastForward(30 * time.Second)
+		valor, err := adapter.Incrementar(ctx, key, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), valor)
+	})
+
+	t.Run("Decrementar", func(t *testing.T) {
+		key := "test:decremento"
+
+		for i := 0; i < 2; i++ {
+			_, err := adapter.Incrementar(ctx, key, time.Minute)
+			assert.NoError(t, err)
+		}
+		mr.FastForward(30 * time.Second)
+
+		// Executar o método a ser testado
+		valor, err := adapter.Decrementar(ctx, key)
+
+		// Verificar resultados: a expiração do contador é mantida
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), valor)
+		assert.Equal(t, 30*time.Second, mr.TTL(key))
+
+		// Um contador expirado não é recriado
+		mr.FastForward(30 * time.Second)
+		valor, err = adapter.Decrementar(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), valor)
+		assert.False(t, mr.Exists(key))
 	})
 
 	t.Run("SetWithExpiration", func(t *testing.T) {
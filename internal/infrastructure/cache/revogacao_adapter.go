@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RevogacaoAdapter define a lista de revogação dos tokens de acesso, identificados pelo jti
+type RevogacaoAdapter interface {
+	// Revogar inclui o token na lista até a sua expiração, quando ele deixa de ser aceito de qualquer forma
+	Revogar(ctx context.Context, jti string, expiraEm time.Time) error
+
+	// Revogado informa se o token está na lista
+	Revogado(ctx context.Context, jti string) (bool, error)
+}
+
+// prefixoRevogacao é o prefixo das chaves da lista de revogação no Redis
+const prefixoRevogacao = "token_revogado:"
+
+// RedisRevogacaoAdapter implementa RevogacaoAdapter usando Redis, compartilhado entre instâncias da API
+type RedisRevogacaoAdapter struct {
+	client *redis.Client
+}
+
+// NewRedisRevogacaoAdapter cria uma nova instância da lista de revogação no Redis
+func NewRedisRevogacaoAdapter(host string, port string, password string, db int) *RedisRevogacaoAdapter {
+	client := redis.NewClient(&redis.Options{
+		Addr:     host + ":" + port,
+		Password: password,
+		DB:       db,
+	})
+
+	return &RedisRevogacaoAdapter{
+		client: client,
+	}
+}
+
+// Revogar implementa a interface RevogacaoAdapter
+func (r *RedisRevogacaoAdapter) Revogar(ctx context.Context, jti string, expiraEm time.Time) error {
+	restante := time.Until(expiraEm)
+	if restante <= 0 {
+		// O token já expirou e não precisa constar na lista
+		return nil
+	}
+
+	return r.client.Set(ctx, prefixoRevogacao+jti, 1, restante).Err()
+}
+
+// Revogado implementa a interface RevogacaoAdapter
+func (r *RedisRevogacaoAdapter) Revogado(ctx context.Context, jti string) (bool, error) {
+	existe, err := r.client.Exists(ctx, prefixoRevogacao+jti).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return existe == 1, nil
+}
+
+// Close fecha a conexão com o Redis
+func (r *RedisRevogacaoAdapter) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,52 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRevogacaoAdapter(t *testing.T) {
+	// Inicializar um servidor Redis em memória para testes
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Não foi possível iniciar o miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	adapter := cache.NewRedisRevogacaoAdapter(mr.Host(), mr.Port(), "", 0)
+	defer adapter.Close()
+	ctx := context.Background()
+
+	t.Run("RevogarAteExpiracao", func(t *testing.T) {
+		// Executar o método a ser testado
+		err := adapter.Revogar(ctx, "jti-1", time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+
+		// Verificar resultados
+		revogado, err := adapter.Revogado(ctx, "jti-1")
+		assert.NoError(t, err)
+		assert.True(t, revogado)
+
+		// Após a expiração do token, a entrada deixa a lista
+		mr.FastForward(2 * time.Minute)
+		revogado, err = adapter.Revogado(ctx, "jti-1")
+		assert.NoError(t, err)
+		assert.False(t, revogado)
+	})
+
+	t.Run("TokenExpirado", func(t *testing.T) {
+		// Executar o método a ser testado
+		err := adapter.Revogar(ctx, "jti-2", time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+
+		// Verificar resultados: tokens expirados não ocupam a lista
+		revogado, err := adapter.Revogado(ctx, "jti-2")
+		assert.NoError(t, err)
+		assert.False(t, revogado)
+	})
+}
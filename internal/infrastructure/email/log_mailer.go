@@ -0,0 +1,47 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// LogMailer implementa Mailer escrevendo as mensagens em um arquivo ou log em vez de enviá-las,
+// para o desenvolvimento e para testes. Os links com tokens ficam legíveis na saída, então não
+// deve ser usado em produção.
+type LogMailer struct {
+	mu       sync.Mutex
+	saida    io.Writer
+	enviadas []models.MensagemEmail
+}
+
+// NewLogMailer cria um LogMailer que escreve as mensagens na saída informada
+func NewLogMailer(saida io.Writer) *LogMailer {
+	return &LogMailer{
+		saida: saida,
+	}
+}
+
+// Enviar implementa a interface Mailer
+func (m *LogMailer) Enviar(ctx context.Context, mensagem models.MensagemEmail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enviadas = append(m.enviadas, mensagem)
+
+	_, err := fmt.Fprintf(m.saida, "----- email %s -----\nPara: %s\nAssunto: %s\n\n%s\n-----\n",
+		time.Now().Format(time.RFC3339), mensagem.Para, mensagem.Assunto, mensagem.Corpo)
+	return err
+}
+
+// Enviadas retorna as mensagens enviadas até o momento, na ordem de envio
+func (m *LogMailer) Enviadas() []models.MensagemEmail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]models.MensagemEmail(nil), m.enviadas...)
+}
@@ -0,0 +1,14 @@
+package email
+
+import (
+	"context"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// Mailer define a interface para o envio dos emails da API, como a verificação do email e a
+// redefinição de senha
+type Mailer interface {
+	// Enviar envia a mensagem ao destinatário informado nela
+	Enviar(ctx context.Context, mensagem models.MensagemEmail) error
+}
@@ -0,0 +1,130 @@
+package email_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+)
+
+// servidorSMTP é um servidor SMTP mínimo, sem TLS nem autenticação, que guarda a conversa
+// recebida de um único cliente
+func servidorSMTP(t *testing.T) (host, porta string, conversa chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Não foi possível iniciar o servidor SMTP: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	conversa = make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var recebido strings.Builder
+		leitor := bufio.NewReader(conn)
+		responder := func(linha string) { conn.Write([]byte(linha + "\r\n")) }
+
+		responder("220 teste ESMTP")
+		dados := false
+		for {
+			linha, err := leitor.ReadString('\n')
+			if err != nil {
+				break
+			}
+			recebido.WriteString(linha)
+
+			comando := strings.ToUpper(strings.TrimSpace(linha))
+			switch {
+			case dados:
+				if comando == "." {
+					dados = false
+					responder("250 mensagem aceita")
+				}
+			case strings.HasPrefix(comando, "EHLO"):
+				responder("250 teste")
+			case comando == "DATA":
+				dados = true
+				responder("354 envie a mensagem")
+			case comando == "QUIT":
+				responder("221 até logo")
+				conversa <- recebido.String()
+				return
+			default:
+				responder("250 ok")
+			}
+		}
+		conversa <- recebido.String()
+	}()
+
+	host, porta, _ = net.SplitHostPort(listener.Addr().String())
+	return host, porta, conversa
+}
+
+func TestMailers(t *testing.T) {
+	mensagem := models.MensagemEmail{
+		Para:    "contato@lojadojose.com.br",
+		Assunto: "Redefinição de senha",
+		Corpo:   "Olá, José.\n\nAcesse https://app.exemplo.com/redefinir-senha?token=abc para definir uma nova senha.\n",
+	}
+
+	t.Run("LogMailer", func(t *testing.T) {
+		var saida bytes.Buffer
+		mailer := email.NewLogMailer(&saida)
+
+		// Executar o método a ser testado
+		err := mailer.Enviar(context.Background(), mensagem)
+
+		// Verificar resultados: a mensagem é escrita na saída e fica disponível para os testes
+		assert.NoError(t, err)
+		assert.Contains(t, saida.String(), "Para: contato@lojadojose.com.br")
+		assert.Contains(t, saida.String(), "token=abc")
+		assert.Equal(t, []models.MensagemEmail{mensagem}, mailer.Enviadas())
+	})
+
+	t.Run("SMTPMailer", func(t *testing.T) {
+		host, porta, conversa := servidorSMTP(t)
+		mailer, err := email.NewSMTPMailer(host, porta, "", "", "Gerador de PIX <nao-responda@exemplo.com>")
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado
+		err = mailer.Enviar(context.Background(), mensagem)
+
+		// Verificar resultados: o envelope usa os endereços e o assunto com acentos é codificado
+		assert.NoError(t, err)
+		recebido := <-conversa
+		assert.Contains(t, recebido, "MAIL FROM:<nao-responda@exemplo.com>")
+		assert.Contains(t, recebido, "RCPT TO:<contato@lojadojose.com.br>")
+		assert.Contains(t, recebido, "Subject: =?utf-8?q?Redefini=C3=A7=C3=A3o_de_senha?=")
+		assert.Contains(t, recebido, "Content-Type: text/plain; charset=UTF-8")
+		assert.Contains(t, recebido, "token=3Dabc")
+	})
+
+	t.Run("SMTPMailerDestinatarioInvalido", func(t *testing.T) {
+		mailer, err := email.NewSMTPMailer("127.0.0.1", "25", "", "", "nao-responda@exemplo.com")
+		assert.NoError(t, err)
+
+		// Executar o método a ser testado: um destinatário com quebra de linha injetaria cabeçalhos
+		err = mailer.Enviar(context.Background(), models.MensagemEmail{Para: "a@exemplo.com\r\nBcc: b@exemplo.com", Assunto: "Teste"})
+
+		// Verificar resultados
+		assert.Error(t, err)
+	})
+
+	t.Run("RemetenteInvalido", func(t *testing.T) {
+		// Executar o método a ser testado
+		_, err := email.NewSMTPMailer("localhost", "587", "", "", "remetente sem email")
+
+		// Verificar resultados
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+const (
+	// portaSMTPS é a porta do SMTP com TLS desde a conexão; nas demais, o TLS é negociado com STARTTLS
+	portaSMTPS = "465"
+
+	// timeoutSMTP limita a conversa com o servidor quando o contexto não tem prazo
+	timeoutSMTP = 30 * time.Second
+)
+
+// SMTPMailer implementa Mailer enviando as mensagens por um servidor SMTP
+type SMTPMailer struct {
+	host      string
+	porta     string
+	usuario   string
+	senha     string
+	remetente *mail.Address
+}
+
+// NewSMTPMailer cria um SMTPMailer para o servidor informado. O usuário e a senha são
+// opcionais; o remetente aceita o formato "Nome <email>".
+func NewSMTPMailer(host, porta, usuario, senha, remetente string) (*SMTPMailer, error) {
+	endereco, err := mail.ParseAddress(remetente)
+	if err != nil {
+		return nil, fmt.Errorf("remetente inválido: %w", err)
+	}
+
+	return &SMTPMailer{
+		host:      host,
+		porta:     porta,
+		usuario:   usuario,
+		senha:     senha,
+		remetente: endereco,
+	}, nil
+}
+
+// Enviar implementa a interface Mailer
+func (m *SMTPMailer) Enviar(ctx context.Context, mensagem models.MensagemEmail) error {
+	destinatario, err := mail.ParseAddress(mensagem.Para)
+	if err != nil {
+		return fmt.Errorf("destinatário inválido: %w", err)
+	}
+
+	conteudo, err := m.montarMensagem(destinatario, mensagem)
+	if err != nil {
+		return err
+	}
+
+	conn, err := m.conectar(ctx)
+	if err != nil {
+		return err
+	}
+
+	cliente, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer cliente.Close()
+
+	if ok, _ := cliente.Extension("STARTTLS"); ok {
+		if err := cliente.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+
+	if m.usuario != "" {
+		if err := cliente.Auth(smtp.PlainAuth("", m.usuario, m.senha, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := cliente.Mail(m.remetente.Address); err != nil {
+		return err
+	}
+	if err := cliente.Rcpt(destinatario.Address); err != nil {
+		return err
+	}
+
+	escritor, err := cliente.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := escritor.Write(conteudo); err != nil {
+		return err
+	}
+	if err := escritor.Close(); err != nil {
+		return err
+	}
+
+	return cliente.Quit()
+}
+
+// conectar abre a conexão com o servidor SMTP, com TLS desde o início na porta 465
+func (m *SMTPMailer) conectar(ctx context.Context) (net.Conn, error) {
+	endereco := net.JoinHostPort(m.host, m.porta)
+	dialer := &net.Dialer{Timeout: timeoutSMTP}
+
+	var conn net.Conn
+	var err error
+	if m.porta == portaSMTPS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: m.host}}).DialContext(ctx, "tcp", endereco)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", endereco)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prazo, ok := ctx.Deadline()
+	if !ok {
+		prazo = time.Now().Add(timeoutSMTP)
+	}
+	if err := conn.SetDeadline(prazo); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// montarMensagem monta o email em texto simples UTF-8, com o assunto codificado e o corpo em
+// quoted-printable
+func (m *SMTPMailer) montarMensagem(destinatario *mail.Address, mensagem models.MensagemEmail) ([]byte, error) {
+	// Quebras de linha no assunto permitiriam injetar cabeçalhos
+	if strings.ContainsAny(mensagem.Assunto, "\r\n") {
+		return nil, errors.New("assunto inválido: contém quebra de linha")
+	}
+
+	var conteudo bytes.Buffer
+	cabecalhos := []string{
+		"From: " + m.remetente.String(),
+		"To: " + destinatario.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", mensagem.Assunto),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: quoted-printable",
+	}
+	for _, cabecalho := range cabecalhos {
+		conteudo.WriteString(cabecalho + "\r\n")
+	}
+	conteudo.WriteString("\r\n")
+
+	corpo := quotedprintable.NewWriter(&conteudo)
+	if _, err := corpo.Write([]byte(strings.ReplaceAll(mensagem.Corpo, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := corpo.Close(); err != nil {
+		return nil, err
+	}
+
+	return conteudo.Bytes(), nil
+}
@@ -0,0 +1,37 @@
+package queue
+
+import "context"
+
+// MemoryAdapter implementa QueueAdapter em memória, para uma única instância da API e para testes
+type MemoryAdapter struct {
+	ids chan string
+}
+
+// NewMemoryAdapter cria uma fila em memória com a capacidade informada
+func NewMemoryAdapter(capacidade int) *MemoryAdapter {
+	return &MemoryAdapter{
+		ids: make(chan string, capacidade),
+	}
+}
+
+// Enqueue implementa a interface QueueAdapter
+func (m *MemoryAdapter) Enqueue(ctx context.Context, id string) error {
+	select {
+	case m.ids <- id:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return ErrFilaCheia
+	}
+}
+
+// Dequeue implementa a interface QueueAdapter
+func (m *MemoryAdapter) Dequeue(ctx context.Context) (string, error) {
+	select {
+	case id := <-m.ids:
+		return id, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
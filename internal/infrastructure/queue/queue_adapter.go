@@ -0,0 +1,18 @@
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrFilaCheia indica que a fila em memória atingiu sua capacidade
+var ErrFilaCheia = errors.New("fila de jobs cheia")
+
+// QueueAdapter define a interface para a fila de jobs processados em segundo plano
+type QueueAdapter interface {
+	// Enqueue adiciona o ID de um job ao fim da fila
+	Enqueue(ctx context.Context, id string) error
+
+	// Dequeue retira o próximo ID da fila, aguardando até que exista um ou o contexto seja cancelado
+	Dequeue(ctx context.Context) (string, error)
+}
@@ -0,0 +1,62 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueAdapters(t *testing.T) {
+	// Inicializar um servidor Redis em memória para testes
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Não foi possível iniciar o miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	filas := map[string]queue.QueueAdapter{
+		"Memoria": queue.NewMemoryAdapter(10),
+		"Redis":   queue.NewRedisAdapter(mr.Host(), mr.Port(), "", 0, "test:jobs"),
+	}
+
+	for nome, fila := range filas {
+		t.Run(nome, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("OrdemDeChegada", func(t *testing.T) {
+				// Enfileirar alguns jobs
+				for _, id := range []string{"job-1", "job-2", "job-3"} {
+					assert.NoError(t, fila.Enqueue(ctx, id))
+				}
+
+				// Os jobs saem na ordem em que entraram
+				for _, esperado := range []string{"job-1", "job-2", "job-3"} {
+					id, err := fila.Dequeue(ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, esperado, id)
+				}
+			})
+
+			t.Run("CancelamentoComFilaVazia", func(t *testing.T) {
+				ctxCancelado, cancelar := context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancelar()
+
+				// A espera termina quando o contexto é cancelado
+				_, err := fila.Dequeue(ctxCancelado)
+				assert.Error(t, err)
+			})
+		})
+	}
+
+	t.Run("MemoriaCheia", func(t *testing.T) {
+		fila := queue.NewMemoryAdapter(1)
+		ctx := context.Background()
+
+		assert.NoError(t, fila.Enqueue(ctx, "job-1"))
+		assert.ErrorIs(t, fila.Enqueue(ctx, "job-2"), queue.ErrFilaCheia)
+	})
+}
@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// esperaDequeue é o tempo máximo de cada espera bloqueante no Redis, para que o
+// cancelamento do contexto seja percebido mesmo com a fila vazia
+const esperaDequeue = 5 * time.Second
+
+// RedisAdapter implementa QueueAdapter usando uma lista do Redis, compartilhada entre instâncias da API
+type RedisAdapter struct {
+	client *redis.Client
+	chave  string
+}
+
+// NewRedisAdapter cria uma nova instância da fila no Redis, armazenada na chave informada
+func NewRedisAdapter(host string, port string, password string, db int, chave string) *RedisAdapter {
+	client := redis.NewClient(&redis.Options{
+		Addr:     host + ":" + port,
+		Password: password,
+		DB:       db,
+	})
+
+	return &RedisAdapter{
+		client: client,
+		chave:  chave,
+	}
+}
+
+// Enqueue implementa a interface QueueAdapter
+func (r *RedisAdapter) Enqueue(ctx context.Context, id string) error {
+	return r.client.LPush(ctx, r.chave, id).Err()
+}
+
+// Dequeue implementa a interface QueueAdapter
+func (r *RedisAdapter) Dequeue(ctx context.Context) (string, error) {
+	for {
+		resultado, err := r.client.BRPop(ctx, esperaDequeue, r.chave).Result()
+		if err == redis.Nil {
+			// Nenhum job no intervalo: aguardar novamente, a menos que o contexto tenha sido cancelado
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// BRPOP retorna a chave e o valor
+		return resultado[1], nil
+	}
+}
+
+// Close fecha a conexão com o Redis
+func (r *RedisAdapter) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// BloqueioLoginRepository interface para a persistência da auditoria dos bloqueios de login
+type BloqueioLoginRepository interface {
+	Salvar(bloqueio models.BloqueioLogin) error
+}
+
+// MysqlBloqueioLoginRepository implementação MySQL do repositório de bloqueios de login
+type MysqlBloqueioLoginRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlBloqueioLoginRepository cria uma nova instância do repositório MySQL de bloqueios de login
+func NewMysqlBloqueioLoginRepository(db *sql.DB) *MysqlBloqueioLoginRepository {
+	return &MysqlBloqueioLoginRepository{db: db}
+}
+
+// Salvar registra um bloqueio de login
+func (r *MysqlBloqueioLoginRepository) Salvar(bloqueio models.BloqueioLogin) error {
+	query := `
+		INSERT INTO bloqueios_login (id, tipo, identificador, ip, tentativas, bloqueado_ate, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		bloqueio.ID,
+		bloqueio.Tipo,
+		bloqueio.Identificador,
+		bloqueio.IP,
+		bloqueio.Tentativas,
+		bloqueio.BloqueadoAte,
+		bloqueio.CriadoEm,
+	)
+	return err
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrChaveAPINaoEncontrada indica que a chave de API não existe ou pertence a outro estabelecimento
+var ErrChaveAPINaoEncontrada = errors.New("chave de API não encontrada")
+
+// ChaveAPIRepository interface para persistência das chaves de API
+type ChaveAPIRepository interface {
+	Salvar(chave models.ChaveAPI) error
+	Listar(estabelecimentoID string) ([]models.ChaveAPI, error)
+	BuscarPorHash(hash string) (models.ChaveAPI, error)
+	Revogar(estabelecimentoID, id string, revogadaEm time.Time) (models.ChaveAPI, error)
+	RegistrarUso(id string, usadaEm time.Time, intervalo time.Duration) error
+}
+
+// colunasChaveAPI são as colunas lidas por scanChaveAPI
+const colunasChaveAPI = `id, estabelecimento_id, nome, prefixo, hash, escopos, expira_em, ultimo_uso_em, revogada_em, criado_em`
+
+// MysqlChaveAPIRepository implementação MySQL do repositório de chaves de API
+type MysqlChaveAPIRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlChaveAPIRepository cria uma nova instância do repositório MySQL de chaves de API
+func NewMysqlChaveAPIRepository(db *sql.DB) *MysqlChaveAPIRepository {
+	return &MysqlChaveAPIRepository{db: db}
+}
+
+// Salvar salva uma chave de API; os escopos são gravados separados por espaço
+func (r *MysqlChaveAPIRepository) Salvar(chave models.ChaveAPI) error {
+	query := `
+		INSERT INTO chaves_api (id, estabelecimento_id, nome, prefixo, hash, escopos, expira_em, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		chave.ID,
+		chave.EstabelecimentoID,
+		chave.Nome,
+		chave.Prefixo,
+		chave.Hash,
+		strings.Join(chave.Escopos, " "),
+		chave.ExpiraEm,
+		chave.CriadoEm,
+	)
+	return err
+}
+
+// Listar lista as chaves de API do estabelecimento, das mais recentes às mais antigas
+func (r *MysqlChaveAPIRepository) Listar(estabelecimentoID string) ([]models.ChaveAPI, error) {
+	query := `SELECT ` + colunasChaveAPI + ` FROM chaves_api WHERE estabelecimento_id = ? ORDER BY criado_em DESC, id`
+
+	rows, err := r.db.Query(query, estabelecimentoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chaves := []models.ChaveAPI{}
+	for rows.Next() {
+		chave, err := scanChaveAPI(rows)
+		if err != nil {
+			return nil, err
+		}
+		chaves = append(chaves, chave)
+	}
+
+	return chaves, rows.Err()
+}
+
+// BuscarPorHash busca uma chave de API pelo hash, incluindo as revogadas e expiradas
+func (r *MysqlChaveAPIRepository) BuscarPorHash(hash string) (models.ChaveAPI, error) {
+	query := `SELECT ` + colunasChaveAPI + ` FROM chaves_api WHERE hash = ?`
+
+	chave, err := scanChaveAPI(r.db.QueryRow(query, hash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ChaveAPI{}, ErrChaveAPINaoEncontrada
+		}
+		return models.ChaveAPI{}, err
+	}
+
+	return chave, nil
+}
+
+// Revogar revoga uma chave de API do estabelecimento; revogar uma chave já revogada
+// mantém a data da primeira revogação
+func (r *MysqlChaveAPIRepository) Revogar(estabelecimentoID, id string, revogadaEm time.Time) (models.ChaveAPI, error) {
+	_, err := r.db.Exec(
+		`UPDATE chaves_api SET revogada_em = ? WHERE id = ? AND estabelecimento_id = ? AND revogada_em IS NULL`,
+		revogadaEm, id, estabelecimentoID,
+	)
+	if err != nil {
+		return models.ChaveAPI{}, err
+	}
+
+	query := `SELECT ` + colunasChaveAPI + ` FROM chaves_api WHERE id = ? AND estabelecimento_id = ?`
+
+	chave, err := scanChaveAPI(r.db.QueryRow(query, id, estabelecimentoID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ChaveAPI{}, ErrChaveAPINaoEncontrada
+		}
+		return models.ChaveAPI{}, err
+	}
+
+	return chave, nil
+}
+
+// RegistrarUso grava o último uso da chave de API, no máximo uma vez por intervalo, para
+// não gerar uma escrita a cada requisição
+func (r *MysqlChaveAPIRepository) RegistrarUso(id string, usadaEm time.Time, intervalo time.Duration) error {
+	_, err := r.db.Exec(
+		`UPDATE chaves_api SET ultimo_uso_em = ? WHERE id = ? AND (ultimo_uso_em IS NULL OR ultimo_uso_em < ?)`,
+		usadaEm, id, usadaEm.Add(-intervalo),
+	)
+	return err
+}
+
+// scanChaveAPI lê uma chave de API selecionada com colunasChaveAPI
+func scanChaveAPI(linha linhaScan) (models.ChaveAPI, error) {
+	var chave models.ChaveAPI
+	var escopos string
+	var expiraEm, ultimoUsoEm, revogadaEm sql.NullTime
+
+	err := linha.Scan(
+		&chave.ID,
+		&chave.EstabelecimentoID,
+		&chave.Nome,
+		&chave.Prefixo,
+		&chave.Hash,
+		&escopos,
+		&expiraEm,
+		&ultimoUsoEm,
+		&revogadaEm,
+		&chave.CriadoEm,
+	)
+	if err != nil {
+		return models.ChaveAPI{}, err
+	}
+
+	chave.Escopos = strings.Fields(escopos)
+	if chave.Escopos == nil {
+		chave.Escopos = []string{}
+	}
+	if expiraEm.Valid {
+		chave.ExpiraEm = &expiraEm.Time
+	}
+	if ultimoUsoEm.Valid {
+		chave.UltimoUsoEm = &ultimoUsoEm.Time
+	}
+	if revogadaEm.Valid {
+		chave.RevogadaEm = &revogadaEm.Time
+	}
+
+	return chave, nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrClienteOAuthNaoEncontrado indica que o cliente OAuth2 não existe ou pertence a outro estabelecimento
+var ErrClienteOAuthNaoEncontrado = errors.New("cliente OAuth não encontrado")
+
+// ClienteOAuthRepository interface para persistência dos clientes OAuth2
+type ClienteOAuthRepository interface {
+	Salvar(cliente models.ClienteOAuth) error
+	Listar(estabelecimentoID string) ([]models.ClienteOAuth, error)
+	BuscarPorID(id string) (models.ClienteOAuth, error)
+	Revogar(estabelecimentoID, id string, revogadoEm time.Time) (models.ClienteOAuth, error)
+	RegistrarUso(id string, usadoEm time.Time) error
+}
+
+// colunasClienteOAuth são as colunas lidas por scanClienteOAuth
+const colunasClienteOAuth = `id, estabelecimento_id, nome, segredo_hash, escopos, ultimo_uso_em, revogado_em, criado_em`
+
+// MysqlClienteOAuthRepository implementação MySQL do repositório de clientes OAuth2
+type MysqlClienteOAuthRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlClienteOAuthRepository cria uma nova instância do repositório MySQL de clientes OAuth2
+func NewMysqlClienteOAuthRepository(db *sql.DB) *MysqlClienteOAuthRepository {
+	return &MysqlClienteOAuthRepository{db: db}
+}
+
+// Salvar salva um cliente OAuth2; os escopos são gravados separados por espaço
+func (r *MysqlClienteOAuthRepository) Salvar(cliente models.ClienteOAuth) error {
+	query := `
+		INSERT INTO clientes_oauth (id, estabelecimento_id, nome, segredo_hash, escopos, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		cliente.ID,
+		cliente.EstabelecimentoID,
+		cliente.Nome,
+		cliente.SegredoHash,
+		strings.Join(cliente.Escopos, " "),
+		cliente.CriadoEm,
+	)
+	return err
+}
+
+// Listar lista os clientes OAuth2 do estabelecimento, dos mais recentes aos mais antigos
+func (r *MysqlClienteOAuthRepository) Listar(estabelecimentoID string) ([]models.ClienteOAuth, error) {
+	query := `SELECT ` + colunasClienteOAuth + ` FROM clientes_oauth WHERE estabelecimento_id = ? ORDER BY criado_em DESC, id`
+
+	rows, err := r.db.Query(query, estabelecimentoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clientes := []models.ClienteOAuth{}
+	for rows.Next() {
+		cliente, err := scanClienteOAuth(rows)
+		if err != nil {
+			return nil, err
+		}
+		clientes = append(clientes, cliente)
+	}
+
+	return clientes, rows.Err()
+}
+
+// BuscarPorID busca um cliente OAuth2 pelo client_id, incluindo os revogados
+func (r *MysqlClienteOAuthRepository) BuscarPorID(id string) (models.ClienteOAuth, error) {
+	query := `SELECT ` + colunasClienteOAuth + ` FROM clientes_oauth WHERE id = ?`
+
+	cliente, err := scanClienteOAuth(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ClienteOAuth{}, ErrClienteOAuthNaoEncontrado
+		}
+		return models.ClienteOAuth{}, err
+	}
+
+	return cliente, nil
+}
+
+// Revogar revoga um cliente OAuth2 do estabelecimento; revogar um cliente já revogado
+// mantém a data da primeira revogação
+func (r *MysqlClienteOAuthRepository) Revogar(estabelecimentoID, id string, revogadoEm time.Time) (models.ClienteOAuth, error) {
+	_, err := r.db.Exec(
+		`UPDATE clientes_oauth SET revogado_em = ? WHERE id = ? AND estabelecimento_id = ? AND revogado_em IS NULL`,
+		revogadoEm, id, estabelecimentoID,
+	)
+	if err != nil {
+		return models.ClienteOAuth{}, err
+	}
+
+	query := `SELECT ` + colunasClienteOAuth + ` FROM clientes_oauth WHERE id = ? AND estabelecimento_id = ?`
+
+	cliente, err := scanClienteOAuth(r.db.QueryRow(query, id, estabelecimentoID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ClienteOAuth{}, ErrClienteOAuthNaoEncontrado
+		}
+		return models.ClienteOAuth{}, err
+	}
+
+	return cliente, nil
+}
+
+// RegistrarUso grava a emissão do último token de acesso do cliente OAuth2
+func (r *MysqlClienteOAuthRepository) RegistrarUso(id string, usadoEm time.Time) error {
+	_, err := r.db.Exec(`UPDATE clientes_oauth SET ultimo_uso_em = ? WHERE id = ?`, usadoEm, id)
+	return err
+}
+
+// scanClienteOAuth lê um cliente OAuth2 selecionado com colunasClienteOAuth
+func scanClienteOAuth(linha linhaScan) (models.ClienteOAuth, error) {
+	var cliente models.ClienteOAuth
+	var escopos string
+	var ultimoUsoEm, revogadoEm sql.NullTime
+
+	err := linha.Scan(
+		&cliente.ID,
+		&cliente.EstabelecimentoID,
+		&cliente.Nome,
+		&cliente.SegredoHash,
+		&escopos,
+		&ultimoUsoEm,
+		&revogadoEm,
+		&cliente.CriadoEm,
+	)
+	if err != nil {
+		return models.ClienteOAuth{}, err
+	}
+
+	cliente.Escopos = strings.Fields(escopos)
+	if cliente.Escopos == nil {
+		cliente.Escopos = []string{}
+	}
+	if ultimoUsoEm.Valid {
+		cliente.UltimoUsoEm = &ultimoUsoEm.Time
+	}
+	if revogadoEm.Valid {
+		cliente.RevogadoEm = &revogadoEm.Time
+	}
+
+	return cliente, nil
+}
@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrCobVNaoEncontrada indica que não existe cobrança com vencimento para o txid informado
+var ErrCobVNaoEncontrada = errors.New("cobrança não encontrada")
+
+// CobVRepository interface para persistência de cobranças com vencimento
+type CobVRepository interface {
+	Save(pix models.Pix, cobv models.CobV) (uint, error)
+	FindByTxid(estabelecimentoID string, txid string) (models.CobV, error)
+}
+
+// MysqlCobVRepository implementação MySQL do repositório de cobranças com vencimento
+type MysqlCobVRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlCobVRepository cria uma nova instância do repositório MySQL de cobranças com vencimento
+func NewMysqlCobVRepository(db *sql.DB) *MysqlCobVRepository {
+	return &MysqlCobVRepository{db: db}
+}
+
+// Save salva o PIX da cobrança e a cobrança com vencimento em uma única transação: ou os dois
+// são gravados, ou nenhum. Um txid já usado retorna ErrTxidDuplicado.
+func (r *MysqlCobVRepository) Save(pix models.Pix, cobv models.CobV) (uint, error) {
+	query := `
+		INSERT INTO cobv (pix_id, txid, valor_original, vencimento, validade_apos_vencimento,
+			juros_modalidade, juros_valor_perc, multa_modalidade, multa_valor_perc,
+			abatimento_modalidade, abatimento_valor_perc, descontos, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var jurosModalidade, multaModalidade, abatimentoModalidade, descontos sql.NullString
+	var jurosValorPerc, multaValorPerc, abatimentoValorPerc *models.Dinheiro
+
+	if cobv.Juros != nil {
+		jurosModalidade = sql.NullString{String: cobv.Juros.Modalidade, Valid: true}
+		jurosValorPerc = &cobv.Juros.ValorPerc
+	}
+
+	if cobv.Multa != nil {
+		multaModalidade = sql.NullString{String: cobv.Multa.Modalidade, Valid: true}
+		multaValorPerc = &cobv.Multa.ValorPerc
+	}
+
+	if cobv.Abatimento != nil {
+		abatimentoModalidade = sql.NullString{String: cobv.Abatimento.Modalidade, Valid: true}
+		abatimentoValorPerc = &cobv.Abatimento.ValorPerc
+	}
+
+	if len(cobv.Descontos) > 0 {
+		dados, err := json.Marshal(cobv.Descontos)
+		if err != nil {
+			return 0, err
+		}
+		descontos = sql.NullString{String: string(dados), Valid: true}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(insertPix, argumentosPix(pix)...)
+	if err != nil {
+		return 0, erroInsertPix(err)
+	}
+
+	pixID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err = tx.Exec(
+		query,
+		pixID,
+		cobv.Txid,
+		cobv.ValorOriginal,
+		cobv.Vencimento.Format(models.FormatoData),
+		cobv.ValidadeAposVencimento,
+		jurosModalidade,
+		jurosValorPerc,
+		multaModalidade,
+		multaValorPerc,
+		abatimentoModalidade,
+		abatimentoValorPerc,
+		descontos,
+		time.Now(),
+	)
+
+	if err != nil {
+		if chaveDuplicada(err) {
+			return 0, ErrTxidDuplicado
+		}
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return uint(id), nil
+}
+
+// FindByTxid busca uma cobrança com vencimento do estabelecimento pelo txid
+func (r *MysqlCobVRepository) FindByTxid(estabelecimentoID string, txid string) (models.CobV, error) {
+	query := `
+		SELECT c.id, c.pix_id, c.txid, c.valor_original, c.vencimento, c.validade_apos_vencimento,
+			c.juros_modalidade, c.juros_valor_perc, c.multa_modalidade, c.multa_valor_perc,
+			c.abatimento_modalidade, c.abatimento_valor_perc, c.descontos, c.criado_em
+		FROM cobv c
+		JOIN pix p ON p.id = c.pix_id
+		WHERE c.txid = ? AND p.estabelecimento_id = ?
+	`
+
+	var cobv models.CobV
+	var jurosModalidade, multaModalidade, abatimentoModalidade, descontos sql.NullString
+	var jurosValorPerc, multaValorPerc, abatimentoValorPerc *models.Dinheiro
+
+	err := r.db.QueryRow(query, txid, estabelecimentoID).Scan(
+		&cobv.ID,
+		&cobv.PixID,
+		&cobv.Txid,
+		&cobv.ValorOriginal,
+		&cobv.Vencimento,
+		&cobv.ValidadeAposVencimento,
+		&jurosModalidade,
+		&jurosValorPerc,
+		&multaModalidade,
+		&multaValorPerc,
+		&abatimentoModalidade,
+		&abatimentoValorPerc,
+		&descontos,
+		&cobv.CriadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CobV{}, ErrCobVNaoEncontrada
+		}
+		return models.CobV{}, err
+	}
+
+	if jurosModalidade.Valid && jurosValorPerc != nil {
+		cobv.Juros = &models.JurosCobV{Modalidade: jurosModalidade.String, ValorPerc: *jurosValorPerc}
+	}
+
+	if multaModalidade.Valid && multaValorPerc != nil {
+		cobv.Multa = &models.MultaCobV{Modalidade: multaModalidade.String, ValorPerc: *multaValorPerc}
+	}
+
+	if abatimentoModalidade.Valid && abatimentoValorPerc != nil {
+		cobv.Abatimento = &models.AbatimentoCobV{Modalidade: abatimentoModalidade.String, ValorPerc: *abatimentoValorPerc}
+	}
+
+	if descontos.Valid {
+		if err := json.Unmarshal([]byte(descontos.String), &cobv.Descontos); err != nil {
+			return models.CobV{}, err
+		}
+	}
+
+	return cobv, nil
+}
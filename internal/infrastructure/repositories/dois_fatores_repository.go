@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrDoisFatoresNaoEncontrado indica que o login não tem o segundo fator cadastrado
+var ErrDoisFatoresNaoEncontrado = errors.New("segundo fator não cadastrado")
+
+// DoisFatoresRepository interface para persistência do segundo fator dos logins e dos seus
+// códigos de recuperação
+type DoisFatoresRepository interface {
+	Buscar(loginID string) (models.DoisFatores, error)
+	SalvarPendente(doisFatores models.DoisFatores) error
+	Ativar(loginID string, passo int64, codigosHash []string, ativadoEm time.Time) (bool, error)
+	RegistrarPasso(loginID string, passo int64) (bool, error)
+	Remover(loginID string) error
+	SubstituirCodigosRecuperacao(loginID string, codigosHash []string, criadoEm time.Time) error
+	UsarCodigoRecuperacao(loginID, codigoHash string, usadoEm time.Time) (bool, error)
+	ContarCodigosRecuperacao(loginID string) (int, error)
+}
+
+// MysqlDoisFatoresRepository implementação MySQL do repositório do segundo fator
+type MysqlDoisFatoresRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlDoisFatoresRepository cria uma nova instância do repositório MySQL do segundo fator
+func NewMysqlDoisFatoresRepository(db *sql.DB) *MysqlDoisFatoresRepository {
+	return &MysqlDoisFatoresRepository{db: db}
+}
+
+// Buscar busca o segundo fator, confirmado ou pendente, de um login
+func (r *MysqlDoisFatoresRepository) Buscar(loginID string) (models.DoisFatores, error) {
+	var doisFatores models.DoisFatores
+	var ativadoEm sql.NullTime
+
+	query := `
+		SELECT login_id, estabelecimento_id, segredo, ultimo_passo, ativado_em, criado_em
+		FROM dois_fatores
+		WHERE login_id = ?
+	`
+
+	err := r.db.QueryRow(query, loginID).Scan(
+		&doisFatores.LoginID,
+		&doisFatores.EstabelecimentoID,
+		&doisFatores.Segredo,
+		&doisFatores.UltimoPasso,
+		&ativadoEm,
+		&doisFatores.CriadoEm,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DoisFatores{}, ErrDoisFatoresNaoEncontrado
+		}
+		return models.DoisFatores{}, err
+	}
+
+	if ativadoEm.Valid {
+		doisFatores.AtivadoEm = &ativadoEm.Time
+	}
+
+	return doisFatores, nil
+}
+
+// SalvarPendente salva um novo cadastro do segundo fator, ainda não confirmado, substituindo
+// um cadastro pendente anterior. Um segundo fator já ativo é mantido.
+func (r *MysqlDoisFatoresRepository) SalvarPendente(doisFatores models.DoisFatores) error {
+	query := `
+		INSERT INTO dois_fatores (login_id, estabelecimento_id, segredo, ultimo_passo, criado_em)
+		VALUES (?, ?, ?, 0, ?)
+		ON DUPLICATE KEY UPDATE
+			segredo = IF(ativado_em IS NULL, VALUES(segredo), segredo),
+			ultimo_passo = IF(ativado_em IS NULL, 0, ultimo_passo),
+			criado_em = IF(ativado_em IS NULL, VALUES(criado_em), criado_em)
+	`
+
+	_, err := r.db.Exec(query, doisFatores.LoginID, doisFatores.EstabelecimentoID, doisFatores.Segredo, doisFatores.CriadoEm)
+	return err
+}
+
+// Ativar confirma o cadastro pendente do segundo fator, registrando o passo do código que o
+// confirmou, e salva os códigos de recuperação. Retorna false se não houver cadastro pendente.
+func (r *MysqlDoisFatoresRepository) Ativar(loginID string, passo int64, codigosHash []string, ativadoEm time.Time) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE dois_fatores SET ativado_em = ?, ultimo_passo = ? WHERE login_id = ? AND ativado_em IS NULL`,
+		ativadoEm, passo, loginID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if linhas == 0 {
+		return false, nil
+	}
+
+	if err := substituirCodigos(tx, loginID, codigosHash, ativadoEm); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// RegistrarPasso registra o passo do último código aceito. Retorna false se um código do
+// mesmo passo ou de um passo posterior já foi aceito, o que indica a reutilização do código.
+func (r *MysqlDoisFatoresRepository) RegistrarPasso(loginID string, passo int64) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE dois_fatores SET ultimo_passo = ? WHERE login_id = ? AND ultimo_passo < ?`,
+		passo, loginID, passo,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas > 0, nil
+}
+
+// Remover remove o segundo fator do login e os seus códigos de recuperação
+func (r *MysqlDoisFatoresRepository) Remover(loginID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM codigos_recuperacao WHERE login_id = ?`, loginID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM dois_fatores WHERE login_id = ?`, loginID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SubstituirCodigosRecuperacao troca todos os códigos de recuperação do login pelos informados
+func (r *MysqlDoisFatoresRepository) SubstituirCodigosRecuperacao(loginID string, codigosHash []string, criadoEm time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := substituirCodigos(tx, loginID, codigosHash, criadoEm); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UsarCodigoRecuperacao marca como usado um código de recuperação ainda não usado do login.
+// Retorna false se o código não existir ou já tiver sido usado.
+func (r *MysqlDoisFatoresRepository) UsarCodigoRecuperacao(loginID, codigoHash string, usadoEm time.Time) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE codigos_recuperacao SET usado_em = ? WHERE login_id = ? AND codigo_hash = ? AND usado_em IS NULL`,
+		usadoEm, loginID, codigoHash,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas > 0, nil
+}
+
+// ContarCodigosRecuperacao conta os códigos de recuperação ainda não usados do login
+func (r *MysqlDoisFatoresRepository) ContarCodigosRecuperacao(loginID string) (int, error) {
+	var total int
+	err := r.db.QueryRow(
+		`SELECT COUNT(*) FROM codigos_recuperacao WHERE login_id = ? AND usado_em IS NULL`,
+		loginID,
+	).Scan(&total)
+	return total, err
+}
+
+// substituirCodigos remove os códigos de recuperação do login e insere os novos na transação
+func substituirCodigos(tx *sql.Tx, loginID string, codigosHash []string, criadoEm time.Time) error {
+	if _, err := tx.Exec(`DELETE FROM codigos_recuperacao WHERE login_id = ?`, loginID); err != nil {
+		return err
+	}
+
+	for _, codigoHash := range codigosHash {
+		_, err := tx.Exec(
+			`INSERT INTO codigos_recuperacao (id, login_id, codigo_hash, criado_em) VALUES (?, ?, ?, ?)`,
+			uuid.New().String(), loginID, codigoHash, criadoEm,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
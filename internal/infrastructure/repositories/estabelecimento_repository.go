@@ -2,7 +2,7 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,6 +10,9 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrEstabelecimentoNaoEncontrado indica que não existe estabelecimento com o ID ou email informado
+var ErrEstabelecimentoNaoEncontrado = errors.New("estabelecimento não encontrado")
+
 // EstabelecimentoRepository interface para persistência de estabelecimentos
 type EstabelecimentoRepository interface {
 	Salvar(estabelecimento models.EstabelecimentoRequest) (models.Estabelecimento, error)
@@ -18,6 +21,57 @@ type EstabelecimentoRepository interface {
 	Listar() ([]models.Estabelecimento, error)
 	Atualizar(id string, estabelecimento models.EstabelecimentoRequest) (models.Estabelecimento, error)
 	Excluir(id string) error
+	AlterarAtivo(id string, ativo bool) error
+	AlterarSenha(id, senha string) error
+	MarcarEmailVerificado(id string, verificadoEm time.Time) error
+	AlterarDoisFatoresObrigatorio(id string, obrigatorio bool) error
+}
+
+// colunasEstabelecimento são as colunas lidas por scanEstabelecimento
+const colunasEstabelecimento = `id, nome, descricao, email, senha, ativo, admin, email_verificado_em, dois_fatores_obrigatorio, tokens_revogados_em, criado_em, atualizado_em`
+
+// linhaScan abstrai sql.Row e sql.Rows para a leitura de uma linha
+type linhaScan interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEstabelecimento lê um estabelecimento selecionado com colunasEstabelecimento
+func scanEstabelecimento(linha linhaScan) (models.Estabelecimento, error) {
+	var estabelecimento models.Estabelecimento
+	var descricao sql.NullString
+	var emailVerificadoEm, tokensRevogadosEm sql.NullTime
+
+	err := linha.Scan(
+		&estabelecimento.ID,
+		&estabelecimento.Nome,
+		&descricao,
+		&estabelecimento.Email,
+		&estabelecimento.Senha,
+		&estabelecimento.Ativo,
+		&estabelecimento.Admin,
+		&emailVerificadoEm,
+		&estabelecimento.DoisFatoresObrigatorio,
+		&tokensRevogadosEm,
+		&estabelecimento.CriadoEm,
+		&estabelecimento.AtualizadoEm,
+	)
+	if err != nil {
+		return models.Estabelecimento{}, err
+	}
+
+	if descricao.Valid {
+		estabelecimento.Descricao = &descricao.String
+	}
+
+	if emailVerificadoEm.Valid {
+		estabelecimento.EmailVerificadoEm = &emailVerificadoEm.Time
+	}
+
+	if tokensRevogadosEm.Valid {
+		estabelecimento.TokensRevogadosEm = &tokensRevogadosEm.Time
+	}
+
+	return estabelecimento, nil
 }
 
 // MysqlEstabelecimentoRepository implementação MySQL do repositório de estabelecimentos
@@ -83,73 +137,31 @@ func (r *MysqlEstabelecimentoRepository) Salvar(req models.EstabelecimentoReques
 
 // BuscarPorID busca um estabelecimento pelo ID
 func (r *MysqlEstabelecimentoRepository) BuscarPorID(id string) (models.Estabelecimento, error) {
-	var estabelecimento models.Estabelecimento
-	var descricao sql.NullString
-
-	query := `
-        SELECT id, nome, descricao, email, senha, ativo, criado_em, atualizado_em
-        FROM estabelecimentos
-        WHERE id = ?
-    `
-
-	err := r.db.QueryRow(query, id).Scan(
-		&estabelecimento.ID,
-		&estabelecimento.Nome,
-		&descricao,
-		&estabelecimento.Email,
-		&estabelecimento.Senha,
-		&estabelecimento.Ativo,
-		&estabelecimento.CriadoEm,
-		&estabelecimento.AtualizadoEm,
-	)
+	query := `SELECT ` + colunasEstabelecimento + ` FROM estabelecimentos WHERE id = ?`
 
+	estabelecimento, err := scanEstabelecimento(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Estabelecimento{}, fmt.Errorf("estabelecimento não encontrado")
+			return models.Estabelecimento{}, ErrEstabelecimentoNaoEncontrado
 		}
 		return models.Estabelecimento{}, err
 	}
 
-	if descricao.Valid {
-		estabelecimento.Descricao = &descricao.String
-	}
-
 	return estabelecimento, nil
 }
 
 // BuscarPorEmail busca um estabelecimento pelo email
 func (r *MysqlEstabelecimentoRepository) BuscarPorEmail(email string) (models.Estabelecimento, error) {
-	var estabelecimento models.Estabelecimento
-	var descricao sql.NullString
-
-	query := `
-        SELECT id, nome, descricao, email, senha, ativo, criado_em, atualizado_em
-        FROM estabelecimentos
-        WHERE email = ?
-    `
-
-	err := r.db.QueryRow(query, email).Scan(
-		&estabelecimento.ID,
-		&estabelecimento.Nome,
-		&descricao,
-		&estabelecimento.Email,
-		&estabelecimento.Senha,
-		&estabelecimento.Ativo,
-		&estabelecimento.CriadoEm,
-		&estabelecimento.AtualizadoEm,
-	)
+	query := `SELECT ` + colunasEstabelecimento + ` FROM estabelecimentos WHERE email = ?`
 
+	estabelecimento, err := scanEstabelecimento(r.db.QueryRow(query, email))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Estabelecimento{}, fmt.Errorf("estabelecimento não encontrado")
+			return models.Estabelecimento{}, ErrEstabelecimentoNaoEncontrado
 		}
 		return models.Estabelecimento{}, err
 	}
 
-	if descricao.Valid {
-		estabelecimento.Descricao = &descricao.String
-	}
-
 	return estabelecimento, nil
 }
 
@@ -157,11 +169,7 @@ func (r *MysqlEstabelecimentoRepository) BuscarPorEmail(email string) (models.Es
 func (r *MysqlEstabelecimentoRepository) Listar() ([]models.Estabelecimento, error) {
 	var estabelecimentos []models.Estabelecimento
 
-	query := `
-        SELECT id, nome, descricao, email, senha, ativo, criado_em, atualizado_em
-        FROM estabelecimentos
-        ORDER BY nome
-    `
+	query := `SELECT ` + colunasEstabelecimento + ` FROM estabelecimentos ORDER BY nome`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -170,32 +178,15 @@ func (r *MysqlEstabelecimentoRepository) Listar() ([]models.Estabelecimento, err
 	defer rows.Close()
 
 	for rows.Next() {
-		var estabelecimento models.Estabelecimento
-		var descricao sql.NullString
-
-		err := rows.Scan(
-			&estabelecimento.ID,
-			&estabelecimento.Nome,
-			&descricao,
-			&estabelecimento.Email,
-			&estabelecimento.Senha,
-			&estabelecimento.Ativo,
-			&estabelecimento.CriadoEm,
-			&estabelecimento.AtualizadoEm,
-		)
-
+		estabelecimento, err := scanEstabelecimento(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		if descricao.Valid {
-			estabelecimento.Descricao = &descricao.String
-		}
-
 		estabelecimentos = append(estabelecimentos, estabelecimento)
 	}
 
-	return estabelecimentos, nil
+	return estabelecimentos, rows.Err()
 }
 
 // Atualizar atualiza um estabelecimento
@@ -206,6 +197,11 @@ func (r *MysqlEstabelecimentoRepository) Atualizar(id string, req models.Estabel
 		return models.Estabelecimento{}, err
 	}
 
+	// Um novo email precisa ser confirmado novamente
+	if req.Email != estabelecimento.Email {
+		estabelecimento.EmailVerificadoEm = nil
+	}
+
 	// Atualizar campos
 	estabelecimento.Nome = req.Nome
 	estabelecimento.Descricao = req.Descricao
@@ -224,7 +220,7 @@ func (r *MysqlEstabelecimentoRepository) Atualizar(id string, req models.Estabel
 	// Executar query de atualização
 	query := `
         UPDATE estabelecimentos 
-        SET nome = ?, descricao = ?, email = ?, senha = ?, atualizado_em = ?
+        SET nome = ?, descricao = ?, email = ?, senha = ?, email_verificado_em = ?, atualizado_em = ?
         WHERE id = ?
     `
 
@@ -234,6 +230,7 @@ func (r *MysqlEstabelecimentoRepository) Atualizar(id string, req models.Estabel
 		estabelecimento.Descricao,
 		estabelecimento.Email,
 		estabelecimento.Senha,
+		estabelecimento.EmailVerificadoEm,
 		estabelecimento.AtualizadoEm,
 		id,
 	)
@@ -247,12 +244,95 @@ func (r *MysqlEstabelecimentoRepository) Atualizar(id string, req models.Estabel
 
 // Excluir remove um estabelecimento (marcando como inativo)
 func (r *MysqlEstabelecimentoRepository) Excluir(id string) error {
+	return r.AlterarAtivo(id, false)
+}
+
+// AlterarAtivo ativa ou desativa um estabelecimento. A desativação revoga os tokens já
+// emitidos, que continuam inválidos mesmo após uma nova ativação.
+func (r *MysqlEstabelecimentoRepository) AlterarAtivo(id string, ativo bool) error {
+	now := time.Now()
+
 	query := `
         UPDATE estabelecimentos 
-        SET ativo = false, atualizado_em = ?
+        SET ativo = ?, atualizado_em = ?
         WHERE id = ?
     `
+	args := []interface{}{ativo, now, id}
+
+	if !ativo {
+		query = `
+        UPDATE estabelecimentos 
+        SET ativo = false, tokens_revogados_em = ?, atualizado_em = ?
+        WHERE id = ?
+    `
+		args = []interface{}{now, now, id}
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, id)
+}
+
+// AlterarSenha define uma nova senha para o estabelecimento
+func (r *MysqlEstabelecimentoRepository) AlterarSenha(id, senha string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		`UPDATE estabelecimentos SET senha = ?, atualizado_em = ? WHERE id = ?`,
+		string(hashedPassword), time.Now(), id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, id)
+}
+
+// MarcarEmailVerificado registra a confirmação do email do estabelecimento. Uma confirmação
+// anterior é mantida.
+func (r *MysqlEstabelecimentoRepository) MarcarEmailVerificado(id string, verificadoEm time.Time) error {
+	result, err := r.db.Exec(
+		`UPDATE estabelecimentos SET email_verificado_em = COALESCE(email_verificado_em, ?) WHERE id = ?`,
+		verificadoEm, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, id)
+}
+
+// AlterarDoisFatoresObrigatorio define se o segundo fator é exigido nos logins do estabelecimento
+func (r *MysqlEstabelecimentoRepository) AlterarDoisFatoresObrigatorio(id string, obrigatorio bool) error {
+	result, err := r.db.Exec(
+		`UPDATE estabelecimentos SET dois_fatores_obrigatorio = ?, atualizado_em = ? WHERE id = ?`,
+		obrigatorio, time.Now(), id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, id)
+}
+
+// confirmarAlteracao confere que o estabelecimento existe quando a atualização não alterou
+// nenhuma linha, pois o MySQL não conta as linhas sem alteração nos dados
+func (r *MysqlEstabelecimentoRepository) confirmarAlteracao(result sql.Result, id string) error {
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if linhas == 0 {
+		_, err := r.BuscarPorID(id)
+		return err
+	}
 
-	_, err := r.db.Exec(query, time.Now(), id)
-	return err
+	return nil
 }
@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrJobNaoEncontrado indica que não existe job com o ID informado
+var ErrJobNaoEncontrado = errors.New("job não encontrado")
+
+// JobRepository interface para persistência dos jobs executados em segundo plano.
+// As mudanças de situação só são aplicadas a partir da situação esperada, para que
+// workers e cancelamentos concorrentes não sobrescrevam uns aos outros. O início e cada
+// progresso de um job em processamento registram o sinal de vida do worker em atualizado_em.
+type JobRepository interface {
+	Save(job models.Job) (string, error)
+	FindByID(id string) (models.Job, error)
+	FindEntrada(id string) ([]byte, error)
+	FindResultado(id string) ([]byte, string, error)
+	ListarPendentes() ([]string, error)
+	Iniciar(id string) (bool, error)
+	AtualizarProgresso(id string, processados, sucessos, falhas int) error
+	Concluir(id string, resultado []byte, resultadoTipo string) (bool, error)
+	Falhar(id string, erro string) (bool, error)
+	FalharInterrompidos(semProgressoDesde time.Time, erro string) (int64, error)
+	Cancelar(id string) (bool, error)
+}
+
+// MysqlJobRepository implementação MySQL do repositório de jobs
+type MysqlJobRepository struct {
+	db *sql.DB
+}
+
+// colunasJob lista as colunas lidas da tabela jobs, sem a entrada e o resultado
+const colunasJob = `id, estabelecimento_id, tipo, status, formato, template, total, processados, sucessos, falhas, erro, criado_em, iniciado_em, concluido_em`
+
+// NewMysqlJobRepository cria uma nova instância do repositório MySQL de jobs
+func NewMysqlJobRepository(db *sql.DB) *MysqlJobRepository {
+	return &MysqlJobRepository{db: db}
+}
+
+// Save salva um novo job pendente e retorna o ID gerado
+func (r *MysqlJobRepository) Save(job models.Job) (string, error) {
+	query := `
+		INSERT INTO jobs (id, estabelecimento_id, tipo, status, formato, template, total, entrada, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	id := uuid.New().String()
+
+	var template sql.NullString
+	if job.Template != "" {
+		template = sql.NullString{String: job.Template, Valid: true}
+	}
+
+	_, err := r.db.Exec(
+		query,
+		id,
+		job.EstabelecimentoID,
+		job.Tipo,
+		models.StatusJobPendente,
+		job.Formato,
+		template,
+		job.Total,
+		job.Entrada,
+		time.Now(),
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// FindByID busca um job pelo ID, sem carregar a entrada e o resultado
+func (r *MysqlJobRepository) FindByID(id string) (models.Job, error) {
+	query := `
+		SELECT ` + colunasJob + `
+		FROM jobs
+		WHERE id = ?
+	`
+
+	var job models.Job
+	var template, erro sql.NullString
+	var iniciadoEm, concluidoEm sql.NullTime
+
+	err := r.db.QueryRow(query, id).Scan(
+		&job.ID,
+		&job.EstabelecimentoID,
+		&job.Tipo,
+		&job.Status,
+		&job.Formato,
+		&template,
+		&job.Total,
+		&job.Processados,
+		&job.Sucessos,
+		&job.Falhas,
+		&erro,
+		&job.CriadoEm,
+		&iniciadoEm,
+		&concluidoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Job{}, ErrJobNaoEncontrado
+		}
+		return models.Job{}, err
+	}
+
+	job.Template = template.String
+	job.Erro = erro.String
+
+	if iniciadoEm.Valid {
+		job.IniciadoEm = &iniciadoEm.Time
+	}
+
+	if concluidoEm.Valid {
+		job.ConcluidoEm = &concluidoEm.Time
+	}
+
+	return job, nil
+}
+
+// FindEntrada retorna a entrada serializada de um job
+func (r *MysqlJobRepository) FindEntrada(id string) ([]byte, error) {
+	var entrada []byte
+
+	err := r.db.QueryRow(`SELECT entrada FROM jobs WHERE id = ?`, id).Scan(&entrada)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrJobNaoEncontrado
+		}
+		return nil, err
+	}
+
+	return entrada, nil
+}
+
+// FindResultado retorna o resultado de um job e o seu Content-Type
+func (r *MysqlJobRepository) FindResultado(id string) ([]byte, string, error) {
+	var resultado []byte
+	var resultadoTipo sql.NullString
+
+	err := r.db.QueryRow(`SELECT resultado, resultado_tipo FROM jobs WHERE id = ?`, id).Scan(&resultado, &resultadoTipo)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", ErrJobNaoEncontrado
+		}
+		return nil, "", err
+	}
+
+	return resultado, resultadoTipo.String, nil
+}
+
+// ListarPendentes lista os IDs dos jobs que ainda aguardam processamento, do mais antigo ao mais novo
+func (r *MysqlJobRepository) ListarPendentes() ([]string, error) {
+	rows, err := r.db.Query(`SELECT id FROM jobs WHERE status = ? ORDER BY criado_em`, models.StatusJobPendente)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Iniciar marca um job pendente como em processamento, indicando se o job foi assumido
+func (r *MysqlJobRepository) Iniciar(id string) (bool, error) {
+	agora := time.Now()
+	return r.atualizar(
+		`UPDATE jobs SET status = ?, iniciado_em = ?, atualizado_em = ? WHERE id = ? AND status = ?`,
+		models.StatusJobProcessando, agora, agora, id, models.StatusJobPendente,
+	)
+}
+
+// AtualizarProgresso registra o número de linhas já processadas de um job e o sinal de vida do worker
+func (r *MysqlJobRepository) AtualizarProgresso(id string, processados, sucessos, falhas int) error {
+	_, err := r.db.Exec(
+		`UPDATE jobs SET processados = ?, sucessos = ?, falhas = ?, atualizado_em = ? WHERE id = ?`,
+		processados, sucessos, falhas, time.Now(), id,
+	)
+	return err
+}
+
+// Concluir grava o resultado de um job em processamento
+func (r *MysqlJobRepository) Concluir(id string, resultado []byte, resultadoTipo string) (bool, error) {
+	return r.atualizar(
+		`UPDATE jobs SET status = ?, resultado = ?, resultado_tipo = ?, concluido_em = ? WHERE id = ? AND status = ?`,
+		models.StatusJobConcluido, resultado, resultadoTipo, time.Now(), id, models.StatusJobProcessando,
+	)
+}
+
+// Falhar registra o erro que interrompeu um job em processamento
+func (r *MysqlJobRepository) Falhar(id string, erro string) (bool, error) {
+	return r.atualizar(
+		`UPDATE jobs SET status = ?, erro = ?, concluido_em = ? WHERE id = ? AND status = ?`,
+		models.StatusJobFalhou, erro, time.Now(), id, models.StatusJobProcessando,
+	)
+}
+
+// FalharInterrompidos registra o erro nos jobs em processamento sem sinal de vida do worker
+// desde o instante informado, como os de uma instância encerrada no meio do processamento,
+// e retorna quantos jobs foram alterados
+func (r *MysqlJobRepository) FalharInterrompidos(semProgressoDesde time.Time, erro string) (int64, error) {
+	result, err := r.db.Exec(
+		`UPDATE jobs SET status = ?, erro = ?, concluido_em = ? WHERE status = ? AND COALESCE(atualizado_em, iniciado_em) < ?`,
+		models.StatusJobFalhou, erro, time.Now(), models.StatusJobProcessando, semProgressoDesde,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Cancelar cancela um job pendente ou em processamento
+func (r *MysqlJobRepository) Cancelar(id string) (bool, error) {
+	return r.atualizar(
+		`UPDATE jobs SET status = ?, concluido_em = ? WHERE id = ? AND status IN (?, ?)`,
+		models.StatusJobCancelado, time.Now(), id, models.StatusJobPendente, models.StatusJobProcessando,
+	)
+}
+
+// atualizar executa uma mudança de situação e indica se alguma linha foi alterada
+func (r *MysqlJobRepository) atualizar(query string, args ...interface{}) (bool, error) {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas > 0, nil
+}
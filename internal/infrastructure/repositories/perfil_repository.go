@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+var (
+	// ErrChaveNaoEncontrada indica que a chave não está cadastrada no perfil do estabelecimento
+	ErrChaveNaoEncontrada = errors.New("chave PIX não encontrada no perfil")
+
+	// ErrChaveDuplicada indica que a chave já está cadastrada no perfil do estabelecimento
+	ErrChaveDuplicada = errors.New("chave PIX já cadastrada no perfil")
+)
+
+// PerfilRepository interface para persistência do perfil do estabelecimento e das suas
+// chaves PIX. Enquanto houver chaves cadastradas, exatamente uma delas é a padrão.
+type PerfilRepository interface {
+	BuscarPerfil(estabelecimentoID string) (models.PerfilEstabelecimento, error)
+	AtualizarPerfil(estabelecimentoID string, perfil models.PerfilEstabelecimento) error
+	SalvarChave(estabelecimentoID string, chave models.ChaveCadastrada) (models.ChaveCadastrada, error)
+	AtualizarApelidoChave(estabelecimentoID string, id uint, apelido string) error
+	DefinirChavePadrao(estabelecimentoID string, id uint) error
+	ExcluirChave(estabelecimentoID string, id uint) error
+}
+
+// MysqlPerfilRepository implementação MySQL do repositório de perfis
+type MysqlPerfilRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlPerfilRepository cria uma nova instância do repositório MySQL de perfis
+func NewMysqlPerfilRepository(db *sql.DB) *MysqlPerfilRepository {
+	return &MysqlPerfilRepository{db: db}
+}
+
+// BuscarPerfil busca o perfil do estabelecimento com as chaves cadastradas, da padrão às demais
+func (r *MysqlPerfilRepository) BuscarPerfil(estabelecimentoID string) (models.PerfilEstabelecimento, error) {
+	var perfil models.PerfilEstabelecimento
+	var razaoSocial, cidade, mcc, cep sql.NullString
+
+	err := r.db.QueryRow(
+		`SELECT razao_social, cidade, mcc, cep FROM estabelecimentos WHERE id = ?`,
+		estabelecimentoID,
+	).Scan(&razaoSocial, &cidade, &mcc, &cep)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.PerfilEstabelecimento{}, ErrEstabelecimentoNaoEncontrado
+		}
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	perfil.RazaoSocial = razaoSocial.String
+	perfil.Cidade = cidade.String
+	perfil.MCC = mcc.String
+	perfil.CEP = cep.String
+
+	query := `
+		SELECT id, chave, tipo_chave, apelido, padrao, criado_em
+		FROM chaves_pix
+		WHERE estabelecimento_id = ?
+		ORDER BY padrao DESC, criado_em, id
+	`
+
+	rows, err := r.db.Query(query, estabelecimentoID)
+	if err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+	defer rows.Close()
+
+	perfil.Chaves = []models.ChaveCadastrada{}
+	for rows.Next() {
+		var chave models.ChaveCadastrada
+		var apelido sql.NullString
+
+		if err := rows.Scan(&chave.ID, &chave.Chave, &chave.TipoChave, &apelido, &chave.Padrao, &chave.CriadoEm); err != nil {
+			return models.PerfilEstabelecimento{}, err
+		}
+
+		chave.Apelido = apelido.String
+		perfil.Chaves = append(perfil.Chaves, chave)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.PerfilEstabelecimento{}, err
+	}
+
+	return perfil, nil
+}
+
+// AtualizarPerfil grava os dados do perfil do estabelecimento; campos vazios são removidos
+func (r *MysqlPerfilRepository) AtualizarPerfil(estabelecimentoID string, perfil models.PerfilEstabelecimento) error {
+	query := `
+		UPDATE estabelecimentos
+		SET razao_social = ?, cidade = ?, mcc = ?, cep = ?, atualizado_em = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(
+		query,
+		textoOpcional(perfil.RazaoSocial),
+		textoOpcional(perfil.Cidade),
+		textoOpcional(perfil.MCC),
+		textoOpcional(perfil.CEP),
+		time.Now(),
+		estabelecimentoID,
+	)
+	if err != nil {
+		return err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// Sem alteração nos dados, o MySQL não conta a linha; confirmar que o estabelecimento existe
+	if linhas == 0 {
+		var id string
+		err := r.db.QueryRow(`SELECT id FROM estabelecimentos WHERE id = ?`, estabelecimentoID).Scan(&id)
+		if err == sql.ErrNoRows {
+			return ErrEstabelecimentoNaoEncontrado
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SalvarChave cadastra uma chave no perfil. A primeira chave do estabelecimento é sempre a
+// padrão; uma nova chave padrão substitui a anterior.
+func (r *MysqlPerfilRepository) SalvarChave(estabelecimentoID string, chave models.ChaveCadastrada) (models.ChaveCadastrada, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+	defer tx.Rollback()
+
+	var total, duplicadas int
+	err = tx.QueryRow(
+		`SELECT COUNT(*), COALESCE(SUM(chave = ?), 0) FROM chaves_pix WHERE estabelecimento_id = ? FOR UPDATE`,
+		chave.Chave, estabelecimentoID,
+	).Scan(&total, &duplicadas)
+	if err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+
+	if duplicadas > 0 {
+		return models.ChaveCadastrada{}, ErrChaveDuplicada
+	}
+
+	chave.Padrao = chave.Padrao || total == 0
+	if chave.Padrao {
+		if _, err := tx.Exec(`UPDATE chaves_pix SET padrao = false WHERE estabelecimento_id = ?`, estabelecimentoID); err != nil {
+			return models.ChaveCadastrada{}, err
+		}
+	}
+
+	chave.CriadoEm = time.Now()
+	result, err := tx.Exec(
+		`INSERT INTO chaves_pix (estabelecimento_id, chave, tipo_chave, apelido, padrao, criado_em) VALUES (?, ?, ?, ?, ?, ?)`,
+		estabelecimentoID, chave.Chave, chave.TipoChave, textoOpcional(chave.Apelido), chave.Padrao, chave.CriadoEm,
+	)
+	if err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+	chave.ID = uint(id)
+
+	if err := tx.Commit(); err != nil {
+		return models.ChaveCadastrada{}, err
+	}
+
+	return chave, nil
+}
+
+// AtualizarApelidoChave altera o apelido de uma chave cadastrada
+func (r *MysqlPerfilRepository) AtualizarApelidoChave(estabelecimentoID string, id uint, apelido string) error {
+	if err := r.verificarChave(r.db, estabelecimentoID, id); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(
+		`UPDATE chaves_pix SET apelido = ? WHERE id = ? AND estabelecimento_id = ?`,
+		textoOpcional(apelido), id, estabelecimentoID,
+	)
+	return err
+}
+
+// DefinirChavePadrao torna a chave informada a padrão do perfil, desmarcando a anterior
+func (r *MysqlPerfilRepository) DefinirChavePadrao(estabelecimentoID string, id uint) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.verificarChave(tx, estabelecimentoID, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE chaves_pix SET padrao = (id = ?) WHERE estabelecimento_id = ?`, id, estabelecimentoID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExcluirChave remove uma chave do perfil. Se era a padrão, a chave cadastrada há mais tempo
+// passa a ser a padrão.
+func (r *MysqlPerfilRepository) ExcluirChave(estabelecimentoID string, id uint) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var padrao bool
+	err = tx.QueryRow(
+		`SELECT padrao FROM chaves_pix WHERE id = ? AND estabelecimento_id = ? FOR UPDATE`,
+		id, estabelecimentoID,
+	).Scan(&padrao)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrChaveNaoEncontrada
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM chaves_pix WHERE id = ? AND estabelecimento_id = ?`, id, estabelecimentoID); err != nil {
+		return err
+	}
+
+	if padrao {
+		_, err := tx.Exec(
+			`UPDATE chaves_pix SET padrao = true WHERE estabelecimento_id = ? ORDER BY criado_em, id LIMIT 1`,
+			estabelecimentoID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// consultaLinha abstrai sql.DB e sql.Tx para consultas de uma linha
+type consultaLinha interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// verificarChave confere se a chave está cadastrada no perfil do estabelecimento
+func (r *MysqlPerfilRepository) verificarChave(db consultaLinha, estabelecimentoID string, id uint) error {
+	var existe uint
+	err := db.QueryRow(`SELECT id FROM chaves_pix WHERE id = ? AND estabelecimento_id = ?`, id, estabelecimentoID).Scan(&existe)
+	if err == sql.ErrNoRows {
+		return ErrChaveNaoEncontrada
+	}
+	return err
+}
+
+// textoOpcional grava textos vazios como NULL
+func textoOpcional(texto string) sql.NullString {
+	return sql.NullString{String: texto, Valid: texto != ""}
+}
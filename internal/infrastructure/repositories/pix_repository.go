@@ -2,18 +2,34 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 )
 
-// PixRepository interface para persistência de dados PIX
+var (
+	// ErrPixNaoEncontrado indica que o PIX não existe ou pertence a outro estabelecimento
+	ErrPixNaoEncontrado = errors.New("código PIX não encontrado")
+
+	// ErrTxidDuplicado indica que o txid informado já foi usado em outra cobrança
+	ErrTxidDuplicado = errors.New("txid já utilizado")
+)
+
+// erroChaveDuplicada é o código do MySQL para a violação de um índice UNIQUE
+const erroChaveDuplicada = 1062
+
+// PixRepository interface para persistência de dados PIX. As leituras são sempre restritas
+// ao estabelecimento dono do PIX.
 type PixRepository interface {
 	Save(pix models.Pix) (uint, error)
-	FindByID(id uint) (models.Pix, error)
-	List() ([]models.Pix, error)
-	FindByCodigoPix(codigoPix string) (models.Pix, error)
+	SaveLote(pixes []models.Pix) ([]uint, error)
+	FindByID(estabelecimentoID string, id uint) (models.Pix, error)
+	Listar(filtro models.FiltroPix) ([]models.Pix, error)
+	Contar(filtro models.FiltroPix) (int64, error)
+	FindByCodigoPix(estabelecimentoID string, codigoPix string) (models.Pix, error)
 }
 
 // MysqlPixRepository implementação MySQL do repositório PIX
@@ -21,169 +37,327 @@ type MysqlPixRepository struct {
 	db *sql.DB
 }
 
+// colunasPix lista as colunas lidas da tabela pix, na ordem esperada por scanPix
+const colunasPix = `id, estabelecimento_id, tipo, modalidade, nome, chave, tipo_chave, cidade, valor, identificador, descricao, txid, location, expira_em, codigo_pix, qrcode_svg, qrcode_png, criado_em`
+
+// colunasListagemPix lista as mesmas colunas de colunasPix, sem carregar as imagens do QR Code
+const colunasListagemPix = `id, estabelecimento_id, tipo, modalidade, nome, chave, tipo_chave, cidade, valor, identificador, descricao, txid, location, expira_em, codigo_pix, '' AS qrcode_svg, '' AS qrcode_png, criado_em`
+
 // NewMysqlPixRepository cria uma nova instância do repositório MySQL
 func NewMysqlPixRepository(db *sql.DB) *MysqlPixRepository {
 	return &MysqlPixRepository{db: db}
 }
 
+// insertPix insere um PIX com todas as colunas gravadas na geração
+const insertPix = `
+	INSERT INTO pix (estabelecimento_id, tipo, modalidade, nome, chave, tipo_chave, cidade, valor, identificador, descricao, txid, location, expira_em, codigo_pix, qrcode_svg, qrcode_png, criado_em)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
 // Save salva um código PIX no banco de dados
 func (r *MysqlPixRepository) Save(pix models.Pix) (uint, error) {
-	query := `
-		INSERT INTO pix (nome, chave, cidade, valor, identificador, descricao, codigo_pix, qrcode_svg, qrcode_png, criado_em)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	result, err := r.db.Exec(insertPix, argumentosPix(pix)...)
+	if err != nil {
+		return 0, erroInsertPix(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(id), nil
+}
+
+// SaveLote salva vários códigos PIX em uma única transação: ou todos são gravados, ou nenhum.
+// Os IDs são retornados na mesma ordem dos PIX recebidos.
+func (r *MysqlPixRepository) SaveLote(pixes []models.Pix) ([]uint, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(insertPix)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	ids := make([]uint, 0, len(pixes))
+	for _, pix := range pixes {
+		result, err := stmt.Exec(argumentosPix(pix)...)
+		if err != nil {
+			return nil, erroInsertPix(err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-	result, err := r.db.Exec(
-		query,
+	return ids, nil
+}
+
+// erroInsertPix converte a violação do índice UNIQUE do txid, única coluna única da tabela pix
+// preenchida na inserção, em ErrTxidDuplicado
+func erroInsertPix(err error) error {
+	if chaveDuplicada(err) {
+		return ErrTxidDuplicado
+	}
+	return err
+}
+
+// chaveDuplicada indica se o erro do MySQL é a violação de um índice UNIQUE
+func chaveDuplicada(err error) bool {
+	var erroMySQL *mysql.MySQLError
+	return errors.As(err, &erroMySQL) && erroMySQL.Number == erroChaveDuplicada
+}
+
+// argumentosPix retorna os valores de insertPix, aplicando os padrões de tipo e modalidade
+func argumentosPix(pix models.Pix) []interface{} {
+	tipo := pix.Tipo
+	if tipo == "" {
+		tipo = models.TipoPixEstatico
+	}
+
+	modalidade := pix.Modalidade
+	if modalidade == "" {
+		modalidade = models.ModalidadeCompra
+	}
+
+	return []interface{}{
+		sql.NullString{String: pix.EstabelecimentoID, Valid: pix.EstabelecimentoID != ""},
+		tipo,
+		modalidade,
 		pix.Nome,
 		pix.Chave,
+		pix.TipoChave,
 		pix.Cidade,
 		pix.Valor,
 		pix.Identificador,
 		pix.Descricao,
+		pix.Txid,
+		pix.Location,
+		pix.ExpiraEm,
 		pix.CodigoPix,
 		pix.QRCodeSVG,
 		pix.QRCodePNG,
 		time.Now(),
-	)
-
-	if err != nil {
-		return 0, err
 	}
-
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, err
-	}
-
-	return uint(id), nil
 }
 
-// FindByID busca um PIX pelo ID
-func (r *MysqlPixRepository) FindByID(id uint) (models.Pix, error) {
-	var pix models.Pix
-
+// FindByID busca um PIX do estabelecimento pelo ID
+func (r *MysqlPixRepository) FindByID(estabelecimentoID string, id uint) (models.Pix, error) {
 	query := `
-		SELECT id, nome, chave, cidade, valor, identificador, descricao, codigo_pix, qrcode_svg, qrcode_png, criado_em
+		SELECT ` + colunasPix + `
 		FROM pix
-		WHERE id = ?
+		WHERE id = ? AND estabelecimento_id = ?
 	`
 
-	var valor sql.NullFloat64
-	var identificador, descricao sql.NullString
-
-	err := r.db.QueryRow(query, id).Scan(
-		&pix.ID,
-		&pix.Nome,
-		&pix.Chave,
-		&pix.Cidade,
-		&valor,
-		&identificador,
-		&descricao,
-		&pix.CodigoPix,
-		&pix.QRCodeSVG,
-		&pix.QRCodePNG,
-		&pix.CriadoEm,
-	)
-
+	pix, err := scanPix(r.db.QueryRow(query, id, estabelecimentoID))
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Pix{}, ErrPixNaoEncontrado
+		}
 		return models.Pix{}, err
 	}
 
-	if valor.Valid {
-		pix.Valor = &valor.Float64
-	}
+	return pix, nil
+}
 
-	if identificador.Valid {
-		pix.Identificador = &identificador.String
-	}
+// Listar lista uma página dos PIX do estabelecimento que atendem aos filtros, a partir do
+// cursor. A paginação por cursor usa os índices (estabelecimento_id, ordenação, id), sem OFFSET.
+func (r *MysqlPixRepository) Listar(filtro models.FiltroPix) ([]models.Pix, error) {
+	condicoes, args := condicoesFiltroPix(filtro)
 
-	if descricao.Valid {
-		pix.Descricao = &descricao.String
+	if filtro.Apos != nil {
+		condicao, argsCursor := condicaoCursorPix(*filtro.Apos)
+		condicoes = append(condicoes, condicao)
+		args = append(args, argsCursor...)
 	}
 
-	return pix, nil
-}
+	direcao := "ASC"
+	if filtro.Decrescente {
+		direcao = "DESC"
+	}
 
-// List lista todos os PIX gerados
-func (r *MysqlPixRepository) List() ([]models.Pix, error) {
-	var pixList []models.Pix
+	coluna := "criado_em"
+	if filtro.Ordenacao == models.OrdenacaoPixValor {
+		coluna = "valor"
+	}
 
 	query := `
-		SELECT id, nome, chave, cidade, valor, identificador, descricao, codigo_pix, qrcode_svg, qrcode_png, criado_em
+		SELECT ` + colunasListagemPix + `
 		FROM pix
-		ORDER BY criado_em DESC
+		WHERE ` + strings.Join(condicoes, " AND ") + `
+		ORDER BY ` + coluna + ` ` + direcao + `, id ` + direcao + `
+		LIMIT ?
 	`
+	args = append(args, filtro.Limite)
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	pixList := make([]models.Pix, 0, filtro.Limite)
 	for rows.Next() {
-		var pix models.Pix
-		var valor sql.NullFloat64
-		var identificador, descricao sql.NullString
-
-		err := rows.Scan(
-			&pix.ID,
-			&pix.Nome,
-			&pix.Chave,
-			&pix.Cidade,
-			&valor,
-			&identificador,
-			&descricao,
-			&pix.CodigoPix,
-			&pix.QRCodeSVG,
-			&pix.QRCodePNG,
-			&pix.CriadoEm,
-		)
-
+		pix, err := scanPix(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		if valor.Valid {
-			pix.Valor = &valor.Float64
-		}
+		pixList = append(pixList, pix)
+	}
 
-		if identificador.Valid {
-			pix.Identificador = &identificador.String
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-		if descricao.Valid {
-			pix.Descricao = &descricao.String
-		}
+	return pixList, nil
+}
 
-		pixList = append(pixList, pix)
+// Contar conta os PIX do estabelecimento que atendem aos filtros, em todas as páginas
+func (r *MysqlPixRepository) Contar(filtro models.FiltroPix) (int64, error) {
+	condicoes, args := condicoesFiltroPix(filtro)
+
+	query := `SELECT COUNT(*) FROM pix WHERE ` + strings.Join(condicoes, " AND ")
+
+	var total int64
+	if err := r.db.QueryRow(query, args...).Scan(&total); err != nil {
+		return 0, err
 	}
 
-	return pixList, nil
+	return total, nil
 }
 
-// FindByCodigoPix busca um PIX pelo código gerado
-func (r *MysqlPixRepository) FindByCodigoPix(codigoPix string) (models.Pix, error) {
-	var pix models.Pix
+// condicoesFiltroPix monta as condições do WHERE para os filtros da listagem
+func condicoesFiltroPix(filtro models.FiltroPix) ([]string, []interface{}) {
+	condicoes := []string{"estabelecimento_id = ?"}
+	args := []interface{}{filtro.EstabelecimentoID}
+
+	if filtro.CriadoDe != nil {
+		condicoes = append(condicoes, "criado_em >= ?")
+		args = append(args, *filtro.CriadoDe)
+	}
+
+	if filtro.CriadoAte != nil {
+		condicoes = append(condicoes, "criado_em <= ?")
+		args = append(args, *filtro.CriadoAte)
+	}
+
+	if filtro.ValorMinimo != nil {
+		condicoes = append(condicoes, "valor >= ?")
+		args = append(args, *filtro.ValorMinimo)
+	}
+
+	if filtro.ValorMaximo != nil {
+		condicoes = append(condicoes, "valor <= ?")
+		args = append(args, *filtro.ValorMaximo)
+	}
+
+	if filtro.Identificador != "" {
+		condicoes = append(condicoes, "identificador = ?")
+		args = append(args, filtro.Identificador)
+	}
+
+	if filtro.Chave != "" {
+		condicoes = append(condicoes, "chave = ?")
+		args = append(args, filtro.Chave)
+	}
 
+	switch filtro.Status {
+	case models.StatusPixAtivo:
+		condicoes = append(condicoes, "(expira_em IS NULL OR expira_em > ?)")
+		args = append(args, filtro.Agora)
+	case models.StatusPixExpirado:
+		condicoes = append(condicoes, "expira_em <= ?")
+		args = append(args, filtro.Agora)
+	}
+
+	return condicoes, args
+}
+
+// condicaoCursorPix monta a condição que seleciona os PIX posteriores ao cursor na ordenação.
+// O ID desempata registros com o mesmo valor; na ordenação por valor, o MySQL posiciona os
+// PIX sem valor antes de todos os demais na ordem crescente.
+func condicaoCursorPix(cursor models.CursorPix) (string, []interface{}) {
+	comparacao := ">"
+	if cursor.Decrescente {
+		comparacao = "<"
+	}
+
+	if cursor.Ordenacao != models.OrdenacaoPixValor {
+		return "(criado_em " + comparacao + " ? OR (criado_em = ? AND id " + comparacao + " ?))",
+			[]interface{}{cursor.CriadoEm, cursor.CriadoEm, cursor.ID}
+	}
+
+	switch {
+	case cursor.Valor == nil && cursor.Decrescente:
+		return "(valor IS NULL AND id < ?)", []interface{}{cursor.ID}
+	case cursor.Valor == nil:
+		return "((valor IS NULL AND id > ?) OR valor IS NOT NULL)", []interface{}{cursor.ID}
+	case cursor.Decrescente:
+		return "(valor < ? OR (valor = ? AND id < ?) OR valor IS NULL)", []interface{}{*cursor.Valor, *cursor.Valor, cursor.ID}
+	default:
+		return "(valor > ? OR (valor = ? AND id > ?))", []interface{}{*cursor.Valor, *cursor.Valor, cursor.ID}
+	}
+}
+
+// FindByCodigoPix busca um PIX do estabelecimento pelo código gerado
+func (r *MysqlPixRepository) FindByCodigoPix(estabelecimentoID string, codigoPix string) (models.Pix, error) {
 	query := `
-        SELECT id, nome, chave, cidade, valor, identificador, descricao, codigo_pix, qrcode_svg, qrcode_png, criado_em
+        SELECT ` + colunasPix + `
         FROM pix
-        WHERE codigo_pix = ?
+        WHERE codigo_pix = ? AND estabelecimento_id = ?
         LIMIT 1
     `
 
-	var valor sql.NullFloat64
-	var identificador, descricao sql.NullString
+	pix, err := scanPix(r.db.QueryRow(query, codigoPix, estabelecimentoID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Pix{}, ErrPixNaoEncontrado
+		}
+		return models.Pix{}, err
+	}
+
+	return pix, nil
+}
+
+// linhaPix abstrai sql.Row e sql.Rows para a leitura de um registro
+type linhaPix interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPix converte uma linha com as colunas de colunasPix em uma entidade PIX
+func scanPix(linha linhaPix) (models.Pix, error) {
+	var pix models.Pix
+	var estabelecimentoID, tipoChave, identificador, descricao, txid, location sql.NullString
+	var expiraEm sql.NullTime
 
-	err := r.db.QueryRow(query, codigoPix).Scan(
+	err := linha.Scan(
 		&pix.ID,
+		&estabelecimentoID,
+		&pix.Tipo,
+		&pix.Modalidade,
 		&pix.Nome,
 		&pix.Chave,
+		&tipoChave,
 		&pix.Cidade,
-		&valor,
+		&pix.Valor,
 		&identificador,
 		&descricao,
+		&txid,
+		&location,
+		&expiraEm,
 		&pix.CodigoPix,
 		&pix.QRCodeSVG,
 		&pix.QRCodePNG,
@@ -191,14 +365,13 @@ func (r *MysqlPixRepository) FindByCodigoPix(codigoPix string) (models.Pix, erro
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return models.Pix{}, fmt.Errorf("código PIX não encontrado")
-		}
 		return models.Pix{}, err
 	}
 
-	if valor.Valid {
-		pix.Valor = &valor.Float64
+	pix.EstabelecimentoID = estabelecimentoID.String
+
+	if tipoChave.Valid {
+		pix.TipoChave = tipoChave.String
 	}
 
 	if identificador.Valid {
@@ -209,5 +382,17 @@ func (r *MysqlPixRepository) FindByCodigoPix(codigoPix string) (models.Pix, erro
 		pix.Descricao = &descricao.String
 	}
 
+	if txid.Valid {
+		pix.Txid = &txid.String
+	}
+
+	if location.Valid {
+		pix.Location = &location.String
+	}
+
+	if expiraEm.Valid {
+		pix.ExpiraEm = &expiraEm.Time
+	}
+
 	return pix, nil
 }
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrRefreshTokenNaoEncontrado indica que não existe refresh token com o hash informado
+var ErrRefreshTokenNaoEncontrado = errors.New("refresh token não encontrado")
+
+// RefreshTokenRepository interface para persistência dos refresh tokens
+type RefreshTokenRepository interface {
+	Salvar(token models.RefreshToken) error
+	BuscarPorHash(tokenHash string) (models.RefreshToken, error)
+	MarcarUsado(id string, usadoEm time.Time) (bool, error)
+	RevogarFamilia(familiaID string, revogadoEm time.Time) error
+	RevogarLogin(estabelecimentoID, usuarioID string, revogadoEm time.Time) error
+}
+
+// MysqlRefreshTokenRepository implementação MySQL do repositório de refresh tokens
+type MysqlRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlRefreshTokenRepository cria uma nova instância do repositório MySQL de refresh tokens
+func NewMysqlRefreshTokenRepository(db *sql.DB) *MysqlRefreshTokenRepository {
+	return &MysqlRefreshTokenRepository{db: db}
+}
+
+// Salvar salva um refresh token no banco de dados
+func (r *MysqlRefreshTokenRepository) Salvar(token models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, familia_id, estabelecimento_id, usuario_id, token_hash, expira_em, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// O login do estabelecimento não tem usuário
+	usuarioID := sql.NullString{String: token.UsuarioID, Valid: token.UsuarioID != ""}
+
+	_, err := r.db.Exec(
+		query,
+		token.ID,
+		token.FamiliaID,
+		token.EstabelecimentoID,
+		usuarioID,
+		token.TokenHash,
+		token.ExpiraEm,
+		token.CriadoEm,
+	)
+	return err
+}
+
+// BuscarPorHash busca um refresh token pelo hash
+func (r *MysqlRefreshTokenRepository) BuscarPorHash(tokenHash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	var usuarioID sql.NullString
+	var usadoEm, revogadoEm sql.NullTime
+
+	query := `
+		SELECT id, familia_id, estabelecimento_id, usuario_id, token_hash, expira_em, usado_em, revogado_em, criado_em
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.FamiliaID,
+		&token.EstabelecimentoID,
+		&usuarioID,
+		&token.TokenHash,
+		&token.ExpiraEm,
+		&usadoEm,
+		&revogadoEm,
+		&token.CriadoEm,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.RefreshToken{}, ErrRefreshTokenNaoEncontrado
+		}
+		return models.RefreshToken{}, err
+	}
+
+	token.UsuarioID = usuarioID.String
+	if usadoEm.Valid {
+		token.UsadoEm = &usadoEm.Time
+	}
+	if revogadoEm.Valid {
+		token.RevogadoEm = &revogadoEm.Time
+	}
+
+	return token, nil
+}
+
+// MarcarUsado marca o refresh token como trocado. Retorna false quando o token já havia sido
+// usado ou revogado, o que garante que duas renovações simultâneas não usem o mesmo token.
+func (r *MysqlRefreshTokenRepository) MarcarUsado(id string, usadoEm time.Time) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE refresh_tokens SET usado_em = ? WHERE id = ? AND usado_em IS NULL AND revogado_em IS NULL`,
+		usadoEm, id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas == 1, nil
+}
+
+// RevogarFamilia revoga todos os refresh tokens ainda não revogados de uma família
+func (r *MysqlRefreshTokenRepository) RevogarFamilia(familiaID string, revogadoEm time.Time) error {
+	_, err := r.db.Exec(
+		`UPDATE refresh_tokens SET revogado_em = ? WHERE familia_id = ? AND revogado_em IS NULL`,
+		revogadoEm, familiaID,
+	)
+	return err
+}
+
+// RevogarLogin revoga todos os refresh tokens ainda não revogados do login do estabelecimento
+// ou, quando informado, do usuário da equipe, encerrando todas as sessões desse login
+func (r *MysqlRefreshTokenRepository) RevogarLogin(estabelecimentoID, usuarioID string, revogadoEm time.Time) error {
+	query := `UPDATE refresh_tokens SET revogado_em = ? WHERE estabelecimento_id = ? AND usuario_id IS NULL AND revogado_em IS NULL`
+	args := []interface{}{revogadoEm, estabelecimentoID}
+
+	if usuarioID != "" {
+		query = `UPDATE refresh_tokens SET revogado_em = ? WHERE estabelecimento_id = ? AND usuario_id = ? AND revogado_em IS NULL`
+		args = append(args, usuarioID)
+	}
+
+	_, err := r.db.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// ErrTokenContaNaoEncontrado indica que não existe token de conta com o hash informado
+var ErrTokenContaNaoEncontrado = errors.New("token não encontrado")
+
+// TokenContaRepository interface para persistência dos tokens de verificação de email e de
+// redefinição de senha
+type TokenContaRepository interface {
+	Salvar(token models.TokenConta) error
+	BuscarPorHash(tokenHash string) (models.TokenConta, error)
+	MarcarUsado(id string, usadoEm time.Time) (bool, error)
+	InvalidarPendentes(tipo, estabelecimentoID, usuarioID string, invalidadoEm time.Time) error
+}
+
+// MysqlTokenContaRepository implementação MySQL do repositório de tokens de conta
+type MysqlTokenContaRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlTokenContaRepository cria uma nova instância do repositório MySQL de tokens de conta
+func NewMysqlTokenContaRepository(db *sql.DB) *MysqlTokenContaRepository {
+	return &MysqlTokenContaRepository{db: db}
+}
+
+// Salvar salva um token de conta no banco de dados
+func (r *MysqlTokenContaRepository) Salvar(token models.TokenConta) error {
+	query := `
+		INSERT INTO tokens_conta (id, tipo, estabelecimento_id, usuario_id, token_hash, expira_em, criado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// Os tokens do login do estabelecimento não têm usuário
+	usuarioID := sql.NullString{String: token.UsuarioID, Valid: token.UsuarioID != ""}
+
+	_, err := r.db.Exec(
+		query,
+		token.ID,
+		token.Tipo,
+		token.EstabelecimentoID,
+		usuarioID,
+		token.TokenHash,
+		token.ExpiraEm,
+		token.CriadoEm,
+	)
+	return err
+}
+
+// BuscarPorHash busca um token de conta pelo hash
+func (r *MysqlTokenContaRepository) BuscarPorHash(tokenHash string) (models.TokenConta, error) {
+	var token models.TokenConta
+	var usuarioID sql.NullString
+	var usadoEm sql.NullTime
+
+	query := `
+		SELECT id, tipo, estabelecimento_id, usuario_id, token_hash, expira_em, usado_em, criado_em
+		FROM tokens_conta
+		WHERE token_hash = ?
+	`
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.Tipo,
+		&token.EstabelecimentoID,
+		&usuarioID,
+		&token.TokenHash,
+		&token.ExpiraEm,
+		&usadoEm,
+		&token.CriadoEm,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.TokenConta{}, ErrTokenContaNaoEncontrado
+		}
+		return models.TokenConta{}, err
+	}
+
+	token.UsuarioID = usuarioID.String
+	if usadoEm.Valid {
+		token.UsadoEm = &usadoEm.Time
+	}
+
+	return token, nil
+}
+
+// MarcarUsado marca o token como usado. Retorna false quando o token já havia sido usado ou
+// invalidado, o que garante que duas requisições simultâneas não usem o mesmo token.
+func (r *MysqlTokenContaRepository) MarcarUsado(id string, usadoEm time.Time) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE tokens_conta SET usado_em = ? WHERE id = ? AND usado_em IS NULL`,
+		usadoEm, id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas == 1, nil
+}
+
+// InvalidarPendentes invalida os tokens do tipo informado ainda não usados do login do
+// estabelecimento ou, quando informado, do usuário da equipe
+func (r *MysqlTokenContaRepository) InvalidarPendentes(tipo, estabelecimentoID, usuarioID string, invalidadoEm time.Time) error {
+	query := `UPDATE tokens_conta SET usado_em = ? WHERE tipo = ? AND estabelecimento_id = ? AND usuario_id IS NULL AND usado_em IS NULL`
+	args := []interface{}{invalidadoEm, tipo, estabelecimentoID}
+
+	if usuarioID != "" {
+		query = `UPDATE tokens_conta SET usado_em = ? WHERE tipo = ? AND estabelecimento_id = ? AND usuario_id = ? AND usado_em IS NULL`
+		args = append(args, usuarioID)
+	}
+
+	_, err := r.db.Exec(query, args...)
+	return err
+}
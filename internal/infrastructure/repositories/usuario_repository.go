@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUsuarioNaoEncontrado indica que o usuário não existe ou pertence a outro estabelecimento
+var ErrUsuarioNaoEncontrado = errors.New("usuário não encontrado")
+
+// UsuarioRepository interface para persistência dos usuários da equipe dos estabelecimentos
+type UsuarioRepository interface {
+	Salvar(usuario models.Usuario) error
+	BuscarPorID(id string) (models.Usuario, error)
+	BuscarPorEmail(email string) (models.Usuario, error)
+	BuscarPorConvite(conviteHash string) (models.Usuario, error)
+	Listar(estabelecimentoID string) ([]models.Usuario, error)
+	Aceitar(id, nome, senha string, aceitoEm time.Time) (bool, error)
+	AlterarPapel(estabelecimentoID, id, papel string) error
+	AlterarSenha(id, senha string) error
+	Desativar(estabelecimentoID, id string, desativadoEm time.Time) error
+}
+
+// colunasUsuario são as colunas lidas por scanUsuario
+const colunasUsuario = `id, estabelecimento_id, nome, email, senha, papel, ativo, convite_hash, convite_expira_em, aceito_em, tokens_revogados_em, criado_em, atualizado_em`
+
+// MysqlUsuarioRepository implementação MySQL do repositório de usuários
+type MysqlUsuarioRepository struct {
+	db *sql.DB
+}
+
+// NewMysqlUsuarioRepository cria uma nova instância do repositório MySQL de usuários
+func NewMysqlUsuarioRepository(db *sql.DB) *MysqlUsuarioRepository {
+	return &MysqlUsuarioRepository{db: db}
+}
+
+// Salvar salva um usuário convidado, ainda sem nome e senha
+func (r *MysqlUsuarioRepository) Salvar(usuario models.Usuario) error {
+	query := `
+		INSERT INTO usuarios (id, estabelecimento_id, email, papel, ativo, convite_hash, convite_expira_em, criado_em, atualizado_em)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		usuario.ID,
+		usuario.EstabelecimentoID,
+		usuario.Email,
+		usuario.Papel,
+		usuario.Ativo,
+		usuario.ConviteHash,
+		usuario.ConviteExpiraEm,
+		usuario.CriadoEm,
+		usuario.AtualizadoEm,
+	)
+	return err
+}
+
+// BuscarPorID busca um usuário pelo ID
+func (r *MysqlUsuarioRepository) BuscarPorID(id string) (models.Usuario, error) {
+	return r.buscar(`SELECT `+colunasUsuario+` FROM usuarios WHERE id = ?`, id)
+}
+
+// BuscarPorEmail busca um usuário pelo email
+func (r *MysqlUsuarioRepository) BuscarPorEmail(email string) (models.Usuario, error) {
+	return r.buscar(`SELECT `+colunasUsuario+` FROM usuarios WHERE email = ?`, email)
+}
+
+// BuscarPorConvite busca um usuário pelo hash do convite ainda não aceito
+func (r *MysqlUsuarioRepository) BuscarPorConvite(conviteHash string) (models.Usuario, error) {
+	return r.buscar(`SELECT `+colunasUsuario+` FROM usuarios WHERE convite_hash = ?`, conviteHash)
+}
+
+// Listar lista os usuários do estabelecimento, incluindo os convites pendentes e os desativados
+func (r *MysqlUsuarioRepository) Listar(estabelecimentoID string) ([]models.Usuario, error) {
+	query := `SELECT ` + colunasUsuario + ` FROM usuarios WHERE estabelecimento_id = ? ORDER BY criado_em, id`
+
+	rows, err := r.db.Query(query, estabelecimentoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usuarios := []models.Usuario{}
+	for rows.Next() {
+		usuario, err := scanUsuario(rows)
+		if err != nil {
+			return nil, err
+		}
+		usuarios = append(usuarios, usuario)
+	}
+
+	return usuarios, rows.Err()
+}
+
+// Aceitar registra o aceite do convite com o nome e a senha do usuário e descarta o convite.
+// Retorna false quando o convite já havia sido aceito, o que impede dois aceites simultâneos.
+func (r *MysqlUsuarioRepository) Aceitar(id, nome, senha string, aceitoEm time.Time) (bool, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		UPDATE usuarios
+		SET nome = ?, senha = ?, aceito_em = ?, convite_hash = NULL, convite_expira_em = NULL, atualizado_em = ?
+		WHERE id = ? AND aceito_em IS NULL AND convite_hash IS NOT NULL
+	`
+
+	result, err := r.db.Exec(query, nome, string(hashedPassword), aceitoEm, aceitoEm, id)
+	if err != nil {
+		return false, err
+	}
+
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return linhas == 1, nil
+}
+
+// AlterarPapel altera o papel de um usuário do estabelecimento. O novo papel vale a partir da
+// próxima requisição, pois o papel é conferido no banco a cada requisição.
+func (r *MysqlUsuarioRepository) AlterarPapel(estabelecimentoID, id, papel string) error {
+	result, err := r.db.Exec(
+		`UPDATE usuarios SET papel = ?, atualizado_em = ? WHERE id = ? AND estabelecimento_id = ?`,
+		papel, time.Now(), id, estabelecimentoID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, estabelecimentoID, id)
+}
+
+// AlterarSenha define uma nova senha para um usuário que já aceitou o convite
+func (r *MysqlUsuarioRepository) AlterarSenha(id, senha string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(
+		`UPDATE usuarios SET senha = ?, atualizado_em = ? WHERE id = ? AND aceito_em IS NOT NULL`,
+		string(hashedPassword), time.Now(), id,
+	)
+	return err
+}
+
+// Desativar desativa um usuário do estabelecimento, revogando os tokens já emitidos e o
+// convite pendente
+func (r *MysqlUsuarioRepository) Desativar(estabelecimentoID, id string, desativadoEm time.Time) error {
+	query := `
+		UPDATE usuarios
+		SET ativo = false, tokens_revogados_em = ?, convite_hash = NULL, convite_expira_em = NULL, atualizado_em = ?
+		WHERE id = ? AND estabelecimento_id = ? AND ativo = true
+	`
+
+	result, err := r.db.Exec(query, desativadoEm, desativadoEm, id, estabelecimentoID)
+	if err != nil {
+		return err
+	}
+
+	return r.confirmarAlteracao(result, estabelecimentoID, id)
+}
+
+// confirmarAlteracao confere que o usuário existe no estabelecimento quando a atualização não
+// alterou nenhuma linha, pois o MySQL não conta as linhas sem alteração nos dados
+func (r *MysqlUsuarioRepository) confirmarAlteracao(result sql.Result, estabelecimentoID, id string) error {
+	linhas, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if linhas > 0 {
+		return nil
+	}
+
+	usuario, err := r.BuscarPorID(id)
+	if err != nil {
+		return err
+	}
+	if usuario.EstabelecimentoID != estabelecimentoID {
+		return ErrUsuarioNaoEncontrado
+	}
+
+	return nil
+}
+
+// buscar busca um único usuário com a consulta informada
+func (r *MysqlUsuarioRepository) buscar(query string, args ...interface{}) (models.Usuario, error) {
+	usuario, err := scanUsuario(r.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Usuario{}, ErrUsuarioNaoEncontrado
+		}
+		return models.Usuario{}, err
+	}
+
+	return usuario, nil
+}
+
+// scanUsuario lê um usuário selecionado com colunasUsuario
+func scanUsuario(linha linhaScan) (models.Usuario, error) {
+	var usuario models.Usuario
+	var nome, senha, conviteHash sql.NullString
+	var conviteExpiraEm, aceitoEm, tokensRevogadosEm sql.NullTime
+
+	err := linha.Scan(
+		&usuario.ID,
+		&usuario.EstabelecimentoID,
+		&nome,
+		&usuario.Email,
+		&senha,
+		&usuario.Papel,
+		&usuario.Ativo,
+		&conviteHash,
+		&conviteExpiraEm,
+		&aceitoEm,
+		&tokensRevogadosEm,
+		&usuario.CriadoEm,
+		&usuario.AtualizadoEm,
+	)
+	if err != nil {
+		return models.Usuario{}, err
+	}
+
+	usuario.Nome = nome.String
+	usuario.Senha = senha.String
+	usuario.ConviteHash = conviteHash.String
+	if conviteExpiraEm.Valid {
+		usuario.ConviteExpiraEm = &conviteExpiraEm.Time
+	}
+	if aceitoEm.Valid {
+		usuario.AceitoEm = &aceitoEm.Time
+	}
+	if tokensRevogadosEm.Valid {
+		usuario.TokensRevogadosEm = &tokensRevogadosEm.Time
+	}
+
+	return usuario, nil
+}
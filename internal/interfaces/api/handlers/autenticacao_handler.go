@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
 )
 
@@ -25,7 +29,7 @@ func NovaAutenticacaoHandler(autenticacaoUseCase *usecases.AutenticacaoUseCase)
 
 // Registrar processa a requisição para registro de um novo estabelecimento
 // @Summary      Registrar estabelecimento
-// @Description  Registra um novo estabelecimento no sistema
+// @Description  Registra um novo estabelecimento no sistema e envia ao email informado o link de confirmação, válido por 24 horas
 // @Tags         autenticacao
 // @Accept       json
 // @Produce      json
@@ -53,14 +57,17 @@ func (h *AutenticacaoHandler) Registrar(c *gin.Context) {
 
 // Login processa a requisição de login
 // @Summary      Login de estabelecimento
-// @Description  Autentica um estabelecimento e retorna um token JWT
+// @Description  Autentica um estabelecimento e retorna um token JWT de curta duração e um refresh token para renová-lo. Quando o segundo fator é exigido, responde 202 com o desafio a ser concluído em /login/dois-fatores.
 // @Tags         autenticacao
 // @Accept       json
 // @Produce      json
 // @Param        request  body      models.LoginRequest  true  "Credenciais de login"
 // @Success      200      {object}  views.Response{data=models.LoginResponse}  "Login realizado com sucesso"
+// @Success      202      {object}  views.Response{data=models.DesafioDoisFatoresResponse}  "Senha correta; o login exige o segundo fator"
 // @Failure      400      {object}  views.Response  "Erro de validação dos dados"
 // @Failure      401      {object}  views.Response  "Credenciais inválidas"
+// @Failure      403      {object}  views.Response  "Email não verificado, quando a verificação é obrigatória"
+// @Failure      429      {object}  views.Response  "Muitas tentativas incorretas; o header Retry-After informa os segundos até uma nova tentativa"
 // @Router       /login [post]
 func (h *AutenticacaoHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -71,11 +78,154 @@ func (h *AutenticacaoHandler) Login(c *gin.Context) {
 	}
 
 	// Executar o caso de uso
-	response, err := h.autenticacaoUseCase.Login(req)
+	response, err := h.autenticacaoUseCase.Login(c.Request.Context(), req, c.ClientIP())
 	if err != nil {
-		h.responseView.Error(c, http.StatusUnauthorized, err.Error())
+		var bloqueado *usecases.ErroLoginBloqueado
+		switch {
+		case errors.As(err, &bloqueado):
+			c.Header("Retry-After", strconv.Itoa(bloqueado.Segundos()))
+			h.responseView.Error(c, http.StatusTooManyRequests, err.Error())
+		case errors.Is(err, services.ErrEmailNaoVerificado):
+			h.responseView.Error(c, http.StatusForbidden, err.Error())
+		default:
+			h.responseView.Error(c, http.StatusUnauthorized, err.Error())
+		}
+		return
+	}
+
+	if response.DoisFatores != nil {
+		h.responseView.Success(c, http.StatusAccepted, response.DoisFatores)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, response)
+}
+
+// LoginDoisFatores conclui o login com o segundo fator
+// @Summary      Login com segundo fator
+// @Description  Conclui o login com o desafio retornado por /login e um código do aplicativo autenticador ou um código de recuperação, que vale uma única vez. No cadastro obrigatório, o código confirma o cadastro iniciado em /login/dois-fatores/cadastro e a resposta traz os códigos de recuperação.
+// @Tags         autenticacao
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.LoginDoisFatoresRequest  true  "Desafio e código"
+// @Success      200      {object}  views.Response{data=models.LoginResponse}  "Login realizado com sucesso"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados ou cadastro não iniciado"
+// @Failure      401      {object}  views.Response  "Desafio inválido ou expirado, código inválido ou conta desativada"
+// @Failure      429      {object}  views.Response  "Muitos códigos incorretos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /login/dois-fatores [post]
+func (h *AutenticacaoHandler) LoginDoisFatores(c *gin.Context) {
+	var req models.LoginDoisFatoresRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Executar o caso de uso
+	response, err := h.autenticacaoUseCase.ConcluirLogin(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrDesafioDoisFatoresInvalido),
+			errors.Is(err, services.ErrCodigoDoisFatoresInvalido),
+			errors.Is(err, services.ErrContaDesativada),
+			errors.Is(err, services.ErrTokenRevogado):
+			h.responseView.Error(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, usecases.ErrDoisFatoresNaoCadastrado):
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecases.ErrMuitasTentativasDoisFatores):
+			h.responseView.Error(c, http.StatusTooManyRequests, err.Error())
+		default:
+			h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, response)
+}
+
+// RefreshToken processa a requisição de renovação do token JWT
+// @Summary      Renovar token
+// @Description  Troca um refresh token por um novo token JWT e um novo refresh token. Cada refresh token vale para uma única troca; a reutilização de um token já trocado encerra a sessão.
+// @Tags         autenticacao
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.RefreshTokenRequest  true  "Refresh token"
+// @Success      200      {object}  views.Response{data=models.LoginResponse}  "Token renovado com sucesso"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Refresh token inválido, expirado ou reutilizado"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /token/refresh [post]
+func (h *AutenticacaoHandler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Executar o caso de uso
+	response, err := h.autenticacaoUseCase.Renovar(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrRefreshTokenInvalido),
+			errors.Is(err, usecases.ErrRefreshTokenReutilizado),
+			errors.Is(err, services.ErrContaDesativada),
+			errors.Is(err, services.ErrTokenRevogado):
+			h.responseView.Error(c, http.StatusUnauthorized, err.Error())
+		default:
+			h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
 	h.responseView.Success(c, http.StatusOK, response)
 }
+
+// Logout processa a requisição de encerramento da sessão
+// @Summary      Logout
+// @Description  Revoga o token JWT da requisição. Com o refresh token no corpo, os refresh tokens da sessão também são revogados.
+// @Tags         autenticacao
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.LogoutRequest  false  "Refresh token da sessão"
+// @Success      200      {object}  views.Response  "Sessão encerrada"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /logout [post]
+func (h *AutenticacaoHandler) Logout(c *gin.Context) {
+	var req models.LogoutRequest
+
+	// O corpo é opcional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err := h.autenticacaoUseCase.Logout(
+		c.Request.Context(),
+		middlewares.EstabelecimentoID(c),
+		middlewares.TokenID(c),
+		middlewares.TokenExpiraEm(c),
+		req,
+	)
+	if err != nil {
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, nil)
+}
+
+// JWKS publica as chaves públicas que verificam os tokens JWT (RFC 7517), para que outros
+// serviços validem os tokens sem compartilhar segredos. Fica fora de /api, no caminho padrão
+// /.well-known/jwks.json, e responde o JSON sem o envelope das demais rotas.
+func (h *AutenticacaoHandler) JWKS(c *gin.Context) {
+	// As chaves mudam apenas na rotação, que deve manter a chave antiga publicada por mais tempo que o cache
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.autenticacaoUseCase.JWKS())
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// ChaveAPIHandler manipula as requisições da API relacionadas às chaves de API
+type ChaveAPIHandler struct {
+	chaveAPIUseCase *usecases.ChaveAPIUseCase
+	responseView    *views.ResponseView
+}
+
+// NewChaveAPIHandler cria uma nova instância do handler de chaves de API
+func NewChaveAPIHandler(chaveAPIUseCase *usecases.ChaveAPIUseCase) *ChaveAPIHandler {
+	return &ChaveAPIHandler{
+		chaveAPIUseCase: chaveAPIUseCase,
+		responseView:    views.NewResponseView(),
+	}
+}
+
+// CreateChaveAPI cria uma chave de API para o estabelecimento autenticado
+// @Summary      Criar chave de API
+// @Description  Cria uma chave de API para integrações servidor a servidor, enviada no header X-API-Key. A chave é exibida apenas nesta resposta.
+// @Tags         chaves-api
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CriarChaveAPIRequest  true  "Dados da chave"
+// @Success      201      {object}  views.Response{data=models.ChaveAPICriada}  "Chave criada"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /chaves-api [post]
+func (h *ChaveAPIHandler) CreateChaveAPI(c *gin.Context) {
+	var req models.CriarChaveAPIRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chave, err := h.chaveAPIUseCase.Criar(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroChaveAPI(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, chave)
+}
+
+// ListChavesAPI lista as chaves de API do estabelecimento autenticado
+// @Summary      Listar chaves de API
+// @Description  Lista as chaves de API do estabelecimento, inclusive as revogadas e expiradas, com o último uso de cada uma
+// @Tags         chaves-api
+// @Produce      json
+// @Success      200  {object}  views.Response{data=[]models.ChaveAPI}  "Chaves de API"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /chaves-api [get]
+func (h *ChaveAPIHandler) ListChavesAPI(c *gin.Context) {
+	chaves, err := h.chaveAPIUseCase.Listar(middlewares.EstabelecimentoID(c))
+	if err != nil {
+		h.erroChaveAPI(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, chaves)
+}
+
+// RevokeChaveAPI revoga uma chave de API do estabelecimento autenticado
+// @Summary      Revogar chave de API
+// @Description  Revoga uma chave de API, que deixa de ser aceita imediatamente
+// @Tags         chaves-api
+// @Produce      json
+// @Param        id   path      string  true  "ID da chave de API"
+// @Success      200  {object}  views.Response{data=models.ChaveAPI}  "Chave revogada"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      404  {object}  views.Response  "Chave de API não encontrada"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /chaves-api/{id} [delete]
+func (h *ChaveAPIHandler) RevokeChaveAPI(c *gin.Context) {
+	chave, err := h.chaveAPIUseCase.Revogar(middlewares.EstabelecimentoID(c), c.Param("id"))
+	if err != nil {
+		h.erroChaveAPI(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, chave)
+}
+
+// erroChaveAPI converte os erros das chaves de API em respostas HTTP
+func (h *ChaveAPIHandler) erroChaveAPI(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	if errors.Is(err, repositories.ErrChaveAPINaoEncontrada) {
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+}
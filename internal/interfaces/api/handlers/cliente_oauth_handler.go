@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// ClienteOAuthHandler manipula as requisições da API relacionadas aos clientes OAuth2 e aos
+// endpoints de token e de introspecção
+type ClienteOAuthHandler struct {
+	clienteOAuthUseCase *usecases.ClienteOAuthUseCase
+	responseView        *views.ResponseView
+}
+
+// NewClienteOAuthHandler cria uma nova instância do handler de clientes OAuth2
+func NewClienteOAuthHandler(clienteOAuthUseCase *usecases.ClienteOAuthUseCase) *ClienteOAuthHandler {
+	return &ClienteOAuthHandler{
+		clienteOAuthUseCase: clienteOAuthUseCase,
+		responseView:        views.NewResponseView(),
+	}
+}
+
+// CreateClienteOAuth registra um cliente OAuth2 para o estabelecimento autenticado
+// @Summary      Criar cliente OAuth2
+// @Description  Registra um cliente OAuth2 para uma plataforma parceira, que obtém tokens de acesso em POST /oauth/token com o grant client_credentials. O segredo é exibido apenas nesta resposta.
+// @Tags         clientes-oauth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CriarClienteOAuthRequest  true  "Dados do cliente"
+// @Success      201      {object}  views.Response{data=models.ClienteOAuthCriado}  "Cliente criado"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /clientes-oauth [post]
+func (h *ClienteOAuthHandler) CreateClienteOAuth(c *gin.Context) {
+	var req models.CriarClienteOAuthRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cliente, err := h.clienteOAuthUseCase.Criar(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroClienteOAuth(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, cliente)
+}
+
+// ListClientesOAuth lista os clientes OAuth2 do estabelecimento autenticado
+// @Summary      Listar clientes OAuth2
+// @Description  Lista os clientes OAuth2 do estabelecimento, inclusive os revogados, com a emissão do último token de cada um
+// @Tags         clientes-oauth
+// @Produce      json
+// @Success      200  {object}  views.Response{data=[]models.ClienteOAuth}  "Clientes OAuth2"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /clientes-oauth [get]
+func (h *ClienteOAuthHandler) ListClientesOAuth(c *gin.Context) {
+	clientes, err := h.clienteOAuthUseCase.Listar(middlewares.EstabelecimentoID(c))
+	if err != nil {
+		h.erroClienteOAuth(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, clientes)
+}
+
+// RevokeClienteOAuth revoga um cliente OAuth2 do estabelecimento autenticado
+// @Summary      Revogar cliente OAuth2
+// @Description  Revoga um cliente OAuth2, que deixa de obter tokens; os tokens já emitidos deixam de ser aceitos imediatamente
+// @Tags         clientes-oauth
+// @Produce      json
+// @Param        id   path      string  true  "ID do cliente (client_id)"
+// @Success      200  {object}  views.Response{data=models.ClienteOAuth}  "Cliente revogado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      404  {object}  views.Response  "Cliente OAuth não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /clientes-oauth/{id} [delete]
+func (h *ClienteOAuthHandler) RevokeClienteOAuth(c *gin.Context) {
+	cliente, err := h.clienteOAuthUseCase.Revogar(middlewares.EstabelecimentoID(c), c.Param("id"))
+	if err != nil {
+		h.erroClienteOAuth(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, cliente)
+}
+
+// Token emite um token de acesso com o grant client_credentials (RFC 6749, seção 4.4). Fica
+// fora de /api, em /oauth/token, recebe o corpo form-urlencoded e responde o JSON do padrão
+// OAuth2, sem o envelope das demais rotas. As credenciais do cliente são enviadas em HTTP
+// Basic ou nos parâmetros client_id e client_secret.
+func (h *ClienteOAuthHandler) Token(c *gin.Context) {
+	// Os tokens e os erros do endpoint de token não podem ser guardados em cache
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req models.TokenOAuthRequest
+	if err := c.ShouldBindWith(&req, binding.FormPost); err != nil {
+		h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, err.Error())
+		return
+	}
+
+	if !h.credenciaisCliente(c, &req.ClientID, &req.ClientSecret) {
+		return
+	}
+
+	token, err := h.clienteOAuthUseCase.EmitirToken(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrRequisicaoOAuthInvalida):
+			h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, err.Error())
+		case errors.Is(err, usecases.ErrGrantOAuthNaoSuportado):
+			h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthGrantNaoSuportado, err.Error())
+		case errors.Is(err, services.ErrEscopoOAuthInvalido):
+			h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthEscopoInvalido, err.Error())
+		default:
+			h.erroAutenticacaoCliente(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// Introspect informa se um token de acesso de cliente OAuth2 está ativo (RFC 7662). Fica fora
+// de /api, em /oauth/introspect, e exige as credenciais de um cliente do mesmo estabelecimento
+// do token, como o endpoint de token.
+func (h *ClienteOAuthHandler) Introspect(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req models.IntrospeccaoOAuthRequest
+	if err := c.ShouldBindWith(&req, binding.FormPost); err != nil {
+		h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, err.Error())
+		return
+	}
+	if req.Token == "" {
+		h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, "token é obrigatório")
+		return
+	}
+
+	if !h.credenciaisCliente(c, &req.ClientID, &req.ClientSecret) {
+		return
+	}
+
+	introspeccao, err := h.clienteOAuthUseCase.Introspectar(c.Request.Context(), req.ClientID, req.ClientSecret, req.Token)
+	if err != nil {
+		h.erroAutenticacaoCliente(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, introspeccao)
+}
+
+// credenciaisCliente lê as credenciais do cliente do header Authorization (HTTP Basic), cujos
+// valores são codificados como form-urlencoded (RFC 6749, seção 2.3.1), e as preenche no lugar
+// das recebidas no corpo. Enviar as credenciais das duas formas é um erro.
+func (h *ClienteOAuthHandler) credenciaisCliente(c *gin.Context, clientID, clientSecret *string) bool {
+	usuario, senha, basic := c.Request.BasicAuth()
+	if !basic {
+		return true
+	}
+
+	if *clientID != "" || *clientSecret != "" {
+		h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, "envie as credenciais do cliente em HTTP Basic ou no corpo, não nos dois")
+		return false
+	}
+
+	var err error
+	if *clientID, err = url.QueryUnescape(usuario); err == nil {
+		*clientSecret, err = url.QueryUnescape(senha)
+	}
+	if err != nil {
+		h.erroOAuth(c, http.StatusBadRequest, models.ErroOAuthRequisicaoInvalida, "credenciais do cliente mal codificadas")
+		return false
+	}
+
+	return true
+}
+
+// erroAutenticacaoCliente converte os erros da autenticação do cliente nas respostas OAuth2.
+// O cliente que usou HTTP Basic recebe o desafio no header WWW-Authenticate.
+func (h *ClienteOAuthHandler) erroAutenticacaoCliente(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrClienteOAuthInvalido) || errors.Is(err, services.ErrContaDesativada) || errors.Is(err, services.ErrTokenRevogado) {
+		if _, _, basic := c.Request.BasicAuth(); basic {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		h.erroOAuth(c, http.StatusUnauthorized, models.ErroOAuthClienteInvalido, err.Error())
+		return
+	}
+
+	h.erroOAuth(c, http.StatusInternalServerError, models.ErroOAuthServidor, err.Error())
+}
+
+// erroOAuth responde um erro no formato do padrão OAuth2 (RFC 6749, seção 5.2)
+func (h *ClienteOAuthHandler) erroOAuth(c *gin.Context, status int, codigo, descricao string) {
+	c.JSON(status, models.ErroOAuthResponse{Error: codigo, ErrorDescription: descricao})
+}
+
+// erroClienteOAuth converte os erros da gestão dos clientes OAuth2 em respostas HTTP
+func (h *ClienteOAuthHandler) erroClienteOAuth(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	if errors.Is(err, repositories.ErrClienteOAuthNaoEncontrado) {
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// CobVHandler manipula as requisições da API relacionadas às cobranças com vencimento
+type CobVHandler struct {
+	cobvUseCase  *usecases.CobVUseCase
+	responseView *views.ResponseView
+}
+
+// NewCobVHandler cria uma nova instância do handler de cobranças com vencimento
+func NewCobVHandler(cobvUseCase *usecases.CobVUseCase) *CobVHandler {
+	return &CobVHandler{
+		cobvUseCase:  cobvUseCase,
+		responseView: views.NewResponseView(),
+	}
+}
+
+// GenerateCobV processa a requisição para gerar uma cobrança com vencimento
+// @Summary      Gerar cobrança com vencimento
+// @Description  Gera um PIX dinâmico com vencimento, juros, multa, descontos e abatimento, retornando o valor a pagar hoje
+// @Tags         cobv
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CobVRequest  true  "Dados da cobrança com vencimento"
+// @Success      200      {object}  views.Response{data=models.CobVResponse}  "Cobrança gerada com sucesso"
+// @Failure      400      {object}  views.Response     "Erro de requisição"
+// @Failure      401      {object}  views.Response     "Não autorizado"
+// @Failure      409      {object}  views.Response     "txid já utilizado"
+// @Failure      422      {object}  views.Response     "Lista de campos inválidos para a cobrança"
+// @Failure      500      {object}  views.Response     "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /cobv [post]
+func (h *CobVHandler) GenerateCobV(c *gin.Context) {
+	var req models.CobVRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Executar o caso de uso
+	response, err := h.cobvUseCase.Execute(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+			h.responseView.ValidationError(c, errosValidacao...)
+			return
+		}
+		if errors.Is(err, services.ErrLocationInvalida) {
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, repositories.ErrTxidDuplicado) {
+			h.responseView.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, response)
+}
+
+// GetCobV consulta uma cobrança com vencimento e o valor a pagar em uma data
+// @Summary      Consultar cobrança com vencimento
+// @Description  Retorna a cobrança e o valor a pagar na data informada (padrão: hoje), com o detalhamento de juros, multa, desconto e abatimento
+// @Tags         cobv
+// @Produce      json
+// @Param        txid  path      string  true   "Identificador da transação"
+// @Param        data  query     string  false  "Data de pagamento (AAAA-MM-DD, padrão hoje)"
+// @Success      200   {object}  views.Response{data=models.CobVResponse}  "Valor a pagar na data"
+// @Failure      400   {object}  views.Response  "Data inválida"
+// @Failure      401   {object}  views.Response  "Não autorizado"
+// @Failure      404   {object}  views.Response  "Cobrança não encontrada"
+// @Failure      422   {object}  views.Response  "Cobrança expirada ou valor a pagar acima do máximo na data informada"
+// @Failure      500   {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /cobv/{txid} [get]
+func (h *CobVHandler) GetCobV(c *gin.Context) {
+	txid := c.Param("txid")
+
+	data := time.Now()
+	if parametro := c.Query("data"); parametro != "" {
+		var err error
+		data, err = time.Parse(models.FormatoData, parametro)
+		if err != nil {
+			h.responseView.Error(c, http.StatusBadRequest, "Data deve estar no formato AAAA-MM-DD")
+			return
+		}
+	}
+
+	response, err := h.cobvUseCase.Consultar(middlewares.EstabelecimentoID(c), txid, data)
+	if err != nil {
+		if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+			h.responseView.ValidationError(c, errosValidacao...)
+			return
+		}
+
+		switch {
+		case errors.Is(err, repositories.ErrCobVNaoEncontrada):
+			h.responseView.Error(c, http.StatusNotFound, "Cobrança não encontrada")
+		case errors.Is(err, services.ErrCobVExpirada):
+			h.responseView.Error(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, response)
+}
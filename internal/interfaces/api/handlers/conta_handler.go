@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// ContaHandler manipula as requisições da API relacionadas à verificação do email e à
+// redefinição de senha
+type ContaHandler struct {
+	contaUseCase *usecases.ContaUseCase
+	responseView *views.ResponseView
+}
+
+// NewContaHandler cria uma nova instância do handler de conta
+func NewContaHandler(contaUseCase *usecases.ContaUseCase) *ContaHandler {
+	return &ContaHandler{
+		contaUseCase: contaUseCase,
+		responseView: views.NewResponseView(),
+	}
+}
+
+// ForgotSenha processa a solicitação de redefinição de senha
+// @Summary      Esqueci a senha
+// @Description  Envia ao email informado um link de uso único, válido por 1 hora, para redefinir a senha do estabelecimento ou do usuário da equipe. A resposta é a mesma para emails não cadastrados.
+// @Tags         conta
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.EsqueciSenhaRequest  true  "Email do login"
+// @Success      202      {object}  views.Response  "Solicitação recebida"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /senha/esqueci [post]
+func (h *ContaHandler) ForgotSenha(c *gin.Context) {
+	var req models.EsqueciSenhaRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.contaUseCase.EsqueciSenha(c.Request.Context(), req); err != nil {
+		h.erroConta(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusAccepted, nil)
+}
+
+// ResetSenha processa a redefinição de senha com o token recebido por email
+// @Summary      Redefinir senha
+// @Description  Define a nova senha com o token recebido por email e encerra as sessões abertas do login. O token vale para um único uso.
+// @Tags         conta
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.RedefinirSenhaRequest  true  "Token e nova senha"
+// @Success      200      {object}  views.Response  "Senha redefinida"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados ou token inválido"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /senha/redefinir [post]
+func (h *ContaHandler) ResetSenha(c *gin.Context) {
+	var req models.RedefinirSenhaRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.contaUseCase.RedefinirSenha(req); err != nil {
+		h.erroConta(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, nil)
+}
+
+// VerifyEmail processa a confirmação do email com o token recebido por email
+// @Summary      Verificar email
+// @Description  Confirma o email do estabelecimento com o token recebido por email. O token vale para um único uso.
+// @Tags         conta
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.VerificarEmailRequest  true  "Token de verificação"
+// @Success      200      {object}  views.Response{data=models.EstabelecimentoResponse}  "Email confirmado"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados ou token inválido"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /email/verificar [post]
+func (h *ContaHandler) VerifyEmail(c *gin.Context) {
+	var req models.VerificarEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	estabelecimento, err := h.contaUseCase.VerificarEmail(req)
+	if err != nil {
+		h.erroConta(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, estabelecimento)
+}
+
+// ResendVerificacao processa o reenvio do link de confirmação do email
+// @Summary      Reenviar verificação de email
+// @Description  Reenvia o link de confirmação, válido por 24 horas, ao estabelecimento com o email informado e invalida os links anteriores. A resposta é a mesma para emails não cadastrados ou já confirmados.
+// @Tags         conta
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ReenviarVerificacaoRequest  true  "Email do estabelecimento"
+// @Success      202      {object}  views.Response  "Solicitação recebida"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /email/verificacao [post]
+func (h *ContaHandler) ResendVerificacao(c *gin.Context) {
+	var req models.ReenviarVerificacaoRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.contaUseCase.ReenviarVerificacao(c.Request.Context(), req); err != nil {
+		h.erroConta(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusAccepted, nil)
+}
+
+// erroConta converte os erros da verificação de email e da redefinição de senha em respostas HTTP
+func (h *ContaHandler) erroConta(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrTokenContaInvalido):
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
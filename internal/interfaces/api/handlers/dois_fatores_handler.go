@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// DoisFatoresHandler manipula as requisições da API relacionadas ao segundo fator (TOTP) dos logins
+type DoisFatoresHandler struct {
+	doisFatoresUseCase *usecases.DoisFatoresUseCase
+	responseView       *views.ResponseView
+}
+
+// NewDoisFatoresHandler cria uma nova instância do handler do segundo fator
+func NewDoisFatoresHandler(doisFatoresUseCase *usecases.DoisFatoresUseCase) *DoisFatoresHandler {
+	return &DoisFatoresHandler{
+		doisFatoresUseCase: doisFatoresUseCase,
+		responseView:       views.NewResponseView(),
+	}
+}
+
+// GetStatus retorna a situação do segundo fator do login autenticado
+// @Summary      Situação do segundo fator
+// @Description  Informa se o segundo fator está ativo no login, se o estabelecimento o exige e quantos códigos de recuperação restam
+// @Tags         dois-fatores
+// @Produce      json
+// @Success      200  {object}  views.Response{data=models.StatusDoisFatoresResponse}  "Situação do segundo fator"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores [get]
+func (h *DoisFatoresHandler) GetStatus(c *gin.Context) {
+	status, err := h.doisFatoresUseCase.Status(middlewares.EstabelecimentoID(c), middlewares.UsuarioEquipeID(c))
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, status)
+}
+
+// Enroll inicia o cadastro do segundo fator do login autenticado
+// @Summary      Cadastrar segundo fator
+// @Description  Gera o segredo TOTP do login, com a URI otpauth:// e o QR code para o aplicativo autenticador. O segundo fator só passa a ser exigido depois de confirmado em /dois-fatores/confirmar; um cadastro pendente anterior é substituído.
+// @Tags         dois-fatores
+// @Produce      json
+// @Success      201  {object}  views.Response{data=models.CadastroDoisFatoresResponse}  "Segredo gerado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      409  {object}  views.Response  "Segundo fator já ativo"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores/cadastro [post]
+func (h *DoisFatoresHandler) Enroll(c *gin.Context) {
+	cadastro, err := h.doisFatoresUseCase.IniciarCadastro(middlewares.EstabelecimentoID(c), middlewares.UsuarioEquipeID(c))
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, cadastro)
+}
+
+// Confirm confirma o cadastro do segundo fator do login autenticado
+// @Summary      Confirmar segundo fator
+// @Description  Ativa o segundo fator com um código do aplicativo e retorna os códigos de recuperação, exibidos apenas nesta resposta
+// @Tags         dois-fatores
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CodigoDoisFatoresRequest  true  "Código do aplicativo"
+// @Success      200      {object}  views.Response{data=models.CodigosRecuperacaoResponse}  "Segundo fator ativado"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados, código inválido ou cadastro não iniciado"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      409      {object}  views.Response  "Segundo fator já ativo"
+// @Failure      429      {object}  views.Response  "Muitos códigos incorretos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores/confirmar [post]
+func (h *DoisFatoresHandler) Confirm(c *gin.Context) {
+	var req models.CodigoDoisFatoresRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codigos, err := h.doisFatoresUseCase.Confirmar(c.Request.Context(), middlewares.EstabelecimentoID(c), middlewares.UsuarioEquipeID(c), req)
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, codigos)
+}
+
+// RegenerateCodigos gera novos códigos de recuperação para o login autenticado
+// @Summary      Gerar novos códigos de recuperação
+// @Description  Substitui todos os códigos de recuperação do login, confirmando com um código do aplicativo. Os novos códigos são exibidos apenas nesta resposta.
+// @Tags         dois-fatores
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CodigoDoisFatoresRequest  true  "Código do aplicativo"
+// @Success      200      {object}  views.Response{data=models.CodigosRecuperacaoResponse}  "Novos códigos de recuperação"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados, código inválido ou segundo fator não cadastrado"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Rota disponível apenas com login"
+// @Failure      429      {object}  views.Response  "Muitos códigos incorretos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores/codigos-recuperacao [post]
+func (h *DoisFatoresHandler) RegenerateCodigos(c *gin.Context) {
+	var req models.CodigoDoisFatoresRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codigos, err := h.doisFatoresUseCase.RegenerarCodigosRecuperacao(c.Request.Context(), middlewares.EstabelecimentoID(c), middlewares.UsuarioEquipeID(c), req)
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, codigos)
+}
+
+// Disable desativa o segundo fator do login autenticado
+// @Summary      Desativar segundo fator
+// @Description  Remove o segundo fator e os códigos de recuperação do login, confirmando com a senha e um código do aplicativo ou de recuperação. Indisponível quando o estabelecimento exige o segundo fator.
+// @Tags         dois-fatores
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.DesativarDoisFatoresRequest  true  "Senha e código"
+// @Success      200      {object}  views.Response  "Segundo fator desativado"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados, código inválido ou segundo fator não cadastrado"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Segundo fator exigido pelo estabelecimento ou rota disponível apenas com login"
+// @Failure      422      {object}  views.Response  "Senha incorreta"
+// @Failure      429      {object}  views.Response  "Muitos códigos incorretos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores/desativar [post]
+func (h *DoisFatoresHandler) Disable(c *gin.Context) {
+	var req models.DesativarDoisFatoresRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.doisFatoresUseCase.Desativar(c.Request.Context(), middlewares.EstabelecimentoID(c), middlewares.UsuarioEquipeID(c), req); err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, nil)
+}
+
+// UpdatePolitica define se o estabelecimento exige o segundo fator
+// @Summary      Exigir segundo fator
+// @Description  Define se o segundo fator é exigido no login do estabelecimento e dos usuários da equipe. Os logins sem o segundo fator passam a cadastrá-lo no próximo login, e ele não pode ser desativado enquanto for exigido.
+// @Tags         dois-fatores
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.PoliticaDoisFatoresRequest  true  "Exigência do segundo fator"
+// @Success      200      {object}  views.Response{data=models.StatusDoisFatoresResponse}  "Exigência atualizada"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Papel sem permissão"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /dois-fatores/politica [put]
+func (h *DoisFatoresHandler) UpdatePolitica(c *gin.Context) {
+	var req models.PoliticaDoisFatoresRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status, err := h.doisFatoresUseCase.AlterarPolitica(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, status)
+}
+
+// EnrollDesafio inicia, durante o login, o cadastro do segundo fator exigido pelo estabelecimento
+// @Summary      Cadastrar segundo fator no login
+// @Description  Com o desafio do login que indica cadastro_obrigatorio, gera o segredo TOTP do login. O cadastro é confirmado com o primeiro código em /login/dois-fatores, que conclui o login e retorna os códigos de recuperação.
+// @Tags         autenticacao
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CadastroDesafioRequest  true  "Desafio do login"
+// @Success      201      {object}  views.Response{data=models.CadastroDoisFatoresResponse}  "Segredo gerado"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Desafio inválido ou expirado"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /login/dois-fatores/cadastro [post]
+func (h *DoisFatoresHandler) EnrollDesafio(c *gin.Context) {
+	var req models.CadastroDesafioRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cadastro, err := h.doisFatoresUseCase.IniciarCadastroDesafio(c.Request.Context(), req)
+	if err != nil {
+		h.erroDoisFatores(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, cadastro)
+}
+
+// erroDoisFatores converte os erros do segundo fator em respostas HTTP
+func (h *DoisFatoresHandler) erroDoisFatores(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrCodigoDoisFatoresInvalido),
+		errors.Is(err, usecases.ErrDoisFatoresNaoCadastrado):
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecases.ErrDesafioDoisFatoresInvalido):
+		h.responseView.Error(c, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, usecases.ErrDoisFatoresObrigatorio):
+		h.responseView.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado),
+		errors.Is(err, repositories.ErrUsuarioNaoEncontrado):
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrDoisFatoresJaAtivo):
+		h.responseView.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, usecases.ErrMuitasTentativasDoisFatores):
+		h.responseView.Error(c, http.StatusTooManyRequests, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
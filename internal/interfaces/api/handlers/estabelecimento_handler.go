@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// EstabelecimentoHandler manipula as requisições da API relacionadas ao cadastro dos estabelecimentos
+type EstabelecimentoHandler struct {
+	estabelecimentoUseCase *usecases.EstabelecimentoUseCase
+	responseView           *views.ResponseView
+}
+
+// NewEstabelecimentoHandler cria uma nova instância do handler de estabelecimentos
+func NewEstabelecimentoHandler(estabelecimentoUseCase *usecases.EstabelecimentoUseCase) *EstabelecimentoHandler {
+	return &EstabelecimentoHandler{
+		estabelecimentoUseCase: estabelecimentoUseCase,
+		responseView:           views.NewResponseView(),
+	}
+}
+
+// GetMe retorna o cadastro do estabelecimento autenticado
+// @Summary      Consultar cadastro
+// @Description  Retorna o cadastro do estabelecimento autenticado
+// @Tags         estabelecimentos
+// @Produce      json
+// @Success      200  {object}  views.Response{data=models.EstabelecimentoResponse}  "Cadastro do estabelecimento"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos/me [get]
+func (h *EstabelecimentoHandler) GetMe(c *gin.Context) {
+	estabelecimento, err := h.estabelecimentoUseCase.Consultar(middlewares.EstabelecimentoID(c))
+	if err != nil {
+		h.erroEstabelecimento(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, estabelecimento)
+}
+
+// UpdateMe altera o cadastro e a senha do estabelecimento autenticado
+// @Summary      Atualizar cadastro
+// @Description  Altera o nome, a descrição, o email e, com nova_senha, a senha do estabelecimento autenticado. A senha atual é obrigatória, e uma nova senha encerra as sessões abertas.
+// @Tags         estabelecimentos
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.AtualizarEstabelecimentoRequest  true  "Dados do estabelecimento"
+// @Success      200      {object}  views.Response{data=models.EstabelecimentoResponse}  "Cadastro atualizado"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      409      {object}  views.Response  "Email já cadastrado"
+// @Failure      422      {object}  views.Response  "Senha atual incorreta"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos/me [put]
+func (h *EstabelecimentoHandler) UpdateMe(c *gin.Context) {
+	var req models.AtualizarEstabelecimentoRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	estabelecimento, err := h.estabelecimentoUseCase.Atualizar(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroEstabelecimento(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, estabelecimento)
+}
+
+// DeleteMe desativa a conta do estabelecimento autenticado
+// @Summary      Desativar conta
+// @Description  Desativa a conta do estabelecimento autenticado e revoga os tokens emitidos. A senha atual é obrigatória.
+// @Tags         estabelecimentos
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.DesativarEstabelecimentoRequest  true  "Confirmação da senha"
+// @Success      200      {object}  views.Response  "Conta desativada"
+// @Failure      400      {object}  views.Response  "Erro de validação dos dados"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      422      {object}  views.Response  "Senha atual incorreta"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos/me [delete]
+func (h *EstabelecimentoHandler) DeleteMe(c *gin.Context) {
+	var req models.DesativarEstabelecimentoRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.estabelecimentoUseCase.Desativar(middlewares.EstabelecimentoID(c), req); err != nil {
+		h.erroEstabelecimento(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, nil)
+}
+
+// ListEstabelecimentos lista todos os estabelecimentos
+// @Summary      Listar estabelecimentos
+// @Description  Lista todos os estabelecimentos, ativos e inativos. Restrito a administradores.
+// @Tags         estabelecimentos
+// @Produce      json
+// @Success      200  {object}  views.Response{data=[]models.EstabelecimentoResponse}  "Estabelecimentos"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Acesso restrito a administradores"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos [get]
+func (h *EstabelecimentoHandler) ListEstabelecimentos(c *gin.Context) {
+	estabelecimentos, err := h.estabelecimentoUseCase.Listar()
+	if err != nil {
+		h.erroEstabelecimento(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, estabelecimentos)
+}
+
+// ActivateEstabelecimento ativa um estabelecimento
+// @Summary      Ativar estabelecimento
+// @Description  Ativa um estabelecimento. Os tokens revogados na desativação continuam inválidos. Restrito a administradores.
+// @Tags         estabelecimentos
+// @Produce      json
+// @Param        id   path      string  true  "ID do estabelecimento"
+// @Success      200  {object}  views.Response{data=models.EstabelecimentoResponse}  "Estabelecimento ativado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Acesso restrito a administradores"
+// @Failure      404  {object}  views.Response  "Estabelecimento não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos/{id}/ativar [post]
+func (h *EstabelecimentoHandler) ActivateEstabelecimento(c *gin.Context) {
+	h.alterarAtivo(c, true)
+}
+
+// DeactivateEstabelecimento desativa um estabelecimento
+// @Summary      Desativar estabelecimento
+// @Description  Desativa um estabelecimento e invalida imediatamente os tokens já emitidos. Restrito a administradores.
+// @Tags         estabelecimentos
+// @Produce      json
+// @Param        id   path      string  true  "ID do estabelecimento"
+// @Success      200  {object}  views.Response{data=models.EstabelecimentoResponse}  "Estabelecimento desativado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Acesso restrito a administradores"
+// @Failure      404  {object}  views.Response  "Estabelecimento não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /estabelecimentos/{id}/desativar [post]
+func (h *EstabelecimentoHandler) DeactivateEstabelecimento(c *gin.Context) {
+	h.alterarAtivo(c, false)
+}
+
+// alterarAtivo ativa ou desativa o estabelecimento indicado na URL
+func (h *EstabelecimentoHandler) alterarAtivo(c *gin.Context, ativo bool) {
+	estabelecimento, err := h.estabelecimentoUseCase.AlterarAtivo(c.Param("id"), ativo)
+	if err != nil {
+		h.erroEstabelecimento(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, estabelecimento)
+}
+
+// erroEstabelecimento converte os erros do cadastro em respostas HTTP
+func (h *EstabelecimentoHandler) erroEstabelecimento(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	switch {
+	case errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado):
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrEmailJaCadastrado):
+		h.responseView.Error(c, http.StatusConflict, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
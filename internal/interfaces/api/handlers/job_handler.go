@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// JobHandler manipula as requisições da API relacionadas aos jobs em segundo plano
+type JobHandler struct {
+	jobUseCase   *usecases.JobUseCase
+	responseView *views.ResponseView
+}
+
+// NewJobHandler cria uma nova instância do handler de jobs
+func NewJobHandler(jobUseCase *usecases.JobUseCase) *JobHandler {
+	return &JobHandler{
+		jobUseCase:   jobUseCase,
+		responseView: views.NewResponseView(),
+	}
+}
+
+// CreateJob processa a requisição para criar um job de geração em lote
+// @Summary      Criar job de geração em lote
+// @Description  Registra um lote de até 5000 PIX para geração em segundo plano, com a mesma entrada de /generate/batch (array JSON, CSV no corpo ou campo "arquivo" em multipart/form-data). Acompanhe o progresso em /jobs/{id}
+// @Tags         jobs
+// @Accept       json
+// @Accept       text/csv
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        request   body      []models.PixRequest  false  "Lote em JSON"
+// @Param        arquivo   formData  file    false  "Lote em CSV, com cabeçalho (nome, chave, cidade, valor, identificador, descricao, mcc, cep, modalidade)"
+// @Param        format    query     string  false  "Formato do resultado (json ou zip, padrão é json)"
+// @Param        template  query     string  false  "Nome do template a ser aplicado às imagens do ZIP (ex: template_pix_1)"
+// @Success      202       {object}  views.Response{data=models.JobResponse}  "Job criado e colocado na fila"
+// @Failure      400       {object}  views.Response     "Lote vazio, muito grande ou em formato inválido"
+// @Failure      401       {object}  views.Response     "Não autorizado"
+// @Failure      500       {object}  views.Response     "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs [post]
+func (h *JobHandler) CreateJob(c *gin.Context) {
+	format := c.Query("format")
+	templateName := c.Query("template")
+
+	if format != "" && format != usecases.FormatoResultadoJSON && format != usecases.FormatoResultadoZIP {
+		h.responseView.Error(c, http.StatusBadRequest, "Formato deve ser json ou zip")
+		return
+	}
+
+	if templateName != "" && format != usecases.FormatoResultadoZIP {
+		h.responseView.Error(c, http.StatusBadRequest, "Templates estão disponíveis apenas para o formato zip")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, tamanhoMaximoCorpoLote)
+
+	itens, err := lerLote(c)
+	if err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Executar o caso de uso
+	job, err := h.jobUseCase.CriarLote(c.Request.Context(), middlewares.EstabelecimentoID(c), itens, format, templateName)
+	if err != nil {
+		if errors.Is(err, usecases.ErrLoteVazio) || errors.Is(err, usecases.ErrLoteMuitoGrande) || errors.Is(err, usecases.ErrTemplateNaoEncontrado) {
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.responseView.Success(c, http.StatusAccepted, novoJobResponse(job))
+}
+
+// GetJob consulta a situação de um job
+// @Summary      Consultar job
+// @Description  Retorna a situação e o progresso de um job
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "ID do job"
+// @Success      200  {object}  views.Response{data=models.JobResponse}  "Situação do job"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      404  {object}  views.Response  "Job não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	job, err := h.jobUseCase.Consultar(middlewares.EstabelecimentoID(c), c.Param("id"))
+	if err != nil {
+		h.erroJob(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, novoJobResponse(job))
+}
+
+// CancelJob cancela um job pendente ou em processamento
+// @Summary      Cancelar job
+// @Description  Cancela um job pendente ou em processamento. Um job em processamento para ao fim da etapa atual e os PIX já salvos são mantidos
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "ID do job"
+// @Success      200  {object}  views.Response{data=models.JobResponse}  "Job cancelado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      404  {object}  views.Response  "Job não encontrado"
+// @Failure      409  {object}  views.Response  "Job já finalizado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs/{id}/cancel [post]
+func (h *JobHandler) CancelJob(c *gin.Context) {
+	job, err := h.jobUseCase.Cancelar(middlewares.EstabelecimentoID(c), c.Param("id"))
+	if err != nil {
+		h.erroJob(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, novoJobResponse(job))
+}
+
+// DownloadJobResult faz o download do resultado de um job concluído
+// @Summary      Download do resultado do job
+// @Description  Retorna o resultado de um job concluído: o JSON com o resultado de cada linha ou o ZIP com os QR codes
+// @Tags         jobs
+// @Produce      json
+// @Produce      application/zip
+// @Param        id   path      string  true  "ID do job"
+// @Success      200  {file}    file    "Resultado do job"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      404  {object}  views.Response  "Job não encontrado"
+// @Failure      409  {object}  views.Response  "Job ainda não foi concluído"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /jobs/{id}/result [get]
+func (h *JobHandler) DownloadJobResult(c *gin.Context) {
+	id := c.Param("id")
+
+	dados, resultadoTipo, err := h.jobUseCase.Resultado(middlewares.EstabelecimentoID(c), id)
+	if err != nil {
+		h.erroJob(c, err)
+		return
+	}
+
+	extensao := ".json"
+	if resultadoTipo == "application/zip" {
+		extensao = ".zip"
+	}
+
+	h.responseView.Download(c, "pix_lote_"+id+extensao, resultadoTipo, dados)
+}
+
+// erroJob responde com o status correspondente a um erro dos casos de uso de jobs
+func (h *JobHandler) erroJob(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrJobNaoEncontrado):
+		h.responseView.Error(c, http.StatusNotFound, "Job não encontrado")
+	case errors.Is(err, usecases.ErrJobFinalizado), errors.Is(err, usecases.ErrJobSemResultado):
+		h.responseView.Error(c, http.StatusConflict, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// novoJobResponse converte um job para a resposta da API, calculando o progresso
+func novoJobResponse(job models.Job) models.JobResponse {
+	response := models.JobResponse{
+		ID:          job.ID,
+		Tipo:        job.Tipo,
+		Status:      job.Status,
+		Formato:     job.Formato,
+		Template:    job.Template,
+		Total:       job.Total,
+		Processados: job.Processados,
+		Sucessos:    job.Sucessos,
+		Falhas:      job.Falhas,
+		Erro:        job.Erro,
+		CriadoEm:    job.CriadoEm,
+		IniciadoEm:  job.IniciadoEm,
+		ConcluidoEm: job.ConcluidoEm,
+	}
+
+	if job.Total > 0 {
+		response.Progresso = job.Processados * 100 / job.Total
+	}
+
+	if job.Status == models.StatusJobConcluido {
+		response.ResultadoURL = "/api/jobs/" + job.ID + "/result"
+	}
+
+	return response
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// PerfilHandler manipula as requisições da API relacionadas ao perfil do estabelecimento
+type PerfilHandler struct {
+	perfilUseCase *usecases.PerfilUseCase
+	responseView  *views.ResponseView
+}
+
+// NewPerfilHandler cria uma nova instância do handler de perfil
+func NewPerfilHandler(perfilUseCase *usecases.PerfilUseCase) *PerfilHandler {
+	return &PerfilHandler{
+		perfilUseCase: perfilUseCase,
+		responseView:  views.NewResponseView(),
+	}
+}
+
+// GetPerfil retorna o perfil do estabelecimento autenticado
+// @Summary      Consultar perfil
+// @Description  Retorna a razão social, a cidade, o MCC, o CEP e as chaves PIX usados como padrão na geração
+// @Tags         perfil
+// @Produce      json
+// @Success      200  {object}  views.Response{data=models.PerfilEstabelecimento}  "Perfil do estabelecimento"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      404  {object}  views.Response  "Estabelecimento não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /perfil [get]
+func (h *PerfilHandler) GetPerfil(c *gin.Context) {
+	perfil, err := h.perfilUseCase.Consultar(middlewares.EstabelecimentoID(c))
+	if err != nil {
+		h.erroPerfil(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, perfil)
+}
+
+// UpdatePerfil substitui os dados do perfil do estabelecimento autenticado
+// @Summary      Atualizar perfil
+// @Description  Substitui a razão social, a cidade, o MCC e o CEP do perfil; campos omitidos são removidos. As chaves cadastradas não são alteradas.
+// @Tags         perfil
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.PerfilRequest  true  "Dados do perfil"
+// @Success      200      {object}  views.Response{data=models.PerfilEstabelecimento}  "Perfil atualizado"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /perfil [put]
+func (h *PerfilHandler) UpdatePerfil(c *gin.Context) {
+	var req models.PerfilRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	perfil, err := h.perfilUseCase.Atualizar(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroPerfil(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, perfil)
+}
+
+// CreateChave cadastra uma chave PIX no perfil do estabelecimento autenticado
+// @Summary      Cadastrar chave PIX
+// @Description  Cadastra uma chave PIX no perfil. A primeira chave cadastrada é a padrão; com padrao=true, a nova chave substitui a padrão atual.
+// @Tags         perfil
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ChaveCadastradaRequest  true  "Chave PIX"
+// @Success      201      {object}  views.Response{data=models.ChaveCadastrada}  "Chave cadastrada"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      409      {object}  views.Response  "Chave já cadastrada"
+// @Failure      422      {object}  views.Response  "Chave ou apelido inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /perfil/chaves [post]
+func (h *PerfilHandler) CreateChave(c *gin.Context) {
+	var req models.ChaveCadastradaRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chave, err := h.perfilUseCase.CadastrarChave(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		h.erroPerfil(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, chave)
+}
+
+// UpdateChave altera o apelido de uma chave cadastrada ou a torna a chave padrão
+// @Summary      Atualizar chave PIX
+// @Description  Altera o apelido de uma chave cadastrada; com padrao=true, a chave passa a ser a padrão do perfil
+// @Tags         perfil
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "ID da chave"
+// @Param        request  body      models.AtualizarChaveRequest  true  "Alterações da chave"
+// @Success      200      {object}  views.Response{data=models.PerfilEstabelecimento}  "Perfil atualizado"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      404      {object}  views.Response  "Chave não encontrada"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /perfil/chaves/{id} [put]
+func (h *PerfilHandler) UpdateChave(c *gin.Context) {
+	id, ok := h.idChave(c)
+	if !ok {
+		return
+	}
+
+	var req models.AtualizarChaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	perfil, err := h.perfilUseCase.AtualizarChave(middlewares.EstabelecimentoID(c), id, req)
+	if err != nil {
+		h.erroPerfil(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, perfil)
+}
+
+// DeleteChave remove uma chave do perfil do estabelecimento autenticado
+// @Summary      Excluir chave PIX
+// @Description  Remove uma chave do perfil. Se era a padrão, a chave cadastrada há mais tempo passa a ser a padrão.
+// @Tags         perfil
+// @Produce      json
+// @Param        id   path      int  true  "ID da chave"
+// @Success      200  {object}  views.Response  "Chave excluída"
+// @Failure      400  {object}  views.Response  "ID inválido"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      404  {object}  views.Response  "Chave não encontrada"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /perfil/chaves/{id} [delete]
+func (h *PerfilHandler) DeleteChave(c *gin.Context) {
+	id, ok := h.idChave(c)
+	if !ok {
+		return
+	}
+
+	if err := h.perfilUseCase.ExcluirChave(middlewares.EstabelecimentoID(c), id); err != nil {
+		h.erroPerfil(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, nil)
+}
+
+// idChave lê o ID da chave da URL, respondendo 400 quando inválido
+func (h *PerfilHandler) idChave(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		h.responseView.Error(c, http.StatusBadRequest, "ID da chave inválido")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// erroPerfil converte os erros do perfil em respostas HTTP
+func (h *PerfilHandler) erroPerfil(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	switch {
+	case errors.Is(err, repositories.ErrChaveNaoEncontrada), errors.Is(err, repositories.ErrEstabelecimentoNaoEncontrado):
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, repositories.ErrChaveDuplicada):
+		h.responseView.Error(c, http.StatusConflict, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
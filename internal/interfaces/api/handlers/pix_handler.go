@@ -3,16 +3,21 @@ package handlers
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/cache"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
 )
 
@@ -22,28 +27,37 @@ type CachedPix struct {
 	PngData []byte     `json:"png_data,omitempty"` // Dados binários do PNG já decodificados
 }
 
+// tamanhoMaximoCorpoLote limita o corpo da requisição de geração em lote (10 MB)
+const tamanhoMaximoCorpoLote = 10 << 20
+
 // PixHandler manipula as requisições da API relacionadas ao PIX
 type PixHandler struct {
 	generatePixUseCase *usecases.GeneratePixUseCase
+	listPixUseCase     *usecases.ListPixUseCase
 	pixRepository      repositories.PixRepository
 	responseView       *views.ResponseView
 	cacheAdapter       cache.CacheAdapter
 	templateProcessor  *services.TemplateProcessor // Novo campo
+	pixService         *services.PixGeneratorService
 }
 
 // NewPixHandler cria uma nova instância do handler PIX
 func NewPixHandler(
 	generatePixUseCase *usecases.GeneratePixUseCase,
+	listPixUseCase *usecases.ListPixUseCase,
 	pixRepository repositories.PixRepository,
 	cacheAdapter cache.CacheAdapter,
 	templateProcessor *services.TemplateProcessor,
+	pixService *services.PixGeneratorService,
 ) *PixHandler {
 	return &PixHandler{
 		generatePixUseCase: generatePixUseCase,
+		listPixUseCase:     listPixUseCase,
 		pixRepository:      pixRepository,
 		responseView:       views.NewResponseView(),
 		cacheAdapter:       cacheAdapter,
 		templateProcessor:  templateProcessor,
+		pixService:         pixService,
 	}
 }
 
@@ -57,8 +71,10 @@ func NewPixHandler(
 // @Success      200      {object}  views.Response{data=models.PixResponse}  "Código PIX gerado com sucesso"
 // @Failure      400      {object}  views.Response     "Erro de requisição"
 // @Failure      401      {object}  views.Response     "Não autorizado"
+// @Failure      422      {object}  views.Response     "Lista de campos inválidos para o BR Code"
 // @Failure      500      {object}  views.Response     "Erro interno do servidor"
 // @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /generate [post]
 func (h *PixHandler) GeneratePix(c *gin.Context) {
 	var req models.PixRequest
@@ -68,79 +84,364 @@ func (h *PixHandler) GeneratePix(c *gin.Context) {
 		return
 	}
 
-	// Validações básicas
-	if req.Nome == "" {
-		h.responseView.Error(c, http.StatusBadRequest, "Nome é obrigatório")
+	// Executar o caso de uso; nome, chave e cidade omitidos vêm do perfil do estabelecimento
+	response, err := h.generatePixUseCase.Execute(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+			h.responseView.ValidationError(c, errosValidacao...)
+			return
+		}
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, response)
+}
+
+// GenerateCob processa a requisição para gerar uma cobrança imediata (PIX dinâmico)
+// @Summary      Gerar cobrança imediata
+// @Description  Gera um código PIX dinâmico de uso único que aponta para a URL de payload da cobrança no PSP
+// @Tags         pix
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CobRequest  true  "Dados da cobrança"
+// @Success      200      {object}  views.Response{data=models.PixResponse}  "Código PIX gerado com sucesso"
+// @Failure      400      {object}  views.Response     "Erro de requisição"
+// @Failure      401      {object}  views.Response     "Não autorizado"
+// @Failure      409      {object}  views.Response     "txid já utilizado"
+// @Failure      422      {object}  views.Response     "Lista de campos inválidos para o BR Code"
+// @Failure      500      {object}  views.Response     "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /cob [post]
+func (h *PixHandler) GenerateCob(c *gin.Context) {
+	var req models.CobRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if req.Chave == "" {
-		h.responseView.Error(c, http.StatusBadRequest, "Chave PIX é obrigatória")
+	// Executar o caso de uso
+	response, err := h.generatePixUseCase.ExecuteDinamico(middlewares.EstabelecimentoID(c), req)
+	if err != nil {
+		if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+			h.responseView.ValidationError(c, errosValidacao...)
+			return
+		}
+		if errors.Is(err, services.ErrLocationInvalida) {
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, repositories.ErrTxidDuplicado) {
+			h.responseView.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Definir cidade padrão se não fornecida
-	if req.Cidade == "" {
-		req.Cidade = "São Paulo"
+	h.responseView.Success(c, http.StatusOK, response)
+}
+
+// GenerateBatch processa a requisição para gerar um lote de códigos PIX
+// @Summary      Gerar lote de códigos PIX
+// @Description  Gera até 5000 PIX estáticos a partir de um array JSON ou de um CSV (corpo text/csv ou campo "arquivo" em multipart/form-data), retornando o resultado de cada linha. Com format=zip, retorna um ZIP com os QR codes em PNG nomeados pelo identificador e o arquivo resultado.json
+// @Tags         pix
+// @Accept       json
+// @Accept       text/csv
+// @Accept       multipart/form-data
+// @Produce      json
+// @Produce      application/zip
+// @Param        request   body      []models.PixRequest  false  "Lote em JSON"
+// @Param        arquivo   formData  file    false  "Lote em CSV, com cabeçalho (nome, chave, cidade, valor, identificador, descricao, mcc, cep, modalidade)"
+// @Param        format    query     string  false  "Formato de resposta (json ou zip, padrão é json)"
+// @Param        template  query     string  false  "Nome do template a ser aplicado às imagens do ZIP (ex: template_pix_1)"
+// @Success      200       {object}  views.Response{data=models.ResultadoLote}  "Resultado de cada linha do lote"
+// @Success      200       {file}    file    "ZIP com os QR codes e o resultado do lote"
+// @Failure      400       {object}  views.Response     "Lote vazio, muito grande ou em formato inválido"
+// @Failure      401       {object}  views.Response     "Não autorizado"
+// @Failure      500       {object}  views.Response     "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /generate/batch [post]
+func (h *PixHandler) GenerateBatch(c *gin.Context) {
+	format := c.Query("format")
+	templateName := c.Query("template")
+
+	if format != "" && format != "json" && format != "zip" {
+		h.responseView.Error(c, http.StatusBadRequest, "Formato deve ser json ou zip")
+		return
+	}
+
+	if templateName != "" && format != "zip" {
+		h.responseView.Error(c, http.StatusBadRequest, "Templates estão disponíveis apenas para o formato zip")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, tamanhoMaximoCorpoLote)
+
+	itens, err := lerLote(c)
+	if err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	// Executar o caso de uso
-	response, err := h.generatePixUseCase.Execute(req)
+	resultado, err := h.generatePixUseCase.ExecuteLote(middlewares.EstabelecimentoID(c), itens)
+	if err != nil {
+		if errors.Is(err, usecases.ErrLoteVazio) || errors.Is(err, usecases.ErrLoteMuitoGrande) {
+			h.responseView.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if format == "zip" {
+		h.enviarZipLote(c, resultado, templateName)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, resultado)
+}
+
+// lerLote lê as linhas do lote conforme o Content-Type: CSV no corpo, CSV enviado como
+// arquivo em um formulário ou array JSON. Cada linha é validada individualmente.
+func lerLote(c *gin.Context) ([]models.ItemLote, error) {
+	var itens []models.ItemLote
+
+	switch c.ContentType() {
+	case "multipart/form-data":
+		arquivo, err := c.FormFile("arquivo")
+		if err != nil {
+			return nil, errors.New("arquivo CSV é obrigatório no campo arquivo")
+		}
+
+		conteudo, err := arquivo.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer conteudo.Close()
+
+		if itens, err = services.LerLoteCSV(conteudo); err != nil {
+			return nil, err
+		}
+	case "text/csv":
+		var err error
+		if itens, err = services.LerLoteCSV(c.Request.Body); err != nil {
+			return nil, err
+		}
+	default:
+		var linhas []json.RawMessage
+		if err := json.NewDecoder(c.Request.Body).Decode(&linhas); err != nil {
+			return nil, errors.New("o corpo deve ser um array JSON de requisições: " + err.Error())
+		}
+
+		for i, linha := range linhas {
+			item := models.ItemLote{Linha: i + 1}
+			item.ErroLeitura = json.Unmarshal(linha, &item.Requisicao)
+			itens = append(itens, item)
+		}
+	}
+
+	for i := range itens {
+		if itens[i].ErroLeitura != nil {
+			continue
+		}
+
+		itens[i].ErroLeitura = binding.Validator.ValidateStruct(&itens[i].Requisicao)
+	}
+
+	return itens, nil
+}
+
+// enviarZipLote envia um ZIP com o QR code de cada linha gerada e o resultado do lote.
+// As imagens são montadas antes do envio, para que um erro ainda possa ser respondido em JSON.
+func (h *PixHandler) enviarZipLote(c *gin.Context, resultado models.ResultadoLote, templateName string) {
+	arquivos, err := services.MontarImagensLote(resultado, h.templateProcessor, templateName)
+	if err != nil {
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename=pix_lote.zip")
+	c.Header("Content-Type", "application/zip")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+
+	if err := services.EscreverZipLote(c.Writer, arquivos, resultado); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// ListPix lista o histórico de PIX gerados pelo estabelecimento
+// @Summary      Listar PIX gerados
+// @Description  Lista os PIX do estabelecimento com paginação por cursor, filtros e ordenação. Para a próxima página, repita a consulta com os mesmos filtros e o proximo_cursor retornado.
+// @Tags         pix
+// @Produce      json
+// @Param        criado_de      query     string  false  "Criados a partir de (AAAA-MM-DD ou RFC 3339)"
+// @Param        criado_ate     query     string  false  "Criados até, inclusive (AAAA-MM-DD ou RFC 3339)"
+// @Param        valor_min      query     string  false  "Valor mínimo"
+// @Param        valor_max      query     string  false  "Valor máximo"
+// @Param        identificador  query     string  false  "Identificador da transação"
+// @Param        chave          query     string  false  "Chave PIX do beneficiário"
+// @Param        status         query     string  false  "Situação: ATIVO ou EXPIRADO"
+// @Param        ordenar        query     string  false  "Campo de ordenação: criado_em ou valor (padrão criado_em)"
+// @Param        ordem          query     string  false  "Direção da ordenação: asc ou desc (padrão desc)"
+// @Param        limite         query     int     false  "Itens por página, de 1 a 200 (padrão 50)"
+// @Param        cursor         query     string  false  "Cursor da próxima página"
+// @Success      200            {object}  views.Response{data=models.PaginaPix}  "Página de PIX"
+// @Failure      400            {object}  views.Response  "Erro de requisição"
+// @Failure      401            {object}  views.Response  "Não autorizado"
+// @Failure      422            {object}  views.Response  "Lista de filtros inválidos"
+// @Failure      500            {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /pix [get]
+func (h *PixHandler) ListPix(c *gin.Context) {
+	var req models.ListarPixRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pagina, err := h.listPixUseCase.Execute(middlewares.EstabelecimentoID(c), req)
 	if err != nil {
+		if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+			h.responseView.ValidationError(c, errosValidacao...)
+			return
+		}
 		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.responseView.Success(c, http.StatusOK, pagina)
+}
+
+// DecodeBRCode processa a requisição para decodificar um BR Code
+// @Summary      Decodificar BR Code
+// @Description  Lê um código PIX "copia e cola", confere o CRC e retorna os campos estruturados com os problemas encontrados
+// @Tags         pix
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.DecodeRequest  true  "Código PIX a ser decodificado"
+// @Success      200      {object}  views.Response{data=models.BRCodeDecodificado}  "Resultado da leitura do BR Code"
+// @Failure      400      {object}  views.Response     "Erro de requisição"
+// @Failure      401      {object}  views.Response     "Não autorizado"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /decode [post]
+func (h *PixHandler) DecodeBRCode(c *gin.Context) {
+	var req models.DecodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// A leitura sempre produz um resultado; os problemas encontrados vêm em "erros"
+	response := h.pixService.DecodificarBRCode(req.CodigoPix)
+
 	h.responseView.Success(c, http.StatusOK, response)
 }
 
 // DownloadQRCode manipula o download do QR code
 // @Summary      Download QR Code
-// @Description  Faz o download de um QR code para o código PIX gerado, opcionalmente aplicando um template
+// @Description  Faz o download de um QR code para o código PIX gerado, opcionalmente aplicando um template e opções de renderização
 // @Tags         pix
 // @Produce      image/png
+// @Produce      image/svg+xml
 // @Produce      application/json
-// @Param        codigo_pix  query     string  true   "Código PIX gerado"
-// @Param        format      query     string  false  "Formato de resposta (json ou png, padrão é png)"
-// @Param        template    query     string  false  "Nome do template a ser aplicado (ex: template_pix_1)"
-// @Success      200         {file}    file    "QR Code em formato PNG"
-// @Success      200         {object}  views.Response{data=models.PixResponse}  "Detalhes do QR Code em JSON"
-// @Failure      400         {object}  views.Response  "Código PIX não fornecido"
-// @Failure      404         {object}  views.Response  "Código PIX não encontrado"
-// @Failure      500         {object}  views.Response  "Erro interno do servidor"
+// @Param        codigo_pix    query     string  true   "Código PIX gerado"
+// @Param        format        query     string  false  "Formato de resposta (json, png ou svg, padrão é png)"
+// @Param        template      query     string  false  "Nome do template a ser aplicado (ex: template_pix_1), apenas para PNG"
+// @Param        nivel         query     string  false  "Nível de correção de erro: L, M, Q ou H (padrão M)"
+// @Param        tamanho       query     int     false  "Largura do PNG em pixels, de 64 a 2048 (padrão 256)"
+// @Param        margem        query     int     false  "Zona de silêncio em módulos, de 0 a 16 (padrão 4)"
+// @Param        cor           query     string  false  "Cor dos módulos em hexadecimal (padrão 000000)"
+// @Param        fundo         query     string  false  "Cor de fundo em hexadecimal (padrão FFFFFF); vazio gera fundo transparente"
+// @Param        transparente  query     bool    false  "Gera o fundo transparente"
+// @Success      200           {file}    file    "QR Code em formato PNG ou SVG"
+// @Success      200           {object}  views.Response{data=models.PixResponse}  "Detalhes do QR Code em JSON"
+// @Failure      400           {object}  views.Response  "Código PIX não fornecido ou opções de renderização inválidas"
+// @Failure      401           {object}  views.Response  "Não autorizado"
+// @Failure      404           {object}  views.Response  "Código PIX não encontrado"
+// @Failure      500           {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /download-qrcode [get]
 func (h *PixHandler) DownloadQRCode(c *gin.Context) {
 	codigoPix := c.Query("codigo_pix")
 	templateName := c.Query("template")
+	format := c.Query("format")
 
 	if codigoPix == "" {
 		h.responseView.Error(c, http.StatusBadRequest, "Código PIX é obrigatório")
 		return
 	}
 
+	if format == "svg" && templateName != "" {
+		h.responseView.Error(c, http.StatusBadRequest, "Templates estão disponíveis apenas para o formato PNG")
+		return
+	}
+
+	// Ler as opções de renderização; sem opções, o PNG gravado na geração é reaproveitado
+	qrCodeReq, err := qrCodeRequestDaQuery(c)
+	if err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opcoes, err := services.NovasOpcoesQRCode(qrCodeReq)
+	if err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	ctx := context.Background()
 
-	// Verificar se os dados do QR code estão em cache
+	// Cada estabelecimento e cada combinação de opções e template é cacheada separadamente
+	estabelecimentoID := middlewares.EstabelecimentoID(c)
 	var cachedData CachedPix
-	cacheKey := "pix_qrcode:" + codigoPix
+	cacheKey := "pix_qrcode:" + estabelecimentoID + ":" + codigoPix
+	if qrCodeReq != nil {
+		cacheKey = cacheKey + ":" + opcoes.ChaveCache()
+	}
 	if templateName != "" {
 		cacheKey = cacheKey + ":" + templateName
 	}
 
 	// Tentar obter do cache
-	err := cache.GetObject(h.cacheAdapter, ctx, cacheKey, &cachedData)
+	err = cache.GetObject(h.cacheAdapter, ctx, cacheKey, &cachedData)
 
 	// Se não estiver em cache ou houver erro, buscar do banco de dados
 	if err != nil {
-		// Buscar o PIX do repositório
-		pix, err := h.pixRepository.FindByCodigoPix(codigoPix)
+		// Buscar o PIX do estabelecimento no repositório
+		pix, err := h.pixRepository.FindByCodigoPix(estabelecimentoID, codigoPix)
 		if err != nil {
-			h.responseView.Error(c, http.StatusNotFound, "Código PIX não encontrado")
+			if errors.Is(err, repositories.ErrPixNaoEncontrado) {
+				h.responseView.Error(c, http.StatusNotFound, "Código PIX não encontrado")
+				return
+			}
+			h.responseView.Error(c, http.StatusInternalServerError, "Erro ao buscar o PIX: "+err.Error())
 			return
 		}
 
+		// Renderizar o PNG novamente apenas quando alguma opção foi informada
+		qrPNG := pix.QRCodePNG
+		if qrCodeReq != nil {
+			qrPNG, err = h.pixService.GerarQRCodePNG(pix.CodigoPix, opcoes)
+			if err != nil {
+				h.responseView.Error(c, http.StatusInternalServerError, "Erro ao gerar QR code: "+err.Error())
+				return
+			}
+		}
+
 		// Processar dados PNG
-		base64Data := strings.TrimPrefix(pix.QRCodePNG, "data:image/png;base64,")
+		base64Data := strings.TrimPrefix(qrPNG, "data:image/png;base64,")
 		decodedData, err := base64.StdEncoding.DecodeString(base64Data)
 		if err != nil {
 			h.responseView.Error(c, http.StatusInternalServerError, "Erro ao decodificar QR code: "+err.Error())
@@ -157,20 +458,39 @@ func (h *PixHandler) DownloadQRCode(c *gin.Context) {
 		_ = cache.SetObject(h.cacheAdapter, ctx, cacheKey, cachedData, 24*time.Hour)
 	}
 
+	qrPNG := "data:image/png;base64," + base64.StdEncoding.EncodeToString(cachedData.PngData)
+
 	// Verificar formato solicitado
-	format := c.Query("format")
 	if format == "json" {
+		// O SVG é renderizado novamente para que registros antigos também recebam um QR Code válido
+		qrSVG, err := h.pixService.GerarQRCodeSVG(cachedData.Pix.CodigoPix, opcoes)
+		if err != nil {
+			h.responseView.Error(c, http.StatusInternalServerError, "Erro ao gerar QR code SVG: "+err.Error())
+			return
+		}
+
 		h.responseView.Success(c, http.StatusOK, gin.H{
 			"codigo_pix": cachedData.Pix.CodigoPix,
-			"qrcode_png": cachedData.Pix.QRCodePNG,
-			"qrcode_svg": cachedData.Pix.QRCodeSVG,
+			"qrcode_png": qrPNG,
+			"qrcode_svg": qrSVG,
 		})
 		return
 	}
 
+	if format == "svg" {
+		qrSVG, err := h.pixService.GerarQRCodeSVG(cachedData.Pix.CodigoPix, opcoes)
+		if err != nil {
+			h.responseView.Error(c, http.StatusInternalServerError, "Erro ao gerar QR code SVG: "+err.Error())
+			return
+		}
+
+		h.responseView.Download(c, "pix_qrcode.svg", "image/svg+xml", []byte(qrSVG))
+		return
+	}
+
 	// Se um template foi especificado, aplicá-lo
 	if templateName != "" {
-		templateImgData, err := h.templateProcessor.ApplyTemplate(cachedData.Pix.QRCodePNG, templateName)
+		templateImgData, err := h.templateProcessor.ApplyTemplate(qrPNG, templateName)
 		if err != nil {
 			h.responseView.Error(c, http.StatusInternalServerError, "Erro ao aplicar template: "+err.Error())
 			return
@@ -183,3 +503,68 @@ func (h *PixHandler) DownloadQRCode(c *gin.Context) {
 	// Para o download de dados binários, usamos o método Download da responseView
 	h.responseView.Download(c, "pix_qrcode.png", "image/png", cachedData.PngData)
 }
+
+// qrCodeRequestDaQuery lê as opções de renderização dos parâmetros da requisição,
+// retornando nil quando nenhuma foi informada
+func qrCodeRequestDaQuery(c *gin.Context) (*models.QRCodeRequest, error) {
+	var req models.QRCodeRequest
+	informado := false
+
+	if nivel := c.Query("nivel"); nivel != "" {
+		req.Nivel = nivel
+		informado = true
+	}
+
+	tamanho, err := inteiroDaQuery(c, "tamanho")
+	if err != nil {
+		return nil, err
+	}
+	margem, err := inteiroDaQuery(c, "margem")
+	if err != nil {
+		return nil, err
+	}
+	if tamanho != nil || margem != nil {
+		req.Tamanho, req.Margem = tamanho, margem
+		informado = true
+	}
+
+	if cor := c.Query("cor"); cor != "" {
+		req.Cor = cor
+		informado = true
+	}
+
+	// Por compatibilidade, fundo vazio também gera fundo transparente
+	if fundo, existe := c.GetQuery("fundo"); existe {
+		req.Fundo = fundo
+		req.Transparente = fundo == ""
+		informado = true
+	}
+
+	if transparente := c.Query("transparente"); transparente != "" {
+		valor, err := strconv.ParseBool(transparente)
+		if err != nil {
+			return nil, errors.New("transparente deve ser true ou false")
+		}
+		req.Transparente = req.Transparente || valor
+		informado = true
+	}
+
+	if !informado {
+		return nil, nil
+	}
+	return &req, nil
+}
+
+// inteiroDaQuery lê um parâmetro inteiro opcional da requisição
+func inteiroDaQuery(c *gin.Context, nome string) (*int, error) {
+	texto := c.Query(nome)
+	if texto == "" {
+		return nil, nil
+	}
+
+	valor, err := strconv.Atoi(texto)
+	if err != nil {
+		return nil, errors.New(nome + " deve ser um número inteiro")
+	}
+	return &valor, nil
+}
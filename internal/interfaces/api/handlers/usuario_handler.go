@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/application/usecases"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
+	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/repositories"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
+	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/views"
+)
+
+// UsuarioHandler manipula as requisições da API relacionadas aos usuários da equipe
+type UsuarioHandler struct {
+	usuarioUseCase *usecases.UsuarioUseCase
+	responseView   *views.ResponseView
+}
+
+// NewUsuarioHandler cria uma nova instância do handler de usuários
+func NewUsuarioHandler(usuarioUseCase *usecases.UsuarioUseCase) *UsuarioHandler {
+	return &UsuarioHandler{
+		usuarioUseCase: usuarioUseCase,
+		responseView:   views.NewResponseView(),
+	}
+}
+
+// ListUsuarios lista os usuários da equipe do estabelecimento autenticado
+// @Summary      Listar usuários
+// @Description  Lista os usuários da equipe do estabelecimento, inclusive os convites pendentes e os usuários desativados
+// @Tags         usuarios
+// @Produce      json
+// @Success      200  {object}  views.Response{data=[]models.Usuario}  "Usuários da equipe"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Papel sem permissão"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /usuarios [get]
+func (h *UsuarioHandler) ListUsuarios(c *gin.Context) {
+	usuarios, err := h.usuarioUseCase.Listar(middlewares.EstabelecimentoID(c))
+	if err != nil {
+		h.erroUsuario(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, usuarios)
+}
+
+// InviteUsuario convida um usuário para a equipe do estabelecimento autenticado
+// @Summary      Convidar usuário
+// @Description  Cria um usuário com o papel gerente, caixa ou leitura e retorna o convite, exibido apenas nesta resposta, com o qual ele define o nome e a senha. Gerentes convidam apenas caixas e usuários de leitura.
+// @Tags         usuarios
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ConvidarUsuarioRequest  true  "Email e papel do usuário"
+// @Success      201      {object}  views.Response{data=models.ConviteCriado}  "Convite criado"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Papel sem permissão"
+// @Failure      409      {object}  views.Response  "Email já cadastrado"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /usuarios/convites [post]
+func (h *UsuarioHandler) InviteUsuario(c *gin.Context) {
+	var req models.ConvidarUsuarioRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	convite, err := h.usuarioUseCase.Convidar(middlewares.EstabelecimentoID(c), middlewares.Papel(c), req)
+	if err != nil {
+		h.erroUsuario(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusCreated, convite)
+}
+
+// AcceptConvite aceita o convite para a equipe de um estabelecimento
+// @Summary      Aceitar convite
+// @Description  Define o nome e a senha do usuário convidado, que passa a fazer login em /login com o email do convite
+// @Tags         usuarios
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.AceitarConviteRequest  true  "Convite, nome e senha"
+// @Success      200      {object}  views.Response{data=models.Usuario}  "Convite aceito"
+// @Failure      400      {object}  views.Response  "Erro de requisição ou convite inválido"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Router       /usuarios/convites/aceitar [post]
+func (h *UsuarioHandler) AcceptConvite(c *gin.Context) {
+	var req models.AceitarConviteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usuario, err := h.usuarioUseCase.AceitarConvite(req)
+	if err != nil {
+		h.erroUsuario(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, usuario)
+}
+
+// UpdateUsuario altera o papel de um usuário da equipe
+// @Summary      Alterar papel
+// @Description  Altera o papel de um usuário da equipe, com efeito na próxima requisição dele. Gerentes alteram apenas caixas e usuários de leitura.
+// @Tags         usuarios
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "ID do usuário"
+// @Param        request  body      models.AlterarPapelRequest  true  "Novo papel"
+// @Success      200      {object}  views.Response{data=models.Usuario}  "Papel alterado"
+// @Failure      400      {object}  views.Response  "Erro de requisição"
+// @Failure      401      {object}  views.Response  "Não autorizado"
+// @Failure      403      {object}  views.Response  "Papel sem permissão"
+// @Failure      404      {object}  views.Response  "Usuário não encontrado"
+// @Failure      422      {object}  views.Response  "Lista de campos inválidos"
+// @Failure      500      {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /usuarios/{id} [put]
+func (h *UsuarioHandler) UpdateUsuario(c *gin.Context) {
+	var req models.AlterarPapelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	usuario, err := h.usuarioUseCase.AlterarPapel(middlewares.EstabelecimentoID(c), middlewares.Papel(c), c.Param("id"), req)
+	if err != nil {
+		h.erroUsuario(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, usuario)
+}
+
+// DeactivateUsuario desativa um usuário da equipe
+// @Summary      Desativar usuário
+// @Description  Desativa um usuário da equipe, revogando imediatamente os tokens emitidos e o convite pendente. Gerentes desativam apenas caixas e usuários de leitura.
+// @Tags         usuarios
+// @Produce      json
+// @Param        id   path      string  true  "ID do usuário"
+// @Success      200  {object}  views.Response{data=models.Usuario}  "Usuário desativado"
+// @Failure      401  {object}  views.Response  "Não autorizado"
+// @Failure      403  {object}  views.Response  "Papel sem permissão"
+// @Failure      404  {object}  views.Response  "Usuário não encontrado"
+// @Failure      500  {object}  views.Response  "Erro interno do servidor"
+// @Security     BearerAuth
+// @Router       /usuarios/{id} [delete]
+func (h *UsuarioHandler) DeactivateUsuario(c *gin.Context) {
+	usuario, err := h.usuarioUseCase.Desativar(middlewares.EstabelecimentoID(c), middlewares.Papel(c), c.Param("id"))
+	if err != nil {
+		h.erroUsuario(c, err)
+		return
+	}
+
+	h.responseView.Success(c, http.StatusOK, usuario)
+}
+
+// erroUsuario converte os erros da gestão de usuários em respostas HTTP
+func (h *UsuarioHandler) erroUsuario(c *gin.Context, err error) {
+	if errosValidacao, ok := models.ExtrairErrosValidacao(err); ok {
+		h.responseView.ValidationError(c, errosValidacao...)
+		return
+	}
+
+	switch {
+	case errors.Is(err, usecases.ErrConviteInvalido):
+		h.responseView.Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrPapelNaoPermitido):
+		h.responseView.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, repositories.ErrUsuarioNaoEncontrado):
+		h.responseView.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrEmailJaCadastrado):
+		h.responseView.Error(c, http.StatusConflict, err.Error())
+	default:
+		h.responseView.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
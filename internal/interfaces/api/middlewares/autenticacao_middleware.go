@@ -1,28 +1,80 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 	"github.com/rodrigocostarcs/pix-generator/internal/domain/services"
 )
 
+// VerificadorSessao confere, a cada requisição, se o token identificado por jti continua
+// dando acesso à API. O usuarioID é vazio no login do estabelecimento. Deve retornar
+// services.ErrContaDesativada ou services.ErrTokenRevogado quando o acesso foi encerrado.
+type VerificadorSessao interface {
+	VerificarSessao(ctx context.Context, estabelecimentoID, usuarioID, jti string, emitidoEm time.Time) (models.SessaoEstabelecimento, error)
+}
+
+// AutenticadorChaveAPI valida as chaves de API recebidas no header X-API-Key. Deve retornar
+// services.ErrChaveAPIInvalida, services.ErrContaDesativada ou services.ErrTokenRevogado
+// quando a chave não dá acesso à API.
+type AutenticadorChaveAPI interface {
+	Autenticar(ctx context.Context, chave string) (models.ChaveAPI, models.Estabelecimento, error)
+}
+
+// AutenticadorClienteOAuth confere, a cada requisição com um token de cliente OAuth2, se o
+// cliente identificado pela claim cid continua dando acesso à API. Deve retornar
+// services.ErrContaDesativada ou services.ErrTokenRevogado quando o acesso foi encerrado.
+type AutenticadorClienteOAuth interface {
+	VerificarAcesso(ctx context.Context, estabelecimentoID, clienteID string, emitidoEm time.Time) error
+}
+
+// Tipos de credencial aceitos por RequererAutenticacao
+const (
+	credencialJWT      = "jwt"
+	credencialChaveAPI = "chave_api"
+	credencialOAuth    = "oauth"
+)
+
 // AutenticacaoMiddleware estrutura do middleware de autenticação
 type AutenticacaoMiddleware struct {
-	autenticacaoService *services.AutenticacaoService
+	autenticacaoService  *services.AutenticacaoService
+	verificadorSessao    VerificadorSessao
+	autenticadorChaveAPI AutenticadorChaveAPI
+	autenticadorOAuth    AutenticadorClienteOAuth
 }
 
 // NewAutenticacaoMiddleware cria uma nova instância do middleware de autenticação
-func NewAutenticacaoMiddleware(autenticacaoService *services.AutenticacaoService) *AutenticacaoMiddleware {
+func NewAutenticacaoMiddleware(
+	autenticacaoService *services.AutenticacaoService,
+	verificadorSessao VerificadorSessao,
+	autenticadorChaveAPI AutenticadorChaveAPI,
+	autenticadorOAuth AutenticadorClienteOAuth,
+) *AutenticacaoMiddleware {
 	return &AutenticacaoMiddleware{
-		autenticacaoService: autenticacaoService,
+		autenticacaoService:  autenticacaoService,
+		verificadorSessao:    verificadorSessao,
+		autenticadorChaveAPI: autenticadorChaveAPI,
+		autenticadorOAuth:    autenticadorOAuth,
 	}
 }
 
-// RequererAutenticacao middleware que requer autenticação para acessar rotas protegidas
+// RequererAutenticacao middleware que requer autenticação para acessar rotas protegidas.
+// Aceita um token JWT no header Authorization, de login ou emitido a um cliente OAuth2, ou
+// uma chave de API no header X-API-Key.
 func (m *AutenticacaoMiddleware) RequererAutenticacao() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Integrações servidor a servidor se autenticam com a chave de API
+		if chave := c.GetHeader("X-API-Key"); chave != "" {
+			m.autenticarChaveAPI(c, chave)
+			return
+		}
+
 		// Obter o token do header Authorization
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -49,10 +101,178 @@ func (m *AutenticacaoMiddleware) RequererAutenticacao() gin.HandlerFunc {
 			return
 		}
 
+		// O ID do estabelecimento identifica o dono dos recursos acessados
+		id, _ := claims["id"].(string)
+		if id == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Token inválido: estabelecimento não informado"})
+			c.Abort()
+			return
+		}
+
+		// O jti identifica o token na lista de revogação do logout
+		jti, _ := claims["jti"].(string)
+		if jti == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Token inválido: identificador do token não informado"})
+			c.Abort()
+			return
+		}
+
+		// O uid identifica o usuário da equipe; é vazio no login do estabelecimento
+		usuarioEquipeID, _ := claims["uid"].(string)
+
+		var emitidoEm, expiraEm time.Time
+		if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+			emitidoEm = iat.Time
+		}
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			expiraEm = exp.Time
+		}
+
+		// Os tokens dos clientes OAuth2 trazem o cliente na claim cid e os escopos na claim scope
+		if clienteID, _ := claims["cid"].(string); clienteID != "" {
+			m.autenticarClienteOAuth(c, claims, clienteID, emitidoEm)
+			return
+		}
+
+		// O logout e a desativação do estabelecimento ou do usuário invalidam imediatamente os
+		// tokens já emitidos, e o papel vem da sessão para que as alterações valham na hora
+		sessao, err := m.verificadorSessao.VerificarSessao(c.Request.Context(), id, usuarioEquipeID, jti, emitidoEm)
+		if err != nil {
+			if errors.Is(err, services.ErrContaDesativada) || errors.Is(err, services.ErrTokenRevogado) {
+				c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Token inválido: " + err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Erro ao verificar a sessão: " + err.Error()})
+			}
+			c.Abort()
+			return
+		}
+
 		// Armazenar as claims no contexto para uso posterior
-		c.Set("usuarioID", claims["id"])
+		c.Set("usuarioID", id)
 		c.Set("usuarioEmail", claims["email"])
 		c.Set("usuarioNome", claims["nome"])
+		c.Set("usuarioAdmin", sessao.Admin)
+		c.Set("usuarioEquipeID", usuarioEquipeID)
+		c.Set("papel", sessao.Papel)
+		c.Set("tokenID", jti)
+		c.Set("tokenExpiraEm", expiraEm)
+		c.Set("credencial", credencialJWT)
+
+		c.Next()
+	}
+}
+
+// autenticarChaveAPI valida a chave de API e armazena no contexto a mesma identidade do
+// estabelecimento que o token JWT, com os escopos concedidos à chave
+func (m *AutenticacaoMiddleware) autenticarChaveAPI(c *gin.Context, chave string) {
+	chaveAPI, estabelecimento, err := m.autenticadorChaveAPI.Autenticar(c.Request.Context(), chave)
+	if err != nil {
+		if errors.Is(err, services.ErrChaveAPIInvalida) || errors.Is(err, services.ErrContaDesativada) || errors.Is(err, services.ErrTokenRevogado) {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Chave de API inválida: " + err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Erro ao verificar a chave de API: " + err.Error()})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("usuarioID", estabelecimento.ID)
+	c.Set("usuarioEmail", estabelecimento.Email)
+	c.Set("usuarioNome", estabelecimento.Nome)
+	c.Set("usuarioAdmin", false)
+	c.Set("chaveAPIID", chaveAPI.ID)
+	c.Set("escopos", chaveAPI.Escopos)
+	c.Set("credencial", credencialChaveAPI)
+
+	c.Next()
+}
+
+// autenticarClienteOAuth confere o cliente OAuth2 do token e armazena no contexto a mesma
+// identidade do estabelecimento que o login, com os escopos concedidos ao token
+func (m *AutenticacaoMiddleware) autenticarClienteOAuth(c *gin.Context, claims jwt.MapClaims, clienteID string, emitidoEm time.Time) {
+	// Um token de cliente sem escopos seria tratado como uma credencial com todos eles
+	escopo, _ := claims["scope"].(string)
+	escopos := strings.Fields(escopo)
+	if len(escopos) == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Token inválido: escopos não informados"})
+		c.Abort()
+		return
+	}
+
+	id, _ := claims["id"].(string)
+	if err := m.autenticadorOAuth.VerificarAcesso(c.Request.Context(), id, clienteID, emitidoEm); err != nil {
+		if errors.Is(err, services.ErrContaDesativada) || errors.Is(err, services.ErrTokenRevogado) {
+			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Token inválido: " + err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Erro ao verificar o cliente OAuth: " + err.Error()})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("usuarioID", id)
+	c.Set("usuarioEmail", claims["email"])
+	c.Set("usuarioNome", claims["nome"])
+	c.Set("usuarioAdmin", false)
+	c.Set("clienteOAuthID", clienteID)
+	c.Set("escopos", escopos)
+	c.Set("credencial", credencialOAuth)
+
+	c.Next()
+}
+
+// EstabelecimentoID retorna o ID do estabelecimento autenticado armazenado no contexto
+// por RequererAutenticacao, ou uma string vazia fora das rotas protegidas
+func EstabelecimentoID(c *gin.Context) string {
+	return c.GetString("usuarioID")
+}
+
+// UsuarioEquipeID retorna o ID do usuário da equipe autenticado, ou uma string vazia no login
+// do estabelecimento, nas chaves de API e nos tokens de clientes OAuth2
+func UsuarioEquipeID(c *gin.Context) string {
+	return c.GetString("usuarioEquipeID")
+}
+
+// Papel retorna o papel de quem fez login, ou uma string vazia nas chaves de API e nos tokens
+// de clientes OAuth2
+func Papel(c *gin.Context) string {
+	return c.GetString("papel")
+}
+
+// TokenID retorna o identificador (jti) do token da requisição autenticada
+func TokenID(c *gin.Context) string {
+	return c.GetString("tokenID")
+}
+
+// TokenExpiraEm retorna a expiração do token da requisição autenticada
+func TokenExpiraEm(c *gin.Context) time.Time {
+	return c.GetTime("tokenExpiraEm")
+}
+
+// RequererAdmin middleware que restringe a rota aos estabelecimentos administradores.
+// Deve ser usado após RequererAutenticacao.
+func (m *AutenticacaoMiddleware) RequererAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("usuarioAdmin") {
+			c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Acesso restrito a administradores"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequererLogin middleware que restringe a rota às sessões de login, recusando chaves de API e
+// tokens de clientes OAuth2. Protege a gestão da conta e das próprias credenciais contra uma
+// credencial vazada.
+func (m *AutenticacaoMiddleware) RequererLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("credencial") != credencialJWT {
+			c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Rota disponível apenas com login, não com chave de API ou cliente OAuth"})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
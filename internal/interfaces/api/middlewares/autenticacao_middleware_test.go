@@ -1,6 +1,7 @@
 package middlewares_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -15,6 +16,59 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// verificadorSessaoMemoria verifica as sessões e os tokens revogados a partir de mapas, com as
+// regras do serviço de autenticação. As sessões dos usuários da equipe usam a chave
+// "estabelecimento/usuario".
+type verificadorSessaoMemoria struct {
+	autenticacaoService *services.AutenticacaoService
+	sessoes             map[string]models.SessaoEstabelecimento
+	revogados           map[string]bool
+}
+
+func (v *verificadorSessaoMemoria) VerificarSessao(ctx context.Context, estabelecimentoID, usuarioID, jti string, emitidoEm time.Time) (models.SessaoEstabelecimento, error) {
+	if v.revogados[jti] {
+		return models.SessaoEstabelecimento{}, services.ErrTokenRevogado
+	}
+
+	chave := estabelecimentoID
+	if usuarioID != "" {
+		chave += "/" + usuarioID
+	}
+
+	sessao, existe := v.sessoes[chave]
+	if !existe {
+		return models.SessaoEstabelecimento{}, services.ErrTokenRevogado
+	}
+	return sessao, v.autenticacaoService.ValidarSessao(sessao, emitidoEm)
+}
+
+// autenticadorChaveAPIMemoria autentica as chaves de API a partir de um mapa
+type autenticadorChaveAPIMemoria struct {
+	chaves           map[string]models.ChaveAPI
+	estabelecimentos map[string]models.Estabelecimento
+}
+
+func (a *autenticadorChaveAPIMemoria) Autenticar(ctx context.Context, chave string) (models.ChaveAPI, models.Estabelecimento, error) {
+	chaveAPI, existe := a.chaves[chave]
+	if !existe {
+		return models.ChaveAPI{}, models.Estabelecimento{}, services.ErrChaveAPIInvalida
+	}
+	return chaveAPI, a.estabelecimentos[chaveAPI.EstabelecimentoID], nil
+}
+
+// autenticadorClienteOAuthMemoria confere os clientes OAuth2 a partir de um mapa com o
+// estabelecimento dono de cada cliente ativo
+type autenticadorClienteOAuthMemoria struct {
+	clientes map[string]string
+}
+
+func (a *autenticadorClienteOAuthMemoria) VerificarAcesso(ctx context.Context, estabelecimentoID, clienteID string, emitidoEm time.Time) error {
+	if a.clientes[clienteID] != estabelecimentoID {
+		return services.ErrTokenRevogado
+	}
+	return nil
+}
+
 func TestAutenticacaoMiddleware(t *testing.T) {
 	// Configurar Gin para modo de teste
 	gin.SetMode(gin.TestMode)
@@ -25,8 +79,13 @@ func TestAutenticacaoMiddleware(t *testing.T) {
 	defer os.Unsetenv("JWT_SECRET")
 
 	// Inicializar serviço e middleware
-	authService := services.NewAutenticacaoService()
-	middleware := middlewares.NewAutenticacaoMiddleware(authService)
+	authService, err := services.NewAutenticacaoService()
+	assert.NoError(t, err)
+	verificador := &verificadorSessaoMemoria{autenticacaoService: authService, sessoes: map[string]models.SessaoEstabelecimento{}, revogados: map[string]bool{}}
+	autenticador := &autenticadorChaveAPIMemoria{chaves: map[string]models.ChaveAPI{}, estabelecimentos: map[string]models.Estabelecimento{}}
+	autenticadorOAuth := &autenticadorClienteOAuthMemoria{clientes: map[string]string{}}
+	middleware := middlewares.NewAutenticacaoMiddleware(authService, verificador, autenticador, autenticadorOAuth)
+	autorizacao := middlewares.NewAutorizacaoMiddleware()
 
 	// Criar um estabelecimento de teste
 	estabelecimento := models.Estabelecimento{
@@ -37,10 +96,17 @@ func TestAutenticacaoMiddleware(t *testing.T) {
 		CriadoEm:     time.Now(),
 		AtualizadoEm: time.Now(),
 	}
+	verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: true, Papel: models.PapelProprietario}
+	autenticador.estabelecimentos[estabelecimento.ID] = estabelecimento
+	autenticador.chaves["pixk_chave_erp"] = models.ChaveAPI{
+		ID:                "chave-erp",
+		EstabelecimentoID: estabelecimento.ID,
+		Escopos:           []string{models.EscopoPixEscrita},
+	}
 
 	t.Run("TokenValido", func(t *testing.T) {
 		// Gerar token válido
-		token, err := authService.GerarToken(estabelecimento)
+		token, err := authService.GerarToken(estabelecimento, nil)
 		assert.NoError(t, err)
 
 		// Criar request com token
@@ -68,6 +134,10 @@ func TestAutenticacaoMiddleware(t *testing.T) {
 			assert.True(t, exists)
 			assert.Equal(t, estabelecimento.Nome, nome)
 
+			// Verificar se o jti e a expiração do token estão disponíveis para o logout
+			assert.NotEmpty(t, middlewares.TokenID(c))
+			assert.True(t, middlewares.TokenExpiraEm(c).After(time.Now()))
+
 			c.Status(http.StatusOK)
 		}
 
@@ -189,4 +259,398 @@ func TestAutenticacaoMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "Token inválido")
 	})
+
+	t.Run("TokenSemEstabelecimento", func(t *testing.T) {
+		// Criar token válido sem o ID do estabelecimento
+		claims := jwt.MapClaims{
+			"email": estabelecimento.Email,
+			"nome":  estabelecimento.Nome,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+tokenString)
+
+		// Executar middleware
+		mw := middleware.RequererAutenticacao()
+		mw(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "estabelecimento não informado")
+	})
+
+	t.Run("ContaDesativada", func(t *testing.T) {
+		token, err := authService.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+
+		// Desativar o estabelecimento depois da emissão do token
+		revogadoEm := time.Now().Add(time.Hour)
+		verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: false, TokensRevogadosEm: &revogadoEm}
+		defer func() {
+			verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: true, Papel: models.PapelProprietario}
+		}()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "conta desativada")
+
+		// Reativado, o estabelecimento continua sem acesso com os tokens anteriores à desativação
+		verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: true, TokensRevogadosEm: &revogadoEm}
+
+		w = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		middleware.RequererAutenticacao()(c)
+
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "token revogado")
+	})
+
+	t.Run("RequererAdmin", func(t *testing.T) {
+		token, err := authService.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+
+		for _, admin := range []bool{false, true} {
+			verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: true, Admin: admin, Papel: models.PapelProprietario}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("GET", "/api/estabelecimentos", nil)
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+
+			// Executar os middlewares em sequência
+			middleware.RequererAutenticacao()(c)
+			if !c.IsAborted() {
+				middleware.RequererAdmin()(c)
+			}
+
+			// Verificações: apenas administradores passam
+			assert.Equal(t, !admin, c.IsAborted())
+			if !admin {
+				assert.Equal(t, http.StatusForbidden, w.Code)
+			}
+		}
+		verificador.sessoes[estabelecimento.ID] = models.SessaoEstabelecimento{Ativo: true, Papel: models.PapelProprietario}
+	})
+
+	t.Run("TokenRevogadoNoLogout", func(t *testing.T) {
+		token, err := authService.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+
+		claims, err := authService.ValidarToken(token)
+		assert.NoError(t, err)
+		verificador.revogados[claims["jti"].(string)] = true
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "token revogado")
+	})
+
+	t.Run("TokenSemJti", func(t *testing.T) {
+		// Tokens sem jti não podem ser revogados e não são aceitos
+		claims := jwt.MapClaims{
+			"id":    estabelecimento.ID,
+			"email": estabelecimento.Email,
+			"nome":  estabelecimento.Nome,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+tokenString)
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "identificador do token não informado")
+	})
+
+	t.Run("ChaveAPIValida", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("POST", "/api/generate", nil)
+		c.Request.Header.Set("X-API-Key", "pixk_chave_erp")
+
+		// Executar os middlewares em sequência
+		middleware.RequererAutenticacao()(c)
+		if !c.IsAborted() {
+			autorizacao.RequererPermissao(models.EscopoPixEscrita)(c)
+		}
+
+		// Verificações: a chave identifica o estabelecimento dono dela
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, estabelecimento.ID, middlewares.EstabelecimentoID(c))
+		assert.Equal(t, "chave-erp", c.GetString("chaveAPIID"))
+	})
+
+	t.Run("ChaveAPIInvalida", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("POST", "/api/generate", nil)
+		c.Request.Header.Set("X-API-Key", "pixk_desconhecida")
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Chave de API inválida")
+	})
+
+	t.Run("ChaveAPIEscopoInsuficiente", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/perfil", nil)
+		c.Request.Header.Set("X-API-Key", "pixk_chave_erp")
+
+		// Executar os middlewares em sequência
+		middleware.RequererAutenticacao()(c)
+		if !c.IsAborted() {
+			autorizacao.RequererPermissao(models.EscopoPerfilLeitura)(c)
+		}
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), models.EscopoPerfilLeitura)
+	})
+
+	t.Run("RequererLogin", func(t *testing.T) {
+		token, err := authService.GerarToken(estabelecimento, nil)
+		assert.NoError(t, err)
+
+		credenciais := map[string]bool{"Authorization": true, "X-API-Key": false}
+		for header, permitido := range credenciais {
+			valor := "pixk_chave_erp"
+			if header == "Authorization" {
+				valor = "Bearer " + token
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("POST", "/api/chaves-api", nil)
+			c.Request.Header.Set(header, valor)
+
+			// Executar os middlewares em sequência; os escopos não restringem o login
+			middleware.RequererAutenticacao()(c)
+			if !c.IsAborted() {
+				autorizacao.RequererPermissao(models.EscopoPerfilEscrita)(c)
+			}
+			if !c.IsAborted() {
+				middleware.RequererLogin()(c)
+			}
+
+			// Verificações: apenas o login passa
+			assert.Equal(t, !permitido, c.IsAborted(), header)
+			if !permitido {
+				assert.Equal(t, http.StatusForbidden, w.Code)
+			}
+		}
+	})
+
+	t.Run("PermissoesPorPapel", func(t *testing.T) {
+		caixa := models.Usuario{ID: "usuario-caixa", Nome: "Maria", Email: "maria@teste.com", Papel: models.PapelCaixa}
+		verificador.sessoes[estabelecimento.ID+"/"+caixa.ID] = models.SessaoEstabelecimento{Ativo: true, Papel: models.PapelCaixa}
+
+		token, err := authService.GerarToken(estabelecimento, &caixa)
+		assert.NoError(t, err)
+
+		// O caixa gera PIX, mas não consulta o histórico nem gerencia as chaves de API
+		permissoes := map[string]bool{
+			models.EscopoPixEscrita:            true,
+			models.EscopoPixLeitura:            false,
+			models.PermissaoGerenciarChavesAPI: false,
+		}
+		for permissao, permitida := range permissoes {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("GET", "/api/protected", nil)
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+
+			// Executar os middlewares em sequência
+			middleware.RequererAutenticacao()(c)
+			if !c.IsAborted() {
+				autorizacao.RequererPermissao(permissao)(c)
+			}
+
+			// Verificações: o estabelecimento continua sendo o dono dos recursos
+			assert.Equal(t, !permitida, c.IsAborted(), permissao)
+			assert.Equal(t, estabelecimento.ID, middlewares.EstabelecimentoID(c))
+			assert.Equal(t, caixa.ID, middlewares.UsuarioEquipeID(c))
+			if !permitida {
+				assert.Equal(t, http.StatusForbidden, w.Code)
+			}
+		}
+
+		// A alteração do papel vale na requisição seguinte, sem novo login
+		verificador.sessoes[estabelecimento.ID+"/"+caixa.ID] = models.SessaoEstabelecimento{Ativo: true, Papel: models.PapelLeitura}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/pix", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		middleware.RequererAutenticacao()(c)
+		if !c.IsAborted() {
+			autorizacao.RequererPermissao(models.EscopoPixLeitura)(c)
+		}
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, models.PapelLeitura, middlewares.Papel(c))
+	})
+
+	t.Run("ChaveAPISemPermissaoDeLogin", func(t *testing.T) {
+		// Uma chave sem escopos acessa todas as rotas de PIX, cobranças e perfil, mas não as de gestão
+		autenticador.chaves["pixk_chave_total"] = models.ChaveAPI{ID: "chave-total", EstabelecimentoID: estabelecimento.ID, Escopos: []string{}}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("GET", "/api/usuarios", nil)
+		c.Request.Header.Set("X-API-Key", "pixk_chave_total")
+
+		// Executar os middlewares em sequência
+		middleware.RequererAutenticacao()(c)
+		if !c.IsAborted() {
+			autorizacao.RequererPermissao(models.PermissaoGerenciarUsuarios)(c)
+		}
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ClienteOAuthEscopos", func(t *testing.T) {
+		cliente := models.ClienteOAuth{ID: "cliente-parceiro", EstabelecimentoID: estabelecimento.ID}
+		autenticadorOAuth.clientes[cliente.ID] = estabelecimento.ID
+
+		token, _, err := authService.GerarTokenClienteOAuth(estabelecimento, cliente, []string{models.EscopoPixEscrita})
+		assert.NoError(t, err)
+
+		// O token dá acesso apenas aos seus escopos e nunca às rotas de login
+		permissoes := map[string]bool{
+			models.EscopoPixEscrita:           true,
+			models.EscopoPixLeitura:           false,
+			models.PermissaoGerenciarUsuarios: false,
+		}
+		for permissao, permitida := range permissoes {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("POST", "/api/generate", nil)
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+
+			// Executar os middlewares em sequência
+			middleware.RequererAutenticacao()(c)
+			if !c.IsAborted() {
+				autorizacao.RequererPermissao(permissao)(c)
+			}
+
+			// Verificações: o cliente identifica o estabelecimento dono dele
+			assert.Equal(t, !permitida, c.IsAborted(), permissao)
+			assert.Equal(t, estabelecimento.ID, middlewares.EstabelecimentoID(c))
+			assert.Equal(t, cliente.ID, c.GetString("clienteOAuthID"))
+			if !permitida {
+				assert.Equal(t, http.StatusForbidden, w.Code)
+			}
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/api/logout", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		middleware.RequererAutenticacao()(c)
+		if !c.IsAborted() {
+			middleware.RequererLogin()(c)
+		}
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ClienteOAuthRevogado", func(t *testing.T) {
+		cliente := models.ClienteOAuth{ID: "cliente-revogado", EstabelecimentoID: estabelecimento.ID}
+		token, _, err := authService.GerarTokenClienteOAuth(estabelecimento, cliente, []string{models.EscopoPixEscrita})
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("POST", "/api/generate", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), services.ErrTokenRevogado.Error())
+	})
+
+	t.Run("ClienteOAuthSemEscopos", func(t *testing.T) {
+		// Um token de cliente sem escopos não pode valer como uma credencial com todos eles
+		autenticadorOAuth.clientes["cliente-vazio"] = estabelecimento.ID
+		token, _, err := authService.GerarTokenClienteOAuth(estabelecimento, models.ClienteOAuth{ID: "cliente-vazio"}, nil)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request, _ = http.NewRequest("POST", "/api/generate", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+
+		// Executar middleware
+		middleware.RequererAutenticacao()(c)
+
+		// Verificações
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 }
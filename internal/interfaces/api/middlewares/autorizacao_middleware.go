@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
+
+// AutorizacaoMiddleware estrutura do middleware de autorização, que aplica as permissões de
+// quem foi autenticado por RequererAutenticacao
+type AutorizacaoMiddleware struct{}
+
+// NewAutorizacaoMiddleware cria uma nova instância do middleware de autorização
+func NewAutorizacaoMiddleware() *AutorizacaoMiddleware {
+	return &AutorizacaoMiddleware{}
+}
+
+// RequererPermissao middleware que exige a permissão informada. Nos logins, a permissão vem
+// do papel atual do usuário; nas chaves de API e nos tokens de clientes OAuth2, dos escopos
+// concedidos à credencial, que só cobrem as permissões que são escopos. Deve ser usado após
+// RequererAutenticacao.
+func (m *AutorizacaoMiddleware) RequererPermissao(permissao string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.GetString("credencial") {
+		case credencialChaveAPI, credencialOAuth:
+			if !models.EscopoValido(permissao) {
+				c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Rota disponível apenas com login, não com chave de API ou cliente OAuth"})
+				c.Abort()
+				return
+			}
+
+			escopos, _ := c.Get("escopos")
+			concedidos, _ := escopos.([]string)
+			if !models.EscopoConcedido(concedidos, permissao) {
+				c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Escopo insuficiente: " + permissao})
+				c.Abort()
+				return
+			}
+		default:
+			papel := Papel(c)
+			if !models.PapelPermite(papel, permissao) {
+				c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "O papel " + papel + " não tem a permissão " + permissao})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/rodrigocostarcs/pix-generator/docs"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
 	"github.com/rodrigocostarcs/pix-generator/internal/infrastructure/metrics"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/handlers"
 	"github.com/rodrigocostarcs/pix-generator/internal/interfaces/api/middlewares"
@@ -14,8 +15,18 @@ import (
 func SetupRoutes(
 	router *gin.Engine,
 	pixHandler *handlers.PixHandler,
+	cobvHandler *handlers.CobVHandler,
+	jobHandler *handlers.JobHandler,
+	perfilHandler *handlers.PerfilHandler,
+	estabelecimentoHandler *handlers.EstabelecimentoHandler,
+	chaveAPIHandler *handlers.ChaveAPIHandler,
+	clienteOAuthHandler *handlers.ClienteOAuthHandler,
+	usuarioHandler *handlers.UsuarioHandler,
+	contaHandler *handlers.ContaHandler,
+	doisFatoresHandler *handlers.DoisFatoresHandler,
 	autenticacaoHandler *handlers.AutenticacaoHandler,
 	autenticacaoMiddleware *middlewares.AutenticacaoMiddleware,
+	autorizacaoMiddleware *middlewares.AutorizacaoMiddleware,
 ) {
 	// Configurar middleware Prometheus para métricas
 	prometheusMiddleware := metrics.NewPrometheusMiddleware()
@@ -26,23 +37,119 @@ func SetupRoutes(
 	// Registrar endpoint para métricas Prometheus
 	prometheusMiddleware.RegisterEndpoint(router)
 
+	// Chaves públicas de verificação dos tokens JWT
+	router.GET("/.well-known/jwks.json", autenticacaoHandler.JWKS)
+
+	// Endpoints OAuth2 das plataformas parceiras, autenticadas pelas credenciais do cliente
+	oauth := router.Group("/oauth")
+	{
+		oauth.POST("/token", clienteOAuthHandler.Token)
+		oauth.POST("/introspect", clienteOAuthHandler.Introspect)
+	}
+
 	// Rotas públicas
 	api := router.Group("/api")
 	{
 		// Autenticação
 		api.POST("/registrar", autenticacaoHandler.Registrar)
 		api.POST("/login", autenticacaoHandler.Login)
+		api.POST("/login/dois-fatores", autenticacaoHandler.LoginDoisFatores)
+		api.POST("/login/dois-fatores/cadastro", doisFatoresHandler.EnrollDesafio)
+		api.POST("/token/refresh", autenticacaoHandler.RefreshToken)
+		api.POST("/usuarios/convites/aceitar", usuarioHandler.AcceptConvite)
 
-		// Rota para download de QR code (mantida pública)
-		api.GET("/download-qrcode", pixHandler.DownloadQRCode)
+		// Verificação do email e redefinição de senha
+		api.POST("/email/verificar", contaHandler.VerifyEmail)
+		api.POST("/email/verificacao", contaHandler.ResendVerificacao)
+		api.POST("/senha/esqueci", contaHandler.ForgotSenha)
+		api.POST("/senha/redefinir", contaHandler.ResetSenha)
 	}
 
-	// Rotas protegidas
+	// Rotas protegidas, acessíveis com token JWT, de login ou de cliente OAuth2, ou chave de API.
+	// Cada rota exige uma permissão, concedida pelo papel de quem fez login ou pelos escopos da
+	// chave de API ou do token do cliente OAuth2.
 	protected := router.Group("/api")
 	protected.Use(autenticacaoMiddleware.RequererAutenticacao())
+	permissao := autorizacaoMiddleware.RequererPermissao
 	{
+		// Rotas de gestão da conta, indisponíveis para chaves de API e clientes OAuth2
+		login := protected.Group("")
+		login.Use(autenticacaoMiddleware.RequererLogin())
+		{
+			// Encerramento da sessão
+			login.POST("/logout", autenticacaoHandler.Logout)
+
+			// Rotas do segundo fator do próprio login; a exigência vale para todo o estabelecimento
+			login.GET("/dois-fatores", doisFatoresHandler.GetStatus)
+			login.POST("/dois-fatores/cadastro", doisFatoresHandler.Enroll)
+			login.POST("/dois-fatores/confirmar", doisFatoresHandler.Confirm)
+			login.POST("/dois-fatores/codigos-recuperacao", doisFatoresHandler.RegenerateCodigos)
+			login.POST("/dois-fatores/desativar", doisFatoresHandler.Disable)
+			login.PUT("/dois-fatores/politica", permissao(models.PermissaoGerenciarConta), doisFatoresHandler.UpdatePolitica)
+
+			// Rotas de autoatendimento do cadastro do estabelecimento autenticado
+			login.GET("/estabelecimentos/me", estabelecimentoHandler.GetMe)
+			login.PUT("/estabelecimentos/me", permissao(models.PermissaoGerenciarConta), estabelecimentoHandler.UpdateMe)
+			login.DELETE("/estabelecimentos/me", permissao(models.PermissaoGerenciarConta), estabelecimentoHandler.DeleteMe)
+
+			// Rotas de administração dos estabelecimentos
+			admin := login.Group("/estabelecimentos")
+			admin.Use(autenticacaoMiddleware.RequererAdmin())
+			admin.GET("", estabelecimentoHandler.ListEstabelecimentos)
+			admin.POST("/:id/ativar", estabelecimentoHandler.ActivateEstabelecimento)
+			admin.POST("/:id/desativar", estabelecimentoHandler.DeactivateEstabelecimento)
+
+			// Rotas das chaves de API
+			login.POST("/chaves-api", permissao(models.PermissaoGerenciarChavesAPI), chaveAPIHandler.CreateChaveAPI)
+			login.GET("/chaves-api", permissao(models.PermissaoGerenciarChavesAPI), chaveAPIHandler.ListChavesAPI)
+			login.DELETE("/chaves-api/:id", permissao(models.PermissaoGerenciarChavesAPI), chaveAPIHandler.RevokeChaveAPI)
+
+			// Rotas dos clientes OAuth2 das plataformas parceiras
+			login.POST("/clientes-oauth", permissao(models.PermissaoGerenciarClientesOAuth), clienteOAuthHandler.CreateClienteOAuth)
+			login.GET("/clientes-oauth", permissao(models.PermissaoGerenciarClientesOAuth), clienteOAuthHandler.ListClientesOAuth)
+			login.DELETE("/clientes-oauth/:id", permissao(models.PermissaoGerenciarClientesOAuth), clienteOAuthHandler.RevokeClienteOAuth)
+
+			// Rotas dos usuários da equipe do estabelecimento
+			login.GET("/usuarios", permissao(models.PermissaoGerenciarUsuarios), usuarioHandler.ListUsuarios)
+			login.POST("/usuarios/convites", permissao(models.PermissaoGerenciarUsuarios), usuarioHandler.InviteUsuario)
+			login.PUT("/usuarios/:id", permissao(models.PermissaoGerenciarUsuarios), usuarioHandler.UpdateUsuario)
+			login.DELETE("/usuarios/:id", permissao(models.PermissaoGerenciarUsuarios), usuarioHandler.DeactivateUsuario)
+		}
+
+		// Rotas do perfil do estabelecimento e das chaves PIX cadastradas
+		protected.GET("/perfil", permissao(models.EscopoPerfilLeitura), perfilHandler.GetPerfil)
+		protected.PUT("/perfil", permissao(models.EscopoPerfilEscrita), perfilHandler.UpdatePerfil)
+		protected.POST("/perfil/chaves", permissao(models.EscopoPerfilEscrita), perfilHandler.CreateChave)
+		protected.PUT("/perfil/chaves/:id", permissao(models.EscopoPerfilEscrita), perfilHandler.UpdateChave)
+		protected.DELETE("/perfil/chaves/:id", permissao(models.EscopoPerfilEscrita), perfilHandler.DeleteChave)
+
 		// Rota para geração de PIX
-		protected.POST("/generate", pixHandler.GeneratePix)
+		protected.POST("/generate", permissao(models.EscopoPixEscrita), pixHandler.GeneratePix)
+
+		// Rota para listagem do histórico de PIX
+		protected.GET("/pix", permissao(models.EscopoPixLeitura), pixHandler.ListPix)
+
+		// Rota para geração de PIX em lote
+		protected.POST("/generate/batch", permissao(models.EscopoPixEscrita), pixHandler.GenerateBatch)
+
+		// Rotas de jobs em segundo plano
+		protected.POST("/jobs", permissao(models.EscopoPixEscrita), jobHandler.CreateJob)
+		protected.GET("/jobs/:id", permissao(models.EscopoPixLeitura), jobHandler.GetJob)
+		protected.POST("/jobs/:id/cancel", permissao(models.EscopoPixEscrita), jobHandler.CancelJob)
+		protected.GET("/jobs/:id/result", permissao(models.EscopoPixLeitura), jobHandler.DownloadJobResult)
+
+		// Rota para geração de cobrança imediata (PIX dinâmico)
+		protected.POST("/cob", permissao(models.EscopoCobEscrita), pixHandler.GenerateCob)
+
+		// Rotas de cobrança com vencimento
+		protected.POST("/cobv", permissao(models.EscopoCobEscrita), cobvHandler.GenerateCobV)
+		protected.GET("/cobv/:txid", permissao(models.EscopoCobLeitura), cobvHandler.GetCobV)
+
+		// Rota para download de QR code dos PIX do estabelecimento
+		protected.GET("/download-qrcode", permissao(models.EscopoPixLeitura), pixHandler.DownloadQRCode)
+
+		// Rota para leitura de BR Codes
+		protected.POST("/decode", permissao(models.EscopoPixLeitura), pixHandler.DecodeBRCode)
 	}
 
 	// Rota para página inicial (pode ser utilizada para interface web)
@@ -1,6 +1,11 @@
 package views
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rodrigocostarcs/pix-generator/internal/domain/models"
+)
 
 // ResponseView encapsula a lógica de formatação de respostas da API
 type ResponseView struct{}
@@ -18,6 +23,9 @@ type Response struct {
 	// Mensagem de erro (apenas quando Success = false)
 	// example: Credenciais inválidas
 	Error string `json:"error,omitempty"`
+
+	// Erros de validação por campo (apenas quando a requisição é rejeitada com 422)
+	Erros []models.ErroValidacao `json:"erros,omitempty"`
 }
 
 // NewResponseView cria uma nova instância de ResponseView
@@ -41,6 +49,20 @@ func (rv *ResponseView) Error(c *gin.Context, statusCode int, message string) {
 	})
 }
 
+// ValidationError retorna uma resposta 422 com os erros de validação por campo
+func (rv *ResponseView) ValidationError(c *gin.Context, erros ...models.ErroValidacao) {
+	message := "Dados inválidos"
+	if len(erros) > 0 {
+		message = erros[0].Error()
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, Response{
+		Success: false,
+		Error:   message,
+		Erros:   erros,
+	})
+}
+
 // Download prepara o contexto para um download de arquivo
 func (rv *ResponseView) Download(c *gin.Context, filename string, mimeType string, data []byte) {
 	c.Header("Content-Description", "File Transfer")
@@ -12,23 +12,215 @@ CREATE TABLE IF NOT EXISTS estabelecimentos (
     email VARCHAR(100) NOT NULL UNIQUE,
     senha VARCHAR(255) NOT NULL,
     ativo BOOLEAN DEFAULT true,
+    admin BOOLEAN NOT NULL DEFAULT false,
+    email_verificado_em DATETIME NULL,
+    dois_fatores_obrigatorio BOOLEAN NOT NULL DEFAULT false,
+    tokens_revogados_em DATETIME NULL,
+    razao_social VARCHAR(100) NULL,
+    cidade VARCHAR(50) NULL,
+    mcc CHAR(4) NULL,
+    cep CHAR(8) NULL,
     criado_em DATETIME NOT NULL,
     atualizado_em DATETIME NOT NULL
 );
 
+-- Criar tabela para os usuários da equipe dos estabelecimentos, criados por convite
+CREATE TABLE IF NOT EXISTS usuarios (
+    id CHAR(36) PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    nome VARCHAR(100) NULL,
+    email VARCHAR(100) NOT NULL UNIQUE,
+    senha VARCHAR(255) NULL,
+    papel VARCHAR(20) NOT NULL,
+    ativo BOOLEAN NOT NULL DEFAULT true,
+    convite_hash CHAR(64) NULL UNIQUE,
+    convite_expira_em DATETIME NULL,
+    aceito_em DATETIME NULL,
+    tokens_revogados_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    atualizado_em DATETIME NOT NULL,
+    INDEX idx_usuarios_estabelecimento (estabelecimento_id, criado_em),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);
+
+-- Criar tabela para as chaves PIX cadastradas no perfil dos estabelecimentos
+CREATE TABLE IF NOT EXISTS chaves_pix (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    chave VARCHAR(100) NOT NULL,
+    tipo_chave VARCHAR(10) NOT NULL,
+    apelido VARCHAR(50) NULL,
+    padrao BOOLEAN NOT NULL DEFAULT false,
+    criado_em DATETIME NOT NULL,
+    UNIQUE KEY uk_chaves_pix_chave (estabelecimento_id, chave),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);
+
+-- Criar tabela para os refresh tokens, armazenados apenas como hash SHA-256
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id CHAR(36) PRIMARY KEY,
+    familia_id CHAR(36) NOT NULL,
+    estabelecimento_id CHAR(36) NOT NULL,
+    usuario_id CHAR(36) NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expira_em DATETIME NOT NULL,
+    usado_em DATETIME NULL,
+    revogado_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_refresh_tokens_familia (familia_id),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id),
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id)
+);
+
+-- Criar tabela para os tokens de verificação de email e de redefinição de senha, armazenados
+-- apenas como hash SHA-256
+CREATE TABLE IF NOT EXISTS tokens_conta (
+    id CHAR(36) PRIMARY KEY,
+    tipo VARCHAR(20) NOT NULL,
+    estabelecimento_id CHAR(36) NOT NULL,
+    usuario_id CHAR(36) NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expira_em DATETIME NOT NULL,
+    usado_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_tokens_conta_login (estabelecimento_id, tipo),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id),
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id)
+);
+
+-- Criar tabela para o segundo fator (TOTP) dos logins, identificados pelo ID do estabelecimento
+-- ou do usuário da equipe
+CREATE TABLE IF NOT EXISTS dois_fatores (
+    login_id CHAR(36) PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    segredo VARCHAR(64) NOT NULL,
+    ultimo_passo BIGINT NOT NULL DEFAULT 0,
+    ativado_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);
+
+-- Criar tabela para os códigos de recuperação do segundo fator, armazenados apenas como hash SHA-256
+CREATE TABLE IF NOT EXISTS codigos_recuperacao (
+    id CHAR(36) PRIMARY KEY,
+    login_id CHAR(36) NOT NULL,
+    codigo_hash CHAR(64) NOT NULL,
+    usado_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_codigos_recuperacao_login (login_id, codigo_hash),
+    FOREIGN KEY (login_id) REFERENCES dois_fatores(login_id)
+);
+
+-- Criar tabela para a auditoria dos bloqueios de login por excesso de tentativas
+CREATE TABLE IF NOT EXISTS bloqueios_login (
+    id CHAR(36) PRIMARY KEY,
+    tipo VARCHAR(10) NOT NULL,
+    identificador VARCHAR(100) NOT NULL,
+    ip VARCHAR(45) NOT NULL,
+    tentativas INT NOT NULL,
+    bloqueado_ate DATETIME NOT NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_bloqueios_login_identificador (identificador, criado_em)
+);
+
+-- Criar tabela para as chaves de API das integrações, armazenadas apenas como hash SHA-256
+CREATE TABLE IF NOT EXISTS chaves_api (
+    id CHAR(36) PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    nome VARCHAR(100) NOT NULL,
+    prefixo VARCHAR(16) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE,
+    escopos VARCHAR(255) NOT NULL DEFAULT '',
+    expira_em DATETIME NULL,
+    ultimo_uso_em DATETIME NULL,
+    revogada_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_chaves_api_estabelecimento (estabelecimento_id, criado_em),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);
+
+-- Criar tabela para os clientes OAuth2 das plataformas parceiras, com o segredo armazenado
+-- apenas como hash SHA-256
+CREATE TABLE IF NOT EXISTS clientes_oauth (
+    id CHAR(36) PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    nome VARCHAR(100) NOT NULL,
+    segredo_hash CHAR(64) NOT NULL,
+    escopos VARCHAR(255) NOT NULL DEFAULT '',
+    ultimo_uso_em DATETIME NULL,
+    revogado_em DATETIME NULL,
+    criado_em DATETIME NOT NULL,
+    INDEX idx_clientes_oauth_estabelecimento (estabelecimento_id, criado_em),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);
+
 -- Criar tabela para armazenar os códigos PIX
 CREATE TABLE IF NOT EXISTS pix (
     id INT AUTO_INCREMENT PRIMARY KEY,
+    tipo VARCHAR(20) NOT NULL DEFAULT 'ESTATICO',
+    modalidade VARCHAR(10) NOT NULL DEFAULT 'COMPRA',
     nome VARCHAR(100) NOT NULL,
     chave VARCHAR(100) NOT NULL,
+    tipo_chave VARCHAR(10) NULL,
     cidade VARCHAR(50) NOT NULL,
     valor DECIMAL(10, 2) NULL,
     identificador VARCHAR(100) NULL,
     descricao VARCHAR(200) NULL,
+    txid VARCHAR(35) NULL UNIQUE,
+    location VARCHAR(77) NULL,
+    expira_em DATETIME NULL,
     codigo_pix TEXT NOT NULL,
     qrcode_svg TEXT NOT NULL,
     qrcode_png TEXT NOT NULL,
     criado_em DATETIME NOT NULL,
     estabelecimento_id CHAR(36) NULL,
+    INDEX idx_pix_criado_em (estabelecimento_id, criado_em, id),
+    INDEX idx_pix_valor (estabelecimento_id, valor, id),
+    INDEX idx_pix_identificador (estabelecimento_id, identificador),
+    INDEX idx_pix_chave (estabelecimento_id, chave),
+    INDEX idx_pix_expira_em (estabelecimento_id, expira_em),
     FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
-);
\ No newline at end of file
+);
+
+-- Criar tabela para as cobranças com vencimento (CobV)
+CREATE TABLE IF NOT EXISTS cobv (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    pix_id INT NOT NULL,
+    txid VARCHAR(35) NOT NULL UNIQUE,
+    valor_original DECIMAL(10, 2) NOT NULL,
+    vencimento DATE NOT NULL,
+    validade_apos_vencimento INT NOT NULL DEFAULT 30,
+    juros_modalidade VARCHAR(20) NULL,
+    juros_valor_perc DECIMAL(10, 2) NULL,
+    multa_modalidade VARCHAR(20) NULL,
+    multa_valor_perc DECIMAL(10, 2) NULL,
+    abatimento_modalidade VARCHAR(20) NULL,
+    abatimento_valor_perc DECIMAL(10, 2) NULL,
+    descontos JSON NULL,
+    criado_em DATETIME NOT NULL,
+    FOREIGN KEY (pix_id) REFERENCES pix(id)
+);
+
+-- Criar tabela para os jobs executados em segundo plano
+CREATE TABLE IF NOT EXISTS jobs (
+    id CHAR(36) PRIMARY KEY,
+    estabelecimento_id CHAR(36) NOT NULL,
+    tipo VARCHAR(20) NOT NULL,
+    status VARCHAR(15) NOT NULL DEFAULT 'PENDENTE',
+    formato VARCHAR(10) NOT NULL DEFAULT 'json',
+    template VARCHAR(100) NULL,
+    total INT NOT NULL DEFAULT 0,
+    processados INT NOT NULL DEFAULT 0,
+    sucessos INT NOT NULL DEFAULT 0,
+    falhas INT NOT NULL DEFAULT 0,
+    entrada LONGBLOB NOT NULL,
+    resultado LONGBLOB NULL,
+    resultado_tipo VARCHAR(50) NULL,
+    erro TEXT NULL,
+    criado_em DATETIME NOT NULL,
+    iniciado_em DATETIME NULL,
+    atualizado_em DATETIME NULL,
+    concluido_em DATETIME NULL,
+    INDEX idx_jobs_status (status, criado_em),
+    FOREIGN KEY (estabelecimento_id) REFERENCES estabelecimentos(id)
+);